@@ -83,7 +83,7 @@ func (args *Arguments) define() {
 		&args.SolutionSummaryFile,
 		"SolutionSummaryFile",
 		"",
-		"file detailing a set of solutions to a particular run of scenario",
+		"file detailing a set of solutions to a particular run of scenario, whose scenario is loaded from any provenance saved beside it",
 	)
 
 	flag.Usage = usageMessage
@@ -151,11 +151,6 @@ func validateArgInterdependence(args *Arguments) {
 		exitError := errors.Errorf("solution file [%s] needs a scenario file (--ScenarioFile flag) specified", args.SolutionFile)
 		Exit(exitError)
 	}
-
-	if args.SolutionSummaryFile != "" && args.ScenarioFile == "" {
-		exitError := errors.Errorf("solution summary file [%s] needs a scenario file (--ScenarioFile flag) specified", args.SolutionSummaryFile)
-		Exit(exitError)
-	}
 }
 
 func Exit(exitValue interface{}) {
@@ -5,7 +5,7 @@ import "fmt"
 const ShortApplicationName = "CREMEngine"
 const LongApplicationName = "Catchment Resilience Exploration Modelling Engine "
 
-const Version = "0.9"
+const Version = "0.10"
 
 func NameAndVersionString() string {
 	return fmt.Sprintf("%s, version %s", ShortApplicationName, Version)
@@ -34,8 +34,9 @@ type Mux struct {
 	model                  *catchment.Model
 	modelSolution          *solution.Solution
 
-	solutionPool     SolutionPool
-	solutionSetTable dataset.HeadingsTable
+	solutionPool          SolutionPool
+	solutionSetTable      dataset.HeadingsTable
+	solutionSetProvenance *solution.Provenance
 
 	jsonMarshaler json.Marshaler
 
@@ -51,6 +52,7 @@ func (m *Mux) Initialise() *Mux {
 		activePath           = "active"
 		applicablePath       = "applicable"
 		subcatchmentPath     = "subcatchment"
+		parameterTypePath    = "\\w+"
 		identityMatchingPath = "\\d+"
 		solutionLabelPath    = "[\\w\\-]+"
 	)
@@ -62,10 +64,13 @@ func (m *Mux) Initialise() *Mux {
 	m.AddHandler(buildV1ApiPath(scenarioPath), m.v1scenarioHandler)
 	m.AddHandler(buildV1ApiPath(solutionsPath), m.v1solutionSetHandler)
 	m.AddHandler(buildV1ApiPath(solutionsPath, solutionLabelPath), m.v1solutionHandler)
+	m.AddHandler(buildV1ApiPath(provenancePath), m.v1provenanceHandler)
 	m.AddHandler(buildV1ApiPath(modelPath), m.v1modelHandler)
 	m.AddHandler(buildV1ApiPath(modelPath, actionsPath, applicablePath), m.v1ApplicableActionsHandler)
 	m.AddHandler(buildV1ApiPath(modelPath, actionsPath, activePath), m.v1activeActionsHandler)
 	m.AddHandler(buildV1ApiPath(modelPath, subcatchmentPath, identityMatchingPath), m.v1subcatchmentHandler)
+	m.AddHandler(buildV1ApiPath(parametersPath), m.v1parametersHandler)
+	m.AddHandler(buildV1ApiPath(parametersPath, parameterTypePath), m.v1parametersHandler)
 
 	return m
 }
@@ -0,0 +1,158 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package api
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	"github.com/LindsayBradford/crem/cmd/cremengine/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	configData "github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+// loadScenarioOf loads the scenario a solution set of the provenance given was produced from, reverse-engineering
+// its configuration from the model type and parameters recorded, against the exact data set recorded.  The scenario
+// is refused, leaving any scenario already loaded in place, where its data set or management actions no longer
+// match those the solution set was produced from.
+func (m *Mux) loadScenarioOf(provenance *solution.Provenance) error {
+	config, configError := scenarioConfigOf(provenance)
+	if configError != nil {
+		return configError
+	}
+
+	candidateModel, buildError := buildModelOf(config)
+	if buildError != nil {
+		return buildError
+	}
+
+	if verificationError := verifyModelMatchesProvenance(candidateModel, provenance); verificationError != nil {
+		return verificationError
+	}
+
+	scenarioText, encodingError := encodeScenarioConfig(config)
+	if encodingError != nil {
+		return encodingError
+	}
+
+	m.rememberScenarioAttributeState(config, scenarioText)
+	m.rememberModelState(candidateModel, config)
+
+	m.modelSolution = new(solution.SolutionBuilder).
+		WithId(m.model.Id()).
+		ForModel(m.model).
+		Build()
+
+	return nil
+}
+
+// scenarioConfigOf reverse-engineers the configuration of the scenario a solution set of the provenance given was
+// produced from, with its model reading the data set recorded, rather than one found relative to the engine.
+func scenarioConfigOf(provenance *solution.Provenance) (*data.ScenarioConfig, error) {
+	incompleteErrors := compositeErrors.New("provenance too incomplete to reverse-engineer its scenario")
+	if provenance.ScenarioName == "" {
+		incompleteErrors.AddMessage("no scenario name recorded")
+	}
+	if provenance.Model.Type == "" {
+		incompleteErrors.AddMessage("no model type recorded")
+	}
+	if provenance.DataSetFile == "" {
+		incompleteErrors.AddMessage("no data set recorded")
+	}
+	if incompleteErrors.Size() > 0 {
+		return nil, incompleteErrors
+	}
+
+	modelParameters := restoredParameters(provenance.Model.Type, provenance.Model.Parameters)
+	modelParameters[catchmentParameters.DataSourcePath] = provenance.DataSetFile
+
+	config := &data.ScenarioConfig{
+		Scenario: data.BasicScenarioConfig{Name: provenance.ScenarioName},
+		Model: configData.ModelConfig{
+			Type:       provenance.Model.Type,
+			Parameters: modelParameters,
+		},
+	}
+	return config, nil
+}
+
+// restoredParameters returns the parameters recorded for a model of modelType, with numbers decoded from JSON
+// restored to the integer or decimal type its parameter specifications accept.
+func restoredParameters(modelType string, recorded map[string]interface{}) parameters.Map {
+	specs, _ := interpreter.ModelParameterSpecifications(modelType)
+	return solution.ComponentProvenance{Type: modelType, Parameters: recorded}.RestoredParameters(specs)
+}
+
+func buildModelOf(config *data.ScenarioConfig) (*catchment.Model, error) {
+	modelInterpreter := interpreter.NewModelConfigInterpreter()
+	interpretedModel := modelInterpreter.Interpret(&config.Model).Model()
+	if modelInterpreter.Errors() != nil {
+		return nil, modelInterpreter.Errors()
+	}
+
+	catchmentModel, isCatchmentModel := interpretedModel.(*catchment.Model)
+	if !isCatchmentModel {
+		return nil, errors.New("model type [" + config.Model.Type + "] is not a catchment model")
+	}
+
+	catchmentModel.Initialise(model.AsIs)
+	if loadErrors := catchmentModel.ParameterErrors(); loadErrors != nil {
+		return nil, loadErrors
+	}
+	return catchmentModel, nil
+}
+
+// verifyModelMatchesProvenance explains every way in which the model built for a solution set differs from the
+// model that solution set was produced from.
+func verifyModelMatchesProvenance(candidateModel *catchment.Model, provenance *solution.Provenance) error {
+	mismatchErrors := compositeErrors.New("scenario no longer matches the solution set's provenance")
+
+	if provenance.DataSetChecksum != "" {
+		dataSetChecksum, checksumError := checksumOfDataSet(candidateModel)
+		if checksumError != nil {
+			mismatchErrors.Add(checksumError)
+		} else if dataSetChecksum != provenance.DataSetChecksum {
+			mismatchErrors.AddMessage(fmt.Sprintf(
+				"data set [%s] has checksum [%s], but the solution set was produced from a data set with checksum [%s]; "+
+					"the data set has changed since", provenance.DataSetFile, dataSetChecksum, provenance.DataSetChecksum))
+		}
+	}
+
+	if provenance.ManagementActions > 0 {
+		actionCount := len(candidateModel.ManagementActions())
+		if actionCount != provenance.ManagementActions {
+			mismatchErrors.AddMessage(fmt.Sprintf(
+				"model has [%d] management actions, but the solution set was produced from a model of [%d]",
+				actionCount, provenance.ManagementActions))
+		}
+	}
+
+	if mismatchErrors.Size() > 0 {
+		return mismatchErrors
+	}
+	return nil
+}
+
+func checksumOfDataSet(candidateModel *catchment.Model) (string, error) {
+	filePaths, locateError := candidateModel.DataSourceFilePaths()
+	if locateError != nil {
+		return "", errors.Wrap(locateError, "locating data set files")
+	}
+	return solution.ChecksumOfFiles(filePaths...)
+}
+
+func encodeScenarioConfig(config *data.ScenarioConfig) (string, error) {
+	var scenarioText bytes.Buffer
+	if encodingError := toml.NewEncoder(&scenarioText).Encode(config); encodingError != nil {
+		return "", errors.Wrap(encodingError, "encoding reverse-engineered scenario")
+	}
+	return scenarioText.String(), nil
+}
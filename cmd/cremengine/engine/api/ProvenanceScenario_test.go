@@ -0,0 +1,122 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	. "github.com/onsi/gomega"
+)
+
+const tamperedSolutionsSummaryFilePath = "testdata/TamperedSolutions-Summary.csv"
+
+func TestSetSolutionSummary_WithoutScenario_LoadsScenarioFromProvenance(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	muxUnderTest.SetSolutionSummary(validSolutionsSummaryFilePath)
+
+	// then
+	g.Expect(muxUnderTest.model).To(Not(BeNil()))
+	g.Expect(muxUnderTest.model.Id()).To(Equal("Kirkpatrick"))
+	g.Expect(muxUnderTest.Attribute(scenarioNameKey)).To(Equal("Kirkpatrick"))
+	g.Expect(muxUnderTest.Attribute(scenarioTextKey)).To(ContainSubstring("testdata/ValidModel.csv"))
+	g.Expect(muxUnderTest.solutionSetTable).To(Not(BeNil()))
+	g.Expect(muxUnderTest.solutionSetProvenance).To(Not(BeNil()))
+
+	muxUnderTest.Shutdown()
+}
+
+func TestSetSolutionSummary_MismatchedProvenance_Refused(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	muxUnderTest.SetSolutionSummary(tamperedSolutionsSummaryFilePath)
+
+	// then
+	g.Expect(muxUnderTest.model).To(BeNil())
+	g.Expect(muxUnderTest.solutionSetTable).To(BeNil())
+	g.Expect(muxUnderTest.solutionSetProvenance).To(BeNil())
+
+	muxUnderTest.Shutdown()
+}
+
+func TestSetSolutionSummary_MismatchedProvenance_KeepsScenarioLoaded(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	muxUnderTest.SetScenario(validScenarioFilePath)
+	loadedModel := muxUnderTest.model
+
+	// when
+	muxUnderTest.SetSolutionSummary(tamperedSolutionsSummaryFilePath)
+
+	// then
+	g.Expect(muxUnderTest.model).To(BeIdenticalTo(loadedModel))
+	g.Expect(muxUnderTest.solutionSetTable).To(BeNil())
+
+	muxUnderTest.Shutdown()
+}
+
+func TestVerifyModelMatchesProvenance_Mismatched_ExplainsWhy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	provenance, _ := readProvenance("testdata/TamperedSolutions-Provenance.json")
+	config, _ := scenarioConfigOf(provenance)
+	modelUnderTest, _ := buildModelOf(config)
+
+	// when
+	verificationError := verifyModelMatchesProvenance(modelUnderTest, provenance)
+
+	// then
+	g.Expect(verificationError).To(Not(BeNil()))
+	g.Expect(verificationError.Error()).To(ContainSubstring("the data set has changed since"))
+	g.Expect(verificationError.Error()).To(ContainSubstring("model has [13] management actions"))
+}
+
+func TestScenarioConfigOf_IncompleteProvenance_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	provenance := &solution.Provenance{Model: solution.ComponentProvenance{Type: "CatchmentModel"}}
+
+	// when
+	config, configError := scenarioConfigOf(provenance)
+
+	// then
+	g.Expect(config).To(BeNil())
+	g.Expect(configError.Error()).To(ContainSubstring("no scenario name recorded"))
+	g.Expect(configError.Error()).To(ContainSubstring("no data set recorded"))
+}
+
+func TestRestoredParameters_RestoresNumbersToSpecifiedTypes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	recorded := map[string]interface{}{
+		"WaterDensity":   json.Number("1"),
+		"DataSourcePath": "testdata/ValidModel.csv",
+		"Unspecified":    json.Number("20"),
+
+		"MaximumImplementationCost": json.Number("500000"),
+	}
+
+	// when
+	restored := restoredParameters("CatchmentModel", recorded)
+
+	// then
+	g.Expect(restored["WaterDensity"]).To(Equal(float64(1)))
+	g.Expect(restored["DataSourcePath"]).To(Equal("testdata/ValidModel.csv"))
+	g.Expect(restored["Unspecified"]).To(Equal(int64(20)))
+	g.Expect(restored["MaximumImplementationCost"]).To(Equal(float64(500000)))
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/LindsayBradford/crem/internal/pkg/config/catalogue"
+	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
+	"github.com/pkg/errors"
+)
+
+const (
+	v1parametersHandler = "v1 parameters handler"
+
+	parametersPath = "parameters"
+)
+
+func (m *Mux) v1parametersHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		m.v1GetParametersHandler(w, r)
+	default:
+		m.MethodNotAllowedError(w, r)
+	}
+}
+
+func (m *Mux) v1GetParametersHandler(w http.ResponseWriter, r *http.Request) {
+	componentType := deriveParameterTypeFrom(r)
+
+	parameterCatalogue, describeError := catalogue.Describe(componentType)
+	if describeError != nil {
+		m.Logger().Warn("Attempted to request parameters of unregistered type [" + componentType + "]")
+		m.NotFoundError(w, r)
+		return
+	}
+
+	restResponse := new(rest.Response).
+		Initialise().
+		WithWriter(w).
+		WithResponseCode(http.StatusOK).
+		WithCacheControlMaxAge(m.CacheMaxAge()).
+		WithJsonContent(parameterCatalogue)
+
+	m.Logger().Info("Responding with parameter catalogue")
+	writeError := restResponse.Write()
+
+	if writeError != nil {
+		wrappingError := errors.Wrap(writeError, v1parametersHandler)
+		m.Logger().Error(wrappingError)
+	}
+}
+
+// deriveParameterTypeFrom returns the annealer or model type the request path ends with, or an empty string where
+// the request is for the parameters of every type.
+func deriveParameterTypeFrom(r *http.Request) string {
+	pathElements := strings.Split(strings.TrimSuffix(r.URL.Path, rest.UrlPathSeparator), rest.UrlPathSeparator)
+	lastElement := pathElements[len(pathElements)-1]
+	if lastElement == parametersPath {
+		return ""
+	}
+	return lastElement
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	httptest "github.com/LindsayBradford/crem/internal/pkg/server/test"
+	. "github.com/onsi/gomega"
+)
+
+const parametersUrl = baseUrl + "api/v1/parameters"
+
+func TestParametersGetRequest_OkResponseListingAllTypes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	context := TestContext{
+		Name: http.MethodGet + " " + parametersUrl + " request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodGet,
+			TargetUrl: parametersUrl,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+
+	// then
+	responseContainer := verifyResponseStatusCode(muxUnderTest, context)
+	components, isList := responseContainer.JsonMap["Components"].([]interface{})
+	g.Expect(isList).To(BeTrue())
+	g.Expect(len(components)).To(BeNumerically(">", 1))
+
+	muxUnderTest.Shutdown()
+}
+
+func TestParametersOfTypeGetRequest_OkResponseListingType(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	parametersUrlUnderTest := parametersUrl + "/CatchmentModel"
+
+	// when
+	context := TestContext{
+		Name: http.MethodGet + " " + parametersUrlUnderTest + " request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodGet,
+			TargetUrl: parametersUrlUnderTest,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+
+	// then
+	responseContainer := verifyResponseStatusCode(muxUnderTest, context)
+	components := responseContainer.JsonMap["Components"].([]interface{})
+	g.Expect(components).To(HaveLen(1))
+	g.Expect(components[0].(map[string]interface{})["Type"]).To(Equal("CatchmentModel"))
+
+	muxUnderTest.Shutdown()
+}
+
+func TestParametersOfUnregisteredTypeGetRequest_NotFoundResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	parametersUrlUnderTest := parametersUrl + "/NoSuchType"
+
+	// when
+	context := TestContext{
+		Name: http.MethodGet + " " + parametersUrlUnderTest + " request returns 404 (not found) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodGet,
+			TargetUrl: parametersUrlUnderTest,
+		},
+		ExpectedResponseStatus: http.StatusNotFound,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+	muxUnderTest.Shutdown()
+}
+
+func TestParametersPostRequest_MethodNotAllowedResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	context := TestContext{
+		Name: http.MethodPost + " " + parametersUrl + " request returns 405 (method not allowed) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodPost,
+			TargetUrl: parametersUrl,
+		},
+		ExpectedResponseStatus: http.StatusMethodNotAllowed,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+	muxUnderTest.Shutdown()
+}
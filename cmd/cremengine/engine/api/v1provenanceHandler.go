@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
+	"github.com/pkg/errors"
+)
+
+const (
+	v1provenanceHandler = "v1 provenance handler"
+
+	provenancePath = "provenance"
+
+	summaryFileSuffix    = "-Summary.csv"
+	provenanceFileSuffix = "-Provenance.json"
+)
+
+func (m *Mux) v1provenanceHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		m.v1GetProvenanceHandler(w, r)
+	default:
+		m.MethodNotAllowedError(w, r)
+	}
+}
+
+func (m *Mux) v1GetProvenanceHandler(w http.ResponseWriter, r *http.Request) {
+	if m.solutionSetProvenance == nil {
+		m.Logger().Warn("Request for solution set provenance received without a solution set of known provenance loaded.")
+		m.NotFoundError(w, r)
+		return
+	}
+
+	restResponse := new(rest.Response).
+		Initialise().
+		WithWriter(w).
+		WithResponseCode(http.StatusOK).
+		WithCacheControlMaxAge(m.CacheMaxAge()).
+		WithJsonContent(m.solutionSetProvenance)
+
+	m.Logger().Info("Responding with solution set provenance")
+	writeError := restResponse.Write()
+
+	if writeError != nil {
+		wrappingError := errors.Wrap(writeError, v1provenanceHandler)
+		m.Logger().Error(wrappingError)
+	}
+}
+
+// retrieveSolutionSetProvenance retrieves the provenance saved beside the solution summary file at
+// solutionSummaryFilePath, returning nil without error where none was saved.
+func (m *Mux) retrieveSolutionSetProvenance(solutionSummaryFilePath string) (*solution.Provenance, error) {
+	provenanceFilePath := deriveProvenanceFilePath(solutionSummaryFilePath)
+	if _, statError := os.Stat(provenanceFilePath); statError != nil {
+		m.Logger().Info("No provenance found for Solution Summary [" + solutionSummaryFilePath + "]")
+		return nil, nil
+	}
+
+	provenance, readError := readProvenance(provenanceFilePath)
+	if readError != nil {
+		return nil, readError
+	}
+
+	m.Logger().Info("Solution Summary " + describeProvenance(provenance))
+	return provenance, nil
+}
+
+// deriveProvenanceFilePath returns the path of the provenance file saved beside the solution summary file at
+// solutionSummaryFilePath.
+func deriveProvenanceFilePath(solutionSummaryFilePath string) string {
+	return strings.TrimSuffix(solutionSummaryFilePath, summaryFileSuffix) + provenanceFileSuffix
+}
+
+func readProvenance(provenanceFilePath string) (*solution.Provenance, error) {
+	content, readError := os.ReadFile(provenanceFilePath)
+	if readError != nil {
+		return nil, errors.Wrap(readError, "reading provenance file")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	provenance := new(solution.Provenance)
+	if decodeError := decoder.Decode(provenance); decodeError != nil {
+		return nil, errors.Wrap(decodeError, "decoding provenance file ["+provenanceFilePath+"]")
+	}
+	return provenance, nil
+}
+
+func describeProvenance(provenance *solution.Provenance) string {
+	description := fmt.Sprintf("produced by [%s] Version [%s] from scenario [%s] and data set [%s]",
+		provenance.ExecutableName, provenance.ExecutableVersion, provenance.ConfigFile, provenance.DataSetFile)
+	if provenance.RunId != "" {
+		description += fmt.Sprintf(", run [%s] of [%d] iterations taking [%s]",
+			provenance.RunId, provenance.Iterations, provenance.ElapsedTime)
+	}
+	return description + fmt.Sprintf(", on host [%s] at [%s]", provenance.Host, provenance.Created)
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/server/rest"
+	httptest "github.com/LindsayBradford/crem/internal/pkg/server/test"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	provenanceUrl = baseUrl + "api/v1/provenance"
+
+	validScenarioFilePath         = "testdata/ValidTestScenario.toml"
+	validSolutionsSummaryFilePath = "testdata/ValidSolutions-Summary.csv"
+)
+
+func TestProvenanceGetRequest_NotFoundResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	context := TestContext{
+		Name: http.MethodGet + " " + provenanceUrl + " request returns 404 (not found) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodGet,
+			TargetUrl: provenanceUrl,
+		},
+		ExpectedResponseStatus: http.StatusNotFound,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+	muxUnderTest.Shutdown()
+}
+
+func TestProvenanceGetRequest_AfterSolutionSummarySet_OkResponse(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	muxUnderTest.SetScenario(validScenarioFilePath)
+
+	// when
+	muxUnderTest.SetSolutionSummary(validSolutionsSummaryFilePath)
+
+	context := TestContext{
+		Name: http.MethodGet + " " + provenanceUrl + " request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodGet,
+			TargetUrl: provenanceUrl,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+
+	// then
+	responseContainer := verifyResponseStatusCode(muxUnderTest, context)
+	g.Expect(responseContainer.JsonMap["ExecutableName"]).To(Equal("CREMExplorer"))
+	g.Expect(responseContainer.JsonMap["RunId"]).To(Equal("Kirkpatrick"))
+
+	muxUnderTest.Shutdown()
+}
+
+func TestProvenanceGetRequest_AfterSolutionsPosted_NotFoundResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+	muxUnderTest.SetScenario(validScenarioFilePath)
+	muxUnderTest.SetSolutionSummary(validSolutionsSummaryFilePath)
+
+	// when
+	postContext := TestContext{
+		Name: "POST /solutions csv request returns 200 (ok) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:      http.MethodPost,
+			TargetUrl:   baseUrl + "api/v1/solutions",
+			RequestBody: validSolutions,
+			ContentType: rest.CsvMimeType,
+		},
+		ExpectedResponseStatus: http.StatusOK,
+	}
+	verifyResponseStatusCode(muxUnderTest, postContext)
+
+	getContext := TestContext{
+		Name: http.MethodGet + " " + provenanceUrl + " request returns 404 (not found) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodGet,
+			TargetUrl: provenanceUrl,
+		},
+		ExpectedResponseStatus: http.StatusNotFound,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, getContext)
+	muxUnderTest.Shutdown()
+}
+
+func TestProvenancePostRequest_NotAllowedResponse(t *testing.T) {
+	// given
+	muxUnderTest := buildMuxUnderTest()
+
+	// when
+	context := TestContext{
+		Name: http.MethodPost + " " + provenanceUrl + " request returns 405 (not allowed) response",
+		T:    t,
+		Request: httptest.HttpTestRequestContext{
+			Method:    http.MethodPost,
+			TargetUrl: provenanceUrl,
+		},
+		ExpectedResponseStatus: http.StatusMethodNotAllowed,
+	}
+
+	// then
+	verifyResponseStatusCode(muxUnderTest, context)
+	muxUnderTest.Shutdown()
+}
@@ -112,6 +112,7 @@ func (m *Mux) processRequestContentForSolutions(r *http.Request, w http.Response
 	}
 
 	m.updateSolutionSummary(solutionsTable, rawTableContent)
+	m.solutionSetProvenance = nil
 	return nil
 }
 
@@ -270,6 +271,29 @@ func (m *Mux) rememberSolutionsAttributeState(requestContent string) {
 
 func (m *Mux) SetSolutionSummary(solutionSummaryFilePath string) {
 	m.Logger().Info("Retrieving Solution Summary [" + solutionSummaryFilePath + "]")
+
+	provenance, provenanceError := m.retrieveSolutionSetProvenance(solutionSummaryFilePath)
+	if provenanceError != nil {
+		wrappingError := errors.Wrap(provenanceError, v1solutionSetHandler)
+		m.Logger().Error(wrappingError)
+		return
+	}
+
+	if provenance != nil {
+		m.Logger().Info("Loading scenario [" + provenance.ScenarioName + "] that Solution Summary was produced from")
+		if loadError := m.loadScenarioOf(provenance); loadError != nil {
+			wrappingError := errors.Wrap(loadError, "refusing Solution Summary ["+solutionSummaryFilePath+"]")
+			m.Logger().Error(errors.Wrap(wrappingError, v1solutionSetHandler))
+			return
+		}
+	}
+
+	if m.model == nil {
+		wrappingError := errors.Wrap(errors.New("Solution Summary supplied without a scenario or provenance to load one from"), v1solutionSetHandler)
+		m.Logger().Error(wrappingError)
+		return
+	}
+
 	rawTableContent := readFileAsText(solutionSummaryFilePath)
 
 	requestTable, parseError := m.deriveSolutionsRequestTable(rawTableContent)
@@ -287,6 +311,7 @@ func (m *Mux) SetSolutionSummary(solutionSummaryFilePath string) {
 	}
 
 	m.updateSolutionSummary(requestTable, rawTableContent)
+	m.solutionSetProvenance = provenance
 }
 
 func (m *Mux) updateSolutionSummary(solutionSetTable dataset.HeadingsTable, rawMessageContent string) {
@@ -0,0 +1,52 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/LindsayBradford/crem/cmd/cremexplorer/commandline"
+	"github.com/LindsayBradford/crem/internal/pkg/config/catalogue"
+	"github.com/pkg/errors"
+)
+
+const (
+	TextParameterFormat = "Text"
+	JsonParameterFormat = "JSON"
+	TomlParameterFormat = "TOML"
+)
+
+// RunParameterDescription prints the parameters accepted by the annealer or model type given (or every registered
+// type, where componentType is empty), in the format given.
+func RunParameterDescription(componentType string, format string) {
+	marshaler, formatError := catalogueMarshalerFor(format)
+	if formatError != nil {
+		commandline.Exit(formatError)
+	}
+
+	parameterCatalogue, describeError := catalogue.Describe(componentType)
+	if describeError != nil {
+		commandline.Exit(describeError)
+	}
+
+	marshaledCatalogue, marshalError := marshaler.Marshal(parameterCatalogue)
+	if marshalError != nil {
+		commandline.Exit(errors.Wrap(marshalError, "marshaling parameter catalogue"))
+	}
+	fmt.Fprintln(os.Stdout, string(marshaledCatalogue))
+}
+
+func catalogueMarshalerFor(format string) (catalogue.Marshaler, error) {
+	switch format {
+	case TextParameterFormat:
+		return new(catalogue.TextMarshaler), nil
+	case JsonParameterFormat:
+		return new(catalogue.JsonMarshaler), nil
+	case TomlParameterFormat:
+		return new(catalogue.TomlMarshaler), nil
+	default:
+		return nil, errors.Errorf("parameter format [%s] must be one of [%s, %s, %s]",
+			format, TextParameterFormat, JsonParameterFormat, TomlParameterFormat)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package bootstrap
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	data2 "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/program"
+)
+
+const programSolutionIdSuffix = " Program Solution"
+
+func RunExcelCompatibleProgramExportFromConfigFile(configFile string, programFile string, objective string) {
+	runExcelCompatibleFromConfigFile(configFile, func(configFile string) {
+		RunProgramExportFromConfigFile(configFile, programFile, objective)
+	})
+}
+
+func RunProgramExportFromConfigFile(configFile string, programFile string, objective string) {
+	deriveScenario(configFile)
+	exportProgram(deriveProgram(objective), programFile)
+	flushStreams()
+}
+
+func RunExcelCompatibleProgramImportFromConfigFile(configFile string, solverFile string, objective string) {
+	runExcelCompatibleFromConfigFile(configFile, func(configFile string) {
+		RunProgramImportFromConfigFile(configFile, solverFile, objective)
+	})
+}
+
+func RunProgramImportFromConfigFile(configFile string, solverFile string, objective string) {
+	myConfig := deriveScenario(configFile)
+	importProgramSolution(deriveProgram(objective), solverFile, &myConfig.Scenario)
+	flushStreams()
+}
+
+// deriveProgram builds a program from the scenario's model, with all management actions inactive.
+func deriveProgram(objective string) *program.Program {
+	programModel := myInterpreter.Model()
+	programModel.Initialise(model.AsIs)
+
+	derivedProgram, buildError := new(program.Builder).
+		ForModel(programModel).
+		WithObjective(objective).
+		Build()
+
+	if buildError != nil {
+		exitOnUncertaintyError(buildError, "building program from scenario model")
+	}
+	return derivedProgram
+}
+
+func exportProgram(programToExport *program.Program, programFile string) {
+	file, createError := os.Create(programFile)
+	if createError != nil {
+		exitOnUncertaintyError(createError, "creating program file ["+programFile+"]")
+	}
+	defer file.Close()
+
+	if writeError := programToExport.WriteFor(programFile, file); writeError != nil {
+		exitOnUncertaintyError(writeError, "writing program file ["+programFile+"]")
+	}
+
+	LogHandler.Info(fmt.Sprintf("Exported program of [%d] actions and [%d] constraints, %s [%s], to [%s]",
+		len(programToExport.Columns), len(programToExport.Constraints), strings.ToLower(programToExport.Sense.String()),
+		programToExport.Objective, programFile))
+}
+
+func importProgramSolution(importedProgram *program.Program, solverFile string, scenarioConfig *data2.ScenarioConfig) {
+	file, openError := os.Open(solverFile)
+	if openError != nil {
+		exitOnUncertaintyError(openError, "opening solver assignment ["+solverFile+"]")
+	}
+	defer file.Close()
+
+	programModel := myInterpreter.Model()
+	solutionId := scenarioConfig.Name + programSolutionIdSuffix
+	programSolution, importError := importedProgram.SolutionFrom(file, programModel, solutionId)
+	if importError != nil {
+		exitOnUncertaintyError(importError, "importing solver assignment ["+solverFile+"]")
+	}
+
+	reportProgramApproximation(importedProgram, programModel)
+	saveProgramSolution(programSolution, scenarioConfig)
+}
+
+// reportProgramApproximation logs how far the program's linear prediction of each decision variable strays from
+// the model's value, for the assignment applied to the model.
+func reportProgramApproximation(importedProgram *program.Program, programModel model.Model) {
+	assignment := make(program.Assignment)
+	for _, column := range importedProgram.Columns {
+		assignment[column.Name] = programModel.ManagementActions()[column.ActionIndex].IsActive()
+	}
+
+	for name, predictedValue := range importedProgram.PredictedValues(assignment) {
+		actualValue := programModel.DecisionVariable(name).Value()
+		LogHandler.Info(fmt.Sprintf("Program solution [%s] = [%g], program predicted [%g] (difference [%g])",
+			name, actualValue, predictedValue, math.Abs(actualValue-predictedValue)))
+	}
+}
+
+func saveProgramSolution(programSolution *solution.Solution, scenarioConfig *data2.ScenarioConfig) {
+	if mkdirError := os.MkdirAll(scenarioConfig.OutputPath, os.ModePerm); mkdirError != nil {
+		exitOnUncertaintyError(mkdirError, "creating output path ["+scenarioConfig.OutputPath+"]")
+	}
+
+	encoder := new(encoding.Builder).
+		ForOutputType(encoding.OutputType(scenarioConfig.OutputType.String())).
+		WithOutputPath(scenarioConfig.OutputPath).
+		WithLogHandler(LogHandler).
+		Build()
+
+	if encodingError := encoder.Encode(programSolution); encodingError != nil {
+		exitOnUncertaintyError(encodingError, "saving program solution")
+	}
+
+	LogHandler.Info("Saved program solution [" + programSolution.Id + "] to [" + scenarioConfig.OutputPath + "]")
+}
@@ -3,8 +3,12 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
 
 	"github.com/LindsayBradford/crem/cmd/cremexplorer/commandline"
 	data2 "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
@@ -16,6 +20,8 @@ import (
 	"github.com/pkg/errors"
 )
 
+const resolvedConfigFileSuffix = "-ResolvedConfig.toml"
+
 var (
 	LogHandler    logging.Logger
 	myScenario    scenario.Scenario
@@ -27,12 +33,16 @@ func init() {
 }
 
 func RunExcelCompatibleScenarioFromConfigFile(configFile string) {
+	runExcelCompatibleFromConfigFile(configFile, RunScenarioFromConfigFile)
+}
+
+func runExcelCompatibleFromConfigFile(configFile string, runFunction func(configFile string)) {
 	defer gracefullyHandlePanics()
 
 	excel.EnableSpreadsheetSafeties()
 	defer excel.DisableSpreadsheetSafeties()
 
-	go runMainThreadBoundScenarioFromConfigFile(configFile)
+	go runMainThreadBoundFromConfigFile(configFile, runFunction)
 	threading.GetMainThreadChannel().RunHandler()
 }
 
@@ -46,7 +56,7 @@ func gracefullyHandlePanics() {
 	}
 }
 
-func runMainThreadBoundScenarioFromConfigFile(configFile string) {
+func runMainThreadBoundFromConfigFile(configFile string, runFunction func(configFile string)) {
 	defer func() {
 		if r := recover(); r != nil {
 			if recoveredError, isError := r.(error); isError {
@@ -57,7 +67,7 @@ func runMainThreadBoundScenarioFromConfigFile(configFile string) {
 		}
 	}()
 
-	RunScenarioFromConfigFile(configFile)
+	runFunction(configFile)
 	defer threading.GetMainThreadChannel().Close()
 }
 
@@ -67,8 +77,12 @@ func RunScenarioFromConfigFile(configFile string) {
 	flushStreams()
 }
 
+// runScenario runs the scenario, stopping it early (but still saving the solutions found so far) on an interrupt.
 func runScenario() {
-	if runError := myScenario.Run(); runError != nil {
+	ctx, stopNotifying := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stopNotifying()
+
+	if runError := myScenario.Run(ctx); runError != nil {
 		wrappingError := errors.Wrap(runError, "running scenario")
 		LogHandler.Error(wrappingError)
 		commandline.Exit(runError)
@@ -80,15 +94,12 @@ func flushStreams() {
 	os.Stderr.Sync()
 }
 
-func deriveScenario(configFile string) {
+func deriveScenario(configFile string) *data2.Config {
 	myConfig := loadScenarioConfig(configFile)
 	myScenario = myInterpreter.Interpret(myConfig).Scenario()
 
 	LogHandler = myScenario.LogHandler()
-	metaData := myConfig.MetaData
-	metaDataSummary := fmt.Sprintf("Running [%s] Version [%s] with scenario [%s]",
-		metaData.ExecutableName, metaData.ExecutableVersion, metaData.FilePath)
-	LogHandler.Info(metaDataSummary)
+	logMetaDataSummary(myConfig)
 
 	interpreterErrors := myInterpreter.Errors()
 
@@ -96,6 +107,52 @@ func deriveScenario(configFile string) {
 		wrappingError := errors.Wrap(interpreterErrors, "interpreting scenario file")
 		commandline.Exit(wrappingError)
 	}
+
+	saveResolvedConfig(myConfig)
+	return myConfig
+}
+
+// deriveConfig retrieves the configuration, interpreting no more of it than needed to log with, for those commands
+// that build their own scenarios or models from it, rather than running the scenario it describes.
+func deriveConfig(configFile string) *data2.Config {
+	myConfig := loadScenarioConfig(configFile)
+
+	reportingInterpreter := interpreter2.NewObserverConfigInterpreter().Interpret(&myConfig.Scenario.Reporting)
+	LogHandler = reportingInterpreter.LogHandler()
+	logMetaDataSummary(myConfig)
+
+	if interpreterErrors := reportingInterpreter.Errors(); interpreterErrors != nil {
+		wrappingError := errors.Wrap(interpreterErrors, "interpreting scenario file reporting")
+		commandline.Exit(wrappingError)
+	}
+
+	return myConfig
+}
+
+func logMetaDataSummary(myConfig *data2.Config) {
+	metaData := myConfig.MetaData
+	metaDataSummary := fmt.Sprintf("Running [%s] Version [%s] with scenario [%s]",
+		metaData.ExecutableName, metaData.ExecutableVersion, metaData.FilePath)
+	LogHandler.Info(metaDataSummary)
+}
+
+// saveResolvedConfig saves the configuration as resolved (inheritance merged, variables substituted) to the scenario's
+// output path, recording exactly what was run.
+func saveResolvedConfig(myConfig *data2.Config) {
+	outputPath := myConfig.Scenario.OutputPath
+	if mkDirError := os.MkdirAll(outputPath, os.ModePerm); mkDirError != nil {
+		LogHandler.Warn(errors.Wrap(mkDirError, "creating output path for resolved configuration"))
+		return
+	}
+
+	fileName := strings.Replace(myConfig.Scenario.Name, " ", "", -1) + resolvedConfigFileSuffix
+	filePath := filepath.Join(outputPath, fileName)
+	if writeError := os.WriteFile(filePath, []byte(myConfig.MetaData.ResolvedContent), 0666); writeError != nil {
+		LogHandler.Warn(errors.Wrap(writeError, "saving resolved configuration"))
+		return
+	}
+
+	LogHandler.Info("Saved resolved configuration to [" + filePath + "]")
 }
 
 func loadScenarioConfig(configFile string) *data2.Config {
@@ -0,0 +1,81 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LindsayBradford/crem/cmd/cremexplorer/commandline"
+	data2 "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	interpreter2 "github.com/LindsayBradford/crem/cmd/cremexplorer/config/interpreter"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
+	"github.com/LindsayBradford/crem/internal/pkg/uncertainty"
+	"github.com/pkg/errors"
+)
+
+const sensitivityFileSuffix = "-Sensitivity.csv"
+
+func RunExcelCompatibleSensitivityAnalysisFromConfigFile(configFile string) {
+	runExcelCompatibleFromConfigFile(configFile, RunSensitivityAnalysisFromConfigFile)
+}
+
+func RunSensitivityAnalysisFromConfigFile(configFile string) {
+	myConfig := deriveConfig(configFile)
+	sensitivityInterpreter := deriveSensitivityAnalysis(myConfig)
+	saveResolvedConfig(myConfig)
+	runSensitivityAnalysis(sensitivityInterpreter, &myConfig.Scenario)
+	flushStreams()
+}
+
+func deriveSensitivityAnalysis(myConfig *data2.Config) *interpreter2.SensitivityConfigInterpreter {
+	sensitivityInterpreter := interpreter2.NewSensitivityConfigInterpreter().
+		Interpret(&myConfig.Sensitivity, &myConfig.Model)
+
+	if interpreterErrors := sensitivityInterpreter.Errors(); interpreterErrors != nil {
+		wrappingError := errors.Wrap(interpreterErrors, "interpreting scenario file sensitivity analysis")
+		commandline.Exit(wrappingError)
+	}
+
+	return sensitivityInterpreter
+}
+
+func runSensitivityAnalysis(sensitivityInterpreter *interpreter2.SensitivityConfigInterpreter, scenarioConfig *data2.ScenarioConfig) {
+	var solutions []set.Solution
+	if solutionSetFile := sensitivityInterpreter.SolutionSetFile(); solutionSetFile != "" {
+		var readError error
+		solutions, readError = set.ReadFromFile(solutionSetFile)
+		if readError != nil {
+			exitOnUncertaintyError(readError, "reading solution set ["+solutionSetFile+"]")
+		}
+		LogHandler.Info(fmt.Sprintf("Analysing parameter sensitivity of [%d] solutions from [%s]", len(solutions), solutionSetFile))
+	} else {
+		LogHandler.Info("Analysing parameter sensitivity of the As-Is solution")
+	}
+
+	results, evaluateError := sensitivityInterpreter.Analysis().Evaluate(solutions)
+	if evaluateError != nil {
+		exitOnUncertaintyError(evaluateError, "evaluating sensitivity analysis")
+	}
+
+	LogHandler.Info(fmt.Sprintf("Sensitivity analysis [%s] took [%d] model evaluations", results.Method, results.EvaluationNumber))
+	saveSensitivityResults(results, scenarioConfig)
+}
+
+func saveSensitivityResults(results *uncertainty.SensitivityResults, scenarioConfig *data2.ScenarioConfig) {
+	marshaledResults, marshalError := new(uncertainty.SensitivityCsvMarshaler).Marshal(results)
+	if marshalError != nil {
+		exitOnUncertaintyError(marshalError, "marshaling sensitivity analysis results")
+	}
+
+	fileName := strings.Replace(scenarioConfig.Name, " ", "", -1) + sensitivityFileSuffix
+	outputPath := filepath.Join(scenarioConfig.OutputPath, fileName)
+
+	if writeError := os.WriteFile(outputPath, marshaledResults, 0666); writeError != nil {
+		exitOnUncertaintyError(writeError, "saving sensitivity analysis results")
+	}
+
+	LogHandler.Info("Saved sensitivity analysis results to [" + outputPath + "]")
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package bootstrap
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/LindsayBradford/crem/cmd/cremexplorer/commandline"
+	data2 "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	interpreter2 "github.com/LindsayBradford/crem/cmd/cremexplorer/config/interpreter"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/suppapitnarm"
+	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/scenario"
+	"github.com/LindsayBradford/crem/internal/pkg/sweep"
+	"github.com/pkg/errors"
+)
+
+const sweepFileSuffix = "-Sweep.csv"
+
+func RunExcelCompatibleSweepFromConfigFile(configFile string) {
+	runExcelCompatibleFromConfigFile(configFile, RunSweepFromConfigFile)
+}
+
+func RunSweepFromConfigFile(configFile string) {
+	myConfig := deriveConfig(configFile)
+	dataSourceModel := deriveDataSourceModel(myConfig)
+	mySweep := deriveSweep(myConfig, dataSourceModel)
+	runSweep(mySweep, &myConfig.Scenario)
+	dataSourceModel.TearDown()
+	flushStreams()
+}
+
+// deriveDataSourceModel builds the configured model, loading its source data set once, for the model of every
+// combination built from the same source data to share.
+func deriveDataSourceModel(myConfig *data2.Config) model.Model {
+	modelInterpreter := interpreter.NewModelConfigInterpreter().Interpret(&myConfig.Model)
+	if interpreterErrors := modelInterpreter.Errors(); interpreterErrors != nil {
+		exitOnSweepError(interpreterErrors, "interpreting scenario file model")
+	}
+
+	dataSourceModel := modelInterpreter.Model()
+	if sharer, canShare := dataSourceModel.(model.DataSourceSharer); canShare {
+		if _, loadError := sharer.SharedDataSource(); loadError != nil {
+			exitOnSweepError(loadError, "loading model data source")
+		}
+	}
+	return dataSourceModel
+}
+
+// deriveSweep builds a sweep running a fresh copy of the configured scenario per combination, with the combination's
+// parameter values applied, and its resolved configuration saved.  Each combination's model shares the source data
+// set of dataSourceModel where built from the same source data.  Each combination runs its runs up to the
+// scenario's maximum concurrent run number at once, with as many combinations running concurrently as that number
+// allows.
+func deriveSweep(myConfig *data2.Config, dataSourceModel model.Model) *sweep.Sweep {
+	sweepInterpreter := interpreter2.NewSweepConfigInterpreter().
+		WithScenarioSeed(myConfig.Scenario.RandomNumberSeed).
+		Interpret(&myConfig.Sweep)
+	if interpreterErrors := sweepInterpreter.Errors(); interpreterErrors != nil {
+		wrappingError := errors.Wrap(interpreterErrors, "interpreting scenario file sweep")
+		commandline.Exit(wrappingError)
+	}
+
+	buildCombinationScenario := func(number int, combination sweep.Combination, tracker scenario.RunTracker) (scenario.Scenario, error) {
+		combinationConfig := interpreter2.SweepCombinationConfig(myConfig, number, combination)
+		combinationInterpreter := interpreter2.NewInterpreter().
+			WithRunTracker(tracker).
+			WithDataSourceOf(dataSourceModel)
+		combinationScenario := combinationInterpreter.Interpret(combinationConfig).Scenario()
+		if interpreterErrors := combinationInterpreter.Errors(); interpreterErrors != nil {
+			return nil, interpreterErrors
+		}
+		saveResolvedConfig(combinationConfig)
+		return combinationScenario, nil
+	}
+
+	return sweep.New().
+		WithName(myConfig.Scenario.Name).
+		WithDesign(sweepInterpreter.Design()).
+		WithScenarioBuilder(buildCombinationScenario).
+		WithMaximumConcurrentScenarios(concurrentCombinationNumber(&myConfig.Scenario)).
+		WithFrontObjectives(frontObjectivesOf(&myConfig.Annealer)...).
+		WithLogHandler(LogHandler)
+}
+
+// concurrentCombinationNumber returns how many combinations may run at once, with each running as many of its runs
+// at once as the scenario allows, without exceeding the scenario's maximum concurrent run number overall.
+func concurrentCombinationNumber(scenarioConfig *data2.ScenarioConfig) uint64 {
+	runsAtOnce := scenarioConfig.MaximumConcurrentRunNumber
+	if scenarioConfig.RunNumber < runsAtOnce {
+		runsAtOnce = scenarioConfig.RunNumber
+	}
+	if runsAtOnce == 0 {
+		return 1
+	}
+	return scenarioConfig.MaximumConcurrentRunNumber / runsAtOnce
+}
+
+// frontObjectivesOf returns the decision variables the configured annealer assesses front quality over, for the
+// sweep to assess fronts over the same.
+func frontObjectivesOf(annealerConfig *data.AnnealerConfig) []string {
+	configuredObjectives, _ := annealerConfig.Parameters[suppapitnarm.FrontQualityObjectives].(string)
+	objectives := make([]string, 0)
+	for _, objective := range strings.Split(configuredObjectives, ",") {
+		if trimmedObjective := strings.TrimSpace(objective); trimmedObjective != "" {
+			objectives = append(objectives, trimmedObjective)
+		}
+	}
+	return objectives
+}
+
+// runSweep runs the sweep, stopping it early (but still saving the results found so far) on an interrupt.
+func runSweep(mySweep *sweep.Sweep, scenarioConfig *data2.ScenarioConfig) {
+	ctx, stopNotifying := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stopNotifying()
+
+	results, runError := mySweep.Run(ctx)
+	if results != nil {
+		saveSweepResults(results, scenarioConfig)
+	}
+
+	if runError != nil {
+		exitOnSweepError(runError, "running sweep")
+	}
+}
+
+func saveSweepResults(results *sweep.Results, scenarioConfig *data2.ScenarioConfig) {
+	marshaledResults, marshalError := new(sweep.CsvMarshaler).Marshal(results)
+	if marshalError != nil {
+		exitOnSweepError(marshalError, "marshaling sweep results")
+	}
+
+	fileName := strings.Replace(scenarioConfig.Name, " ", "", -1) + sweepFileSuffix
+	outputPath := filepath.Join(scenarioConfig.OutputPath, fileName)
+
+	if writeError := os.WriteFile(outputPath, marshaledResults, 0666); writeError != nil {
+		exitOnSweepError(writeError, "saving sweep results")
+	}
+
+	LogHandler.Info("Saved sweep results to [" + outputPath + "]")
+}
+
+func exitOnSweepError(sweepError error, context string) {
+	wrappingError := errors.Wrap(sweepError, context)
+	LogHandler.Error(wrappingError)
+	commandline.Exit(wrappingError)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LindsayBradford/crem/cmd/cremexplorer/commandline"
+	data2 "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	interpreter2 "github.com/LindsayBradford/crem/cmd/cremexplorer/config/interpreter"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
+	"github.com/LindsayBradford/crem/internal/pkg/uncertainty"
+	"github.com/pkg/errors"
+)
+
+const uncertaintyFileSuffix = "-Uncertainty.csv"
+
+func RunExcelCompatibleUncertaintyAnalysisFromConfigFile(configFile string) {
+	runExcelCompatibleFromConfigFile(configFile, RunUncertaintyAnalysisFromConfigFile)
+}
+
+func RunUncertaintyAnalysisFromConfigFile(configFile string) {
+	myConfig := deriveConfig(configFile)
+	uncertaintyInterpreter := deriveUncertaintyAnalysis(myConfig)
+	saveResolvedConfig(myConfig)
+	runUncertaintyAnalysis(uncertaintyInterpreter, &myConfig.Scenario)
+	flushStreams()
+}
+
+func deriveUncertaintyAnalysis(myConfig *data2.Config) *interpreter2.UncertaintyConfigInterpreter {
+	uncertaintyInterpreter := interpreter2.NewUncertaintyConfigInterpreter().
+		Interpret(&myConfig.Uncertainty, &myConfig.Model)
+
+	if interpreterErrors := uncertaintyInterpreter.Errors(); interpreterErrors != nil {
+		wrappingError := errors.Wrap(interpreterErrors, "interpreting scenario file uncertainty analysis")
+		commandline.Exit(wrappingError)
+	}
+
+	return uncertaintyInterpreter
+}
+
+func runUncertaintyAnalysis(uncertaintyInterpreter *interpreter2.UncertaintyConfigInterpreter, scenarioConfig *data2.ScenarioConfig) {
+	solutionSetFile := uncertaintyInterpreter.SolutionSetFile()
+	solutions, readError := set.ReadFromFile(solutionSetFile)
+	if readError != nil {
+		exitOnUncertaintyError(readError, "reading solution set ["+solutionSetFile+"]")
+	}
+
+	LogHandler.Info(fmt.Sprintf("Analysing uncertainty of [%d] solutions from [%s]", len(solutions), solutionSetFile))
+
+	results, evaluateError := uncertaintyInterpreter.Analysis().Evaluate(solutions)
+	if evaluateError != nil {
+		exitOnUncertaintyError(evaluateError, "evaluating uncertainty analysis")
+	}
+
+	reportFragileSolutions(results)
+	saveUncertaintyResults(results, scenarioConfig)
+}
+
+func reportFragileSolutions(results *uncertainty.Results) {
+	for _, solutionResult := range results.Solutions {
+		if solutionResult.IsFragile() {
+			LogHandler.Warn("Solution [" + solutionResult.Id + "] is fragile to parameter uncertainty")
+		}
+		if solutionResult.UnmatchedActions > 0 {
+			LogHandler.Warn(fmt.Sprintf("Solution [%s] had [%d] active actions unmatched across sampled models",
+				solutionResult.Id, solutionResult.UnmatchedActions))
+		}
+	}
+}
+
+func saveUncertaintyResults(results *uncertainty.Results, scenarioConfig *data2.ScenarioConfig) {
+	marshaledResults, marshalError := new(uncertainty.CsvMarshaler).Marshal(results)
+	if marshalError != nil {
+		exitOnUncertaintyError(marshalError, "marshaling uncertainty analysis results")
+	}
+
+	fileName := strings.Replace(scenarioConfig.Name, " ", "", -1) + uncertaintyFileSuffix
+	outputPath := filepath.Join(scenarioConfig.OutputPath, fileName)
+
+	if writeError := os.WriteFile(outputPath, marshaledResults, 0666); writeError != nil {
+		exitOnUncertaintyError(writeError, "saving uncertainty analysis results")
+	}
+
+	LogHandler.Info("Saved uncertainty analysis results to [" + outputPath + "]")
+}
+
+func exitOnUncertaintyError(uncertaintyError error, context string) {
+	wrappingError := errors.Wrap(uncertaintyError, context)
+	LogHandler.Error(wrappingError)
+	commandline.Exit(wrappingError)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/LindsayBradford/crem/cmd/cremexplorer/commandline"
+	interpreter2 "github.com/LindsayBradford/crem/cmd/cremexplorer/config/interpreter"
+	"github.com/LindsayBradford/crem/internal/pkg/config/validation"
+	"github.com/pkg/errors"
+)
+
+const (
+	TextValidationFormat = "Text"
+	JsonValidationFormat = "JSON"
+)
+
+func RunExcelCompatibleValidationFromConfigFile(configFile string, format string) {
+	runExcelCompatibleFromConfigFile(configFile, func(configFile string) {
+		RunValidationFromConfigFile(configFile, format)
+	})
+}
+
+// RunValidationFromConfigFile checks the configuration file without annealing, printing a report of every check
+// made in the format given, and exiting with a failure code if any check failed.
+func RunValidationFromConfigFile(configFile string, format string) {
+	marshaler, formatError := validationMarshalerFor(format)
+	if formatError != nil {
+		commandline.Exit(formatError)
+	}
+
+	report := interpreter2.NewConfigValidator().ValidateFile(configFile)
+
+	marshaledReport, marshalError := marshaler.Marshal(report)
+	if marshalError != nil {
+		commandline.Exit(errors.Wrap(marshalError, "marshaling validation report"))
+	}
+	fmt.Fprintln(os.Stdout, string(marshaledReport))
+
+	if report.HasFailures() {
+		commandline.Exit(errors.Errorf("configuration [%s] failed %d validation check(s)",
+			configFile, report.Number(validation.Failed)))
+	}
+}
+
+func validationMarshalerFor(format string) (validation.Marshaler, error) {
+	switch format {
+	case TextValidationFormat:
+		return new(validation.TextMarshaler), nil
+	case JsonValidationFormat:
+		return new(validation.JsonMarshaler), nil
+	default:
+		return nil, errors.Errorf("validation format [%s] must be one of [%s, %s]",
+			format, TextValidationFormat, JsonValidationFormat)
+	}
+}
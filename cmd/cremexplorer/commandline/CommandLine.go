@@ -31,9 +31,21 @@ func ParseArguments() *Arguments {
 }
 
 type Arguments struct {
-	Version      bool
-	Licence      bool
-	ScenarioFile string
+	Version             bool
+	Licence             bool
+	ScenarioFile        string
+	UncertaintyAnalysis bool
+	SensitivityAnalysis bool
+	Sweep               bool
+	Validate            bool
+	ValidationFormat    string
+	DescribeParameters  bool
+	ParameterType       string
+	ParameterFormat     string
+
+	ExportProgram         string
+	ImportProgramSolution string
+	ProgramObjective      string
 }
 
 // THe define sets up the relevant command-line
@@ -48,6 +60,76 @@ func (args *Arguments) define() {
 		"file dictating scenario run-time behaviour",
 	)
 
+	flag.BoolVar(
+		&args.UncertaintyAnalysis,
+		"UncertaintyAnalysis",
+		false,
+		"Analyses the uncertainty of the scenario's solution set instead of running the scenario.",
+	)
+
+	flag.BoolVar(
+		&args.SensitivityAnalysis,
+		"SensitivityAnalysis",
+		false,
+		"Ranks the model parameters driving the scenario's decision variables instead of running the scenario.",
+	)
+
+	flag.BoolVar(
+		&args.Sweep,
+		"Sweep",
+		false,
+		"Runs the scenario once per combination of the parameter values its [Sweep] section describes.",
+	)
+
+	flag.BoolVar(
+		&args.Validate,
+		"Validate",
+		false,
+		"Checks the scenario's configuration, reporting any problems found, instead of running the scenario.",
+	)
+
+	flag.StringVar(
+		&args.ValidationFormat,
+		"ValidationFormat",
+		"Text",
+		"Format of the validation report, one of Text or JSON.",
+	)
+
+	flag.BoolVar(
+		&args.DescribeParameters,
+		"DescribeParameters",
+		false,
+		"Describes the parameters of the annealer or model type following the flags (or of all types), then exits.",
+	)
+
+	flag.StringVar(
+		&args.ParameterFormat,
+		"ParameterFormat",
+		"Text",
+		"Format of the parameter description, one of Text, JSON or TOML.",
+	)
+
+	flag.StringVar(
+		&args.ExportProgram,
+		"ExportProgram",
+		"",
+		"Exports the scenario's model as a 0-1 program to the (.lp or .mps) file given instead of running the scenario.",
+	)
+
+	flag.StringVar(
+		&args.ImportProgramSolution,
+		"ImportProgramSolution",
+		"",
+		"Saves a solution from the solver assignment file given for an exported program instead of running the scenario.",
+	)
+
+	flag.StringVar(
+		&args.ProgramObjective,
+		"ProgramObjective",
+		"SedimentProduction",
+		"Decision variable to optimise in exported programs.",
+	)
+
 	flag.BoolVar(
 		&args.Version,
 		"Version",
@@ -91,9 +173,17 @@ func (args *Arguments) process() {
 		Exit(0)
 	}
 
+	if args.DescribeParameters {
+		args.ParameterType = flag.Arg(0)
+	}
+
 	if args.ScenarioFile != "" {
 		validateFilePath(args.ScenarioFile)
 	}
+
+	if args.ImportProgramSolution != "" {
+		validateFilePath(args.ImportProgramSolution)
+	}
 }
 
 func validateFilePath(filePath string) {
@@ -136,9 +226,38 @@ func usageMessage() {
 	fmt.Println("  --Version                      Prints the version number of this utility.")
 	fmt.Println("  --Licence                       Prints the copyright licence of this utility.")
 	fmt.Println("  --ScenarioFile  <FilePath>     File describing a scenario to run and its  run-time behaviour.")
+	fmt.Println("  --UncertaintyAnalysis          Re-evaluates the scenario's solution set under sampled model parameters.")
+	fmt.Println("  --SensitivityAnalysis          Ranks the model parameters driving the scenario's decision variables.")
+	fmt.Println("  --Sweep                        Runs the scenario per combination of its [Sweep] parameter values.")
+	fmt.Println("  --Validate                     Checks the scenario's configuration without running the scenario.")
+	fmt.Println("  --ValidationFormat <Format>    Format of the validation report, Text or JSON (default Text).")
+	fmt.Println("  --DescribeParameters [Type]    Describes the parameters each annealer and model type accepts.")
+	fmt.Println("  --ParameterFormat <Format>     Format of parameter descriptions, Text, JSON or TOML (default Text).")
+	fmt.Println("  --ExportProgram <FilePath>     Exports the scenario's model as a 0-1 program in LP or MPS (.mps) format.")
+	fmt.Println("  --ImportProgramSolution <FilePath>  Saves the solution a solver found for an exported program.")
+	fmt.Println("  --ProgramObjective <Name>      Decision variable an exported program optimises (default SedimentProduction).")
 	fmt.Println()
 	fmt.Println("Running a single scenario takes the form:")
 	fmt.Printf("  %s --ScenarioFile <FilePath>\n", justExecutableName())
+	fmt.Println()
+	fmt.Println("Analysing the uncertainty of a scenario's solution set takes the form:")
+	fmt.Printf("  %s --ScenarioFile <FilePath> --UncertaintyAnalysis\n", justExecutableName())
+	fmt.Println()
+	fmt.Println("Analysing the sensitivity of a scenario's decision variables to model parameters takes the form:")
+	fmt.Printf("  %s --ScenarioFile <FilePath> --SensitivityAnalysis\n", justExecutableName())
+	fmt.Println()
+	fmt.Println("Sweeping a scenario over combinations of annealer and model parameter values takes the form:")
+	fmt.Printf("  %s --ScenarioFile <FilePath> --Sweep\n", justExecutableName())
+	fmt.Println()
+	fmt.Println("Checking a scenario's configuration, without running the scenario, takes the form:")
+	fmt.Printf("  %s --ScenarioFile <FilePath> --Validate --ValidationFormat <Text|JSON>\n", justExecutableName())
+	fmt.Println()
+	fmt.Println("Describing the parameters of an annealer or model type (or all types, if none given) takes the form:")
+	fmt.Printf("  %s --ParameterFormat <Text|JSON|TOML> --DescribeParameters [Type]\n", justExecutableName())
+	fmt.Println()
+	fmt.Println("Benchmarking a scenario against an exact solver takes the form:")
+	fmt.Printf("  %s --ScenarioFile <FilePath> --ExportProgram <ProgramFilePath> --ProgramObjective <Name>\n", justExecutableName())
+	fmt.Printf("  %s --ScenarioFile <FilePath> --ImportProgramSolution <SolverFilePath> --ProgramObjective <Name>\n", justExecutableName())
 
 	Exit(0)
 }
@@ -10,4 +10,11 @@ type Config struct {
 	Scenario ScenarioConfig
 	Annealer data.AnnealerConfig
 	Model    data.ModelConfig
+
+	Robustness RobustnessConfig
+
+	Uncertainty UncertaintyConfig
+	Sensitivity SensitivityConfig
+
+	Sweep SweepConfig
 }
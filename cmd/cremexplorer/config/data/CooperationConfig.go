@@ -0,0 +1,13 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package data
+
+// CooperationConfig has the concurrent runs of a scenario share their progress, every ExchangeInterval iterations.
+// Suppapitnarm runs exchange non-dominated solutions via a shared archive. Kirkpatrick runs anneal as parallel
+// tempering replicas, starting at StartingTemperature scaled by TemperatureLadderRatio^(run - 1), and swapping
+// solutions between neighbouring temperatures.
+type CooperationConfig struct {
+	Enabled                bool
+	ExchangeInterval       uint64
+	TemperatureLadderRatio float64
+}
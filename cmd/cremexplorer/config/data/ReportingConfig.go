@@ -9,5 +9,6 @@ import (
 type ReportingConfig struct {
 	ReportEveryNumberOfIterations uint64
 	CheckingLoopInvariant         bool
+	Trace                         TraceConfig
 	data.LoggingConfig
 }
@@ -0,0 +1,312 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	errors2 "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+// Top-level configuration keys directing how a configuration is resolved, removed from the configuration resolved.
+const (
+	extendsKey   = "Extends"
+	includeKey   = "Include"
+	variablesKey = "Variables"
+)
+
+type table = map[string]interface{}
+
+// variableReference matches '${name}' references, and '$${name}' escaped references, left as '${name}'.
+var variableReference = regexp.MustCompile(`\$(\$?)\{([^}]*)\}`)
+
+// resolveFile returns the TOML content of the file at filePath, fully resolved.
+func resolveFile(filePath string) (string, error) {
+	resolvedTable, loadError := loadTableFromFile(filePath, nil)
+	if loadError != nil {
+		return "", loadError
+	}
+	return resolveTable(resolvedTable)
+}
+
+// resolveText returns the TOML content of tomlText, fully resolved, with any files it extends or includes relative
+// to the working directory.
+func resolveText(tomlText string) (string, error) {
+	resolvedTable, loadError := loadTableFromText(tomlText, ".", nil)
+	if loadError != nil {
+		return "", loadError
+	}
+	return resolveTable(resolvedTable)
+}
+
+// resolveTable substitutes variable references throughout resolvedTable, returning the table encoded as TOML text.
+func resolveTable(resolvedTable table) (string, error) {
+	variables, _ := popKey(resolvedTable, variablesKey).(table)
+
+	substituter := newSubstituter(variables)
+	substituter.substitute(resolvedTable)
+	if substituter.errors.Size() > 0 {
+		return "", substituter.errors
+	}
+
+	return encodeTable(resolvedTable)
+}
+
+// OverriddenContent returns resolvedContent with the value at each dotted key of overrides (e.g.
+// 'Annealer.Parameters.MaximumIterations') replaced, creating any tables missing along the way.  Keys are matched
+// case-insensitively, as they are in decoding a configuration.
+func OverriddenContent(resolvedContent string, overrides map[string]interface{}) (string, error) {
+	resolvedTable := make(table)
+	if _, decodeError := toml.Decode(resolvedContent, &resolvedTable); decodeError != nil {
+		return "", errors.Wrap(decodeError, "decoding resolved configuration")
+	}
+
+	for key, value := range overrides {
+		override(resolvedTable, strings.Split(key, "."), value)
+	}
+	return encodeTable(resolvedTable)
+}
+
+func override(target table, keyPath []string, value interface{}) {
+	key := matchingKey(target, keyPath[0])
+	if len(keyPath) == 1 {
+		target[key] = value
+		return
+	}
+
+	subTable, isTable := target[key].(table)
+	if !isTable {
+		subTable = make(table)
+		target[key] = subTable
+	}
+	override(subTable, keyPath[1:], value)
+}
+
+func matchingKey(target table, key string) string {
+	for existingKey := range target {
+		if strings.EqualFold(existingKey, key) {
+			return existingKey
+		}
+	}
+	return key
+}
+
+func encodeTable(resolvedTable table) (string, error) {
+	var buffer bytes.Buffer
+	encoder := toml.NewEncoder(&buffer)
+	encoder.Indent = ""
+	if encodeError := encoder.Encode(resolvedTable); encodeError != nil {
+		return "", errors.Wrap(encodeError, "encoding resolved configuration")
+	}
+	return buffer.String(), nil
+}
+
+// loadTableFromFile loads the file at filePath as a table, deep-merged over the tables of any files it extends or
+// includes.  loading lists the files whose loading led to this one, to catch circular inheritance.
+func loadTableFromFile(filePath string, loading []string) (table, error) {
+	absolutePath, pathError := filepath.Abs(filePath)
+	if pathError != nil {
+		return nil, errors.Wrapf(pathError, "resolving path of [%s]", filePath)
+	}
+	for _, loadingPath := range loading {
+		if loadingPath == absolutePath {
+			return nil, errors.Errorf("configuration file [%s] circularly extends or includes itself", filePath)
+		}
+	}
+
+	content, readError := os.ReadFile(absolutePath)
+	if readError != nil {
+		return nil, errors.Wrapf(readError, "reading configuration file [%s]", filePath)
+	}
+
+	loadedTable, loadError := loadTableFromText(string(content), filepath.Dir(absolutePath), append(loading, absolutePath))
+	if loadError != nil {
+		return nil, errors.Wrapf(loadError, "loading configuration file [%s]", filePath)
+	}
+	return loadedTable, nil
+}
+
+// loadTableFromText decodes tomlText as a table, deep-merged over the tables of the files it extends, then the files it
+// includes, in the order given.  Relative file paths are taken relative to directory.
+func loadTableFromText(tomlText string, directory string, loading []string) (table, error) {
+	loadedTable := make(table)
+	if _, decodeError := toml.Decode(tomlText, &loadedTable); decodeError != nil {
+		return nil, decodeError
+	}
+
+	basePaths, pathsError := basePathsOf(loadedTable)
+	if pathsError != nil {
+		return nil, pathsError
+	}
+
+	baseTable := make(table)
+	for _, basePath := range basePaths {
+		if !filepath.IsAbs(basePath) {
+			basePath = filepath.Join(directory, basePath)
+		}
+		loadedBaseTable, loadError := loadTableFromFile(basePath, loading)
+		if loadError != nil {
+			return nil, loadError
+		}
+		deepMerge(baseTable, loadedBaseTable)
+	}
+
+	return deepMerge(baseTable, loadedTable), nil
+}
+
+// basePathsOf removes the extends and include directives from configTable, returning the paths they list.  Paths may
+// reference environment variables, but not the configuration's own variables, which are yet to be resolved.
+func basePathsOf(configTable table) ([]string, error) {
+	var basePaths []string
+	for _, key := range []string{extendsKey, includeKey} {
+		directive := popKey(configTable, key)
+		if directive == nil {
+			continue
+		}
+
+		paths, pathsError := stringsOf(directive)
+		if pathsError != nil {
+			return nil, errors.Wrapf(pathsError, "configuration key [%s]", key)
+		}
+
+		environmentSubstituter := newSubstituter(nil)
+		for _, path := range paths {
+			basePaths = append(basePaths, environmentSubstituter.substituteString(path).(string))
+		}
+		if environmentSubstituter.errors.Size() > 0 {
+			return nil, errors.Wrapf(environmentSubstituter.errors, "configuration key [%s]", key)
+		}
+	}
+	return basePaths, nil
+}
+
+func stringsOf(value interface{}) ([]string, error) {
+	switch typedValue := value.(type) {
+	case string:
+		return []string{typedValue}, nil
+	case []interface{}:
+		values := make([]string, len(typedValue))
+		for index, entry := range typedValue {
+			stringEntry, isString := entry.(string)
+			if !isString {
+				return nil, errors.Errorf("value [%v] must be a file path", entry)
+			}
+			values[index] = stringEntry
+		}
+		return values, nil
+	default:
+		return nil, errors.Errorf("value [%v] must be a file path, or an array of file paths", value)
+	}
+}
+
+// popKey removes key (matched case-insensitively) from configTable, returning its value, or nil if absent.
+func popKey(configTable table, key string) interface{} {
+	for tableKey, value := range configTable {
+		if strings.EqualFold(tableKey, key) {
+			delete(configTable, tableKey)
+			return value
+		}
+	}
+	return nil
+}
+
+// deepMerge merges overriding into base, returning base.  Keys are matched case-insensitively, as configuration keys
+// are decoded, with base keeping its own spelling of keys present in both.  Tables present in both are merged in
+// turn. Any other value in overriding, including arrays, replaces that of base.
+func deepMerge(base table, overriding table) table {
+	for key, overridingValue := range overriding {
+		baseKey := matchingKey(base, key)
+		baseTable, baseIsTable := base[baseKey].(table)
+		overridingTable, overridingIsTable := overridingValue.(table)
+		if baseIsTable && overridingIsTable {
+			base[baseKey] = deepMerge(baseTable, overridingTable)
+			continue
+		}
+		base[baseKey] = overridingValue
+	}
+	return base
+}
+
+// substituter replaces '${name}' references in string values with the value of the named configuration variable, or
+// failing that, the named environment variable.  A string consisting solely of a reference to a non-string variable
+// takes on the variable's value and type.
+type substituter struct {
+	variables table
+	resolved  table
+	resolving map[string]bool
+	errors    *errors2.CompositeError
+}
+
+func newSubstituter(variables table) *substituter {
+	return &substituter{
+		variables: variables,
+		resolved:  make(table),
+		resolving: make(map[string]bool),
+		errors:    errors2.New("variable substitution"),
+	}
+}
+
+func (s *substituter) substitute(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case string:
+		return s.substituteString(typedValue)
+	case table:
+		for key, entry := range typedValue {
+			typedValue[key] = s.substitute(entry)
+		}
+	case []table:
+		for _, entry := range typedValue {
+			s.substitute(entry)
+		}
+	case []interface{}:
+		for index, entry := range typedValue {
+			typedValue[index] = s.substitute(entry)
+		}
+	}
+	return value
+}
+
+func (s *substituter) substituteString(text string) interface{} {
+	if match := variableReference.FindStringSubmatch(text); match != nil && match[0] == text && match[1] == "" {
+		return s.valueOf(match[2])
+	}
+
+	return variableReference.ReplaceAllStringFunc(text, func(reference string) string {
+		match := variableReference.FindStringSubmatch(reference)
+		if match[1] != "" {
+			return reference[1:]
+		}
+		return fmt.Sprint(s.valueOf(match[2]))
+	})
+}
+
+func (s *substituter) valueOf(name string) interface{} {
+	if value, isResolved := s.resolved[name]; isResolved {
+		return value
+	}
+
+	if value, isVariable := s.variables[name]; isVariable {
+		if s.resolving[name] {
+			s.errors.Add(errors.Errorf("variable [%s] references itself, directly or indirectly", name))
+			return ""
+		}
+		s.resolving[name] = true
+		s.resolved[name] = s.substitute(value)
+		delete(s.resolving, name)
+		return s.resolved[name]
+	}
+
+	if value, isEnvironmentVariable := os.LookupEnv(name); isEnvironmentVariable {
+		return value
+	}
+
+	s.errors.Add(errors.Errorf("variable [%s] is neither a configuration nor environment variable", name))
+	return ""
+}
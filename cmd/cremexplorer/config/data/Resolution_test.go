@@ -0,0 +1,151 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package data
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	extendingTestFile = "testdata/ExtendingConfig.toml"
+	circularTestFile  = "testdata/CircularConfig.toml"
+)
+
+func TestRetrieveConfigFromFile_ExtendsAndIncludes_DeepMerged(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	config, retrieveError := RetrieveConfigFromFile(extendingTestFile)
+	if retrieveError != nil {
+		t.Log(retrieveError)
+	}
+
+	// then
+	g.Expect(retrieveError).To(BeNil())
+	g.Expect(config.Scenario.Name).To(Equal("extendingScenario"))
+	g.Expect(config.Annealer.Type.Value).To(Equal(testAnnealerType))
+	g.Expect(config.Model.Type).To(Equal(expectedModelType))
+
+	g.Expect(config.Model.Parameters["WaterDensity"]).To(Equal(999.0))
+	g.Expect(config.Model.Parameters["YearsOfErosion"]).To(Equal(int64(100)))
+	g.Expect(config.Model.Parameters["DataSourcePath"]).To(Equal("input/data.xlsx"))
+	g.Expect(config.Annealer.Parameters["MaximumIterations"]).To(Equal(int64(5_000)))
+	g.Expect(config.Annealer.Parameters["CoolingFactor"]).To(Equal(0.99))
+
+	g.Expect(config.MetaData.ResolvedContent).To(ContainSubstring(`DataSourcePath = "input/data.xlsx"`))
+	g.Expect(config.MetaData.ResolvedContent).To(Not(ContainSubstring("Variables")))
+}
+
+func TestRetrieveConfigFromFile_CircularExtends_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	config, retrieveError := RetrieveConfigFromFile(circularTestFile)
+	if retrieveError != nil {
+		t.Log(retrieveError)
+	}
+
+	// then
+	g.Expect(retrieveError).To(Not(BeNil()))
+	g.Expect(retrieveError.Error()).To(ContainSubstring("circularly"))
+	g.Expect(config).To(BeNil())
+}
+
+func TestRetrieveConfigFromString_EnvironmentVariable_Substituted(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	t.Setenv("CREM_TEST_OUTPUT", "environmentOutput")
+	configText := readTestFileAsText(minimalValidTestFile) + `
+[Model.Parameters]
+DataSourcePath = "${DataDirectory}/${CREM_TEST_OUTPUT}.xlsx"
+Note = "costs $${Currency}"
+
+[Variables]
+DataDirectory = "${CREM_TEST_OUTPUT}/input"
+`
+
+	// when
+	config, retrieveError := RetrieveConfigFromString(configText)
+	if retrieveError != nil {
+		t.Log(retrieveError)
+	}
+
+	// then
+	g.Expect(retrieveError).To(BeNil())
+	g.Expect(config.Model.Parameters["DataSourcePath"]).To(Equal("environmentOutput/input/environmentOutput.xlsx"))
+	g.Expect(config.Model.Parameters["Note"]).To(Equal("costs ${Currency}"))
+}
+
+func TestRetrieveConfigFromString_UndefinedVariable_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := readTestFileAsText(minimalValidTestFile) + `
+[Model.Parameters]
+DataSourcePath = "${CREM_TEST_UNDEFINED_VARIABLE}/data.xlsx"
+`
+
+	// when
+	config, retrieveError := RetrieveConfigFromString(configText)
+	if retrieveError != nil {
+		t.Log(retrieveError)
+	}
+
+	// then
+	g.Expect(retrieveError).To(Not(BeNil()))
+	g.Expect(retrieveError.Error()).To(ContainSubstring("CREM_TEST_UNDEFINED_VARIABLE"))
+	g.Expect(config).To(BeNil())
+}
+
+func TestRetrieveConfigFromString_SelfReferencingVariable_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := readTestFileAsText(minimalValidTestFile) + `
+[Model.Parameters]
+DataSourcePath = "${First}"
+
+[Variables]
+First = "${Second}"
+Second = "${First}"
+`
+
+	// when
+	config, retrieveError := RetrieveConfigFromString(configText)
+	if retrieveError != nil {
+		t.Log(retrieveError)
+	}
+
+	// then
+	g.Expect(retrieveError).To(Not(BeNil()))
+	g.Expect(config).To(BeNil())
+}
+
+func TestDeepMerge_KeysDifferingInCase_Merged(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	base := table{
+		"Model": table{
+			"Type":       "CatchmentModel",
+			"Parameters": table{"WaterDensity": 1000.0, "YearsOfErosion": int64(100)},
+		},
+	}
+	overriding := table{
+		"model": table{
+			"parameters": table{"waterDensity": 999.0},
+		},
+	}
+
+	// when
+	merged := deepMerge(base, overriding)
+
+	// then
+	g.Expect(merged).To(HaveLen(1))
+	mergedModel := merged["Model"].(table)
+	g.Expect(mergedModel["Type"]).To(Equal("CatchmentModel"))
+	g.Expect(mergedModel["Parameters"]).To(Equal(table{"WaterDensity": 999.0, "YearsOfErosion": int64(100)}))
+}
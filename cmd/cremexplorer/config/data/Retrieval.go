@@ -16,7 +16,7 @@ type decoderSummary struct {
 	contentType contentType
 	content     string
 
-	decoder func(data string, v interface{}) (toml.MetaData, error)
+	resolver func(content string) (string, error)
 }
 
 type contentType int
@@ -37,11 +37,14 @@ func (st contentType) String() string {
 	}
 }
 
+// RetrieveConfigFromFile retrieves the configuration held in the TOML file at configFilePath.  The file may extend
+// or include other files via top-level 'Extends' and 'Include' keys, and reference variables as '${name}', as resolved
+// by resolveFile.
 func RetrieveConfigFromFile(configFilePath string) (*Config, error) {
 	summary := decoderSummary{
 		content:     configFilePath,
 		contentType: file,
-		decoder:     toml.DecodeFile,
+		resolver:    resolveFile,
 	}
 	return retrieveConfig(summary)
 }
@@ -50,7 +53,7 @@ func RetrieveConfigFromString(tomlString string) (*Config, error) {
 	summary := decoderSummary{
 		content:     tomlString,
 		contentType: text,
-		decoder:     toml.Decode,
+		resolver:    resolveText,
 	}
 	return retrieveConfig(summary)
 }
@@ -59,15 +62,21 @@ func retrieveConfig(source decoderSummary) (*Config, error) {
 	allErrors := errors2.New("configuration retrieval")
 
 	var conf = defaultConfig()
-	metaData, decodeErr := source.decoder(source.content, &conf)
-	if decodeErr != nil {
-		allErrors.Add(errors.Wrap(decodeErr, "failed retrieving config from "+source.contentType.String()))
-	}
-	if len(metaData.Undecoded()) > 0 {
-		errorMsg := fmt.Sprintf("unrecognised configuration key(s) %q", metaData.Undecoded())
-		allErrors.Add(errors.New(errorMsg))
+	resolvedContent, resolveErr := source.resolver(source.content)
+	if resolveErr != nil {
+		allErrors.Add(errors.Wrap(resolveErr, "failed retrieving config from "+source.contentType.String()))
+	} else {
+		metaData, decodeErr := toml.Decode(resolvedContent, &conf)
+		if decodeErr != nil {
+			allErrors.Add(errors.Wrap(decodeErr, "failed retrieving config from "+source.contentType.String()))
+		}
+		if len(metaData.Undecoded()) > 0 {
+			errorMsg := fmt.Sprintf("unrecognised configuration key(s) %q", metaData.Undecoded())
+			allErrors.Add(errors.New(errorMsg))
+		}
 	}
 	conf.MetaData.FilePath = deriveFilePathFromSource(source)
+	conf.MetaData.ResolvedContent = resolvedContent
 	conf.MetaData.ExecutableName = config.ExecutableName
 	conf.MetaData.ExecutableVersion = config.Version
 
@@ -90,8 +99,35 @@ func defaultConfig() Config {
 			OutputPath:                 ".",
 			Reporting: ReportingConfig{
 				ReportEveryNumberOfIterations: 1,
+				Trace: TraceConfig{
+					Format:                  CsvTrace,
+					EveryNumberOfIterations: 1,
+				},
+			},
+			Cooperation: CooperationConfig{
+				ExchangeInterval:       1_000,
+				TemperatureLadderRatio: 0.5,
 			},
 		},
+		Uncertainty: UncertaintyConfig{
+			SampleNumber:       100,
+			Percentiles:        []float64{5, 50, 95},
+			FragilityThreshold: 0.1,
+		},
+		Robustness: RobustnessConfig{
+			Statistic:  MeanStatistic,
+			Percentile: 90,
+		},
+		Sensitivity: SensitivityConfig{
+			Method:           MorrisMethod,
+			TrajectoryNumber: 10,
+			Levels:           4,
+			SampleNumber:     64,
+		},
+		Sweep: SweepConfig{
+			Method:       GridSweep,
+			SampleNumber: 10,
+		},
 	}
 	return config
 }
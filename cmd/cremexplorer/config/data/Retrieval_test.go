@@ -87,6 +87,7 @@ func TestRetrieveConfigFromFile_RichValidConfig_NoErrors(t *testing.T) {
 	// then
 	g.Expect(retrieveError).To(BeNil())
 	g.Expect(config.Scenario.Name).To(Equal(expectedScenarioName))
+	g.Expect(config.Scenario.CombineRunSolutions).To(BeTrue())
 }
 
 func TestRetrieveConfigFromString_RichValidConfig_NoErrors(t *testing.T) {
@@ -106,6 +107,110 @@ func TestRetrieveConfigFromString_RichValidConfig_NoErrors(t *testing.T) {
 	g.Expect(config.Scenario.Name).To(Equal(expectedScenarioName))
 }
 
+func TestRetrieveConfigFromFile_RichValidConfig_UncertaintyDecoded(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	config, retrieveError := RetrieveConfigFromFile(richValidTestFile)
+	if retrieveError != nil {
+		t.Log(retrieveError)
+	}
+
+	// then
+	g.Expect(retrieveError).To(BeNil())
+	g.Expect(config.Uncertainty.SampleNumber).To(BeNumerically("==", 50))
+	g.Expect(config.Uncertainty.Percentiles).To(Equal([]float64{10, 50, 90}))
+	g.Expect(config.Uncertainty.FragilityThreshold).To(BeNumerically("==", 0.1))
+
+	distribution := config.Uncertainty.Distributions["InitialObjectiveValue"]
+	g.Expect(distribution.Type).To(Equal(TriangularDistribution))
+	g.Expect(distribution.Mode).To(BeNumerically("==", 2_000))
+}
+
+func TestRetrieveConfigFromFile_RichValidConfig_SensitivityDecoded(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	config, retrieveError := RetrieveConfigFromFile(richValidTestFile)
+	if retrieveError != nil {
+		t.Log(retrieveError)
+	}
+
+	// then
+	g.Expect(retrieveError).To(BeNil())
+	g.Expect(config.Sensitivity.Method).To(Equal(SobolMethod))
+	g.Expect(config.Sensitivity.SampleNumber).To(BeNumerically("==", 32))
+	g.Expect(config.Sensitivity.TrajectoryNumber).To(BeNumerically("==", 10))
+	g.Expect(config.Sensitivity.Parameters).To(Equal([]string{"InitialObjectiveValue"}))
+	g.Expect(config.Sensitivity.Ranges["InitialObjectiveValue"]).To(Equal(RangeConfig{Minimum: 1_500, Maximum: 2_500}))
+}
+
+func TestRetrieveConfigFromString_UnknownDistributionType_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := readTestFileAsText(minimalValidTestFile) + `
+[Uncertainty.Distributions.InitialObjectiveValue]
+Type = "Lognormal"
+`
+
+	// when
+	_, retrieveError := RetrieveConfigFromString(configText)
+	if retrieveError != nil {
+		t.Log(retrieveError)
+	}
+
+	// then
+	g.Expect(retrieveError).To(Not(BeNil()))
+}
+
+func TestRetrieveConfigFromString_Robustness_Decoded(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := readTestFileAsText(minimalValidTestFile) + `
+[Robustness]
+SampleNumber = 5
+Statistic = "Percentile"
+[Robustness.Distributions.InitialObjectiveValue]
+Type = "Uniform"
+Minimum = 1_900.0
+Maximum = 2_100.0
+`
+
+	// when
+	config, retrieveError := RetrieveConfigFromString(configText)
+	if retrieveError != nil {
+		t.Log(retrieveError)
+	}
+
+	// then
+	g.Expect(retrieveError).To(BeNil())
+	g.Expect(config.Robustness.SampleNumber).To(BeNumerically("==", 5))
+	g.Expect(config.Robustness.Statistic).To(Equal(PercentileStatistic))
+	g.Expect(config.Robustness.Percentile).To(BeNumerically("==", 90))
+	g.Expect(config.Robustness.Distributions["InitialObjectiveValue"].Type).To(Equal(UniformDistribution))
+}
+
+func TestRetrieveConfigFromString_UnknownRobustStatistic_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := readTestFileAsText(minimalValidTestFile) + `
+[Robustness]
+Statistic = "Median"
+`
+
+	// when
+	_, retrieveError := RetrieveConfigFromString(configText)
+	if retrieveError != nil {
+		t.Log(retrieveError)
+	}
+
+	// then
+	g.Expect(retrieveError).To(Not(BeNil()))
+}
+
 func TestRetrieveConfigFromString_RichInvalidSyntaxConfig_Errors(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -144,3 +249,105 @@ func readTestFileAsText(filePath string) string {
 	}
 	return "error reading file"
 }
+
+func TestRetrieveConfigFromString_LogFiles_Decoded(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := readTestFileAsText(minimalValidTestFile) + `
+[Scenario.Reporting.LogFiles]
+MaximumSizeInMegabytes = 50.0
+RotationPeriod = "Daily"
+MaximumBackups = 7
+Compress = true
+SplitByRun = true
+`
+
+	// when
+	config, retrieveError := RetrieveConfigFromString(configText)
+	if retrieveError != nil {
+		t.Log(retrieveError)
+	}
+
+	// then
+	g.Expect(retrieveError).To(BeNil())
+	logFiles := config.Scenario.Reporting.LogFiles
+	g.Expect(logFiles.MaximumSizeInMegabytes).To(BeNumerically("==", 50))
+	g.Expect(logFiles.RotationPeriod).To(Equal(data.DailyRotated))
+	g.Expect(logFiles.MaximumBackups).To(BeNumerically("==", 7))
+	g.Expect(logFiles.Compress).To(BeTrue())
+	g.Expect(logFiles.SplitByRun).To(BeTrue())
+}
+
+func TestRetrieveConfigFromString_Trace_Decoded(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := readTestFileAsText(minimalValidTestFile) + `
+[Scenario.Reporting.Trace]
+Enabled = true
+Format = "JSONLines"
+EveryNumberOfIterations = 100
+`
+
+	// when
+	config, retrieveError := RetrieveConfigFromString(configText)
+	if retrieveError != nil {
+		t.Log(retrieveError)
+	}
+
+	// then
+	g.Expect(retrieveError).To(BeNil())
+	trace := config.Scenario.Reporting.Trace
+	g.Expect(trace.Enabled).To(BeTrue())
+	g.Expect(trace.Format).To(Equal(JsonLinesTrace))
+	g.Expect(trace.EveryNumberOfIterations).To(BeNumerically("==", 100))
+}
+
+func TestRetrieveConfigFromString_TraceUnspecified_Defaulted(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	config, retrieveError := RetrieveConfigFromString(readTestFileAsText(minimalValidTestFile))
+
+	// then
+	g.Expect(retrieveError).To(BeNil())
+	trace := config.Scenario.Reporting.Trace
+	g.Expect(trace.Enabled).To(BeFalse())
+	g.Expect(trace.Format).To(Equal(CsvTrace))
+	g.Expect(trace.EveryNumberOfIterations).To(BeNumerically("==", 1))
+}
+
+func TestRetrieveConfigFromString_Sweep_Decoded(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := readTestFileAsText(minimalValidTestFile) + `
+[Sweep]
+Method = "LatinHypercube"
+SampleNumber = 20
+[Sweep.Annealer.Values]
+CoolingFactor = [0.99, 0.995]
+InitialReturnToBaseStep = [10_000, 20_000]
+[Sweep.Annealer.Ranges]
+StartingTemperature = { Minimum = 100.0, Maximum = 1000.0 }
+MinimumReturnToBaseRate = { Minimum = 5, Maximum = 50 }
+`
+
+	// when
+	config, retrieveError := RetrieveConfigFromString(configText)
+	if retrieveError != nil {
+		t.Log(retrieveError)
+	}
+
+	// then
+	g.Expect(retrieveError).To(BeNil())
+	sweep := config.Sweep
+	g.Expect(sweep.Method).To(Equal(LatinHypercubeSweep))
+	g.Expect(sweep.SampleNumber).To(BeNumerically("==", 20))
+	g.Expect(sweep.Annealer.Values["CoolingFactor"]).To(Equal([]interface{}{0.99, 0.995}))
+	g.Expect(sweep.Annealer.Values["InitialReturnToBaseStep"]).To(Equal([]interface{}{int64(10_000), int64(20_000)}))
+	g.Expect(sweep.Annealer.Ranges["StartingTemperature"].Maximum).To(Equal(1000.0))
+	g.Expect(sweep.Annealer.Ranges["MinimumReturnToBaseRate"].Minimum).To(Equal(int64(5)))
+	g.Expect(sweep.Model.Values).To(BeEmpty())
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package data
+
+import "github.com/LindsayBradford/crem/internal/pkg/config/data"
+
+// RobustnessConfig has the scenario's model optimised for a statistic of its decision variables across a number of
+// sampled parameter sets, rather than for nominal parameter values.  A SampleNumber of 0 leaves the model as is.
+type RobustnessConfig struct {
+	SampleNumber     uint64
+	RandomNumberSeed int64
+
+	Statistic  RobustStatistic
+	Percentile float64
+
+	Distributions map[string]DistributionConfig
+}
+
+type RobustStatistic struct {
+	value string
+}
+
+func (rs *RobustStatistic) String() string {
+	return rs.value
+}
+
+var (
+	MeanStatistic       = RobustStatistic{"Mean"}
+	PercentileStatistic = RobustStatistic{"Percentile"}
+	WorstStatistic      = RobustStatistic{"Worst"}
+)
+
+func (rs *RobustStatistic) UnmarshalText(text []byte) error {
+	context := data.UnmarshalContext{
+		ConfigKey: "Statistic",
+		ValidValues: []string{
+			MeanStatistic.value, PercentileStatistic.value, WorstStatistic.value,
+		},
+		TextToValidate: string(text),
+		AssignmentFunction: func() {
+			rs.value = string(text)
+		},
+	}
+
+	return data.ProcessUnmarshalContext(context)
+}
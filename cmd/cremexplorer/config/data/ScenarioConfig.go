@@ -9,11 +9,16 @@ type ScenarioConfig struct {
 
 	RunNumber                  uint64
 	MaximumConcurrentRunNumber uint64
+	RandomNumberSeed           int64
 
 	OutputPath  string
 	OutputType  ScenarioOutputType
 	OutputLevel ScenarioOutputLevel
 
+	CombineRunSolutions bool
+
+	Cooperation CooperationConfig
+
 	CpuProfilePath string
 
 	Reporting ReportingConfig
@@ -0,0 +1,51 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package data
+
+import "github.com/LindsayBradford/crem/internal/pkg/config/data"
+
+type SensitivityConfig struct {
+	Method          SensitivityMethod
+	SolutionSetFile string
+
+	Parameters []string
+	Ranges     map[string]RangeConfig
+
+	TrajectoryNumber uint64
+	Levels           uint64
+	SampleNumber     uint64
+	RandomNumberSeed int64
+}
+
+type RangeConfig struct {
+	Minimum float64
+	Maximum float64
+}
+
+type SensitivityMethod struct {
+	value string
+}
+
+func (sm *SensitivityMethod) String() string {
+	return sm.value
+}
+
+var (
+	MorrisMethod = SensitivityMethod{"Morris"}
+	SobolMethod  = SensitivityMethod{"Sobol"}
+)
+
+func (sm *SensitivityMethod) UnmarshalText(text []byte) error {
+	context := data.UnmarshalContext{
+		ConfigKey: "Method",
+		ValidValues: []string{
+			MorrisMethod.value, SobolMethod.value,
+		},
+		TextToValidate: string(text),
+		AssignmentFunction: func() {
+			sm.value = string(text)
+		},
+	}
+
+	return data.ProcessUnmarshalContext(context)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package data
+
+import "github.com/LindsayBradford/crem/internal/pkg/config/data"
+
+// SweepConfig declares the annealer and model parameter values a parameter sweep runs its scenario over.
+type SweepConfig struct {
+	Method           SweepMethod
+	SampleNumber     uint64
+	RandomNumberSeed int64
+
+	Annealer SweepParametersConfig
+	Model    SweepParametersConfig
+}
+
+// SweepParametersConfig lists the values each parameter takes in a 'Grid' sweep, and the range each parameter is
+// sampled from in a 'LatinHypercube' sweep.  Values and range bounds are integers for integer parameters.
+type SweepParametersConfig struct {
+	Values map[string][]interface{}
+	Ranges map[string]SweepRangeConfig
+}
+
+type SweepRangeConfig struct {
+	Minimum interface{}
+	Maximum interface{}
+}
+
+type SweepMethod struct {
+	value string
+}
+
+func (sm *SweepMethod) String() string {
+	return sm.value
+}
+
+var (
+	GridSweep           = SweepMethod{"Grid"}
+	LatinHypercubeSweep = SweepMethod{"LatinHypercube"}
+)
+
+func (sm *SweepMethod) UnmarshalText(text []byte) error {
+	context := data.UnmarshalContext{
+		ConfigKey: "Sweep.Method",
+		ValidValues: []string{
+			GridSweep.value, LatinHypercubeSweep.value,
+		},
+		TextToValidate: string(text),
+		AssignmentFunction: func() {
+			sm.value = string(text)
+		},
+	}
+
+	return data.ProcessUnmarshalContext(context)
+}
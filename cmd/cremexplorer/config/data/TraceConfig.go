@@ -0,0 +1,43 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package data
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+)
+
+// TraceConfig has each run record a trace of every EveryNumberOfIterations iterations to a file alongside its
+// solutions, for convergence analysis, independent of any logging.
+type TraceConfig struct {
+	Enabled                 bool
+	Format                  TraceFormatType
+	EveryNumberOfIterations uint64
+}
+
+type TraceFormatType struct {
+	value string
+}
+
+func (tft *TraceFormatType) String() string {
+	return tft.value
+}
+
+var (
+	CsvTrace       = TraceFormatType{"CSV"}
+	JsonLinesTrace = TraceFormatType{"JSONLines"}
+)
+
+func (tft *TraceFormatType) UnmarshalText(text []byte) error {
+	context := data.UnmarshalContext{
+		ConfigKey: "Reporting.Trace.Format",
+		ValidValues: []string{
+			CsvTrace.value, JsonLinesTrace.value,
+		},
+		TextToValidate: string(text),
+		AssignmentFunction: func() {
+			tft.value = string(text)
+		},
+	}
+
+	return data.ProcessUnmarshalContext(context)
+}
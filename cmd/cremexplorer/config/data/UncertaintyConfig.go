@@ -0,0 +1,56 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package data
+
+import "github.com/LindsayBradford/crem/internal/pkg/config/data"
+
+type UncertaintyConfig struct {
+	SolutionSetFile string
+
+	SampleNumber       uint64
+	RandomNumberSeed   int64
+	Percentiles        []float64
+	FragilityThreshold float64
+
+	Distributions map[string]DistributionConfig
+}
+
+type DistributionConfig struct {
+	Type DistributionType
+
+	Minimum           float64
+	Maximum           float64
+	Mode              float64
+	Mean              float64
+	StandardDeviation float64
+}
+
+type DistributionType struct {
+	value string
+}
+
+func (dt *DistributionType) String() string {
+	return dt.value
+}
+
+var (
+	UnspecifiedDistribution = DistributionType{""}
+	UniformDistribution     = DistributionType{"Uniform"}
+	TriangularDistribution  = DistributionType{"Triangular"}
+	NormalDistribution      = DistributionType{"Normal"}
+)
+
+func (dt *DistributionType) UnmarshalText(text []byte) error {
+	context := data.UnmarshalContext{
+		ConfigKey: "Type",
+		ValidValues: []string{
+			UniformDistribution.value, TriangularDistribution.value, NormalDistribution.value,
+		},
+		TextToValidate: string(text),
+		AssignmentFunction: func() {
+			dt.value = string(text)
+		},
+	}
+
+	return data.ProcessUnmarshalContext(context)
+}
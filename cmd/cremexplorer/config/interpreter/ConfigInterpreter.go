@@ -25,6 +25,7 @@ type ConfigInterpreter struct {
 
 	modelInterpreter *interpreter.ModelConfigInterpreter
 	model            model.Model
+	dataSourceModel  model.Model
 
 	annealerInterpreter *interpreter.AnnealerConfigInterpreter
 	annealer            annealing.Annealer
@@ -48,6 +49,19 @@ func (i *ConfigInterpreter) initialise() *ConfigInterpreter {
 	return i
 }
 
+// WithRunTracker has tracker observe, and track the model of, every run of the scenario interpreted.
+func (i *ConfigInterpreter) WithRunTracker(tracker scenario.RunTracker) *ConfigInterpreter {
+	i.scenarioInterpreter.WithRunTracker(tracker)
+	return i
+}
+
+// WithDataSourceOf has the model interpreted share the source data set already loaded by dataSourceModel, rather
+// than load its own, where both are built from the same source data.
+func (i *ConfigInterpreter) WithDataSourceOf(dataSourceModel model.Model) *ConfigInterpreter {
+	i.dataSourceModel = dataSourceModel
+	return i
+}
+
 func (i *ConfigInterpreter) Interpret(config *appData.Config) *ConfigInterpreter {
 	if config == nil {
 		i.errors.Add(errors.New("no config supplied for interpretation"))
@@ -55,6 +69,9 @@ func (i *ConfigInterpreter) Interpret(config *appData.Config) *ConfigInterpreter
 	}
 
 	i.interpretModelConfig(&config.Model)
+	provenance := deriveProvenance(config, i.model)
+	provenance.SamplingSeed = i.interpretRobustnessConfig(config.Robustness, &config.Model, config.Scenario.RandomNumberSeed)
+	i.scenarioInterpreter.WithProvenance(provenance)
 	i.interpretAnnealerConfig(&config.Annealer)
 	i.interpretScenarioConfig(&config.Scenario)
 
@@ -68,7 +85,59 @@ func (i *ConfigInterpreter) interpretModelConfig(config *data.ModelConfig) {
 	i.model = i.modelInterpreter.Interpret(config).Model()
 	if i.modelInterpreter.Errors() != nil {
 		i.errors.Add(i.modelInterpreter.Errors())
+		return
+	}
+	i.shareDataSourceIfPossible()
+}
+
+func (i *ConfigInterpreter) shareDataSourceIfPossible() {
+	sharer, modelCanShare := i.model.(model.DataSourceSharer)
+	source, sourceCanShare := i.dataSourceModel.(model.DataSourceSharer)
+	if !modelCanShare || !sourceCanShare || !sameDataSource(i.model, i.dataSourceModel) {
+		return
+	}
+
+	sharedDataSet, loadError := source.SharedDataSource()
+	if loadError != nil {
+		i.errors.Add(errors.Wrap(loadError, "loading shared data source"))
+		return
+	}
+	sharer.ShareDataSource(sharedDataSet)
+}
+
+func sameDataSource(firstModel model.Model, secondModel model.Model) bool {
+	firstLocator, firstCanLocate := firstModel.(model.DataSourceLocator)
+	secondLocator, secondCanLocate := secondModel.(model.DataSourceLocator)
+	if !firstCanLocate || !secondCanLocate {
+		return false
+	}
+
+	firstPaths, firstError := firstLocator.DataSourceFilePaths()
+	secondPaths, secondError := secondLocator.DataSourceFilePaths()
+	return firstError == nil && secondError == nil && firstPaths[0] == secondPaths[0]
+}
+
+// interpretRobustnessConfig replaces the model with a robust equivalent, where robustness sampling is configured,
+// tearing down the nominal model replaced and returning the seed its parameter sets were sampled with.  Without a robustness seed configured, sampling is seeded
+// from the scenario's seed, so a seeded scenario is reproducible as a whole.
+func (i *ConfigInterpreter) interpretRobustnessConfig(config appData.RobustnessConfig, modelConfig *data.ModelConfig, scenarioSeed int64) int64 {
+	if config.SampleNumber == 0 || i.modelInterpreter.Errors() != nil {
+		return 0
 	}
+
+	if config.RandomNumberSeed == 0 {
+		config.RandomNumberSeed = scenarioSeed
+	}
+	robustnessInterpreter := NewRobustnessConfigInterpreter().Interpret(&config, modelConfig)
+	if robustnessInterpreter.Errors() != nil {
+		i.errors.Add(robustnessInterpreter.Errors())
+		return 0
+	}
+
+	nominalModel := i.model
+	i.model = robustnessInterpreter.Model().WithName(nominalModel.Name())
+	nominalModel.TearDown()
+	return robustnessInterpreter.RandomNumberSeed()
 }
 
 func (i *ConfigInterpreter) interpretAnnealerConfig(config *data.AnnealerConfig) {
@@ -90,6 +159,11 @@ func (i *ConfigInterpreter) Scenario() scenario.Scenario {
 	return i.scenario
 }
 
+// Model returns the model interpreted, for uses other than running the scenario's annealer over it.
+func (i *ConfigInterpreter) Model() model.Model {
+	return i.model
+}
+
 func (i *ConfigInterpreter) Errors() error {
 	if i.errors.Size() > 0 {
 		return i.errors
@@ -6,6 +6,8 @@ import (
 	"io/ioutil"
 	"testing"
 
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	catchmentTesting "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/test"
 	"github.com/LindsayBradford/crem/internal/pkg/scenario"
 	. "github.com/onsi/gomega"
 
@@ -53,3 +55,115 @@ func readTestFileAsText(filePath string) string {
 	}
 	return "error reading file"
 }
+
+func TestConfigInterpreter_WithDataSourceOf_SharesLoadedDataSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	dataSourceModel, modelError := catchmentTesting.NewTestingModel(nil)
+	g.Expect(modelError).To(BeNil())
+	sharedDataSet, _ := dataSourceModel.(model.DataSourceSharer).SharedDataSource()
+
+	configUnderTest, configError := data.RetrieveConfigFromString(`
+[Scenario]
+Name = "SharedDataSource"
+
+[Annealer]
+Type = "Kirkpatrick"
+
+[Model]
+Type = "CatchmentModel"
+
+[Model.Parameters]
+DataSourcePath = '` + catchmentTesting.TestingModelPath + `'
+`)
+	g.Expect(configError).To(BeNil())
+
+	// when
+	interpreterUnderTest := NewInterpreter().WithDataSourceOf(dataSourceModel).Interpret(configUnderTest)
+
+	// then
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+	interpretedDataSet, _ := interpreterUnderTest.Model().(model.DataSourceSharer).SharedDataSource()
+	g.Expect(interpretedDataSet).To(BeIdenticalTo(sharedDataSet))
+}
+
+func TestConfigInterpreter_UnseededRobustness_SampledFromScenarioSeed(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configUnderTest, configError := data.RetrieveConfigFromString(`
+[Scenario]
+Name = "SeededRobustness"
+RandomNumberSeed = 1234
+
+[Annealer]
+Type = "Kirkpatrick"
+
+[Model]
+Type = "MultiObjectiveDumbModel"
+
+[Robustness]
+SampleNumber = 3
+
+[Robustness.Distributions.InitialObjectiveOneValue]
+Type = "Uniform"
+Minimum = 900.0
+Maximum = 1100.0
+`)
+	g.Expect(configError).To(BeNil())
+
+	// when
+	interpreterUnderTest := NewInterpreter().Interpret(configUnderTest)
+
+	// then
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+	g.Expect(interpreterUnderTest.scenarioInterpreter.provenance.SamplingSeed).To(Equal(int64(1234)))
+}
+
+func TestConfigInterpreter_Robustness_TearsDownNominalModel(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configUnderTest, configError := data.RetrieveConfigFromString(`
+[Scenario]
+Name = "RobustTearDown"
+
+[Annealer]
+Type = "Kirkpatrick"
+
+[Model]
+Type = "MultiObjectiveDumbModel"
+
+[Robustness]
+SampleNumber = 2
+RandomNumberSeed = 1234
+
+[Robustness.Distributions.InitialObjectiveOneValue]
+Type = "Uniform"
+Minimum = 900.0
+Maximum = 1100.0
+`)
+	g.Expect(configError).To(BeNil())
+
+	interpreterUnderTest := NewInterpreter()
+	nominalModel := &tearDownSpyModel{Model: model.NewNullModel()}
+	interpreterUnderTest.model = nominalModel
+
+	// when
+	interpreterUnderTest.interpretRobustnessConfig(configUnderTest.Robustness, &configUnderTest.Model, 0)
+
+	// then
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+	g.Expect(interpreterUnderTest.Model()).To(Not(BeIdenticalTo(nominalModel)))
+	g.Expect(nominalModel.tornDown).To(BeTrue())
+}
+
+type tearDownSpyModel struct {
+	model.Model
+	tornDown bool
+}
+
+func (m *tearDownSpyModel) TearDown() {
+	m.tornDown = true
+}
@@ -0,0 +1,305 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	"fmt"
+	"sort"
+
+	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/greedy"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/kirkpatrick"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/seeding"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/moves"
+	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	"github.com/LindsayBradford/crem/internal/pkg/config/validation"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+	"github.com/pkg/errors"
+)
+
+const (
+	configurationSection    = "Configuration"
+	scenarioSection         = "Scenario"
+	annealerSection         = "Annealer"
+	modelSection            = "Model"
+	robustnessSection       = "Robustness"
+	sweepSection            = "Sweep"
+	dataSourceSection       = "Data Source"
+	decisionVariableSection = "Decision Variables"
+)
+
+const (
+	typeSubject        = "Type"
+	combinationSubject = "Parameter combination"
+)
+
+// ConfigValidator checks a configuration as thoroughly as possible without annealing, reporting the outcome of
+// every check made.  Beyond each parameter's specification, it checks the model's source data, and that every
+// decision variable the annealer names is offered by the model.
+type ConfigValidator struct {
+	report *validation.Report
+	model  model.Model
+}
+
+func NewConfigValidator() *ConfigValidator {
+	return new(ConfigValidator)
+}
+
+// ValidateFile retrieves, then validates, the configuration file at configFilePath.
+func (v *ConfigValidator) ValidateFile(configFilePath string) *validation.Report {
+	v.report = validation.NewReport(configFilePath)
+
+	config, retrievalError := appData.RetrieveConfigFromFile(configFilePath)
+	if retrievalError != nil {
+		v.report.Fail(configurationSection, "File", retrievalError)
+		return v.report
+	}
+	v.report.Pass(configurationSection, "File", "retrieved and decoded")
+
+	v.validate(config)
+	return v.report
+}
+
+func (v *ConfigValidator) Validate(config *appData.Config) *validation.Report {
+	v.report = validation.NewReport(config.MetaData.FilePath)
+	v.validate(config)
+	return v.report
+}
+
+func (v *ConfigValidator) validate(config *appData.Config) {
+	v.validateScenario(&config.Scenario)
+	annealerIsValid := v.validateAnnealer(&config.Annealer)
+	modelIsValid := v.validateModel(&config.Model)
+	v.validateRobustness(&config.Robustness, &config.Model)
+	v.validateSweep(&config.Sweep)
+
+	if !modelIsValid {
+		v.report.Skip(dataSourceSection, config.Model.Type, "model configuration is invalid")
+		v.report.Skip(decisionVariableSection, config.Model.Type, "model configuration is invalid")
+		return
+	}
+
+	if !v.validateDataSource(config.Model.Type) {
+		v.report.Skip(decisionVariableSection, config.Model.Type, "model could not be initialised")
+		return
+	}
+
+	if !annealerIsValid {
+		v.report.Skip(decisionVariableSection, config.Annealer.Type.String(), "annealer configuration is invalid")
+		return
+	}
+	v.validateDecisionVariables(&config.Annealer)
+}
+
+func (v *ConfigValidator) validateScenario(config *appData.ScenarioConfig) {
+	subject := config.Name
+	if subject == "" {
+		subject = "Name"
+	}
+
+	scenarioInterpreter := NewScenarioConfigInterpreter().Interpret(config)
+	if scenarioErrors := scenarioInterpreter.Errors(); scenarioErrors != nil {
+		v.report.Fail(scenarioSection, subject, scenarioErrors)
+		return
+	}
+	v.report.Pass(scenarioSection, subject, fmt.Sprintf("%d run(s), a maximum of %d concurrently",
+		config.RunNumber, config.MaximumConcurrentRunNumber))
+}
+
+// validateAnnealer checks each annealer parameter supplied against its specification, then (where all are valid)
+// builds the annealer to check the parameters in combination.
+func (v *ConfigValidator) validateAnnealer(config *data.AnnealerConfig) bool {
+	specs, isRegistered := interpreter.AnnealerParameterSpecifications(config.Type)
+	if !isRegistered {
+		v.report.Fail(annealerSection, typeSubject,
+			errors.Errorf("no annealers are registered for type [%s]", config.Type.String()))
+		return false
+	}
+	v.report.Pass(annealerSection, typeSubject, config.Type.String())
+
+	if !v.validateParameters(annealerSection, config.Parameters, specs) {
+		return false
+	}
+
+	annealerInterpreter := interpreter.NewAnnealerConfigInterpreter().Interpret(config)
+	if annealerErrors := annealerInterpreter.Errors(); annealerErrors != nil {
+		v.report.Fail(annealerSection, combinationSubject, annealerErrors)
+		return false
+	}
+	v.report.Pass(annealerSection, combinationSubject, "annealer built")
+	return true
+}
+
+// validateModel checks each model parameter supplied against its specification, then (where all are valid) builds
+// the model to check the parameters in combination.
+func (v *ConfigValidator) validateModel(config *data.ModelConfig) bool {
+	specs, isRegistered := interpreter.ModelParameterSpecifications(config.Type)
+	if !isRegistered {
+		v.report.Fail(modelSection, typeSubject, errors.Errorf("no models are registered for type [%s]", config.Type))
+		return false
+	}
+	v.report.Pass(modelSection, typeSubject, config.Type)
+
+	if !v.validateParameters(modelSection, config.Parameters, specs) {
+		return false
+	}
+
+	modelInterpreter := interpreter.NewModelConfigInterpreter().Interpret(config)
+	if modelErrors := modelInterpreter.Errors(); modelErrors != nil {
+		v.report.Fail(modelSection, combinationSubject, modelErrors)
+		return false
+	}
+	v.model = modelInterpreter.Model()
+	v.report.Pass(modelSection, combinationSubject, "model built")
+	return true
+}
+
+// validateParameters checks each of the parameters supplied against its specification, in key order, returning
+// whether all are valid.  Parameters without a specification are invalid.
+func (v *ConfigValidator) validateParameters(section string, params parameters.Map, specs *specification.Specifications) bool {
+	allAreValid := true
+	for _, key := range sortedKeysOf(params) {
+		validationError := specs.Validate(key, params[key]).(specification.ValidationError)
+		if !validationError.IsValid() {
+			v.report.Fail(section, key, validationError)
+			allAreValid = false
+			continue
+		}
+		v.report.Pass(section, key, fmt.Sprintf("%v", params[key]))
+	}
+	return allAreValid
+}
+
+func sortedKeysOf(params parameters.Map) []string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (v *ConfigValidator) validateRobustness(config *appData.RobustnessConfig, modelConfig *data.ModelConfig) {
+	if config.SampleNumber == 0 {
+		return
+	}
+
+	robustnessInterpreter := NewRobustnessConfigInterpreter().Interpret(config, modelConfig)
+	defer robustnessInterpreter.Model().TearDown()
+	if robustnessErrors := robustnessInterpreter.Errors(); robustnessErrors != nil {
+		v.report.Fail(robustnessSection, combinationSubject, robustnessErrors)
+		return
+	}
+	v.report.Pass(robustnessSection, combinationSubject, fmt.Sprintf("%d sample(s)", config.SampleNumber))
+}
+
+func (v *ConfigValidator) validateSweep(config *appData.SweepConfig) {
+	if !sweepIsConfigured(config) {
+		return
+	}
+
+	sweepInterpreter := NewSweepConfigInterpreter().Interpret(config)
+	if sweepErrors := sweepInterpreter.Errors(); sweepErrors != nil {
+		v.report.Fail(sweepSection, combinationSubject, sweepErrors)
+		return
+	}
+	v.report.Pass(sweepSection, combinationSubject, fmt.Sprintf("%s over %d parameter(s)",
+		sweepInterpreter.Design().Name(), len(sweepInterpreter.Design().Parameters())))
+}
+
+func sweepIsConfigured(config *appData.SweepConfig) bool {
+	return len(config.Annealer.Values) > 0 || len(config.Annealer.Ranges) > 0 ||
+		len(config.Model.Values) > 0 || len(config.Model.Ranges) > 0
+}
+
+// validateDataSource checks the model's source data, where the model can, then initialises the model, returning
+// whether the model initialised cleanly.
+func (v *ConfigValidator) validateDataSource(modelType string) bool {
+	if dataSourceValidator, canValidate := v.model.(model.DataSourceValidator); canValidate {
+		if dataSourceError := dataSourceValidator.ValidateDataSource(); dataSourceError != nil {
+			v.report.Fail(dataSourceSection, "Schema", dataSourceError)
+			return false
+		}
+		v.report.Pass(dataSourceSection, "Schema", "tables and columns match those the model reads")
+	}
+
+	if initialisationError := initialise(v.model); initialisationError != nil {
+		v.report.Fail(dataSourceSection, "Initialisation", initialisationError)
+		return false
+	}
+	v.report.Pass(dataSourceSection, "Initialisation", fmt.Sprintf("model [%s] initialised with %d planning unit(s)",
+		modelType, len(v.model.PlanningUnits())))
+	return true
+}
+
+// initialise initialises modelToInitialise as-is, returning any problem doing so as an error, including panics.
+func initialise(modelToInitialise model.Model) (initialisationError error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			initialisationError = errors.Errorf("initialisation failed: %v", recovered)
+		}
+	}()
+
+	modelToInitialise.Initialise(model.AsIs)
+	if parameterisedModel, hasParameters := modelToInitialise.(parameters.Container); hasParameters {
+		return parameterisedModel.ParameterErrors()
+	}
+	return nil
+}
+
+// validateDecisionVariables checks that every decision variable named by the annealer parameters in use is offered
+// by the model.
+func (v *ConfigValidator) validateDecisionVariables(config *data.AnnealerConfig) {
+	specs, _ := interpreter.AnnealerParameterSpecifications(config.Type)
+	valueOf := func(key string) string {
+		if value, isSupplied := config.Parameters[key]; isSupplied {
+			return fmt.Sprintf("%v", value)
+		}
+		return fmt.Sprintf("%v", (*specs)[key].DefaultValue)
+	}
+
+	for _, key := range decisionVariableParametersInUse(config.Type, specs, valueOf) {
+		variableName := valueOf(key)
+		if !v.model.OffersDecisionVariable(variableName) {
+			v.report.Fail(decisionVariableSection, key,
+				errors.Errorf("decision variable [%s] is not offered by the model", variableName))
+			continue
+		}
+		v.report.Pass(decisionVariableSection, key, variableName)
+	}
+}
+
+// decisionVariableParametersInUse returns the keys of annealer parameters naming a decision variable that the
+// annealer type, as parameterised, will use.
+func decisionVariableParametersInUse(annealerType data.AnnealerType, specs *specification.Specifications, valueOf func(key string) string) []string {
+	keys := make([]string, 0)
+	addIfSpecified := func(key string) {
+		if specs.HasEntry(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	if annealerType == data.Kirkpatrick {
+		addIfSpecified(kirkpatrick.DecisionVariableName)
+	}
+
+	if annealerType == data.Greedy || (specs.HasEntry(seeding.InitialState) && valueOf(seeding.InitialState) == seeding.GreedyInitialState) {
+		addIfSpecified(greedy.GreedyDecisionVariable)
+		addIfSpecified(greedy.GreedyCostVariable)
+	}
+
+	if specs.HasEntry(moves.MoveStrategy) {
+		switch valueOf(moves.MoveStrategy) {
+		case moves.SwapStrategy:
+			addIfSpecified(moves.MoveCostVariable)
+		case moves.CostEffectiveStrategy, moves.AdaptiveStrategy:
+			addIfSpecified(moves.MoveCostVariable)
+			addIfSpecified(moves.MoveBenefitVariable)
+		}
+	}
+
+	return keys
+}
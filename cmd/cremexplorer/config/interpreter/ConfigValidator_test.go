@@ -0,0 +1,156 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/validation"
+	. "github.com/onsi/gomega"
+)
+
+func TestConfigValidator_MinimalConfig_NoFailures(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := readTestFileAsText("testdata/MinimalValidConfig.toml")
+	configUnderTest, configError := data.RetrieveConfigFromString(configText)
+	g.Expect(configError).To(BeNil())
+
+	// when
+	report := NewConfigValidator().Validate(configUnderTest)
+
+	// then
+	g.Expect(report.HasFailures()).To(BeFalse())
+	g.Expect(report.Checks).To(ContainElement(validation.Check{
+		Section: decisionVariableSection, Subject: "DecisionVariable", Outcome: validation.Passed, Message: "ObjectiveValue",
+	}))
+}
+
+func TestConfigValidator_InvalidParameters_FailurePerParameter(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := `
+[Scenario]
+Name = "testScenario"
+
+[Annealer]
+Type = "Kirkpatrick"
+
+[Annealer.Parameters]
+MaximumIterations = -5
+UnknownAnnealerParameter = 1
+
+[Model]
+Type = "DumbModel"
+
+[Model.Parameters]
+UnknownModelParameter = 1
+`
+	configUnderTest, configError := data.RetrieveConfigFromString(configText)
+	g.Expect(configError).To(BeNil())
+
+	// when
+	report := NewConfigValidator().Validate(configUnderTest)
+
+	// then
+	g.Expect(report.Number(validation.Failed)).To(BeNumerically("==", 3))
+	g.Expect(failedSubjectsOf(report)).To(ConsistOf(
+		"MaximumIterations", "UnknownAnnealerParameter", "UnknownModelParameter",
+	))
+}
+
+func TestConfigValidator_UnregisteredTypes_Failures(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := `
+[Scenario]
+Name = "testScenario"
+
+[Annealer]
+Type = "Kirkpatrick"
+
+[Model]
+Type = "NoSuchModel"
+`
+	configUnderTest, configError := data.RetrieveConfigFromString(configText)
+	g.Expect(configError).To(BeNil())
+
+	// when
+	report := NewConfigValidator().Validate(configUnderTest)
+
+	// then
+	g.Expect(failedSubjectsOf(report)).To(ConsistOf(typeSubject))
+	g.Expect(report.Number(validation.Skipped)).To(BeNumerically("==", 2))
+}
+
+func TestConfigValidator_DecisionVariableNotOffered_Failure(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := `
+[Scenario]
+Name = "testScenario"
+
+[Annealer]
+Type = "Kirkpatrick"
+
+[Annealer.Parameters]
+DecisionVariable = "NoSuchVariable"
+
+[Model]
+Type = "DumbModel"
+`
+	configUnderTest, configError := data.RetrieveConfigFromString(configText)
+	g.Expect(configError).To(BeNil())
+
+	// when
+	report := NewConfigValidator().Validate(configUnderTest)
+
+	// then
+	g.Expect(report.Number(validation.Failed)).To(BeNumerically("==", 1))
+	g.Expect(report.Checks).To(ContainElement(validation.Check{
+		Section: decisionVariableSection, Subject: "DecisionVariable", Outcome: validation.Failed,
+		Message: "decision variable [NoSuchVariable] is not offered by the model",
+	}))
+}
+
+func TestConfigValidator_MoveStrategy_ChecksMoveVariables(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configText := `
+[Scenario]
+Name = "testScenario"
+
+[Annealer]
+Type = "Kirkpatrick"
+
+[Annealer.Parameters]
+MoveStrategy = "CostEffective"
+
+[Model]
+Type = "DumbModel"
+`
+	configUnderTest, configError := data.RetrieveConfigFromString(configText)
+	g.Expect(configError).To(BeNil())
+
+	// when
+	report := NewConfigValidator().Validate(configUnderTest)
+
+	// then
+	g.Expect(failedSubjectsOf(report)).To(ConsistOf("MoveCostVariable", "MoveBenefitVariable"))
+}
+
+func failedSubjectsOf(report *validation.Report) []string {
+	subjects := make([]string, 0)
+	for _, check := range report.Checks {
+		if check.Outcome == validation.Failed {
+			subjects = append(subjects, check.Subject)
+		}
+	}
+	return subjects
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	"os"
+	"path/filepath"
+
+	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+// deriveProvenance returns the provenance common to every solution a scenario produces, from its configuration and
+// the model built from it.  The config hash is that of the configuration as resolved, and the parameters recorded
+// are those supplied, completed with the defaults of any not supplied.  The config file is recorded by absolute path,
+// so the scenario can be found again from wherever its solutions are later loaded.
+func deriveProvenance(config *appData.Config, builtModel model.Model) *solution.Provenance {
+	annealerSpecs, _ := interpreter.AnnealerParameterSpecifications(config.Annealer.Type)
+	modelSpecs, _ := interpreter.ModelParameterSpecifications(config.Model.Type)
+
+	provenance := &solution.Provenance{
+		ExecutableName:    config.MetaData.ExecutableName,
+		ExecutableVersion: config.MetaData.ExecutableVersion,
+		ScenarioName:      config.Scenario.Name,
+		ConfigFile:        absolutePathOf(config.MetaData.FilePath),
+		ConfigHash:        solution.ChecksumOf([]byte(config.MetaData.ResolvedContent)),
+		Annealer: solution.ComponentProvenance{
+			Type:       config.Annealer.Type.String(),
+			Parameters: resolvedParameters(config.Annealer.Parameters, annealerSpecs),
+		},
+		Model: solution.ComponentProvenance{
+			Type:       config.Model.Type,
+			Parameters: resolvedParameters(config.Model.Parameters, modelSpecs),
+		},
+	}
+
+	if locator, canLocate := builtModel.(model.DataSourceLocator); canLocate {
+		if filePaths, locateError := locator.DataSourceFilePaths(); locateError == nil {
+			provenance.DataSetFile = filePaths[0]
+			provenance.DataSetChecksum, _ = solution.ChecksumOfFiles(filePaths...)
+		}
+	}
+
+	provenance.Host, _ = os.Hostname()
+	return provenance
+}
+
+func resolvedParameters(supplied parameters.Map, specs *specification.Specifications) map[string]interface{} {
+	resolved := make(map[string]interface{})
+	if specs != nil {
+		for key, spec := range *specs {
+			if spec.DefaultValue != nil {
+				resolved[key] = spec.DefaultValue
+			}
+		}
+	}
+	for key, value := range supplied {
+		resolved[key] = value
+	}
+	return resolved
+}
+
+func absolutePathOf(filePath string) string {
+	if filePath == "" {
+		return filePath
+	}
+	if absolutePath, absError := filepath.Abs(filePath); absError == nil {
+		return absolutePath
+	}
+	return filePath
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	. "github.com/onsi/gomega"
+)
+
+func TestDeriveProvenance_RecordedParametersRestoreToValidModelConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	config, retrieveError := appData.RetrieveConfigFromString(`
+[scenario]
+Name = "RoundTrip"
+
+[Annealer]
+Type = "Kirkpatrick"
+
+[Annealer.Parameters]
+MaximumIterations = 1000
+
+[Model]
+Type = "CatchmentModel"
+
+[Model.Parameters]
+DataSourcePath = "../../../cremengine/engine/api/testdata/ValidModel.csv"
+MaximumImplementationCost = 500000.0
+BankErosionFudgeFactor = 0.0002
+`)
+	g.Expect(retrieveError).To(BeNil())
+
+	provenance := deriveProvenance(config, nil)
+	encodedProvenance, encodingError := json.Marshal(provenance)
+	g.Expect(encodingError).To(BeNil())
+
+	decoder := json.NewDecoder(bytes.NewReader(encodedProvenance))
+	decoder.UseNumber()
+	decodedProvenance := new(solution.Provenance)
+	g.Expect(decoder.Decode(decodedProvenance)).To(BeNil())
+
+	// when
+	annealerSpecs, _ := interpreter.AnnealerParameterSpecifications(data.AnnealerType{Value: decodedProvenance.Annealer.Type})
+	restoredAnnealerParameters := decodedProvenance.Annealer.RestoredParameters(annealerSpecs)
+
+	modelSpecs, _ := interpreter.ModelParameterSpecifications(decodedProvenance.Model.Type)
+	restoredModelParameters := decodedProvenance.Model.RestoredParameters(modelSpecs)
+
+	modelInterpreter := interpreter.NewModelConfigInterpreter()
+	modelInterpreter.Interpret(&data.ModelConfig{Type: decodedProvenance.Model.Type, Parameters: restoredModelParameters})
+
+	// then
+	g.Expect(restoredAnnealerParameters["MaximumIterations"]).To(Equal(int64(1000)))
+	g.Expect(restoredModelParameters["MaximumImplementationCost"]).To(Equal(float64(500000)))
+	g.Expect(restoredModelParameters["BankErosionFudgeFactor"]).To(Equal(0.0002))
+	g.Expect(modelInterpreter.Errors()).To(BeNil())
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	"fmt"
+	"sort"
+
+	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	"github.com/LindsayBradford/crem/internal/pkg/uncertainty"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+type RobustnessConfigInterpreter struct {
+	errors *compositeErrors.CompositeError
+
+	distributions map[string]uncertainty.Distribution
+	generator     *rand.Rand
+	model         *uncertainty.RobustModel
+}
+
+func NewRobustnessConfigInterpreter() *RobustnessConfigInterpreter {
+	interpreter := new(RobustnessConfigInterpreter).initialise()
+	return interpreter
+}
+
+func (i *RobustnessConfigInterpreter) initialise() *RobustnessConfigInterpreter {
+	i.errors = compositeErrors.New("Robustness Configuration")
+	i.distributions = make(map[string]uncertainty.Distribution)
+	i.generator = rand.NewTimeSeeded()
+	i.model = uncertainty.NewRobustModel()
+	return i
+}
+
+// Interpret builds a RobustModel with a member model for each of the configured number of parameter sets sampled,
+// sampled with the configured random number seed, or one drawn from the system time where none was configured.
+func (i *RobustnessConfigInterpreter) Interpret(robustnessConfig *appData.RobustnessConfig, modelConfig *data.ModelConfig) *RobustnessConfigInterpreter {
+	if robustnessConfig.SampleNumber < 1 {
+		i.errors.Add(errors.New("Robustness sample number must be at least 1"))
+	}
+	i.generator = rand.NewSeeded(rand.SeedOrTime(robustnessConfig.RandomNumberSeed))
+
+	i.model.WithStatistic(interpretRobustStatistic(robustnessConfig))
+	i.interpretDistributions(robustnessConfig.Distributions)
+
+	if i.errors.Size() > 0 {
+		return i
+	}
+
+	i.interpretMembers(robustnessConfig.SampleNumber, *modelConfig)
+
+	if i.errors.Size() > 0 {
+		return i
+	}
+
+	if validationErrors := i.model.Validate(); validationErrors != nil {
+		i.errors.Add(validationErrors)
+	}
+
+	return i
+}
+
+func interpretRobustStatistic(config *appData.RobustnessConfig) uncertainty.RobustStatistic {
+	switch config.Statistic {
+	case appData.PercentileStatistic:
+		return uncertainty.PercentileStatistic(config.Percentile)
+	case appData.WorstStatistic:
+		return uncertainty.WorstStatistic
+	default:
+		return uncertainty.MeanStatistic
+	}
+}
+
+func (i *RobustnessConfigInterpreter) interpretDistributions(configs map[string]appData.DistributionConfig) {
+	if len(configs) == 0 {
+		i.errors.Add(errors.New("No robustness parameter distributions supplied"))
+		return
+	}
+
+	parameterNames := make([]string, 0, len(configs))
+	for parameterName := range configs {
+		parameterNames = append(parameterNames, parameterName)
+	}
+	sort.Strings(parameterNames)
+
+	for _, parameterName := range parameterNames {
+		distribution := distributionFrom(configs[parameterName])
+		if distribution == nil {
+			i.errors.Add(errors.New("Missing mandatory distribution type for parameter [" + parameterName + "]"))
+			continue
+		}
+		if distributionError := distribution.Validate(); distributionError != nil {
+			i.errors.Add(errors.Wrap(distributionError, "parameter ["+parameterName+"]"))
+			continue
+		}
+		i.distributions[parameterName] = distribution
+	}
+}
+
+func (i *RobustnessConfigInterpreter) interpretMembers(sampleNumber uint64, modelConfig data.ModelConfig) {
+	factory := modelFactoryFor(modelConfig)
+
+	members := make([]model.Model, 0, sampleNumber)
+	for sample := uint64(1); sample <= sampleNumber; sample++ {
+		member, factoryError := factory(uncertainty.SampleParameters(i.distributions, i.generator))
+		if factoryError != nil {
+			i.errors.Add(errors.Wrap(factoryError, fmt.Sprintf("building robustness sample [%d]", sample)))
+			return
+		}
+		members = append(members, member)
+	}
+
+	i.model.WithMembers(members...)
+}
+
+func (i *RobustnessConfigInterpreter) Model() *uncertainty.RobustModel {
+	return i.model
+}
+
+// RandomNumberSeed returns the seed the parameter sets of member models were sampled with.
+func (i *RobustnessConfigInterpreter) RandomNumberSeed() int64 {
+	return i.generator.Seed()
+}
+
+func (i *RobustnessConfigInterpreter) Errors() error {
+	if i.errors.Size() > 0 {
+		return i.errors
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	"testing"
+
+	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	modumbParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/modumb/parameters"
+	. "github.com/onsi/gomega"
+)
+
+func TestRobustnessConfigInterpreter_ValidConfig_MemberPerSample(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	robustnessConfig := appData.RobustnessConfig{
+		SampleNumber:     3,
+		RandomNumberSeed: 42,
+		Statistic:        appData.PercentileStatistic,
+		Percentile:       90,
+		Distributions: map[string]appData.DistributionConfig{
+			modumbParameters.InitialObjectiveOneValue: {Type: appData.UniformDistribution, Minimum: 900, Maximum: 1100},
+		},
+	}
+	modelConfig := data.ModelConfig{Type: interpreter.MultiObjectiveDumbModel}
+
+	// when
+	interpreterUnderTest := NewRobustnessConfigInterpreter().Interpret(&robustnessConfig, &modelConfig)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+	g.Expect(interpreterUnderTest.Model().Members()).To(HaveLen(3))
+}
+
+func TestRobustnessConfigInterpreter_NoDistributions_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	robustnessConfig := appData.RobustnessConfig{
+		SampleNumber: 3,
+		Statistic:    appData.MeanStatistic,
+	}
+	modelConfig := data.ModelConfig{Type: interpreter.MultiObjectiveDumbModel}
+
+	// when
+	interpreterUnderTest := NewRobustnessConfigInterpreter().Interpret(&robustnessConfig, &modelConfig)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+}
@@ -4,6 +4,7 @@ package interpreter
 
 import (
 	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding"
 	"github.com/LindsayBradford/crem/internal/pkg/scenario"
 	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
@@ -15,8 +16,10 @@ type ScenarioConfigInterpreter struct {
 
 	reportingInterpreter *ReportingConfigInterpreter
 
-	scenario scenario.Scenario
-	runner   scenario.CallableRunner
+	scenario    scenario.Scenario
+	runner      scenario.CallableRunner
+	runTrackers []scenario.RunTracker
+	provenance  *solution.Provenance
 }
 
 func NewScenarioConfigInterpreter() *ScenarioConfigInterpreter {
@@ -33,6 +36,18 @@ func (i *ScenarioConfigInterpreter) initialise() *ScenarioConfigInterpreter {
 	return i
 }
 
+// WithRunTracker has tracker observe, and track the model of, every run of the scenario interpreted.
+func (i *ScenarioConfigInterpreter) WithRunTracker(tracker scenario.RunTracker) *ScenarioConfigInterpreter {
+	i.runTrackers = append(i.runTrackers, tracker)
+	return i
+}
+
+// WithProvenance has every solution the interpreted scenario saves record provenance as its origin.
+func (i *ScenarioConfigInterpreter) WithProvenance(provenance *solution.Provenance) *ScenarioConfigInterpreter {
+	i.provenance = provenance
+	return i
+}
+
 func (i *ScenarioConfigInterpreter) Interpret(scenarioConfig *appData.ScenarioConfig) *ScenarioConfigInterpreter {
 	i.interpretReporting(&scenarioConfig.Reporting)
 	i.interpretRunner(scenarioConfig)
@@ -59,15 +74,32 @@ func (i *ScenarioConfigInterpreter) interpretRunner(config *appData.ScenarioConf
 	}
 
 	logHandler := i.reportingInterpreter.LogHandler()
-	saver := buildSaver(config).WithLogHandler(logHandler)
+	saver := buildSaver(config).WithProvenance(i.provenance).WithLogHandler(logHandler)
 
-	runner = scenario.NewRunner().
+	baseRunner := scenario.NewRunner().
 		WithName(config.Name).
 		WithRunNumber(config.RunNumber).
 		WithMaximumConcurrentRuns(config.MaximumConcurrentRunNumber).
+		WithRandomNumberSeed(config.RandomNumberSeed).
 		WithLogHandler(logHandler).
 		WithSaver(saver)
 
+	if config.Cooperation.Enabled {
+		i.checkCooperation(&config.Cooperation)
+		baseRunner.WithCooperativeRuns(config.Cooperation.ExchangeInterval, config.Cooperation.TemperatureLadderRatio)
+	}
+
+	if config.Reporting.Trace.Enabled {
+		i.checkTrace(&config.Reporting.Trace)
+		baseRunner.WithTraceRecorder(buildTraceRecorder(config))
+	}
+
+	for _, tracker := range i.runTrackers {
+		baseRunner.WithRunTracker(tracker)
+	}
+
+	runner = baseRunner
+
 	if config.CpuProfilePath != "" {
 		profilingRunner := new(scenario.ProfilingRunner).
 			ThatProfiles(runner).
@@ -79,15 +111,39 @@ func (i *ScenarioConfigInterpreter) interpretRunner(config *appData.ScenarioConf
 	i.runner = runner
 }
 
+func (i *ScenarioConfigInterpreter) checkCooperation(config *appData.CooperationConfig) {
+	if config.ExchangeInterval == 0 {
+		i.errors.Add(errors.New("Cooperation ExchangeInterval must be greater than 0"))
+	}
+	if config.TemperatureLadderRatio <= 0 {
+		i.errors.Add(errors.New("Cooperation TemperatureLadderRatio must be greater than 0"))
+	}
+}
+
+func (i *ScenarioConfigInterpreter) checkTrace(config *appData.TraceConfig) {
+	if config.EveryNumberOfIterations == 0 {
+		i.errors.Add(errors.New("Trace EveryNumberOfIterations must be greater than 0"))
+	}
+}
+
 func buildSaver(scenarioConfig *appData.ScenarioConfig) *scenario.Saver {
 	saver := scenario.NewSaver().
 		WithOutputType(configOutputTypeToEncodingOutputType(scenarioConfig.OutputType)).
 		WithOutputPath(scenarioConfig.OutputPath).
-		WithOutputLevel(configOutputLevelToScenarioOutputLevel(scenarioConfig.OutputLevel))
+		WithOutputLevel(configOutputLevelToScenarioOutputLevel(scenarioConfig.OutputLevel)).
+		WithCombinedRunSolutions(scenarioConfig.CombineRunSolutions)
 
 	return saver
 }
 
+func buildTraceRecorder(scenarioConfig *appData.ScenarioConfig) *scenario.TraceRecorder {
+	traceConfig := scenarioConfig.Reporting.Trace
+	return scenario.NewTraceRecorder().
+		WithOutputPath(scenarioConfig.OutputPath).
+		WithFormat(scenario.TraceFormat(traceConfig.Format.String())).
+		WithEveryNumberOfIterations(traceConfig.EveryNumberOfIterations)
+}
+
 func configOutputTypeToEncodingOutputType(outputType appData.ScenarioOutputType) encoding.OutputType {
 	return encoding.OutputType(outputType.String())
 }
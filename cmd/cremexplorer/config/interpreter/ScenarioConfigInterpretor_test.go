@@ -56,3 +56,85 @@ func TestConfigInterpreter_ProfilingScenario_HasProfilingRunner(t *testing.T) {
 	}
 	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
 }
+
+func TestConfigInterpreter_CooperativeScenario_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configUnderTest := data.ScenarioConfig{
+		Name: "Cooperative Scenario test",
+		Cooperation: data.CooperationConfig{
+			Enabled:                true,
+			ExchangeInterval:       100,
+			TemperatureLadderRatio: 0.5,
+		},
+	}
+
+	// when
+	interpreterUnderTest := NewScenarioConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+}
+
+func TestConfigInterpreter_InvalidCooperation_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configUnderTest := data.ScenarioConfig{
+		Name:        "Cooperative Scenario test",
+		Cooperation: data.CooperationConfig{Enabled: true},
+	}
+
+	// when
+	interpreterUnderTest := NewScenarioConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+}
+
+func TestConfigInterpreter_TracedScenario_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configUnderTest := data.ScenarioConfig{
+		Name: "Traced Scenario test",
+		Reporting: data.ReportingConfig{
+			Trace: data.TraceConfig{
+				Enabled:                 true,
+				Format:                  data.JsonLinesTrace,
+				EveryNumberOfIterations: 10,
+			},
+		},
+	}
+
+	// when
+	interpreterUnderTest := NewScenarioConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+}
+
+func TestConfigInterpreter_InvalidTrace_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configUnderTest := data.ScenarioConfig{
+		Name: "Traced Scenario test",
+		Reporting: data.ReportingConfig{
+			Trace: data.TraceConfig{Enabled: true},
+		},
+	}
+
+	// when
+	interpreterUnderTest := NewScenarioConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	baseRand "math/rand"
+
+	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/dumb"
+	modumbParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/modumb/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	"github.com/LindsayBradford/crem/internal/pkg/uncertainty"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+// modelParameterSpecifications supplies the parameter specifications of each model type, from which default
+// sensitivity analysis parameter ranges are derived.
+var modelParameterSpecifications = map[string]func() *specification.Specifications{
+	interpreter.CatchmentModel:          catchmentParameters.ParameterSpecifications,
+	interpreter.DumbModel:               dumb.ParameterSpecifications,
+	interpreter.MultiObjectiveDumbModel: modumbParameters.ParameterSpecifications,
+}
+
+type SensitivityConfigInterpreter struct {
+	errors *compositeErrors.CompositeError
+
+	analysis        *uncertainty.SensitivityAnalysis
+	solutionSetFile string
+}
+
+func NewSensitivityConfigInterpreter() *SensitivityConfigInterpreter {
+	interpreter := new(SensitivityConfigInterpreter).initialise()
+	return interpreter
+}
+
+func (i *SensitivityConfigInterpreter) initialise() *SensitivityConfigInterpreter {
+	i.errors = compositeErrors.New("Sensitivity Configuration")
+	i.analysis = uncertainty.NewSensitivityAnalysis()
+	return i
+}
+
+func (i *SensitivityConfigInterpreter) Interpret(sensitivityConfig *appData.SensitivityConfig, modelConfig *data.ModelConfig) *SensitivityConfigInterpreter {
+	i.solutionSetFile = sensitivityConfig.SolutionSetFile
+
+	i.analysis.
+		WithModelFactory(modelFactoryFor(*modelConfig)).
+		WithMethod(interpretSensitivityMethod(sensitivityConfig))
+
+	if sensitivityConfig.RandomNumberSeed != 0 {
+		i.analysis.WithRandomNumberGenerator(rand.New(baseRand.NewSource(sensitivityConfig.RandomNumberSeed)))
+	}
+
+	i.interpretRanges(sensitivityConfig, modelConfig)
+
+	if validationErrors := i.analysis.Validate(); validationErrors != nil {
+		i.errors.Add(validationErrors)
+	}
+
+	return i
+}
+
+func interpretSensitivityMethod(config *appData.SensitivityConfig) uncertainty.SensitivityMethod {
+	switch config.Method {
+	case appData.SobolMethod:
+		return uncertainty.NewSobol().
+			WithSampleNumber(int(config.SampleNumber))
+	default:
+		return uncertainty.NewMorris().
+			WithTrajectoryNumber(int(config.TrajectoryNumber)).
+			WithLevels(int(config.Levels))
+	}
+}
+
+// interpretRanges varies the parameters listed (or all with a default range where none are listed), over their
+// configured range, or their default range where not configured.
+func (i *SensitivityConfigInterpreter) interpretRanges(config *appData.SensitivityConfig, modelConfig *data.ModelConfig) {
+	ranges := make(map[string]uncertainty.ParameterRange)
+	if specifications, hasSpecifications := modelParameterSpecifications[modelConfig.Type]; hasSpecifications {
+		ranges = uncertainty.DefaultParameterRanges(specifications(), modelConfig.Parameters)
+	}
+
+	for parameterName, rangeConfig := range config.Ranges {
+		ranges[parameterName] = uncertainty.ParameterRange{Minimum: rangeConfig.Minimum, Maximum: rangeConfig.Maximum}
+	}
+
+	parameterNames := config.Parameters
+	if len(parameterNames) == 0 {
+		for parameterName := range ranges {
+			parameterNames = append(parameterNames, parameterName)
+		}
+	}
+
+	for _, parameterName := range parameterNames {
+		parameterRange, hasRange := ranges[parameterName]
+		if !hasRange {
+			i.errors.Add(errors.New("No range available for sensitivity parameter [" + parameterName + "]"))
+			continue
+		}
+		i.analysis.WithParameterRange(parameterName, parameterRange)
+	}
+}
+
+func (i *SensitivityConfigInterpreter) Analysis() *uncertainty.SensitivityAnalysis {
+	return i.analysis
+}
+
+func (i *SensitivityConfigInterpreter) SolutionSetFile() string {
+	return i.solutionSetFile
+}
+
+func (i *SensitivityConfigInterpreter) Errors() error {
+	if i.errors.Size() > 0 {
+		return i.errors
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	"testing"
+
+	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	. "github.com/onsi/gomega"
+)
+
+func TestSensitivityConfigInterpreter_DefaultRanges_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sensitivityConfig := appData.SensitivityConfig{
+		Method:           appData.MorrisMethod,
+		TrajectoryNumber: 10,
+		Levels:           4,
+	}
+	modelConfig := data.ModelConfig{Type: interpreter.CatchmentModel}
+
+	// when
+	interpreterUnderTest := NewSensitivityConfigInterpreter().Interpret(&sensitivityConfig, &modelConfig)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+	g.Expect(interpreterUnderTest.SolutionSetFile()).To(BeEmpty())
+}
+
+func TestSensitivityConfigInterpreter_ParameterWithoutRange_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sensitivityConfig := appData.SensitivityConfig{
+		Method:       appData.SobolMethod,
+		SampleNumber: 64,
+		Parameters:   []string{"InitialObjectiveValue", "MinimumObjectiveValue"},
+	}
+	modelConfig := data.ModelConfig{Type: interpreter.DumbModel}
+
+	// when
+	interpreterUnderTest := NewSensitivityConfigInterpreter().Interpret(&sensitivityConfig, &modelConfig)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+}
+
+func TestSensitivityConfigInterpreter_ConfiguredRange_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sensitivityConfig := appData.SensitivityConfig{
+		Method:       appData.SobolMethod,
+		SampleNumber: 64,
+		Parameters:   []string{"InitialObjectiveValue", "MinimumObjectiveValue"},
+		Ranges: map[string]appData.RangeConfig{
+			"MinimumObjectiveValue": {Minimum: 0, Maximum: 100},
+		},
+	}
+	modelConfig := data.ModelConfig{Type: interpreter.DumbModel}
+
+	// when
+	interpreterUnderTest := NewSensitivityConfigInterpreter().Interpret(&sensitivityConfig, &modelConfig)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/sweep"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	annealerSweepPrefix = "Annealer."
+	modelSweepPrefix    = "Model."
+)
+
+type SweepConfigInterpreter struct {
+	errors *compositeErrors.CompositeError
+
+	design       sweep.Design
+	scenarioSeed int64
+}
+
+func NewSweepConfigInterpreter() *SweepConfigInterpreter {
+	interpreter := new(SweepConfigInterpreter).initialise()
+	return interpreter
+}
+
+func (i *SweepConfigInterpreter) initialise() *SweepConfigInterpreter {
+	i.errors = compositeErrors.New("Sweep Configuration")
+	return i
+}
+
+// WithScenarioSeed has sampled designs configured without a random number seed of their own sampled from seed, being
+// that of the scenario swept, so a seeded scenario is reproducible as a whole.
+func (i *SweepConfigInterpreter) WithScenarioSeed(seed int64) *SweepConfigInterpreter {
+	i.scenarioSeed = seed
+	return i
+}
+
+// Interpret builds the sweep's design, naming its annealer and model parameters with an 'Annealer.' or 'Model.'
+// prefix respectively.
+func (i *SweepConfigInterpreter) Interpret(sweepConfig *appData.SweepConfig) *SweepConfigInterpreter {
+	switch sweepConfig.Method {
+	case appData.LatinHypercubeSweep:
+		i.design = i.interpretLatinHypercube(sweepConfig)
+	default:
+		i.design = i.interpretGrid(sweepConfig)
+	}
+
+	if validationErrors := i.design.Validate(); validationErrors != nil {
+		i.errors.Add(validationErrors)
+	}
+
+	return i
+}
+
+func (i *SweepConfigInterpreter) interpretGrid(config *appData.SweepConfig) *sweep.Grid {
+	grid := sweep.NewGrid()
+	addValues := func(prefix string, parametersConfig appData.SweepParametersConfig) {
+		for name, values := range parametersConfig.Values {
+			grid.WithValues(prefix+name, values...)
+		}
+		if len(parametersConfig.Ranges) > 0 {
+			i.errors.Add(errors.Errorf("%s sweep parameter ranges are only used by method [%s]",
+				strings.TrimSuffix(prefix, "."), appData.LatinHypercubeSweep.String()))
+		}
+	}
+
+	addValues(annealerSweepPrefix, config.Annealer)
+	addValues(modelSweepPrefix, config.Model)
+	return grid
+}
+
+func (i *SweepConfigInterpreter) interpretLatinHypercube(config *appData.SweepConfig) *sweep.LatinHypercube {
+	seed := config.RandomNumberSeed
+	if seed == 0 {
+		seed = i.scenarioSeed
+	}
+	latinHypercube := sweep.NewLatinHypercube().
+		WithSampleNumber(int(config.SampleNumber)).
+		WithRandomNumberSeed(seed)
+
+	addRanges := func(prefix string, parametersConfig appData.SweepParametersConfig) {
+		for name, rangeConfig := range parametersConfig.Ranges {
+			latinHypercube.WithRange(prefix+name, i.interpretRange(prefix+name, rangeConfig))
+		}
+		if len(parametersConfig.Values) > 0 {
+			i.errors.Add(errors.Errorf("%s sweep parameter values are only used by method [%s]",
+				strings.TrimSuffix(prefix, "."), appData.GridSweep.String()))
+		}
+	}
+
+	addRanges(annealerSweepPrefix, config.Annealer)
+	addRanges(modelSweepPrefix, config.Model)
+	return latinHypercube
+}
+
+// interpretRange returns the range configured, being an integer range where both its bounds are integers.
+func (i *SweepConfigInterpreter) interpretRange(parameter string, config appData.SweepRangeConfig) sweep.Range {
+	minimum, minimumIsInteger, minimumError := numberOf(config.Minimum)
+	maximum, maximumIsInteger, maximumError := numberOf(config.Maximum)
+	if minimumError != nil || maximumError != nil {
+		i.errors.Add(errors.Errorf("sweep parameter [%s] range must have numeric Minimum and Maximum bounds", parameter))
+	}
+	return sweep.Range{Minimum: minimum, Maximum: maximum, IsInteger: minimumIsInteger && maximumIsInteger}
+}
+
+func numberOf(value interface{}) (number float64, isInteger bool, conversionError error) {
+	switch typedValue := value.(type) {
+	case int64:
+		return float64(typedValue), true, nil
+	case float64:
+		return typedValue, false, nil
+	default:
+		return 0, false, errors.Errorf("value [%v] is not a number", value)
+	}
+}
+
+func (i *SweepConfigInterpreter) Design() sweep.Design {
+	return i.design
+}
+
+func (i *SweepConfigInterpreter) Errors() error {
+	if i.errors.Size() > 0 {
+		return i.errors
+	}
+	return nil
+}
+
+// SweepCombinationConfig returns a copy of config for the numbered sweep combination given, its annealer and model
+// parameters assigned the combination's values.  The copy's scenario is named for the combination.
+func SweepCombinationConfig(config *appData.Config, number int, combination sweep.Combination) *appData.Config {
+	combinationConfig := *config
+
+	combinationConfig.Scenario.Name = fmt.Sprintf("%s Sweep %d", config.Scenario.Name, number)
+	combinationConfig.Scenario.CpuProfilePath = ""
+
+	combinationConfig.Annealer.Parameters = copyParameters(config.Annealer.Parameters)
+	combinationConfig.Model.Parameters = copyParameters(config.Model.Parameters)
+
+	overrides := map[string]interface{}{
+		"Scenario.Name":           combinationConfig.Scenario.Name,
+		"Scenario.CpuProfilePath": combinationConfig.Scenario.CpuProfilePath,
+	}
+
+	for _, setting := range combination {
+		switch {
+		case strings.HasPrefix(setting.Parameter, annealerSweepPrefix):
+			parameterName := strings.TrimPrefix(setting.Parameter, annealerSweepPrefix)
+			combinationConfig.Annealer.Parameters[parameterName] = setting.Value
+			overrides["Annealer.Parameters."+parameterName] = setting.Value
+		case strings.HasPrefix(setting.Parameter, modelSweepPrefix):
+			parameterName := strings.TrimPrefix(setting.Parameter, modelSweepPrefix)
+			combinationConfig.Model.Parameters[parameterName] = setting.Value
+			overrides["Model.Parameters."+parameterName] = setting.Value
+		}
+	}
+
+	// The content overridden was decoded from, so will decode again; should it not, the sweep's own is kept.
+	if combinationContent, overrideError := appData.OverriddenContent(config.MetaData.ResolvedContent, overrides); overrideError == nil {
+		combinationConfig.MetaData.ResolvedContent = combinationContent
+	}
+
+	return &combinationConfig
+}
+
+func copyParameters(original parameters.Map) parameters.Map {
+	parametersCopy := make(parameters.Map, len(original))
+	for key, value := range original {
+		parametersCopy[key] = value
+	}
+	return parametersCopy
+}
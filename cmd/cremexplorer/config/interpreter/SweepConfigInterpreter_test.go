@@ -0,0 +1,205 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	"testing"
+
+	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/sweep"
+	. "github.com/onsi/gomega"
+)
+
+func TestSweepConfigInterpreter_GridValues_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sweepConfig := appData.SweepConfig{
+		Method: appData.GridSweep,
+		Annealer: appData.SweepParametersConfig{
+			Values: map[string][]interface{}{"MaximumIterations": {int64(1000), int64(2000)}},
+		},
+		Model: appData.SweepParametersConfig{
+			Values: map[string][]interface{}{"BankErosionFudgeFactor": {0.0001, 0.0002, 0.0003}},
+		},
+	}
+
+	// when
+	interpreterUnderTest := NewSweepConfigInterpreter().Interpret(&sweepConfig)
+
+	// then
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+	g.Expect(interpreterUnderTest.Design().Name()).To(Equal(sweep.GridMethod))
+	g.Expect(interpreterUnderTest.Design().Parameters()).To(
+		Equal([]string{"Annealer.MaximumIterations", "Model.BankErosionFudgeFactor"}))
+	g.Expect(interpreterUnderTest.Design().Combinations()).To(HaveLen(6))
+}
+
+func TestSweepConfigInterpreter_LatinHypercubeRanges_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sweepConfig := appData.SweepConfig{
+		Method:           appData.LatinHypercubeSweep,
+		SampleNumber:     4,
+		RandomNumberSeed: 1234,
+		Annealer: appData.SweepParametersConfig{
+			Ranges: map[string]appData.SweepRangeConfig{
+				"MaximumIterations": {Minimum: int64(1000), Maximum: int64(5000)},
+			},
+		},
+		Model: appData.SweepParametersConfig{
+			Ranges: map[string]appData.SweepRangeConfig{
+				"BankErosionFudgeFactor": {Minimum: int64(0), Maximum: 0.001},
+			},
+		},
+	}
+
+	// when
+	interpreterUnderTest := NewSweepConfigInterpreter().Interpret(&sweepConfig)
+
+	// then
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+
+	combinations := interpreterUnderTest.Design().Combinations()
+	g.Expect(combinations).To(HaveLen(4))
+	g.Expect(combinations[0][0].Value).To(BeAssignableToTypeOf(int64(0)))
+	g.Expect(combinations[0][1].Value).To(BeAssignableToTypeOf(float64(0)))
+}
+
+func TestSweepConfigInterpreter_UnseededLatinHypercube_SampledFromScenarioSeed(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const scenarioSeed = int64(4321)
+	sweepConfig := appData.SweepConfig{
+		Method:       appData.LatinHypercubeSweep,
+		SampleNumber: 4,
+		Annealer: appData.SweepParametersConfig{
+			Ranges: map[string]appData.SweepRangeConfig{
+				"MaximumIterations": {Minimum: int64(1000), Maximum: int64(5000)},
+			},
+		},
+	}
+
+	// when
+	firstInterpreter := NewSweepConfigInterpreter().WithScenarioSeed(scenarioSeed).Interpret(&sweepConfig)
+	secondInterpreter := NewSweepConfigInterpreter().WithScenarioSeed(scenarioSeed).Interpret(&sweepConfig)
+
+	// then
+	g.Expect(firstInterpreter.Errors()).To(BeNil())
+	g.Expect(firstInterpreter.Design().(sweep.Sampled).RandomNumberSeed()).To(Equal(scenarioSeed))
+	g.Expect(firstInterpreter.Design().Combinations()).To(Equal(secondInterpreter.Design().Combinations()))
+}
+
+func TestSweepConfigInterpreter_RangesForGrid_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sweepConfig := appData.SweepConfig{
+		Method: appData.GridSweep,
+		Annealer: appData.SweepParametersConfig{
+			Values: map[string][]interface{}{"MaximumIterations": {int64(1000)}},
+			Ranges: map[string]appData.SweepRangeConfig{
+				"StartingTemperature": {Minimum: int64(1), Maximum: int64(10)},
+			},
+		},
+	}
+
+	// when
+	interpreterUnderTest := NewSweepConfigInterpreter().Interpret(&sweepConfig)
+
+	// then
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+}
+
+func TestSweepConfigInterpreter_NonNumericRange_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sweepConfig := appData.SweepConfig{
+		Method:       appData.LatinHypercubeSweep,
+		SampleNumber: 4,
+		Model: appData.SweepParametersConfig{
+			Ranges: map[string]appData.SweepRangeConfig{
+				"BankErosionFudgeFactor": {Minimum: "low", Maximum: 0.001},
+			},
+		},
+	}
+
+	// when
+	interpreterUnderTest := NewSweepConfigInterpreter().Interpret(&sweepConfig)
+
+	// then
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+}
+
+func TestSweepCombinationConfig_AppliesSettingsToCopy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	baseConfig := appData.Config{
+		Scenario: appData.ScenarioConfig{Name: "Base", MaximumConcurrentRunNumber: 4},
+		Annealer: data.AnnealerConfig{Parameters: parameters.Map{"MaximumIterations": int64(1000)}},
+		Model:    data.ModelConfig{Parameters: parameters.Map{"DataSourcePath": "model.csv"}},
+	}
+	combination := sweep.Combination{
+		{Parameter: "Annealer.MaximumIterations", Value: int64(2000)},
+		{Parameter: "Model.BankErosionFudgeFactor", Value: 0.0002},
+	}
+
+	// when
+	combinationConfig := SweepCombinationConfig(&baseConfig, 3, combination)
+
+	// then
+	g.Expect(combinationConfig.Scenario.Name).To(Equal("Base Sweep 3"))
+	g.Expect(combinationConfig.Scenario.MaximumConcurrentRunNumber).To(BeNumerically("==", 4))
+	g.Expect(combinationConfig.Annealer.Parameters["MaximumIterations"]).To(Equal(int64(2000)))
+	g.Expect(combinationConfig.Model.Parameters["BankErosionFudgeFactor"]).To(Equal(0.0002))
+	g.Expect(combinationConfig.Model.Parameters["DataSourcePath"]).To(Equal("model.csv"))
+
+	g.Expect(baseConfig.Scenario.Name).To(Equal("Base"))
+	g.Expect(baseConfig.Annealer.Parameters["MaximumIterations"]).To(Equal(int64(1000)))
+	g.Expect(baseConfig.Model.Parameters).To(Not(HaveKey("BankErosionFudgeFactor")))
+}
+
+func TestSweepCombinationConfig_ResolvedContentRecordsCombination(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	baseConfig, retrieveError := appData.RetrieveConfigFromString(`
+[scenario]
+Name = "Base"
+MaximumConcurrentRunNumber = 4
+
+[Annealer]
+Type = "Kirkpatrick"
+
+[Annealer.Parameters]
+MaximumIterations = 1000
+
+[Model]
+Type = "CatchmentModel"
+`)
+	g.Expect(retrieveError).To(BeNil())
+
+	combination := sweep.Combination{
+		{Parameter: "Annealer.MaximumIterations", Value: int64(2000)},
+		{Parameter: "Model.BankErosionFudgeFactor", Value: 0.0002},
+	}
+
+	// when
+	combinationConfig := SweepCombinationConfig(baseConfig, 3, combination)
+	rerunConfig, rerunError := appData.RetrieveConfigFromString(combinationConfig.MetaData.ResolvedContent)
+
+	// then
+	g.Expect(rerunError).To(BeNil())
+	g.Expect(rerunConfig.Scenario.Name).To(Equal("Base Sweep 3"))
+	g.Expect(rerunConfig.Scenario.MaximumConcurrentRunNumber).To(BeNumerically("==", 4))
+	g.Expect(rerunConfig.Annealer.Parameters["MaximumIterations"]).To(Equal(int64(2000)))
+	g.Expect(rerunConfig.Model.Parameters["BankErosionFudgeFactor"]).To(Equal(0.0002))
+
+	g.Expect(baseConfig.MetaData.ResolvedContent).To(ContainSubstring("MaximumIterations = 1000"))
+}
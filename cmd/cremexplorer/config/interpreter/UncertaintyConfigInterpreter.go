@@ -0,0 +1,136 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	baseRand "math/rand"
+
+	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	"github.com/LindsayBradford/crem/internal/pkg/uncertainty"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+type UncertaintyConfigInterpreter struct {
+	errors *compositeErrors.CompositeError
+
+	analysis        *uncertainty.Analysis
+	solutionSetFile string
+}
+
+func NewUncertaintyConfigInterpreter() *UncertaintyConfigInterpreter {
+	interpreter := new(UncertaintyConfigInterpreter).initialise()
+	return interpreter
+}
+
+func (i *UncertaintyConfigInterpreter) initialise() *UncertaintyConfigInterpreter {
+	i.errors = compositeErrors.New("Uncertainty Configuration")
+	i.analysis = uncertainty.NewAnalysis()
+	return i
+}
+
+func (i *UncertaintyConfigInterpreter) Interpret(uncertaintyConfig *appData.UncertaintyConfig, modelConfig *data.ModelConfig) *UncertaintyConfigInterpreter {
+	if uncertaintyConfig.SolutionSetFile == "" {
+		i.errors.Add(errors.New("Missing mandatory uncertainty solution set file field"))
+	}
+	i.solutionSetFile = uncertaintyConfig.SolutionSetFile
+
+	i.analysis.
+		WithModelFactory(modelFactoryFor(*modelConfig)).
+		WithSampleNumber(uncertaintyConfig.SampleNumber).
+		WithPercentiles(uncertaintyConfig.Percentiles...).
+		WithFragilityThreshold(uncertaintyConfig.FragilityThreshold)
+
+	if uncertaintyConfig.RandomNumberSeed != 0 {
+		i.analysis.WithRandomNumberGenerator(rand.New(baseRand.NewSource(uncertaintyConfig.RandomNumberSeed)))
+	}
+
+	for parameterName, distributionConfig := range uncertaintyConfig.Distributions {
+		i.interpretDistribution(parameterName, distributionConfig)
+	}
+
+	if validationErrors := i.analysis.Validate(); validationErrors != nil {
+		i.errors.Add(validationErrors)
+	}
+
+	return i
+}
+
+func (i *UncertaintyConfigInterpreter) interpretDistribution(parameterName string, config appData.DistributionConfig) {
+	distribution := distributionFrom(config)
+	if distribution == nil {
+		i.errors.Add(errors.New("Missing mandatory distribution type for parameter [" + parameterName + "]"))
+		return
+	}
+	i.analysis.WithDistribution(parameterName, distribution)
+}
+
+// distributionFrom returns the distribution configured, or nil where no distribution type was configured.
+func distributionFrom(config appData.DistributionConfig) uncertainty.Distribution {
+	switch config.Type {
+	case appData.UniformDistribution:
+		return uncertainty.Uniform{Minimum: config.Minimum, Maximum: config.Maximum}
+	case appData.TriangularDistribution:
+		return uncertainty.Triangular{Minimum: config.Minimum, Mode: config.Mode, Maximum: config.Maximum}
+	case appData.NormalDistribution:
+		return uncertainty.Normal{
+			Mean:              config.Mean,
+			StandardDeviation: config.StandardDeviation,
+			Minimum:           config.Minimum,
+			Maximum:           config.Maximum,
+		}
+	default:
+		return nil
+	}
+}
+
+// modelFactoryFor builds models as configured, but with any sampled parameter values overriding those configured.
+func modelFactoryFor(modelConfig data.ModelConfig) uncertainty.ModelFactory {
+	return func(sampledParameters parameters.Map) (model.Model, error) {
+		sampledConfig := data.ModelConfig{
+			Type:       modelConfig.Type,
+			Parameters: make(parameters.Map),
+		}
+		for key, value := range modelConfig.Parameters {
+			sampledConfig.Parameters[key] = value
+		}
+		for key, value := range sampledParameters {
+			sampledConfig.Parameters[key] = value
+		}
+
+		modelInterpreter := interpreter.NewModelConfigInterpreter().Interpret(&sampledConfig)
+		if modelInterpreter.Errors() != nil {
+			return nil, modelInterpreter.Errors()
+		}
+
+		sampledModel := modelInterpreter.Model()
+		sampledModel.Initialise(model.AsIs)
+
+		if parameterisedModel, hasParameters := sampledModel.(parameters.Container); hasParameters {
+			if paramErrors := parameterisedModel.ParameterErrors(); paramErrors != nil {
+				return nil, paramErrors
+			}
+		}
+		return sampledModel, nil
+	}
+}
+
+func (i *UncertaintyConfigInterpreter) Analysis() *uncertainty.Analysis {
+	return i.analysis
+}
+
+func (i *UncertaintyConfigInterpreter) SolutionSetFile() string {
+	return i.solutionSetFile
+}
+
+func (i *UncertaintyConfigInterpreter) Errors() error {
+	if i.errors.Size() > 0 {
+		return i.errors
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	"testing"
+
+	appData "github.com/LindsayBradford/crem/cmd/cremexplorer/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/onsi/gomega"
+)
+
+func TestUncertaintyConfigInterpreter_ValidConfig_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	uncertaintyConfig := appData.UncertaintyConfig{
+		SolutionSetFile: "testdata/SomeSolutionSet.csv",
+		SampleNumber:    10,
+		Percentiles:     []float64{5, 95},
+		Distributions: map[string]appData.DistributionConfig{
+			"InitialObjectiveValue": {Type: appData.UniformDistribution, Minimum: 900, Maximum: 1100},
+		},
+	}
+	modelConfig := data.ModelConfig{Type: interpreter.DumbModel}
+
+	// when
+	interpreterUnderTest := NewUncertaintyConfigInterpreter().Interpret(&uncertaintyConfig, &modelConfig)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+	g.Expect(interpreterUnderTest.SolutionSetFile()).To(Equal(uncertaintyConfig.SolutionSetFile))
+}
+
+func TestUncertaintyConfigInterpreter_MissingFields_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	uncertaintyConfig := appData.UncertaintyConfig{
+		SampleNumber: 10,
+		Distributions: map[string]appData.DistributionConfig{
+			"InitialObjectiveValue": {Minimum: 900, Maximum: 1100},
+		},
+	}
+	modelConfig := data.ModelConfig{Type: interpreter.DumbModel}
+
+	// when
+	interpreterUnderTest := NewUncertaintyConfigInterpreter().Interpret(&uncertaintyConfig, &modelConfig)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+}
+
+func TestModelFactoryFor_SampledParameters_OverrideConfigured(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelConfig := data.ModelConfig{
+		Type: interpreter.DumbModel,
+		Parameters: parameters.Map{
+			"InitialObjectiveValue": 1000.0,
+			"MaximumObjectiveValue": 3000.0,
+		},
+	}
+	factoryUnderTest := modelFactoryFor(modelConfig)
+
+	// when
+	sampledModel, factoryError := factoryUnderTest(parameters.Map{"InitialObjectiveValue": 1234.0})
+
+	// then
+	g.Expect(factoryError).To(BeNil())
+	g.Expect(sampledModel.DecisionVariable("ObjectiveValue").Value()).To(BeNumerically("==", 1234))
+	g.Expect(modelConfig.Parameters["InitialObjectiveValue"]).To(BeNumerically("==", 1000))
+}
+
+func TestModelFactoryFor_InvalidSampledParameter_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelConfig := data.ModelConfig{Type: interpreter.DumbModel}
+	factoryUnderTest := modelFactoryFor(modelConfig)
+
+	// when
+	_, factoryError := factoryUnderTest(parameters.Map{"InitialObjectiveValue": "not a number"})
+	t.Log(factoryError)
+
+	// then
+	g.Expect(factoryError).To(Not(BeNil()))
+}
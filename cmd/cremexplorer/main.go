@@ -11,5 +11,22 @@ import (
 
 func main() {
 	args := commandline.ParseArguments()
-	bootstrap.RunExcelCompatibleScenarioFromConfigFile(args.ScenarioFile)
+	switch {
+	case args.DescribeParameters:
+		bootstrap.RunParameterDescription(args.ParameterType, args.ParameterFormat)
+	case args.Validate:
+		bootstrap.RunExcelCompatibleValidationFromConfigFile(args.ScenarioFile, args.ValidationFormat)
+	case args.UncertaintyAnalysis:
+		bootstrap.RunExcelCompatibleUncertaintyAnalysisFromConfigFile(args.ScenarioFile)
+	case args.SensitivityAnalysis:
+		bootstrap.RunExcelCompatibleSensitivityAnalysisFromConfigFile(args.ScenarioFile)
+	case args.Sweep:
+		bootstrap.RunExcelCompatibleSweepFromConfigFile(args.ScenarioFile)
+	case args.ExportProgram != "":
+		bootstrap.RunExcelCompatibleProgramExportFromConfigFile(args.ScenarioFile, args.ExportProgram, args.ProgramObjective)
+	case args.ImportProgramSolution != "":
+		bootstrap.RunExcelCompatibleProgramImportFromConfigFile(args.ScenarioFile, args.ImportProgramSolution, args.ProgramObjective)
+	default:
+		bootstrap.RunExcelCompatibleScenarioFromConfigFile(args.ScenarioFile)
+	}
 }
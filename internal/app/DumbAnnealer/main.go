@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"os"
 
 	"github.com/LindsayBradford/crem/cmd/cremexplorer/commandline"
@@ -65,7 +66,7 @@ func loadScenarioConfig(configFile string) *data2.Config {
 }
 
 func runScenario() {
-	if runError := myScenario.Run(); runError != nil {
+	if runError := myScenario.Run(context.Background()); runError != nil {
 		wrappingError := errors.Wrap(runError, "running scenario")
 		LogHandler.Error(wrappingError)
 		commandline.Exit(runError)
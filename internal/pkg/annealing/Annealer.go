@@ -3,6 +3,8 @@
 package annealing
 
 import (
+	"context"
+
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
@@ -32,7 +34,7 @@ type Annealer interface {
 	EventAttributes(eventType observer.EventType) attributes.Attributes
 
 	Cloneable
-	Anneal()
+	Anneal(ctx context.Context)
 }
 
 type Cloneable interface {
@@ -3,10 +3,12 @@
 package annealers
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/LindsayBradford/crem/internal/pkg/annealing"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
 )
 
 type ElapsedTimeTrackingAnnealer struct {
@@ -21,9 +23,9 @@ func (annealer *ElapsedTimeTrackingAnnealer) Initialise() {
 	annealer.SimpleAnnealer.SetId("Elapsed-Time Tracking Annealer")
 }
 
-func (annealer *ElapsedTimeTrackingAnnealer) Anneal() {
+func (annealer *ElapsedTimeTrackingAnnealer) Anneal(ctx context.Context) {
 	annealer.startTime = time.Now()
-	annealer.SimpleAnnealer.Anneal()
+	annealer.SimpleAnnealer.Anneal(ctx)
 	annealer.finishTime = time.Now()
 
 	annealer.LogHandler().Info(annealer.generateElapsedTimeString())
@@ -41,6 +43,7 @@ func (annealer *ElapsedTimeTrackingAnnealer) DeepClone() annealing.Annealer {
 	clone := *annealer
 	explorerClone := annealer.SolutionExplorer().DeepClone()
 	clone.SetSolutionExplorer(explorerClone)
+	clone.SetEventNotifier(observer.DeepCloneOf(annealer.EventNotifier()))
 
 	return &clone
 }
@@ -3,6 +3,8 @@
 package annealers
 
 import (
+	"context"
+
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
 	. "github.com/onsi/gomega"
 )
@@ -31,7 +33,7 @@ func TestAnneal(t *testing.T) {
 
 	g.Expect(actualClone).To(Equal(annealer), "Deep clone of annealer should equal clone")
 
-	annealer.Anneal()
+	annealer.Anneal(context.Background())
 
 	g.Expect(
 		elapsedTimeAnnealer.ElapsedTime().Nanoseconds()).To(Not(BeZero()), "Annealer should recorded elapsed time")
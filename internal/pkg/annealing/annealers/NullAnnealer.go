@@ -3,6 +3,8 @@
 package annealers
 
 import (
+	"context"
+
 	"github.com/LindsayBradford/crem/internal/pkg/annealing"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/null"
@@ -41,6 +43,7 @@ func (sa *NullAnnealer) DeepClone() annealing.Annealer {
 	clone := *sa
 	explorerClone := sa.SolutionExplorer().DeepClone()
 	clone.SetSolutionExplorer(explorerClone)
+	clone.SetEventNotifier(observer.DeepCloneOf(sa.EventNotifier()))
 	return &clone
 }
 
@@ -50,7 +53,7 @@ func (sa *NullAnnealer) EventAttributes(eventType observer.EventType) attributes
 func (sa *NullAnnealer) SetParameters(params parameters.Map) error           { return nil }
 func (sa *NullAnnealer) ParameterErrors() error                              { return nil }
 func (sa *NullAnnealer) Model() model.Model                                  { return model.NullModel }
-func (sa *NullAnnealer) Anneal()                                             {}
+func (sa *NullAnnealer) Anneal(ctx context.Context)                          {}
 func (sa *NullAnnealer) AddObserver(observer observer.Observer) error        { return nil }
 func (sa *NullAnnealer) AddObserverAsFirst(observer observer.Observer) error { return nil }
 func (sa *NullAnnealer) Observers() []observer.Observer                      { return nil }
@@ -3,6 +3,10 @@
 package annealers
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/LindsayBradford/crem/internal/pkg/annealing"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/null"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
@@ -19,12 +23,32 @@ import (
 )
 
 const (
-	Id               = "Id"
-	CurrentIteration = "CurrentIteration"
+	Id                = "Id"
+	CurrentIteration  = "CurrentIteration"
+	TerminationReason = "TerminationReason"
+	ElapsedTime       = "ElapsedTime"
+	DecisionVariables = "DecisionVariables"
+)
+
+const (
+	maximumIterationsReached  = "maximum iterations reached"
+	annealingCancelled        = "annealing cancelled"
+	maximumDurationReached    = "maximum duration reached"
+	progressStalled           = "maximum iterations without progress reached"
+	minimumTemperatureReached = "minimum temperature reached"
+	searchExhausted           = "search exhausted"
 )
 
 var _ observer.Observer = new(SimpleAnnealer)
 
+// DecisionVariableReporter is an annealer able to report the value of each of its model's decision variables in the
+// events it raises, taken as they are raised, so observers need not read a model that annealing may be changing.
+type DecisionVariableReporter interface {
+	ReportDecisionVariables()
+}
+
+var _ DecisionVariableReporter = new(SimpleAnnealer)
+
 type SimpleAnnealer struct {
 	name.IdentifiableContainer
 
@@ -37,8 +61,17 @@ type SimpleAnnealer struct {
 
 	observer.ContainedEventNotifier
 
-	maximumIterations uint64
-	currentIteration  uint64
+	maximumIterations                uint64
+	maximumDuration                  time.Duration
+	maximumIterationsWithoutProgress uint64
+	minimumTemperature               float64
+
+	currentIteration          uint64
+	iterationsWithoutProgress uint64
+	startTime                 time.Time
+	terminationReason         string
+
+	reportingDecisionVariables bool
 
 	baseAttributes attributes.Attributes
 }
@@ -55,14 +88,27 @@ func (sa *SimpleAnnealer) Initialise() {
 	sa.parameters.Initialise()
 	sa.assignStateFromParameters()
 
+	sa.deriveBaseAttributes()
+}
+
+// deriveBaseAttributes builds the attributes common to all annealer events afresh, so clones given a run id of
+// their own neither report a stale id, nor overwrite the attributes of the annealer they were cloned from.
+func (sa *SimpleAnnealer) deriveBaseAttributes() {
 	sa.baseAttributes = new(attributes.Attributes).
 		Add(Id, sa.Id()).
 		Add(MaximumIterations, sa.maximumIterations)
 }
 
+// ReportDecisionVariables has the StartedAnnealing and FinishedIteration events of the annealer carry the value of
+// each of its model's decision variables, keyed by variable name, as the DecisionVariables attribute.
+func (sa *SimpleAnnealer) ReportDecisionVariables() {
+	sa.reportingDecisionVariables = true
+}
+
 func (sa *SimpleAnnealer) SetId(title string) {
 	sa.IdentifiableContainer.SetId(title)
 	sa.SolutionExplorer().SetId(title)
+	sa.deriveBaseAttributes()
 }
 
 func (sa *SimpleAnnealer) SetLogHandler(logHandler logging.Logger) {
@@ -82,13 +128,16 @@ func (sa *SimpleAnnealer) SetParameters(params parameters.Map) error {
 
 	sa.assignStateFromParameters()
 
-	sa.baseAttributes = sa.baseAttributes.Replace(MaximumIterations, sa.maximumIterations)
+	sa.deriveBaseAttributes()
 
 	return sa.parameters.ValidationErrors()
 }
 
 func (sa *SimpleAnnealer) assignStateFromParameters() {
 	sa.maximumIterations = uint64(sa.parameters.GetInt64(MaximumIterations))
+	sa.maximumDuration = time.Duration(sa.parameters.GetFloat64(MaximumDurationInSeconds) * float64(time.Second))
+	sa.maximumIterationsWithoutProgress = uint64(sa.parameters.GetInt64(MaximumIterationsWithoutProgress))
+	sa.minimumTemperature = sa.parameters.GetFloat64(MinimumTemperature)
 }
 
 func (sa *SimpleAnnealer) ParameterErrors() error {
@@ -108,6 +157,7 @@ func (sa *SimpleAnnealer) DeepClone() annealing.Annealer {
 	clone := *sa
 	explorerClone := sa.SolutionExplorer().DeepClone()
 	clone.SetSolutionExplorer(explorerClone)
+	clone.SetEventNotifier(observer.DeepCloneOf(sa.EventNotifier()))
 	return &clone
 }
 
@@ -115,24 +165,29 @@ func (sa *SimpleAnnealer) Model() model.Model {
 	return sa.SolutionExplorer().Model()
 }
 
-func (sa *SimpleAnnealer) Anneal() {
+// Anneal runs the annealer's explorer until one of its stopping criteria is met, or until ctx is cancelled.
+// Either way, annealing finishes normally, so observers are still notified of the solutions found so far.
+func (sa *SimpleAnnealer) Anneal(ctx context.Context) {
 	defer sa.handlePanicRecovery()
 
 	sa.SolutionExplorer().Initialise()
 	defer sa.SolutionExplorer().TearDown()
 
+	sa.startTime = time.Now()
+	sa.iterationsWithoutProgress = 0
 	sa.annealingStarted()
 
-	for done := sa.initialDoneValue(); !done; {
+	for done := sa.initialDoneValue(ctx); !done; {
 		sa.iterationStarted()
 
 		sa.SolutionExplorer().TryRandomChange()
 		sa.SolutionExplorer().CoolDown()
 
 		sa.iterationFinished()
-		done = sa.checkIfDone()
+		done = sa.checkIfDone(ctx)
 	}
 
+	sa.logEarlyTermination()
 	sa.annealingFinished()
 }
 
@@ -164,6 +219,9 @@ func (sa *SimpleAnnealer) iterationFinished() {
 }
 
 func (sa *SimpleAnnealer) annealingFinished() {
+	if finisher, isFinisher := sa.SolutionExplorer().(explorer.Finisher); isFinisher {
+		finisher.FinishAnnealing()
+	}
 	event := sa.newEvent(observer.FinishedAnnealing)
 	sa.EventNotifier().NotifyObserversOfEvent(*event)
 }
@@ -176,7 +234,9 @@ func (sa *SimpleAnnealer) newEvent(eventType observer.EventType) *observer.Event
 func (sa *SimpleAnnealer) EventAttributes(eventType observer.EventType) attributes.Attributes {
 	switch eventType {
 	case observer.StartedAnnealing:
-		return sa.baseAttributes.Join(sa.SolutionExplorer().EventAttributes(eventType))
+		return sa.baseAttributes.
+			Join(sa.decisionVariableAttributes()).
+			Join(sa.SolutionExplorer().EventAttributes(eventType))
 	case observer.StartedIteration:
 		return sa.baseAttributes.
 			Add(CurrentIteration, sa.currentIteration).
@@ -184,21 +244,103 @@ func (sa *SimpleAnnealer) EventAttributes(eventType observer.EventType) attribut
 	case observer.FinishedIteration:
 		return sa.baseAttributes.
 			Add(CurrentIteration, sa.currentIteration).
+			Join(sa.decisionVariableAttributes()).
 			Join(sa.SolutionExplorer().EventAttributes(eventType))
 	case observer.FinishedAnnealing:
 		return sa.baseAttributes.
 			Add(CurrentIteration, sa.currentIteration).
+			Add(TerminationReason, sa.terminationReason).
+			Add(ElapsedTime, sa.elapsedTime()).
 			Join(sa.SolutionExplorer().EventAttributes(eventType))
 	}
 	return nil
 }
 
-func (sa *SimpleAnnealer) initialDoneValue() bool {
-	return sa.parameters.GetInt64(MaximumIterations) == 0
+func (sa *SimpleAnnealer) decisionVariableAttributes() attributes.Attributes {
+	if !sa.reportingDecisionVariables || sa.Model().NameMappedVariables() == nil {
+		return nil
+	}
+
+	variables := *sa.Model().NameMappedVariables()
+	values := make(map[string]float64, len(variables))
+	for name, decisionVariable := range variables {
+		values[name] = decisionVariable.Value()
+	}
+	return new(attributes.Attributes).Add(DecisionVariables, values)
+}
+
+// elapsedTime returns the time annealing has taken, or zero where annealing has not yet started.
+func (sa *SimpleAnnealer) elapsedTime() time.Duration {
+	if sa.startTime.IsZero() {
+		return 0
+	}
+	return time.Since(sa.startTime)
+}
+
+func (sa *SimpleAnnealer) initialDoneValue(ctx context.Context) bool {
+	switch {
+	case ctx.Err() != nil:
+		sa.terminationReason = annealingCancelled
+	case sa.maximumIterations == 0:
+		sa.terminationReason = maximumIterationsReached
+	default:
+		sa.terminationReason = ""
+		return false
+	}
+	return true
+}
+
+func (sa *SimpleAnnealer) checkIfDone(ctx context.Context) bool {
+	sa.trackProgress()
+
+	switch {
+	case ctx.Err() != nil:
+		sa.terminationReason = annealingCancelled
+	case sa.currentIteration >= sa.maximumIterations:
+		sa.terminationReason = maximumIterationsReached
+	case sa.maximumDuration > 0 && time.Since(sa.startTime) >= sa.maximumDuration:
+		sa.terminationReason = maximumDurationReached
+	case sa.maximumIterationsWithoutProgress > 0 && sa.iterationsWithoutProgress >= sa.maximumIterationsWithoutProgress:
+		sa.terminationReason = progressStalled
+	case sa.minimumTemperature > 0 && sa.temperatureAtOrBelowMinimum():
+		sa.terminationReason = minimumTemperatureReached
+	case sa.searchExhausted():
+		sa.terminationReason = searchExhausted
+	default:
+		return false
+	}
+	return true
 }
 
-func (sa *SimpleAnnealer) checkIfDone() bool {
-	return sa.currentIteration >= uint64(sa.parameters.GetInt64(MaximumIterations))
+// trackProgress counts the iterations since the explorer last reported progress. Explorers that cannot report
+// progress are assumed to always be progressing.
+func (sa *SimpleAnnealer) trackProgress() {
+	reporter, canReportProgress := sa.SolutionExplorer().(explorer.ProgressReporter)
+	if !canReportProgress || reporter.ChangeProgressed() {
+		sa.iterationsWithoutProgress = 0
+		return
+	}
+	sa.iterationsWithoutProgress++
+}
+
+func (sa *SimpleAnnealer) searchExhausted() bool {
+	exhaustible, canExhaust := sa.SolutionExplorer().(explorer.Exhaustible)
+	return canExhaust && exhaustible.SearchExhausted()
+}
+
+func (sa *SimpleAnnealer) temperatureAtOrBelowMinimum() bool {
+	explorerAttributes := sa.SolutionExplorer().EventAttributes(observer.FinishedIteration)
+	temperature, hasTemperature := explorerAttributes.Value(explorer.Temperature).(float64)
+	return hasTemperature && temperature <= sa.minimumTemperature
+}
+
+func (sa *SimpleAnnealer) logEarlyTermination() {
+	if sa.terminationReason == maximumIterationsReached {
+		return
+	}
+	message := fmt.Sprintf("Scenario [%s]: annealing stopped at iteration [%d] of [%d], %s",
+		sa.Id(), sa.currentIteration, sa.maximumIterations, sa.terminationReason)
+	sa.LogHandler().Info(message)
 }
 
 func (sa *SimpleAnnealer) AddObserver(observer observer.Observer) error {
@@ -3,12 +3,17 @@
 package annealers
 
 import (
+	"context"
 	"errors"
 	"testing"
 
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/null"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/modumb"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/attributes"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
 	. "github.com/onsi/gomega"
 )
@@ -45,6 +50,47 @@ func TestSimpleAnnealer_DeepClone(t *testing.T) {
 	g.Expect(actualClone).To(Equal(annealer))
 }
 
+func TestSimpleAnnealer_DeepClone_OwnEventNotifier(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	annealer := new(SimpleAnnealer)
+	annealer.Initialise()
+	annealer.SetEventNotifier(observer.NewConcurrentAnnealingEventNotifier())
+	annealer.AddObserver(new(dummyObserver))
+
+	// when
+	clone := annealer.DeepClone()
+	observer.Close(annealer.EventNotifier())
+
+	// then
+	g.Expect(clone.EventNotifier()).To(Not(BeIdenticalTo(annealer.EventNotifier())))
+	g.Expect(clone.Observers()).To(Equal(annealer.Observers()))
+	g.Expect(clone.AddObserver(new(dummyObserver))).To(Succeed())
+
+	observer.Close(clone.EventNotifier())
+}
+
+func TestSimpleAnnealer_DeepClone_SetId_EventsCarryCloneId(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	annealer := new(SimpleAnnealer)
+	annealer.Initialise()
+	annealer.SetId("Scenario")
+
+	// when
+	clone := annealer.DeepClone()
+	clone.SetId("Scenario (1/2)")
+
+	// then
+	cloneAttributes := clone.(*SimpleAnnealer).EventAttributes(observer.StartedAnnealing)
+	g.Expect(cloneAttributes.Value(Id)).To(Equal("Scenario (1/2)"))
+
+	originalAttributes := annealer.EventAttributes(observer.StartedAnnealing)
+	g.Expect(originalAttributes.Value(Id)).To(Equal("Scenario"))
+}
+
 func TestSimpleAnnealer_Errors(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -87,7 +133,7 @@ func TestSimpleAnnealer_Anneal(t *testing.T) {
 	actualBeforeCurrentIteration := beforeAttributes.Value(CurrentIteration).(uint64)
 	g.Expect(actualBeforeCurrentIteration).To(BeZero())
 
-	annealer.Anneal()
+	annealer.Anneal(context.Background())
 
 	afterAttributes := annealer.EventAttributes(observer.FinishedAnnealing)
 	actualAfterCurrentIteration := afterAttributes.Value(CurrentIteration).(uint64)
@@ -123,7 +169,7 @@ func TestSimpleAnnealer_AddObserver(t *testing.T) {
 	g.Expect(observerError).To(BeNil())
 	g.Expect(annealer.Observers()).To(ContainElement(counter))
 
-	annealer.Anneal()
+	annealer.Anneal(context.Background())
 
 	g.Expect(counter.eventCounts[observer.StartedAnnealing]).To(BeNumerically("==", 1))
 	g.Expect(counter.eventCounts[observer.FinishedAnnealing]).To(BeNumerically("==", 1))
@@ -160,7 +206,7 @@ func TestSimpleAnnealer_SetSolutionExplorer(t *testing.T) {
 	g.Expect(explorerErr).To(BeNil())
 	g.Expect(annealer.SolutionExplorer()).To(BeIdenticalTo(expectedSolutionExplorer))
 
-	annealer.Anneal()
+	annealer.Anneal(context.Background())
 
 	g.Expect(expectedSolutionExplorer.changesTried).To(BeNumerically("==", expectedTryCount))
 }
@@ -231,12 +277,128 @@ func TestSimpleAnnealer_PanicingExplorer(t *testing.T) {
 	annealer.SetParameters(expectedParams)
 
 	annealingCall := func() {
-		annealer.Anneal()
+		annealer.Anneal(context.Background())
 	}
 
 	g.Expect(annealingCall).To(Panic())
 }
 
+func TestSimpleAnnealer_Anneal_CancelledContext_StopsEarly(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	annealer := new(SimpleAnnealer)
+	annealer.Initialise()
+	annealer.SetParameters(parameters.Map{MaximumIterations: int64(1_000)})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancellingExplorer := &cancellingExplorer{cancelAfter: 3, cancel: cancel}
+	annealer.SetSolutionExplorer(cancellingExplorer)
+
+	counter := &CountingObserver{eventCounts: make(map[observer.EventType]uint64)}
+	annealer.AddObserver(counter)
+
+	annealer.Anneal(ctx)
+
+	afterAttributes := annealer.EventAttributes(observer.FinishedAnnealing)
+	g.Expect(afterAttributes.Value(CurrentIteration)).To(BeNumerically("==", 3))
+	g.Expect(afterAttributes.Value(TerminationReason)).To(Equal(annealingCancelled))
+	g.Expect(counter.eventCounts[observer.FinishedAnnealing]).To(BeNumerically("==", 1))
+}
+
+type cancellingExplorer struct {
+	null.Explorer
+	changesTried uint64
+	cancelAfter  uint64
+	cancel       context.CancelFunc
+}
+
+func (ce *cancellingExplorer) TryRandomChange() {
+	ce.changesTried++
+	if ce.changesTried == ce.cancelAfter {
+		ce.cancel()
+	}
+}
+
+func TestSimpleAnnealer_Anneal_WithoutProgress_StopsEarly(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	annealer := new(SimpleAnnealer)
+	annealer.Initialise()
+	annealer.SetParameters(parameters.Map{
+		MaximumIterations:                int64(1_000),
+		MaximumIterationsWithoutProgress: int64(5),
+	})
+	annealer.SetSolutionExplorer(&progressingExplorer{progressUntil: 10})
+
+	annealer.Anneal(context.Background())
+
+	afterAttributes := annealer.EventAttributes(observer.FinishedAnnealing)
+	g.Expect(afterAttributes.Value(CurrentIteration)).To(BeNumerically("==", 15))
+	g.Expect(afterAttributes.Value(TerminationReason)).To(Equal(progressStalled))
+}
+
+type progressingExplorer struct {
+	null.Explorer
+	changesTried  uint64
+	progressUntil uint64
+}
+
+func (pe *progressingExplorer) TryRandomChange() {
+	pe.changesTried++
+}
+
+func (pe *progressingExplorer) ChangeProgressed() bool {
+	return pe.changesTried <= pe.progressUntil
+}
+
+func TestSimpleAnnealer_Anneal_MinimumTemperature_StopsEarly(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	annealer := new(SimpleAnnealer)
+	annealer.Initialise()
+	annealer.SetParameters(parameters.Map{
+		MaximumIterations:  int64(1_000),
+		MinimumTemperature: 10.0,
+	})
+	annealer.SetSolutionExplorer(&coolingExplorer{temperature: 100})
+
+	annealer.Anneal(context.Background())
+
+	afterAttributes := annealer.EventAttributes(observer.FinishedAnnealing)
+	g.Expect(afterAttributes.Value(CurrentIteration)).To(BeNumerically("==", 9))
+	g.Expect(afterAttributes.Value(TerminationReason)).To(Equal(minimumTemperatureReached))
+}
+
+type coolingExplorer struct {
+	null.Explorer
+	temperature float64
+}
+
+func (ce *coolingExplorer) CoolDown() {
+	ce.temperature -= 10
+}
+
+func (ce *coolingExplorer) EventAttributes(eventType observer.EventType) attributes.Attributes {
+	return new(attributes.Attributes).Add(explorer.Temperature, ce.temperature)
+}
+
+func TestSimpleAnnealer_Anneal_MaximumDuration_StopsEarly(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	annealer := new(SimpleAnnealer)
+	annealer.Initialise()
+	annealer.SetParameters(parameters.Map{
+		MaximumIterations:        int64(1_000_000_000),
+		MaximumDurationInSeconds: 0.01,
+	})
+
+	annealer.Anneal(context.Background())
+
+	afterAttributes := annealer.EventAttributes(observer.FinishedAnnealing)
+	g.Expect(afterAttributes.Value(CurrentIteration)).To(BeNumerically("<", 1_000_000_000))
+	g.Expect(afterAttributes.Value(TerminationReason)).To(Equal(maximumDurationReached))
+}
+
 type flawedExplorer struct {
 	null.Explorer
 }
@@ -244,3 +406,64 @@ type flawedExplorer struct {
 func (fe *flawedExplorer) TryRandomChange() {
 	panic(errors.New("gotta panic"))
 }
+
+type modelledExplorer struct {
+	null.Explorer
+	explorerModel model.Model
+}
+
+func (me *modelledExplorer) Model() model.Model { return me.explorerModel }
+
+func TestSimpleAnnealer_ReportDecisionVariables_FinishedIterationCarriesValues(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	annealedModel := modumb.NewModel()
+	annealedModel.Initialise(model.AsIs)
+
+	annealer := new(SimpleAnnealer)
+	annealer.Initialise()
+	annealer.SetSolutionExplorer(&modelledExplorer{explorerModel: annealedModel})
+
+	g.Expect(annealer.EventAttributes(observer.FinishedIteration).Has(DecisionVariables)).To(BeFalse())
+
+	// when
+	annealer.ReportDecisionVariables()
+	reportedAttributes := annealer.EventAttributes(observer.FinishedIteration)
+	reportedValues := reportedAttributes.Value(DecisionVariables)
+
+	// then
+	g.Expect(reportedValues).To(HaveLen(len(modumb.Objectives)))
+	for _, objective := range modumb.Objectives {
+		g.Expect(reportedValues).To(HaveKeyWithValue(objective, annealedModel.DecisionVariable(objective).Value()))
+	}
+}
+
+func TestSimpleAnnealer_Anneal_SearchExhausted_StopsEarly(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	annealer := new(SimpleAnnealer)
+	annealer.Initialise()
+	annealer.SetParameters(parameters.Map{MaximumIterations: int64(1_000)})
+	annealer.SetSolutionExplorer(&exhaustingExplorer{exhaustAfter: 7})
+
+	annealer.Anneal(context.Background())
+
+	afterAttributes := annealer.EventAttributes(observer.FinishedAnnealing)
+	g.Expect(afterAttributes.Value(CurrentIteration)).To(BeNumerically("==", 7))
+	g.Expect(afterAttributes.Value(TerminationReason)).To(Equal(searchExhausted))
+}
+
+type exhaustingExplorer struct {
+	null.Explorer
+	changesTried uint64
+	exhaustAfter uint64
+}
+
+func (ee *exhaustingExplorer) TryRandomChange() {
+	ee.changesTried++
+}
+
+func (ee *exhaustingExplorer) SearchExhausted() bool {
+	return ee.changesTried >= ee.exhaustAfter
+}
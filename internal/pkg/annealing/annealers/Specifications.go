@@ -6,8 +6,15 @@ import (
 	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
 )
 
-const MaximumIterations string = "MaximumIterations"
+const (
+	MaximumIterations                = "MaximumIterations"
+	MaximumDurationInSeconds         = "MaximumDurationInSeconds"
+	MaximumIterationsWithoutProgress = "MaximumIterationsWithoutProgress"
+	MinimumTemperature               = "MinimumTemperature"
+)
 
+// DefineSpecifications defines the annealer's stopping criteria. Annealing always stops at MaximumIterations, and
+// stops earlier if any of the remaining criteria, each disabled by a value of 0, are met.
 func DefineSpecifications() *Specifications {
 	specs := NewSpecifications()
 	specs.Add(
@@ -16,6 +23,24 @@ func DefineSpecifications() *Specifications {
 			Validator:    IsNonNegativeInteger,
 			DefaultValue: int64(0),
 		},
+	).Add(
+		Specification{
+			Key:          MaximumDurationInSeconds,
+			Validator:    IsNonNegativeDecimal,
+			DefaultValue: float64(0),
+		},
+	).Add(
+		Specification{
+			Key:          MaximumIterationsWithoutProgress,
+			Validator:    IsNonNegativeInteger,
+			DefaultValue: int64(0),
+		},
+	).Add(
+		Specification{
+			Key:          MinimumTemperature,
+			Validator:    IsNonNegativeDecimal,
+			DefaultValue: float64(0),
+		},
 	)
 	return specs
 }
@@ -22,4 +22,6 @@ type TemperatureCoolant interface {
 	AcceptanceProbability() float64
 
 	CoolDown()
+
+	DeepClone() TemperatureCoolant
 }
@@ -100,3 +100,10 @@ func (c *Coolant) AcceptanceProbability() float64 {
 func (c *Coolant) CoolDown() {
 	c.temperature *= c.coolingFactor
 }
+
+// DeepClone returns a copy of the coolant, with its own time-seeded random number generator.
+func (c *Coolant) DeepClone() cooling.TemperatureCoolant {
+	clone := *c
+	clone.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	return &clone
+}
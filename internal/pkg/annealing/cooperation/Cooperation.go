@@ -0,0 +1,78 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package cooperation offers the means by which the explorers of concurrent annealing runs share their progress.
+// Suppapitnarm explorers periodically exchange non-dominated model states through a SharedArchive, while
+// Kirkpatrick explorers run as replicas at differing temperatures, periodically swapping model states through a
+// ReplicaExchange (parallel tempering).
+package cooperation
+
+import (
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+)
+
+const (
+	DefaultExchangeInterval       = 1_000
+	DefaultTemperatureLadderRatio = 0.5
+)
+
+var _ rand.Seedable = new(Cooperation)
+
+// Cooperation is shared by all runs of a scenario annealing cooperatively.
+type Cooperation struct {
+	exchangeInterval       uint64
+	temperatureLadderRatio float64
+
+	archive  *SharedArchive
+	replicas *ReplicaExchange
+}
+
+func New() *Cooperation {
+	return &Cooperation{
+		exchangeInterval:       DefaultExchangeInterval,
+		temperatureLadderRatio: DefaultTemperatureLadderRatio,
+		archive:                NewSharedArchive(),
+		replicas:               NewReplicaExchange(),
+	}
+}
+
+// WithExchangeInterval sets the number of iterations between exchanges with other runs.
+func (c *Cooperation) WithExchangeInterval(interval uint64) *Cooperation {
+	if interval > 0 {
+		c.exchangeInterval = interval
+	}
+	return c
+}
+
+// WithTemperatureLadderRatio sets the ratio between the starting temperatures of consecutive replicas.
+func (c *Cooperation) WithTemperatureLadderRatio(ratio float64) *Cooperation {
+	if ratio > 0 {
+		c.temperatureLadderRatio = ratio
+	}
+	return c
+}
+
+// SeedRandomNumbers drives the replica exchange's choice of which model states to swap from seed.
+func (c *Cooperation) SeedRandomNumbers(seed int64) {
+	c.replicas.SeedRandomNumbers(seed)
+}
+
+// ExchangeDue reports whether an explorer at the iteration supplied should exchange with other runs.
+func (c *Cooperation) ExchangeDue(iteration uint64) bool {
+	return iteration > 0 && iteration%c.exchangeInterval == 0
+}
+
+// TemperatureScaleOf returns the factor by which the starting temperature of the (zero-based) replica supplied is
+// scaled, so replicas start on a geometric ladder of temperatures.
+func (c *Cooperation) TemperatureScaleOf(replica int) float64 {
+	return math.Pow(c.temperatureLadderRatio, float64(replica))
+}
+
+func (c *Cooperation) Archive() *SharedArchive {
+	return c.archive
+}
+
+func (c *Cooperation) Replicas() *ReplicaExchange {
+	return c.replicas
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package cooperation
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	booleanArchive "github.com/LindsayBradford/crem/pkg/archive"
+	. "github.com/onsi/gomega"
+)
+
+func buildState(activeAction int, variables ...float64) *archive.CompressedModelState {
+	state := new(archive.CompressedModelState)
+	state.Variables = variables
+	state.Actions = *booleanArchive.New(4)
+	state.Actions.SetValue(activeAction, true)
+	return state
+}
+
+func TestCooperation_ExchangeDueAndTemperatureLadder(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	cooperationUnderTest := New().WithExchangeInterval(10).WithTemperatureLadderRatio(0.5)
+
+	// then
+	g.Expect(cooperationUnderTest.ExchangeDue(0)).To(BeFalse())
+	g.Expect(cooperationUnderTest.ExchangeDue(5)).To(BeFalse())
+	g.Expect(cooperationUnderTest.ExchangeDue(20)).To(BeTrue())
+
+	g.Expect(cooperationUnderTest.TemperatureScaleOf(0)).To(BeNumerically("==", 1))
+	g.Expect(cooperationUnderTest.TemperatureScaleOf(2)).To(BeNumerically("==", 0.25))
+}
+
+func TestSharedArchive_Exchange_CombinesNonDominatedStates(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	archiveUnderTest := NewSharedArchive()
+	firstStates := []*archive.CompressedModelState{buildState(0, 1, 4), buildState(1, 3, 3)}
+	secondStates := []*archive.CompressedModelState{buildState(2, 2, 2)}
+
+	// when
+	firstReturned := archiveUnderTest.Exchange(firstStates)
+	secondReturned := archiveUnderTest.Exchange(secondStates)
+
+	// then
+	g.Expect(firstReturned).To(ConsistOf(firstStates[0], firstStates[1]))
+	g.Expect(secondReturned).To(ConsistOf(firstStates[0], secondStates[0]))
+	g.Expect(archiveUnderTest.Len()).To(Equal(2))
+}
+
+func TestReplicaExchange_ColderReplicaWithWorseEnergy_Swaps(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	exchangeUnderTest := NewReplicaExchange()
+	exchangeUnderTest.Join()
+	exchangeUnderTest.Join()
+
+	colderState, warmerState := buildState(0, 10), buildState(1, 1)
+	var colderReceived, warmerReceived *archive.CompressedModelState
+
+	// when
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(2)
+	go func() {
+		colderReceived = exchangeUnderTest.Exchange(ReplicaOffer{Energy: 10, Temperature: 1, State: colderState})
+		waitGroup.Done()
+	}()
+	go func() {
+		warmerReceived = exchangeUnderTest.Exchange(ReplicaOffer{Energy: 1, Temperature: 100, State: warmerState})
+		waitGroup.Done()
+	}()
+	waitGroup.Wait()
+
+	// then
+	g.Expect(colderReceived).To(BeIdenticalTo(warmerState))
+	g.Expect(warmerReceived).To(BeIdenticalTo(colderState))
+}
+
+func TestReplicaExchange_LeavingReplica_ReleasesWaitingReplica(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	exchangeUnderTest := NewReplicaExchange()
+	exchangeUnderTest.Join()
+	exchangeUnderTest.Join()
+
+	offeredState := buildState(0, 10)
+	received := make(chan *archive.CompressedModelState)
+
+	// when
+	go func() {
+		received <- exchangeUnderTest.Exchange(ReplicaOffer{Energy: 10, Temperature: 1, State: offeredState})
+	}()
+	exchangeUnderTest.Leave()
+
+	// then
+	g.Expect(<-received).To(BeIdenticalTo(offeredState))
+}
+
+func TestCooperation_SeedRandomNumbers_SeedsReplicaSwaps(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const seed = int64(42)
+	firstCooperation, secondCooperation := New(), New()
+
+	// when
+	firstCooperation.SeedRandomNumbers(seed)
+	secondCooperation.SeedRandomNumbers(seed)
+
+	// then
+	colder := &ReplicaOffer{Energy: 1.5, Temperature: 1}
+	warmer := &ReplicaOffer{Energy: 1, Temperature: 2}
+	for attempt := 0; attempt < 100; attempt++ {
+		firstAccepted := firstCooperation.Replicas().swapAccepted(colder, warmer)
+		secondAccepted := secondCooperation.Replicas().swapAccepted(colder, warmer)
+		g.Expect(firstAccepted).To(Equal(secondAccepted))
+	}
+	g.Expect(firstCooperation.Replicas().RandomNumberGenerator().Seed()).To(Equal(seed))
+}
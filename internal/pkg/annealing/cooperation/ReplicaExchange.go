@@ -0,0 +1,108 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package cooperation
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+)
+
+// ReplicaOffer is what a replica brings to an exchange: the model state it holds, the (minimised) energy of that
+// state, and the temperature the replica is annealing at.
+type ReplicaOffer struct {
+	Energy      float64
+	Temperature float64
+	State       *archive.CompressedModelState
+
+	received *archive.CompressedModelState
+}
+
+// ReplicaExchange swaps model states between replicas annealing at neighbouring temperatures.  Each exchange
+// waits for every replica that has joined (and not yet left) to arrive, so replicas must leave once they finish
+// annealing.  Swaps are drawn from the system time, unless seeded via SeedRandomNumbers before replicas join.
+type ReplicaExchange struct {
+	mutex   sync.Mutex
+	arrived *sync.Cond
+	members int
+	round   uint64
+	offers  []*ReplicaOffer
+
+	rand.RandContainer
+}
+
+func NewReplicaExchange() *ReplicaExchange {
+	exchange := new(ReplicaExchange)
+	exchange.arrived = sync.NewCond(&exchange.mutex)
+	exchange.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	return exchange
+}
+
+func (re *ReplicaExchange) Join() {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+	re.members++
+}
+
+func (re *ReplicaExchange) Leave() {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+	re.members--
+	re.exchangeIfAllArrived()
+}
+
+// Exchange blocks until all replicas have made an offer, returning the model state the replica should continue
+// annealing from; either the state it offered, or that of a replica at a neighbouring temperature.
+func (re *ReplicaExchange) Exchange(offer ReplicaOffer) *archive.CompressedModelState {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	offer.received = offer.State
+	re.offers = append(re.offers, &offer)
+	arrivalRound := re.round
+
+	re.exchangeIfAllArrived()
+	for re.round == arrivalRound {
+		re.arrived.Wait()
+	}
+
+	return offer.received
+}
+
+func (re *ReplicaExchange) exchangeIfAllArrived() {
+	if len(re.offers) == 0 || len(re.offers) < re.members {
+		return
+	}
+
+	re.swapNeighbours()
+
+	re.offers = nil
+	re.round++
+	re.arrived.Broadcast()
+}
+
+// swapNeighbours pairs offers at neighbouring temperatures, alternating between even and odd pairings each round,
+// swapping states with the Metropolis probability min(1, exp((1/T_i - 1/T_j) * (E_i - E_j))).
+func (re *ReplicaExchange) swapNeighbours() {
+	sort.SliceStable(re.offers, func(i, j int) bool {
+		return re.offers[i].Temperature < re.offers[j].Temperature
+	})
+
+	for index := int(re.round % 2); index+1 < len(re.offers); index += 2 {
+		colder, warmer := re.offers[index], re.offers[index+1]
+		if re.swapAccepted(colder, warmer) {
+			colder.received, warmer.received = warmer.State, colder.State
+		}
+	}
+}
+
+func (re *ReplicaExchange) swapAccepted(colder *ReplicaOffer, warmer *ReplicaOffer) bool {
+	exponent := (1/colder.Temperature - 1/warmer.Temperature) * (colder.Energy - warmer.Energy)
+	if exponent >= 0 {
+		return true
+	}
+	return re.RandomNumberGenerator().Float64Unitary() < math.Exp(exponent)
+}
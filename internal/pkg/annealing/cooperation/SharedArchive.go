@@ -0,0 +1,40 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package cooperation
+
+import (
+	"sync"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+)
+
+// SharedArchive is a NonDominanceModelArchive safe for concurrent use by the explorers of multiple runs.
+type SharedArchive struct {
+	archive *archive.NonDominanceModelArchive
+	mutex   sync.Mutex
+}
+
+func NewSharedArchive() *SharedArchive {
+	return &SharedArchive{archive: archive.New()}
+}
+
+// Exchange attempts to archive the model states supplied, returning the shared archive's resulting non-dominated
+// model states. Model states are shared between archives, and so must not be altered once archived.
+func (sa *SharedArchive) Exchange(states []*archive.CompressedModelState) []*archive.CompressedModelState {
+	sa.mutex.Lock()
+	defer sa.mutex.Unlock()
+
+	for _, state := range states {
+		sa.archive.AttemptToArchiveState(state)
+	}
+
+	sharedStates := make([]*archive.CompressedModelState, sa.archive.Len())
+	copy(sharedStates, sa.archive.Archive())
+	return sharedStates
+}
+
+func (sa *SharedArchive) Len() int {
+	sa.mutex.Lock()
+	defer sa.mutex.Unlock()
+	return sa.archive.Len()
+}
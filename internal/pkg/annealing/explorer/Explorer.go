@@ -5,6 +5,7 @@ package explorer
 import (
 	"errors"
 
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooperation"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
@@ -25,6 +26,10 @@ const (
 
 	AcceptanceProbability = "AcceptanceProbability"
 	ChangeAccepted        = "ChangeAccepted"
+
+	RandomNumberSeed = "RandomNumberSeed"
+
+	NoFeasibleSolution = "NoFeasibleSolution"
 )
 
 type Explorer interface {
@@ -44,6 +49,30 @@ type Explorer interface {
 	EventAttributes(eventType observer.EventType) attributes.Attributes
 }
 
+// ProgressReporter is an optional interface for explorers able to report whether the last change they tried
+// progressed their search, allowing annealers to stop once a search has stagnated.
+type ProgressReporter interface {
+	ChangeProgressed() bool
+}
+
+// Exhaustible is an optional interface for explorers whose search can run out of changes to try, allowing annealers
+// to stop once it has.
+type Exhaustible interface {
+	SearchExhausted() bool
+}
+
+// Finisher is an optional interface for explorers needing to settle the final state of their model once annealing
+// has finished, before that state is reported.
+type Finisher interface {
+	FinishAnnealing()
+}
+
+// Cooperative is an optional interface for explorers able to share their progress with the explorers of other runs
+// annealing concurrently. Replica is the zero-based index of the explorer's run.
+type Cooperative interface {
+	Cooperate(cooperation *cooperation.Cooperation, replica int)
+}
+
 // Container defines an interface embedding an Explorer
 type Container interface {
 	SolutionExplorer() Explorer
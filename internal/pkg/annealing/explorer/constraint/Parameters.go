@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package constraint
+
+import (
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+const (
+	ConstraintHandling        = "ConstraintHandling"
+	PenaltyWeight             = "PenaltyWeight"
+	PenaltyAdaptationFactor   = "PenaltyAdaptationFactor"
+	PenaltyAdaptationInterval = "PenaltyAdaptationInterval"
+)
+
+const (
+	RejectHandling  = "Reject"
+	PenaltyHandling = "Penalty"
+)
+
+// WithParameterSpecifications adds the specifications choosing how an explorer handles changes breaking decision
+// variable bounds to those of the explorer.
+func WithParameterSpecifications(specs *Specifications) *Specifications {
+	specs.Add(
+		Specification{
+			Key:          ConstraintHandling,
+			Validator:    isConstraintHandling,
+			DefaultValue: RejectHandling,
+		},
+	).Add(
+		Specification{
+			Key:          PenaltyWeight,
+			Validator:    isPositiveDecimal,
+			DefaultValue: float64(1),
+		},
+	).Add(
+		Specification{
+			Key:          PenaltyAdaptationFactor,
+			Validator:    isAdaptationFactor,
+			DefaultValue: float64(2), // 1 leaves the penalty weight unchanged
+		},
+	).Add(
+		Specification{
+			Key:          PenaltyAdaptationInterval,
+			Validator:    IsNonNegativeInteger,
+			DefaultValue: int64(100), // 0 leaves the penalty weight unchanged
+		},
+	)
+	return specs
+}
+
+func isConstraintHandling(key string, value interface{}) error {
+	valueAsString, typeIsOk := value.(string)
+	if !typeIsOk {
+		return NewInvalidSpecificationError("Parameter [" + key + "] must be a string value")
+	}
+	if valueAsString == RejectHandling || valueAsString == PenaltyHandling {
+		return NewValidSpecificationError(key, value)
+	}
+	return NewInvalidSpecificationError("Parameter [" + key + "] must be one of [" + RejectHandling + ", " +
+		PenaltyHandling + "], but was supplied [" + valueAsString + "]")
+}
+
+func isPositiveDecimal(key string, value interface{}) error {
+	return IsDecimalWithInclusiveBounds(key, value, math.SmallestNonzeroFloat64, math.MaxFloat64)
+}
+
+func isAdaptationFactor(key string, value interface{}) error {
+	return IsDecimalWithInclusiveBounds(key, value, 1, math.MaxFloat64)
+}
+
+// PenalisesViolations reports whether an explorer's parameters ask for it to penalise changes breaking decision
+// variable bounds, rather than rejecting them.
+func PenalisesViolations(params *parameters.Parameters) bool {
+	return params.GetString(ConstraintHandling) == PenaltyHandling
+}
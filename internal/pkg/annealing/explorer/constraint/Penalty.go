@@ -0,0 +1,160 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package constraint offers explorers an alternative to rejecting changes that break decision variable bounds:
+// penalising them instead, so a search can cross infeasible model states to reach other feasible ones.
+package constraint
+
+import (
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+)
+
+// Values holds the values of a model's bounded decision variables, by name.
+type Values map[string]float64
+
+// ValuesOf returns the current values of a model's bounded decision variables.
+func ValuesOf(boundedModel model.Model) Values {
+	values := make(Values)
+	for name, decisionVariable := range *boundedModel.NameMappedVariables() {
+		if _, isLimited := decisionVariable.(variable.Limited); isLimited {
+			values[name] = decisionVariable.Value()
+		}
+	}
+	return values
+}
+
+// ChangedBy returns the values, as changed by the change a model is trying.  The values must be those of the model
+// before it tried the change.
+func (v Values) ChangedBy(changedModel model.Model) Values {
+	changedValues := make(Values, len(v))
+	for name, value := range v {
+		changedValues[name] = value + changedModel.DecisionVariableChange(name)
+	}
+	return changedValues
+}
+
+// Violation sums how far the values stray outside the bounds of a model's decision variables, each relative to the
+// bound broken (or absolute, for a bound of zero).  Values within bounds have no violation.
+func (v Values) Violation(boundedModel model.Model) float64 {
+	violation := 0.0
+	for name, value := range v {
+		if limits, isLimited := boundedModel.DecisionVariable(name).(variable.Limited); isLimited {
+			violation += violationOf(value, limits)
+		}
+	}
+	return violation
+}
+
+func violationOf(value float64, limits variable.Limited) float64 {
+	if minimum, hasMinimum := limits.Minimum(); hasMinimum && value < minimum {
+		return relativeTo(minimum, minimum-value)
+	}
+	if maximum, hasMaximum := limits.Maximum(); hasMaximum && value > maximum {
+		return relativeTo(maximum, value-maximum)
+	}
+	return 0
+}
+
+func relativeTo(bound float64, distance float64) float64 {
+	if bound == 0 {
+		return distance
+	}
+	return distance / math.Abs(bound)
+}
+
+// Violation returns how far the current values of a model's decision variables stray outside their bounds.
+func Violation(boundedModel model.Model) float64 {
+	return ValuesOf(boundedModel).Violation(boundedModel)
+}
+
+// IsFeasible reports whether the current values of a model's decision variables are all within their bounds.
+func IsFeasible(boundedModel model.Model) bool {
+	return Violation(boundedModel) == 0
+}
+
+// Penalty weighs violations of decision variable bounds against an explorer's objective(s).  Every adaptation
+// interval, its weight grows by the adaptation factor if the model states observed were all infeasible, and shrinks
+// by it if they were all feasible, steering the search along the boundary of feasible model states.
+type Penalty struct {
+	enabled bool
+
+	initialWeight      float64
+	weight             float64
+	scale              float64
+	adaptationFactor   float64
+	adaptationInterval uint64
+
+	observations       uint64
+	feasibleObserved   bool
+	infeasibleObserved bool
+}
+
+// PenaltyFrom returns the penalty an explorer's parameters ask for, being disabled (of no weight) where the explorer
+// rejects changes breaking decision variable bounds instead.
+func PenaltyFrom(params *parameters.Parameters) Penalty {
+	if !PenalisesViolations(params) {
+		return Penalty{}
+	}
+
+	weight := params.GetFloat64(PenaltyWeight)
+	return Penalty{
+		enabled:            true,
+		initialWeight:      weight,
+		weight:             weight,
+		scale:              1,
+		adaptationFactor:   params.GetFloat64(PenaltyAdaptationFactor),
+		adaptationInterval: uint64(params.GetInt64(PenaltyAdaptationInterval)),
+	}
+}
+
+// Initialise resets the penalty to its initial weight, scaling violations by the magnitude given (typically that of
+// the objective being penalised), so that at a weight of 1, breaking a bound by its own size costs that magnitude.
+func (p *Penalty) Initialise(magnitude float64) {
+	p.weight = p.initialWeight
+	p.scale = math.Max(1, math.Abs(magnitude))
+	p.observations = 0
+	p.feasibleObserved, p.infeasibleObserved = false, false
+}
+
+func (p *Penalty) Enabled() bool {
+	return p.enabled
+}
+
+func (p *Penalty) Weight() float64 {
+	return p.weight
+}
+
+// Of returns the penalty for the violation given.
+func (p *Penalty) Of(violation float64) float64 {
+	if !p.enabled {
+		return 0
+	}
+	return p.weight * p.scale * violation
+}
+
+// Observe records whether the model state an explorer holds after an iteration is feasible, adapting the penalty's
+// weight at the end of each adaptation interval.
+func (p *Penalty) Observe(feasible bool) {
+	if !p.enabled || p.adaptationInterval == 0 {
+		return
+	}
+
+	p.feasibleObserved = p.feasibleObserved || feasible
+	p.infeasibleObserved = p.infeasibleObserved || !feasible
+
+	p.observations++
+	if p.observations%p.adaptationInterval != 0 {
+		return
+	}
+
+	switch {
+	case !p.feasibleObserved:
+		p.weight *= p.adaptationFactor
+	case !p.infeasibleObserved:
+		p.weight /= p.adaptationFactor
+	}
+	p.feasibleObserved, p.infeasibleObserved = false, false
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package constraint
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	catchmenttest "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/test"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	costVariable = "ImplementationCost"
+	maximumCost  = 100_000.0
+)
+
+func buildTestingModel(g *GomegaWithT) model.Model {
+	modelUnderTest, buildError := catchmenttest.NewTestingModel(parameters.Map{catchmentParameters.MaximumImplementationCost: maximumCost})
+	g.Expect(buildError).To(BeNil())
+	return modelUnderTest
+}
+
+func buildParameters(g *GomegaWithT, params parameters.Map) *parameters.Parameters {
+	constraintParameters := new(parameters.Parameters).
+		Initialise("Constraint Parameter Validation").
+		Enforcing(WithParameterSpecifications(specification.NewSpecifications()))
+	constraintParameters.AssignOnlyEnforcedUserValues(params)
+	g.Expect(constraintParameters.ValidationErrors()).To(BeNil())
+	return constraintParameters
+}
+
+func TestViolation_BrokenMaximum_RelativeToBound(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestingModel(g)
+	g.Expect(IsFeasible(modelUnderTest)).To(BeTrue())
+
+	// when
+	for index := range modelUnderTest.ManagementActions() {
+		modelUnderTest.SetManagementAction(index, true)
+	}
+
+	// then
+	cost := modelUnderTest.DecisionVariable(costVariable).Value()
+	g.Expect(cost).To(BeNumerically(">", maximumCost))
+	g.Expect(IsFeasible(modelUnderTest)).To(BeFalse())
+	g.Expect(Violation(modelUnderTest)).To(BeNumerically("~", (cost-maximumCost)/maximumCost, 1e-9))
+}
+
+func TestValues_ChangedBy_MatchesAcceptedChange(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestingModel(g)
+	valuesBefore := ValuesOf(modelUnderTest)
+	g.Expect(valuesBefore).To(HaveKey(costVariable))
+
+	// when
+	modelUnderTest.TryRandomChange()
+	changedValues := valuesBefore.ChangedBy(modelUnderTest)
+	modelUnderTest.AcceptChange()
+
+	// then
+	acceptedValues := ValuesOf(modelUnderTest)
+	g.Expect(changedValues).To(HaveLen(len(acceptedValues)))
+	for name, acceptedValue := range acceptedValues {
+		g.Expect(changedValues).To(HaveKey(name))
+		g.Expect(changedValues[name]).To(BeNumerically("~", acceptedValue, 1e-9))
+	}
+}
+
+func TestPenaltyFrom_Rejecting_Disabled(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	penaltyUnderTest := PenaltyFrom(buildParameters(g, parameters.Map{}))
+
+	// then
+	g.Expect(penaltyUnderTest.Enabled()).To(BeFalse())
+	g.Expect(penaltyUnderTest.Of(1)).To(BeZero())
+}
+
+func TestPenalty_Observe_AdaptsWeightPerInterval(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	penaltyUnderTest := PenaltyFrom(buildParameters(g, parameters.Map{
+		ConstraintHandling:        PenaltyHandling,
+		PenaltyWeight:             float64(1),
+		PenaltyAdaptationFactor:   float64(2),
+		PenaltyAdaptationInterval: int64(2),
+	}))
+	penaltyUnderTest.Initialise(-10)
+	g.Expect(penaltyUnderTest.Of(0.5)).To(Equal(float64(5)))
+
+	// when -- an interval of infeasible states, then one mixed, then one feasible.
+	penaltyUnderTest.Observe(false)
+	penaltyUnderTest.Observe(false)
+	weightAfterInfeasible := penaltyUnderTest.Weight()
+
+	penaltyUnderTest.Observe(true)
+	penaltyUnderTest.Observe(false)
+	weightAfterMixed := penaltyUnderTest.Weight()
+
+	penaltyUnderTest.Observe(true)
+	penaltyUnderTest.Observe(true)
+	weightAfterFeasible := penaltyUnderTest.Weight()
+
+	// then
+	g.Expect(weightAfterInfeasible).To(Equal(float64(2)))
+	g.Expect(weightAfterMixed).To(Equal(float64(2)))
+	g.Expect(weightAfterFeasible).To(Equal(float64(1)))
+}
+
+func TestParameters_InvalidConstraintHandling_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	paramsUnderTest := new(parameters.Parameters).
+		Initialise("Constraint Parameter Validation").
+		Enforcing(WithParameterSpecifications(specification.NewSpecifications()))
+
+	// when
+	paramsUnderTest.AssignOnlyEnforcedUserValues(parameters.Map{
+		ConstraintHandling:      "Ignore",
+		PenaltyAdaptationFactor: float64(0.5),
+	})
+
+	// then
+	g.Expect(paramsUnderTest.ValidationErrors()).To(Not(BeNil()))
+	t.Log(paramsUnderTest.ValidationErrors())
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package greedy offers an explorer that, rather than annealing, activates management actions in order of their
+// cost-effectiveness, giving a fast baseline against which annealed solutions can be compared.
+package greedy
+
+import (
+	"fmt"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/attributes"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	"github.com/LindsayBradford/crem/pkg/name"
+	"github.com/pkg/errors"
+)
+
+const (
+	ObjectiveValue = "ObjectiveValue"
+	CostValue      = "CostValue"
+	ArchiveSize    = "ArchiveSize"
+	ModelArchive   = "ModelArchive"
+	RankedActions  = "RankedActions"
+)
+
+// Explorer starts from the As-Is model state, and each iteration activates the next most cost-effective management
+// action (skipping those breaking the budget or model bounds), until no ranked actions remain, its search then being
+// exhausted.  Every model state along the way, the As-Is state included, is offered to its archive, which is emitted
+// as the solution set (marginal cost curve) on finishing.
+type Explorer struct {
+	name.NameContainer
+	name.IdentifiableContainer
+
+	model.ContainedModel
+	loggers.ContainedLogger
+
+	parameters Parameters
+	settings   settings
+
+	ranking          []int
+	nextRanked       int
+	changeProgressed bool
+	exhausted        bool
+
+	modelArchive archive.NonDominanceModelArchive
+
+	observer.SynchronousAnnealingEventNotifier
+
+	baseAttributes attributes.Attributes
+}
+
+func New() *Explorer {
+	newExplorer := new(Explorer)
+	newExplorer.parameters.Initialise()
+	newExplorer.settings = settingsFrom(&newExplorer.parameters.Parameters)
+	newExplorer.modelArchive.Initialise()
+	newExplorer.SetModel(model.NewNullModel())
+	return newExplorer
+}
+
+func (ge *Explorer) Initialise() {
+	ge.LogHandler().Debug(ge.Id() + ": Initialising Greedy Explorer")
+
+	ge.Model().Initialise(model.AsIs)
+	ge.modelArchive.Initialise()
+	ge.rankManagementActions()
+
+	ge.baseAttributes = new(attributes.Attributes).
+		Add(ObjectiveValue, ge.ObjectiveValue()).
+		Add(CostValue, ge.CostValue()).
+		Add(ArchiveSize, ge.modelArchive.Len())
+
+	ge.notifyInitialisation()
+}
+
+// rankManagementActions ranks the management actions of the As-Is model state, archiving that state as the first of
+// the solution set.  Where the model lacks the decision variables named, the error is added to those of the
+// explorer's parameters and logged, the search being exhausted from the outset.
+func (ge *Explorer) rankManagementActions() {
+	ranking, rankError := ge.settings.rank(ge.Model())
+	ge.ranking, ge.nextRanked = ranking, 0
+	ge.exhausted = rankError != nil
+	if rankError != nil {
+		ge.parameters.AddValidationErrorMessage(rankError.Error())
+		ge.LogHandler().Error(errors.Wrap(rankError, ge.Id()+": ranking management actions"))
+		return
+	}
+
+	ge.modelArchive.AttemptToArchive(ge.Model())
+}
+
+func (ge *Explorer) notifyInitialisation() {
+	event := observer.NewEvent(observer.Explorer).
+		WithNote("Initialising").
+		WithAttribute("ObjectiveVariable", ge.settings.objective).
+		WithAttribute("CostVariable", ge.settings.cost).
+		WithAttribute(RankedActions, len(ge.ranking))
+
+	ge.NotifyObserversOfEvent(*event)
+}
+
+func (ge *Explorer) WithName(name string) *Explorer {
+	ge.SetName(name)
+	return ge
+}
+
+func (ge *Explorer) WithModel(model model.Model) *Explorer {
+	ge.SetModel(model)
+	return ge
+}
+
+func (ge *Explorer) WithParameters(params parameters.Map) *Explorer {
+	ge.SetParameters(params)
+	return ge
+}
+
+func (ge *Explorer) SetId(id string) {
+	ge.IdentifiableContainer.SetId(id)
+	ge.modelArchive.SetId(id)
+}
+
+func (ge *Explorer) SetParameters(params parameters.Map) error {
+	ge.parameters.AssignOnlyEnforcedUserValues(params)
+	ge.settings = settingsFrom(&ge.parameters.Parameters)
+	return ge.parameters.ValidationErrors()
+}
+
+func (ge *Explorer) ParameterErrors() error {
+	return ge.parameters.ValidationErrors()
+}
+
+func (ge *Explorer) ObjectiveValue() float64 {
+	return ge.valueOf(ge.settings.objective)
+}
+
+func (ge *Explorer) CostValue() float64 {
+	return ge.valueOf(ge.settings.cost)
+}
+
+// valueOf returns the value of the decision variable named, or zero where the model doesn't offer it (an error
+// already recorded on ranking management actions).
+func (ge *Explorer) valueOf(variableName string) float64 {
+	if !ge.Model().OffersDecisionVariable(variableName) {
+		return 0
+	}
+	return ge.Model().DecisionVariable(variableName).Value()
+}
+
+// TryRandomChange activates the next ranked management action that keeps within budget and model bounds, the
+// search being exhausted once none remain.
+func (ge *Explorer) TryRandomChange() {
+	ge.changeProgressed = false
+	if ge.exhausted {
+		return
+	}
+
+	for ge.nextRanked < len(ge.ranking) && !ge.changeProgressed {
+		index := ge.ranking[ge.nextRanked]
+		ge.nextRanked++
+		ge.changeProgressed = ge.settings.tryActivating(ge.Model(), index)
+	}
+
+	if !ge.changeProgressed {
+		ge.exhausted = true
+		ge.note("No Ranked Actions Remaining")
+		return
+	}
+
+	ge.modelArchive.AttemptToArchive(ge.Model())
+	ge.note(fmt.Sprintf("Activated Ranked Action [%d] of [%d]", ge.nextRanked, len(ge.ranking)))
+}
+
+// ChangeProgressed reports whether the last change activated a management action, so annealers configured to stop
+// without progress stop once the ranking is exhausted.
+func (ge *Explorer) ChangeProgressed() bool {
+	return ge.changeProgressed
+}
+
+// SearchExhausted reports whether no ranked actions remain, so annealers stop once the ranking is exhausted.
+func (ge *Explorer) SearchExhausted() bool {
+	return ge.exhausted
+}
+
+func (ge *Explorer) CoolDown() {}
+
+func (ge *Explorer) DeepClone() explorer.Explorer {
+	clone := *ge
+	clone.SetModel(ge.Model().DeepClone())
+	return &clone
+}
+
+func (ge *Explorer) TearDown() {
+	ge.LogHandler().Debug(ge.Id() + ": Triggering tear-down of Greedy Explorer")
+	ge.Model().TearDown()
+}
+
+func (ge *Explorer) EventAttributes(eventType observer.EventType) attributes.Attributes {
+	switch eventType {
+	case observer.StartedAnnealing, observer.StartedIteration, observer.FinishedIteration, observer.Explorer:
+		return ge.currentAttributes()
+	case observer.FinishedAnnealing:
+		return ge.currentAttributes().Add(ModelArchive, ge.modelArchive)
+	}
+	return nil
+}
+
+func (ge *Explorer) currentAttributes() attributes.Attributes {
+	return ge.baseAttributes.
+		Replace(ObjectiveValue, ge.ObjectiveValue()).
+		Replace(CostValue, ge.CostValue()).
+		Replace(ArchiveSize, ge.modelArchive.Len())
+}
+
+func (ge *Explorer) note(note string) {
+	noteEvent := observer.NewEvent(observer.Explorer).
+		WithAttribute(observer.Note.String(), note)
+	ge.NotifyObserversOfEvent(*noteEvent)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package greedy
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	catchmenttest "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/test"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testBudget = 200_000.0
+)
+
+func buildTestingModel(g *GomegaWithT) model.Model {
+	modelUnderTest, buildError := catchmenttest.NewTestingModel(nil)
+	g.Expect(buildError).To(BeNil())
+	return modelUnderTest
+}
+
+func TestSettings_Rank_MostCostEffectiveFirst(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestingModel(g)
+	modelUnderTest.Initialise(model.AsIs)
+	settingsUnderTest := settingsFrom(&new(Parameters).Initialise().Parameters)
+
+	// when
+	ranking, rankError := settingsUnderTest.rank(modelUnderTest)
+
+	// then
+	g.Expect(rankError).To(BeNil())
+	g.Expect(ranking).To(Not(BeEmpty()))
+
+	previousEffectiveness := 0.0
+	for position, index := range ranking {
+		deltas := modelUnderTest.EvaluateManagementActionToggle(index)
+		improvement := -deltas.Delta(settingsUnderTest.objective)
+		g.Expect(improvement).To(BeNumerically(">", 0))
+
+		effectiveness := improvement / deltas.Delta(settingsUnderTest.cost)
+		if position > 0 {
+			g.Expect(effectiveness).To(BeNumerically("<=", previousEffectiveness))
+		}
+		previousEffectiveness = effectiveness
+	}
+}
+
+func TestSeed_RespectsBudget(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestingModel(g)
+	modelUnderTest.Initialise(model.AsIs)
+
+	seedParameters := new(Parameters).Initialise()
+	seedParameters.AssignOnlyEnforcedUserValues(parameters.Map{GreedyBudget: testBudget})
+	g.Expect(seedParameters.ValidationErrors()).To(BeNil())
+
+	// when
+	activated, seedError := Seed(modelUnderTest, &seedParameters.Parameters)
+
+	// then
+	g.Expect(seedError).To(BeNil())
+	g.Expect(activated).To(BeNumerically(">", 0))
+	g.Expect(modelUnderTest.ActiveManagementActions()).To(HaveLen(activated))
+	g.Expect(modelUnderTest.DecisionVariable("ImplementationCost").Value()).To(BeNumerically("<=", testBudget))
+}
+
+func TestExplorer_TryRandomChange_ArchivesUntilRankingExhausted(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := New().
+		WithModel(buildTestingModel(g)).
+		WithParameters(parameters.Map{GreedyBudget: testBudget})
+	g.Expect(explorerUnderTest.ParameterErrors()).To(BeNil())
+
+	explorerUnderTest.SetLogHandler(loggers.NewNullLogger())
+	explorerUnderTest.Initialise()
+	initialObjective := explorerUnderTest.ObjectiveValue()
+
+	// when
+	changes := 0
+	for explorerUnderTest.TryRandomChange(); explorerUnderTest.ChangeProgressed(); explorerUnderTest.TryRandomChange() {
+		changes++
+	}
+
+	// then
+	g.Expect(changes).To(BeNumerically(">", 0))
+	g.Expect(explorerUnderTest.Model().ActiveManagementActions()).To(HaveLen(changes))
+	g.Expect(explorerUnderTest.ObjectiveValue()).To(BeNumerically("<", initialObjective))
+	g.Expect(explorerUnderTest.CostValue()).To(BeNumerically("<=", testBudget))
+	g.Expect(explorerUnderTest.modelArchive.Len()).To(BeNumerically(">", 0))
+	g.Expect(explorerUnderTest.SearchExhausted()).To(BeTrue())
+}
+
+func TestExplorer_Initialise_ArchivesAsIsState(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := New().
+		WithModel(buildTestingModel(g)).
+		WithParameters(parameters.Map{GreedyBudget: testBudget})
+	explorerUnderTest.SetLogHandler(loggers.NewNullLogger())
+
+	// when
+	explorerUnderTest.Initialise()
+
+	// then
+	g.Expect(explorerUnderTest.modelArchive.Len()).To(BeNumerically("==", 1))
+	g.Expect(explorerUnderTest.SearchExhausted()).To(BeFalse())
+}
+
+func TestExplorer_TryRandomChange_NotesExhaustionOnce(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := New().
+		WithModel(buildTestingModel(g)).
+		WithParameters(parameters.Map{GreedyBudget: testBudget})
+	explorerUnderTest.SetLogHandler(loggers.NewNullLogger())
+	explorerUnderTest.Initialise()
+
+	counter := new(noteCountingObserver)
+	explorerUnderTest.AddObserver(counter)
+
+	// when
+	for explorerUnderTest.TryRandomChange(); explorerUnderTest.ChangeProgressed(); explorerUnderTest.TryRandomChange() {
+	}
+	activeActions := len(explorerUnderTest.Model().ActiveManagementActions())
+	explorerUnderTest.TryRandomChange()
+	explorerUnderTest.TryRandomChange()
+
+	// then
+	g.Expect(explorerUnderTest.SearchExhausted()).To(BeTrue())
+	g.Expect(explorerUnderTest.ChangeProgressed()).To(BeFalse())
+	g.Expect(explorerUnderTest.Model().ActiveManagementActions()).To(HaveLen(activeActions))
+	g.Expect(counter.notes["No Ranked Actions Remaining"]).To(Equal(1))
+}
+
+func TestExplorer_Initialise_UnknownDecisionVariable_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := New().
+		WithModel(buildTestingModel(g)).
+		WithParameters(parameters.Map{GreedyDecisionVariable: "NoSuchVariable"})
+	g.Expect(explorerUnderTest.ParameterErrors()).To(BeNil())
+	explorerUnderTest.SetLogHandler(loggers.NewNullLogger())
+
+	// when
+	explorerUnderTest.Initialise()
+	explorerUnderTest.TryRandomChange()
+
+	// then
+	g.Expect(explorerUnderTest.ParameterErrors()).To(Not(BeNil()))
+	g.Expect(explorerUnderTest.SearchExhausted()).To(BeTrue())
+	g.Expect(explorerUnderTest.ChangeProgressed()).To(BeFalse())
+	g.Expect(explorerUnderTest.modelArchive.Len()).To(BeZero())
+	t.Log(explorerUnderTest.ParameterErrors())
+}
+
+type noteCountingObserver struct {
+	notes map[string]int
+}
+
+func (nco *noteCountingObserver) ObserveEvent(event observer.Event) {
+	if nco.notes == nil {
+		nco.notes = make(map[string]int)
+	}
+	if event.HasNote() {
+		nco.notes[event.Note()]++
+	}
+}
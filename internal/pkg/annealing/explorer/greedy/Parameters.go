@@ -0,0 +1,54 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package greedy
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+type Parameters struct {
+	parameters.Parameters
+}
+
+func (p *Parameters) Initialise() *Parameters {
+	p.Parameters.
+		Initialise("Greedy Explorer Parameter Validation").
+		Enforcing(ParameterSpecifications())
+	return p
+}
+
+const (
+	GreedyDecisionVariable = "GreedyDecisionVariable"
+	GreedyCostVariable     = "GreedyCostVariable"
+	GreedyBudget           = "GreedyBudget"
+)
+
+func ParameterSpecifications() *Specifications {
+	return WithParameterSpecifications(NewSpecifications())
+}
+
+// WithParameterSpecifications adds the specifications guiding a greedy choice of model state to those of another
+// explorer.
+func WithParameterSpecifications(specs *Specifications) *Specifications {
+	specs.Add(
+		Specification{
+			Key:          GreedyDecisionVariable,
+			Validator:    IsString,
+			DefaultValue: "SedimentProduction",
+		},
+	).Add(
+		Specification{
+			Key:          GreedyCostVariable,
+			Validator:    IsString,
+			DefaultValue: "ImplementationCost",
+		},
+	).Add(
+		Specification{
+			Key:          GreedyBudget,
+			Validator:    IsNonNegativeDecimal,
+			DefaultValue: float64(0), // 0 leaves spending limited only by the model's own bounds
+		},
+	)
+	return specs
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package greedy
+
+import (
+	"math"
+	"sort"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/pkg/errors"
+)
+
+type settings struct {
+	objective string
+	cost      string
+	budget    float64
+}
+
+func settingsFrom(params *parameters.Parameters) settings {
+	return settings{
+		objective: params.GetString(GreedyDecisionVariable),
+		cost:      params.GetString(GreedyCostVariable),
+		budget:    params.GetFloat64(GreedyBudget),
+	}
+}
+
+// Seed greedily activates the most cost-effective of a model's inactive management actions, as the greedy explorer
+// would, returning the number of actions activated, or an error where the model lacks the decision variables named.
+func Seed(model model.Model, params *parameters.Parameters) (int, error) {
+	seedSettings := settingsFrom(params)
+	ranking, rankError := seedSettings.rank(model)
+	if rankError != nil {
+		return 0, rankError
+	}
+
+	activated := 0
+	for _, index := range ranking {
+		if seedSettings.tryActivating(model, index) {
+			activated++
+		}
+	}
+	return activated, nil
+}
+
+// rank returns the indexes of a model's inactive management actions that improve its objective, most cost-effective
+// (greatest objective improvement per unit of cost) first.  Actions that cost nothing come first, by improvement.
+func (s settings) rank(model model.Model) ([]int, error) {
+	if checkError := s.checkVariablesOfferedBy(model); checkError != nil {
+		return nil, checkError
+	}
+
+	improvementSign := -1.0
+	if variable.SenseOf(model.DecisionVariable(s.objective)) == variable.Maximised {
+		improvementSign = 1
+	}
+
+	ranked := make([]int, 0)
+	improvements := make(map[int]float64)
+	effectiveness := make(map[int]float64)
+	for index, action := range model.ManagementActions() {
+		if action.IsActive() {
+			continue
+		}
+
+		deltas := model.EvaluateManagementActionToggle(index)
+		improvement := improvementSign * deltas.Delta(s.objective)
+		if improvement <= 0 {
+			continue
+		}
+
+		ranked = append(ranked, index)
+		improvements[index] = improvement
+		effectiveness[index] = math.Inf(1)
+		if cost := deltas.Delta(s.cost); cost > 0 {
+			effectiveness[index] = improvement / cost
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		first, second := ranked[i], ranked[j]
+		if effectiveness[first] == effectiveness[second] {
+			return improvements[first] > improvements[second]
+		}
+		return effectiveness[first] > effectiveness[second]
+	})
+	return ranked, nil
+}
+
+func (s settings) checkVariablesOfferedBy(model model.Model) error {
+	variableParameters := map[string]string{GreedyDecisionVariable: s.objective, GreedyCostVariable: s.cost}
+	for _, key := range []string{GreedyDecisionVariable, GreedyCostVariable} {
+		if name := variableParameters[key]; !model.OffersDecisionVariable(name) {
+			return errors.New("parameter [" + key + "] decision variable [" + name + "] not recognised by model")
+		}
+	}
+	return nil
+}
+
+// tryActivating activates the management action at index, deactivating it again if that breaks the budget, or
+// takes any decision variable outside bounds it was within.
+func (s settings) tryActivating(model model.Model, index int) bool {
+	variablesWithinBounds := withinBounds(model)
+
+	model.SetManagementAction(index, true)
+
+	overBudget := s.budget > 0 && model.DecisionVariable(s.cost).Value() > s.budget
+	if overBudget || leftBounds(model, variablesWithinBounds) {
+		model.SetManagementAction(index, false)
+		return false
+	}
+	return true
+}
+
+func withinBounds(model model.Model) map[string]bool {
+	within := make(map[string]bool)
+	for name, decisionVariable := range *model.NameMappedVariables() {
+		if boundedVariable, isBounded := decisionVariable.(variable.Bounded); isBounded {
+			within[name] = boundedVariable.WithinBounds(decisionVariable.Value())
+		}
+	}
+	return within
+}
+
+func leftBounds(model model.Model, previouslyWithin map[string]bool) bool {
+	for name, nowWithin := range withinBounds(model) {
+		if previouslyWithin[name] && !nowWithin {
+			return true
+		}
+	}
+	return false
+}
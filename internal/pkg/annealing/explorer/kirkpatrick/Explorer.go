@@ -3,11 +3,16 @@
 package kirkpatrick
 
 import (
-	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
 	"math"
 
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/kirkpatrick"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooperation"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/constraint"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/seeding"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/moves"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
@@ -46,6 +51,20 @@ type Explorer struct {
 	reasonChangeInvalid  string
 	objectiveValueChange float64
 
+	bestObjectiveValue float64
+	changeProgressed   bool
+
+	penalty           constraint.Penalty
+	valuesBefore      constraint.Values
+	bestFeasibleState *archive.CompressedModelState
+	noFeasibleState   bool
+
+	iteration   uint64
+	cooperation *cooperation.Cooperation
+
+	seed    int64
+	runSeed int64
+
 	observer.SynchronousAnnealingEventNotifier
 
 	baseAttributes attributes.Attributes
@@ -64,15 +83,69 @@ func (ke *Explorer) Initialise() {
 
 	ke.notifyInitialisation()
 
-	ke.SetRandomNumberGenerator(rand.NewTimeSeeded())
-	ke.Model().Initialise(model.Random)
-	ke.Model().Randomize()
+	ke.SetModel(moves.Wrap(ke.Model(), moves.StrategyFrom(&ke.parameters.Parameters)))
+	ke.seedRandomNumbers()
+	ke.initialiseModel()
+	ke.initialiseBestObjectiveValue()
+	ke.iteration = 0
 
 	ke.baseAttributes = new(attributes.Attributes).
 		Add(ObjectiveValue, ke.ObjectiveValue()).
 		Add(explorer.Temperature, ke.Temperature)
 }
 
+// SeedRandomNumbers has the explorer drive all random number generation of its next annealing run from seed.
+func (ke *Explorer) SeedRandomNumbers(seed int64) {
+	ke.seed = seed
+}
+
+// seedRandomNumbers drives the coolant, and the model with any moves made of it, from a single run seed, being
+// that supplied to SeedRandomNumbers, or one drawn from the system time where none was.
+func (ke *Explorer) seedRandomNumbers() {
+	ke.runSeed = rand.SeedOrTime(ke.seed)
+	seeds := rand.NewSeeded(ke.runSeed)
+	ke.Coolant.SeedRandomNumbers(seeds.Int63())
+	rand.Seed(ke.Model(), seeds.Int63())
+}
+
+// initialiseModel starts the model from the state configured, taking the prior solution of lowest energy where
+// offered several.  A prior solution the model cannot take is unrecoverable.
+func (ke *Explorer) initialiseModel() {
+	seedingNote, seedingError := seeding.InitialiseModel(ke.Model(), &ke.parameters.Parameters, ke.energy)
+	if seedingError != nil {
+		panic(errors.Wrap(seedingError, "initialising model"))
+	}
+	ke.note(seedingNote)
+}
+
+// initialiseBestObjectiveValue takes the objective value of the initial model state as the best seen so far.  Where
+// penalising bound violations, only feasible model states count, their best also being kept as the final solution.
+func (ke *Explorer) initialiseBestObjectiveValue() {
+	ke.bestObjectiveValue = ke.ObjectiveValue()
+	if !ke.penalty.Enabled() {
+		return
+	}
+
+	ke.penalty.Initialise(ke.ObjectiveValue())
+	ke.bestFeasibleState = nil
+	ke.noFeasibleState = false
+	if constraint.IsFeasible(ke.Model()) {
+		ke.recordBestFeasibleState()
+		return
+	}
+
+	ke.bestObjectiveValue = math.Inf(1)
+	if ke.optimisationDirection == Maximising {
+		ke.bestObjectiveValue = math.Inf(-1)
+	}
+}
+
+func (ke *Explorer) recordBestFeasibleState() {
+	if ke.penalty.Enabled() {
+		ke.bestFeasibleState = new(archive.ModelCompressor).Compress(ke.Model())
+	}
+}
+
 func (ke *Explorer) notifyInitialisation() {
 	event := observer.NewEvent(observer.Explorer).
 		WithNote("Initialising").
@@ -103,6 +176,8 @@ func (ke *Explorer) SetParameters(params parameters.Map) error {
 
 	ke.setOptimisationDirectionFromParams()
 	ke.checkDecisionVariableFromParams()
+	seeding.CheckParameters(&ke.parameters.Parameters)
+	ke.penalty = constraint.PenaltyFrom(&ke.parameters.Parameters)
 
 	ke.baseAttributes = new(attributes.Attributes).
 		Add(ObjectiveValue, ke.ObjectiveValue()).
@@ -154,8 +229,112 @@ func (ke *Explorer) ObjectiveValue() float64 {
 
 func (ke *Explorer) TryRandomChange() {
 	ke.note("Trying Random Model Change")
+	ke.captureValuesBeforeChange()
 	ke.Model().TryRandomChange()
 	ke.defaultAcceptOrRevertChange()
+	ke.checkProgress()
+	ke.adaptPenalty()
+
+	ke.iteration++
+	ke.exchangeReplicaIfRequired()
+}
+
+// Cooperate has the explorer anneal as one replica of a parallel tempering ensemble, starting at a temperature
+// scaled by its place on the cooperation's temperature ladder.
+func (ke *Explorer) Cooperate(cooperation *cooperation.Cooperation, replica int) {
+	ke.cooperation = cooperation
+	ke.Temperature *= cooperation.TemperatureScaleOf(replica)
+	cooperation.Replicas().Join()
+}
+
+func (ke *Explorer) exchangeReplicaIfRequired() {
+	if ke.cooperation == nil || !ke.cooperation.ExchangeDue(ke.iteration) {
+		return
+	}
+
+	offer := cooperation.ReplicaOffer{
+		Energy:      ke.energy(),
+		Temperature: ke.Temperature,
+		State:       new(archive.ModelCompressor).Compress(ke.Model()),
+	}
+
+	receivedState := ke.cooperation.Replicas().Exchange(offer)
+	if receivedState == offer.State {
+		return
+	}
+
+	new(archive.ModelCompressor).Decompress(receivedState, ke.Model())
+	ke.note("Swapped Model State With Neighbouring Replica")
+}
+
+// energy returns the objective value, oriented for minimisation, plus any penalty for bound violations.
+func (ke *Explorer) energy() float64 {
+	violationPenalty := 0.0
+	if ke.penalty.Enabled() {
+		violationPenalty = ke.penalty.Of(constraint.Violation(ke.Model()))
+	}
+	if ke.optimisationDirection == Maximising {
+		return -ke.ObjectiveValue() + violationPenalty
+	}
+	return ke.ObjectiveValue() + violationPenalty
+}
+
+func (ke *Explorer) captureValuesBeforeChange() {
+	if ke.penalty.Enabled() {
+		ke.valuesBefore = constraint.ValuesOf(ke.Model())
+	}
+}
+
+// penaltyChange returns the change in penalty for bound violations that the change tried makes, oriented to worsen
+// the objective value.
+func (ke *Explorer) penaltyChange() float64 {
+	if !ke.penalty.Enabled() {
+		return 0
+	}
+
+	violationBefore := ke.valuesBefore.Violation(ke.Model())
+	violationAfter := ke.valuesBefore.ChangedBy(ke.Model()).Violation(ke.Model())
+	change := ke.penalty.Of(violationAfter) - ke.penalty.Of(violationBefore)
+
+	if ke.optimisationDirection == Maximising {
+		return -change
+	}
+	return change
+}
+
+func (ke *Explorer) adaptPenalty() {
+	if ke.penalty.Enabled() {
+		ke.penalty.Observe(constraint.IsFeasible(ke.Model()))
+	}
+}
+
+// checkProgress notes whether the change just tried was accepted with an objective value better than any seen so far.
+// Where penalising bound violations, only changes to feasible model states progress.
+func (ke *Explorer) checkProgress() {
+	ke.changeProgressed = false
+	if !ke.changeAccepted {
+		return
+	}
+	if ke.penalty.Enabled() && !constraint.IsFeasible(ke.Model()) {
+		return
+	}
+
+	objectiveValue := ke.ObjectiveValue()
+	switch ke.optimisationDirection {
+	case Minimising:
+		ke.changeProgressed = objectiveValue < ke.bestObjectiveValue
+	case Maximising:
+		ke.changeProgressed = objectiveValue > ke.bestObjectiveValue
+	}
+
+	if ke.changeProgressed {
+		ke.bestObjectiveValue = objectiveValue
+		ke.recordBestFeasibleState()
+	}
+}
+
+func (ke *Explorer) ChangeProgressed() bool {
+	return ke.changeProgressed
 }
 
 func (ke *Explorer) defaultAcceptOrRevertChange() {
@@ -166,8 +345,10 @@ func (ke *Explorer) AcceptOrRevertChange(acceptChange func(), revertChange func(
 	ke.changeInvalid = false
 	if isValid, invalidationErrors := ke.Model().ChangeIsValid(); !isValid {
 		ke.reportInvalidChange(invalidationErrors)
-		revertChange()
-		return
+		if !ke.penalty.Enabled() {
+			revertChange()
+			return
+		}
 	}
 
 	if ke.changeTriedIsDesirable() {
@@ -188,7 +369,9 @@ func (ke *Explorer) AcceptOrRevertChange(acceptChange func(), revertChange func(
 func (ke *Explorer) notifyDesirableAcceptance() {
 	event := observer.NewEvent(observer.Explorer).
 		WithNote("Accepting Desirable Change").
-		WithAttribute(explorer.AcceptanceProbability, ke.AcceptanceProbability)
+		WithAttribute(explorer.AcceptanceProbability, ke.AcceptanceProbability).
+		WithAttribute(explorer.ChangeIsDesirable, true).
+		WithAttribute(explorer.ChangeAccepted, true)
 
 	ke.NotifyObserversOfEvent(*event)
 }
@@ -196,7 +379,9 @@ func (ke *Explorer) notifyDesirableAcceptance() {
 func (ke *Explorer) notifyUndesirableAcceptance() {
 	acceptEvent := observer.NewEvent(observer.Explorer).
 		WithNote("Accepting Undesirable Change").
-		WithAttribute(explorer.AcceptanceProbability, ke.AcceptanceProbability)
+		WithAttribute(explorer.AcceptanceProbability, ke.AcceptanceProbability).
+		WithAttribute(explorer.ChangeIsDesirable, false).
+		WithAttribute(explorer.ChangeAccepted, true)
 
 	ke.NotifyObserversOfEvent(*acceptEvent)
 }
@@ -204,7 +389,9 @@ func (ke *Explorer) notifyUndesirableAcceptance() {
 func (ke *Explorer) notifyUndesirableReversion() {
 	revertEvent := observer.NewEvent(observer.Explorer).
 		WithNote("Reverting Undesirable Change").
-		WithAttribute(explorer.AcceptanceProbability, ke.AcceptanceProbability)
+		WithAttribute(explorer.AcceptanceProbability, ke.AcceptanceProbability).
+		WithAttribute(explorer.ChangeIsDesirable, false).
+		WithAttribute(explorer.ChangeAccepted, false)
 
 	ke.NotifyObserversOfEvent(*revertEvent)
 }
@@ -250,7 +437,7 @@ func (ke *Explorer) notifyDesirability() {
 }
 
 func (ke *Explorer) calculateChangeInObjectiveValue() float64 {
-	ke.objectiveValueChange = ke.Model().DecisionVariableChange(ke.objectiveVariableName)
+	ke.objectiveValueChange = ke.Model().DecisionVariableChange(ke.objectiveVariableName) + ke.penaltyChange()
 	return ke.objectiveValueChange
 }
 
@@ -267,6 +454,7 @@ func (ke *Explorer) RevertLastChange() {
 func (ke *Explorer) DeepClone() explorer.Explorer {
 	clone := *ke
 	clone.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	clone.seed = 0
 	modelClone := ke.Model().DeepClone()
 	clone.SetModel(modelClone)
 	return &clone
@@ -274,6 +462,10 @@ func (ke *Explorer) DeepClone() explorer.Explorer {
 
 func (ke *Explorer) TearDown() {
 	ke.LogHandler().Debug(ke.scenarioId + ": Triggering tear-down of CompressedModel Explorer")
+	if ke.cooperation != nil {
+		ke.cooperation.Replicas().Leave()
+		ke.cooperation = nil
+	}
 	ke.Model().TearDown()
 }
 
@@ -293,10 +485,14 @@ func (ke *Explorer) EventAttributes(eventType observer.EventType) attributes.Att
 			Replace(ObjectiveValue, ke.ObjectiveValue()).
 			Replace(explorer.Temperature, ke.Temperature)
 	case observer.FinishedAnnealing:
-		return ke.baseAttributes.
+		finishedAttributes := ke.baseAttributes.
 			Replace(ObjectiveValue, ke.ObjectiveValue()).
 			Replace(explorer.Temperature, ke.Temperature).
-			Add(CompressedModel, *ke.fetchFinalCompressedModel())
+			Add(explorer.RandomNumberSeed, ke.runSeed)
+		if ke.noFeasibleState {
+			return finishedAttributes.Add(explorer.NoFeasibleSolution, true)
+		}
+		return finishedAttributes.Add(CompressedModel, *ke.fetchFinalCompressedModel())
 	case observer.Explorer:
 		return ke.baseAttributes.
 			Replace(ObjectiveValue, ke.ObjectiveValue()).
@@ -309,6 +505,22 @@ func (ke *Explorer) EventAttributes(eventType observer.EventType) attributes.Att
 	return nil
 }
 
+// FinishAnnealing returns a model left infeasible by penalised bound violations to the best feasible state seen, so
+// only feasible solutions are reported.  Where no feasible state was ever seen, the final state is flagged as
+// infeasible instead, and no solution reported.
+func (ke *Explorer) FinishAnnealing() {
+	if !ke.penalty.Enabled() || constraint.IsFeasible(ke.Model()) {
+		return
+	}
+	if ke.bestFeasibleState == nil {
+		ke.noFeasibleState = true
+		ke.note("No Feasible Model State Found")
+		return
+	}
+	new(archive.ModelCompressor).Decompress(ke.bestFeasibleState, ke.Model())
+	ke.note("Restored Best Feasible Model State")
+}
+
 func (ke *Explorer) fetchFinalCompressedModel() *archive.CompressedModelState {
 	compressedModel := new(archive.ModelCompressor).Compress(ke.Model())
 	compressedModel.SetId(ke.Model().Id())
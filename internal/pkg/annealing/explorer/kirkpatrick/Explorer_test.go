@@ -0,0 +1,158 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package kirkpatrick
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/constraint"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/moves"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	catchmenttest "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/test"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/attributes"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testIterations = 500
+)
+
+func buildTestingModel(g *GomegaWithT) model.Model {
+	modelUnderTest, buildError := catchmenttest.NewTestingModel(parameters.Map{catchmentParameters.MaximumImplementationCost: 100_000.0})
+	g.Expect(buildError).To(BeNil())
+	return modelUnderTest
+}
+
+func TestExplorer_PenaltyHandling_CrossesInfeasibleStatesButFinishesFeasible(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := New().
+		WithModel(buildTestingModel(g)).
+		WithParameters(parameters.Map{
+			DecisionVariableName:                 "SedimentProduction",
+			constraint.ConstraintHandling:        constraint.PenaltyHandling,
+			constraint.PenaltyWeight:             1e-9,
+			constraint.PenaltyAdaptationInterval: int64(0),
+		})
+	g.Expect(explorerUnderTest.ParameterErrors()).To(BeNil())
+
+	explorerUnderTest.SetLogHandler(loggers.NewNullLogger())
+	explorerUnderTest.Initialise()
+	g.Expect(constraint.IsFeasible(explorerUnderTest.Model())).To(BeTrue())
+
+	// when
+	infeasibleStatesVisited := 0
+	for iteration := 0; iteration < testIterations; iteration++ {
+		explorerUnderTest.TryRandomChange()
+		if !constraint.IsFeasible(explorerUnderTest.Model()) {
+			infeasibleStatesVisited++
+		}
+	}
+	explorerUnderTest.FinishAnnealing()
+	finalAttributes := explorerUnderTest.EventAttributes(observer.FinishedAnnealing)
+
+	// then
+	g.Expect(infeasibleStatesVisited).To(BeNumerically(">", 0))
+	g.Expect(constraint.IsFeasible(explorerUnderTest.Model())).To(BeTrue())
+
+	finalState := finalAttributes.Value(CompressedModel).(archive.CompressedModelState)
+	g.Expect(finalState.MatchesStateOf(explorerUnderTest.Model())).To(BeTrue())
+}
+
+func TestExplorer_PenaltyHandling_NoFeasibleStateFlaggedNotReported(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest, buildError := catchmenttest.NewTestingModel(parameters.Map{catchmentParameters.MaximumSedimentProduction: 0.0})
+	g.Expect(buildError).To(BeNil())
+
+	explorerUnderTest := New().
+		WithModel(modelUnderTest).
+		WithParameters(parameters.Map{
+			DecisionVariableName:          "SedimentProduction",
+			constraint.ConstraintHandling: constraint.PenaltyHandling,
+		})
+	g.Expect(explorerUnderTest.ParameterErrors()).To(BeNil())
+
+	explorerUnderTest.SetLogHandler(loggers.NewNullLogger())
+	explorerUnderTest.Initialise()
+
+	// when
+	for iteration := 0; iteration < testIterations; iteration++ {
+		explorerUnderTest.TryRandomChange()
+	}
+	explorerUnderTest.FinishAnnealing()
+	finalAttributes := explorerUnderTest.EventAttributes(observer.FinishedAnnealing)
+
+	// then
+	g.Expect(constraint.IsFeasible(explorerUnderTest.Model())).To(BeFalse())
+	g.Expect(finalAttributes.Value(explorer.NoFeasibleSolution)).To(Equal(true))
+	g.Expect(finalAttributes.Has(CompressedModel)).To(BeFalse())
+}
+
+func TestExplorer_RejectHandling_NeverInfeasible(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerUnderTest := New().
+		WithModel(buildTestingModel(g)).
+		WithParameters(parameters.Map{DecisionVariableName: "SedimentProduction"})
+	g.Expect(explorerUnderTest.ParameterErrors()).To(BeNil())
+
+	explorerUnderTest.SetLogHandler(loggers.NewNullLogger())
+	explorerUnderTest.Initialise()
+
+	// when
+	for iteration := 0; iteration < testIterations; iteration++ {
+		explorerUnderTest.TryRandomChange()
+
+		// then
+		g.Expect(constraint.IsFeasible(explorerUnderTest.Model())).To(BeTrue())
+	}
+}
+
+func TestExplorer_SeedRandomNumbers_SameSeedReproducesRun(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const seed = int64(20211019)
+
+	// when
+	firstState, firstAttributes := seededRunOf(g, seed)
+	secondState, secondAttributes := seededRunOf(g, seed)
+
+	// then
+	g.Expect(firstState.Encoding()).To(Equal(secondState.Encoding()))
+	g.Expect(firstState.Variables).To(Equal(secondState.Variables))
+	g.Expect(firstAttributes.Value(explorer.RandomNumberSeed)).To(Equal(seed))
+	g.Expect(secondAttributes.Value(explorer.RandomNumberSeed)).To(Equal(seed))
+}
+
+func seededRunOf(g *GomegaWithT, seed int64) (archive.CompressedModelState, attributes.Attributes) {
+	explorerUnderTest := New().
+		WithModel(buildTestingModel(g)).
+		WithParameters(parameters.Map{
+			DecisionVariableName: "SedimentProduction",
+			moves.MoveStrategy:   moves.AdaptiveStrategy,
+		})
+	g.Expect(explorerUnderTest.ParameterErrors()).To(BeNil())
+
+	explorerUnderTest.SetLogHandler(loggers.NewNullLogger())
+	explorerUnderTest.SeedRandomNumbers(seed)
+	explorerUnderTest.Initialise()
+
+	for iteration := 0; iteration < testIterations; iteration++ {
+		explorerUnderTest.TryRandomChange()
+		explorerUnderTest.CoolDown()
+	}
+
+	finalAttributes := explorerUnderTest.EventAttributes(observer.FinishedAnnealing)
+	return finalAttributes.Value(CompressedModel).(archive.CompressedModelState), finalAttributes
+}
@@ -5,6 +5,9 @@ package kirkpatrick
 import (
 	"fmt"
 
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/constraint"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/seeding"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/moves"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
 
 	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
@@ -61,7 +64,8 @@ func ParameterSpecifications() *Specifications {
 			DefaultValue: Minimising.String(),
 		},
 	)
-	return specs
+	return constraint.WithParameterSpecifications(
+		seeding.WithParameterSpecifications(moves.WithParameterSpecifications(specs)))
 }
 
 func isOptimisationDirection(key string, value interface{}) error {
@@ -0,0 +1,67 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package seeding
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/greedy"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+const (
+	InitialState    = "InitialState"
+	InitialSolution = "InitialSolution"
+)
+
+const (
+	RandomInitialState   = "Random"
+	GreedyInitialState   = "Greedy"
+	SolutionInitialState = "Solution"
+)
+
+// WithParameterSpecifications adds the specifications choosing the model state an explorer starts from to those of
+// the explorer.
+func WithParameterSpecifications(specs *Specifications) *Specifications {
+	specs.Add(
+		Specification{
+			Key:          InitialState,
+			Validator:    isInitialState,
+			DefaultValue: RandomInitialState,
+		},
+	).Add(
+		Specification{
+			Key:          InitialSolution,
+			Validator:    IsString,
+			DefaultValue: "",
+		},
+	)
+	return greedy.WithParameterSpecifications(specs)
+}
+
+func isInitialState(key string, value interface{}) error {
+	valueAsString, typeIsOk := value.(string)
+	if !typeIsOk {
+		return NewInvalidSpecificationError("Parameter [" + key + "] must be a string value")
+	}
+	if valueAsString == RandomInitialState || valueAsString == GreedyInitialState ||
+		valueAsString == SolutionInitialState {
+		return NewValidSpecificationError(key, value)
+	}
+	return NewInvalidSpecificationError("Parameter [" + key + "] must be one of [" + RandomInitialState + ", " +
+		GreedyInitialState + ", " + SolutionInitialState + "], but was supplied [" + valueAsString + "]")
+}
+
+// CheckParameters adds a validation error to params where they ask to start from prior solutions that cannot be read.
+func CheckParameters(params *parameters.Parameters) {
+	if !StartsFromSolutions(params) {
+		return
+	}
+	if _, readError := Solutions(params); readError != nil {
+		params.AddValidationErrorMessage("Parameter [" + InitialSolution + "] invalid: " + readError.Error())
+	}
+}
+
+// StartsFromSolutions reports whether an explorer's parameters ask for it to start from prior solutions.
+func StartsFromSolutions(params *parameters.Parameters) bool {
+	return params.GetString(InitialState) == SolutionInitialState
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package seeding initialises the models of explorers to the state they are configured to start from: a random one,
+// a greedily chosen one, or that of a prior solution.
+package seeding
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/greedy"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	booleanArchive "github.com/LindsayBradford/crem/pkg/archive"
+	"github.com/pkg/errors"
+)
+
+const (
+	encodingDelimiter = ':'
+	archiveEntrySize  = 64
+)
+
+// Score scores the current state of a model, lower scores preferred, letting an explorer start from the best of
+// several prior solutions.
+type Score func() float64
+
+// InitialiseModel initialises model to the state params ask for, returning a note describing the state chosen.
+// Where starting from several prior solutions, the first with the lowest score is chosen (the first, if score is nil).
+func InitialiseModel(model model.Model, params *parameters.Parameters, score Score) (string, error) {
+	switch params.GetString(InitialState) {
+	case GreedyInitialState:
+		return initialiseGreedily(model, params)
+	case SolutionInitialState:
+		return initialiseFromBestSolution(model, params, score)
+	default:
+		return initialiseRandomly(model), nil
+	}
+}
+
+func initialiseRandomly(modelToInitialise model.Model) string {
+	modelToInitialise.Initialise(model.Random)
+	modelToInitialise.Randomize()
+	return "Seeded Model Randomly"
+}
+
+func initialiseGreedily(modelToInitialise model.Model, params *parameters.Parameters) (string, error) {
+	modelToInitialise.Initialise(model.AsIs)
+	activated, seedError := greedy.Seed(modelToInitialise, params)
+	if seedError != nil {
+		return "", errors.Wrap(seedError, "greedily seeding model")
+	}
+	return fmt.Sprintf("Seeded Model With [%d] Greedily Activated Actions", activated), nil
+}
+
+func initialiseFromBestSolution(modelToInitialise model.Model, params *parameters.Parameters, score Score) (string, error) {
+	if score == nil {
+		score = func() float64 { return 0 }
+	}
+
+	var bestSolution set.Solution
+	bestScore := 0.0
+	solutionNumber, applyError := ApplyEach(modelToInitialise, params, func(solution set.Solution) {
+		if solutionScore := score(); bestSolution.Id == "" || solutionScore < bestScore {
+			bestSolution, bestScore = solution, solutionScore
+		}
+	})
+	if applyError != nil {
+		return "", applyError
+	}
+
+	if applyError := Apply(modelToInitialise, bestSolution); applyError != nil {
+		return "", applyError
+	}
+	return fmt.Sprintf("Seeded Model With Solution [%s] of [%d] Prior Solutions", bestSolution.Id, solutionNumber), nil
+}
+
+// ApplyEach initialises model to its As-Is state, then applies each prior solution params name to it in turn,
+// calling visit after each, returning the number of solutions applied.  It stops with an error at the first solution
+// the model cannot take.
+func ApplyEach(modelToSeed model.Model, params *parameters.Parameters, visit func(solution set.Solution)) (int, error) {
+	solutions, readError := Solutions(params)
+	if readError != nil {
+		return 0, errors.Wrap(readError, "reading initial solutions")
+	}
+
+	modelToSeed.Initialise(model.AsIs)
+	for index, solution := range solutions {
+		if applyError := Apply(modelToSeed, solution); applyError != nil {
+			return index, applyError
+		}
+		visit(solution)
+	}
+	return len(solutions), nil
+}
+
+// Solutions returns the prior solutions named by the InitialSolution parameter of params, being either those of a
+// CSV or JSON solution set summary file, or a single solution given as an encoding of its active management actions.
+// Values that cannot be encodings are taken as file paths, and must name an existing file.  Every encoding is
+// decoded, so a malformed one is reported here rather than when applied to a model.
+func Solutions(params *parameters.Parameters) ([]set.Solution, error) {
+	initialSolution := params.GetString(InitialSolution)
+	if initialSolution == "" {
+		return nil, errors.New("no solution file or action encoding supplied")
+	}
+
+	solutions, readError := solutionsOf(initialSolution)
+	if readError != nil {
+		return nil, readError
+	}
+
+	for _, solution := range solutions {
+		if decodeError := decode(solution); decodeError != nil {
+			return nil, decodeError
+		}
+	}
+	return solutions, nil
+}
+
+func solutionsOf(initialSolution string) ([]set.Solution, error) {
+	fileInfo, statError := os.Stat(initialSolution)
+	switch {
+	case statError == nil && fileInfo.IsDir():
+		return nil, errors.New("solution set file [" + initialSolution + "] is a directory")
+	case statError == nil:
+		return solutionsFromFile(initialSolution)
+	case !isEncodingLike(initialSolution):
+		return nil, errors.New("solution set file [" + initialSolution + "] does not exist")
+	default:
+		return []set.Solution{{Id: InitialSolution, Encoding: initialSolution}}, nil
+	}
+}
+
+func solutionsFromFile(solutionSetPath string) ([]set.Solution, error) {
+	solutions, readError := set.ReadFromFile(solutionSetPath)
+	if readError != nil {
+		return nil, readError
+	}
+	if len(solutions) == 0 {
+		return nil, errors.New("solution set file [" + solutionSetPath + "] lists no solutions")
+	}
+	return solutions, nil
+}
+
+// isEncodingLike reports whether value could be an encoding of management actions, being made of hexadecimal
+// entries separated by colons.  Anything else (path separators or file extensions, say) is taken as a file path.
+func isEncodingLike(value string) bool {
+	for _, character := range value {
+		isHexDigit := unicode.Is(unicode.ASCII_Hex_Digit, character)
+		if !isHexDigit && character != encodingDelimiter {
+			return false
+		}
+	}
+	return true
+}
+
+func decode(solution set.Solution) error {
+	entryNumber := len(strings.Split(solution.Encoding, string(encodingDelimiter)))
+	if decodeError := booleanArchive.New(entryNumber * archiveEntrySize).Decode(solution.Encoding); decodeError != nil {
+		return errors.Wrap(decodeError, "decoding actions of solution ["+solution.Id+"]")
+	}
+	return nil
+}
+
+// Apply sets the management actions of model to match those active in solution.
+func Apply(model model.Model, solution set.Solution) error {
+	var modelCompressor archive.ModelCompressor
+	compressedModel := modelCompressor.Compress(model)
+	if decodeError := compressedModel.Decode(solution.Encoding); decodeError != nil {
+		return errors.Wrap(decodeError, "decoding actions of solution ["+solution.Id+"]")
+	}
+	modelCompressor.Decompress(compressedModel, model)
+	return nil
+}
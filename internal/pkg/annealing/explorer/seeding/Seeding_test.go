@@ -0,0 +1,169 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package seeding
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	catchmenttest "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/test"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	sedimentVariable = "SedimentProduction"
+)
+
+func buildTestingModel(g *GomegaWithT) model.Model {
+	modelUnderTest, buildError := catchmenttest.NewTestingModel(nil)
+	g.Expect(buildError).To(BeNil())
+	return modelUnderTest
+}
+
+func buildParameters(g *GomegaWithT, params parameters.Map) *parameters.Parameters {
+	seedingParameters := new(parameters.Parameters).
+		Initialise("Seeding Parameter Validation").
+		Enforcing(WithParameterSpecifications(specification.NewSpecifications()))
+	seedingParameters.AssignOnlyEnforcedUserValues(params)
+	CheckParameters(seedingParameters)
+	return seedingParameters
+}
+
+// encodingActivating returns the encoding of the model's state with every step-th management action active.
+func encodingActivating(testModel model.Model, step int) string {
+	testModel.Initialise(model.AsIs)
+	for index := range testModel.ManagementActions() {
+		testModel.SetManagementAction(index, index%step == 0)
+	}
+	var compressor archive.ModelCompressor
+	return compressor.Compress(testModel).Encoding()
+}
+
+func writeSolutionSet(g *GomegaWithT, directory string, encodings ...string) string {
+	content := "Solution, Actions, Summary\n"
+	for index, encoding := range encodings {
+		content += fmt.Sprintf("Solution (%d/%d), %s, Pareto front member\n", index+1, len(encodings), encoding)
+	}
+	solutionSetPath := filepath.Join(directory, "SolutionSet.csv")
+	g.Expect(os.WriteFile(solutionSetPath, []byte(content), 0666)).To(Succeed())
+	return solutionSetPath
+}
+
+func TestParameters_InvalidInitialState_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	paramsUnderTest := buildParameters(g, parameters.Map{InitialState: "Optimal"})
+
+	// then
+	g.Expect(paramsUnderTest.ValidationErrors()).To(Not(BeNil()))
+	g.Expect(StartsFromSolutions(paramsUnderTest)).To(BeFalse())
+}
+
+func TestCheckParameters_SolutionStateWithoutSolution_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	paramsUnderTest := buildParameters(g, parameters.Map{InitialState: SolutionInitialState})
+
+	// then
+	g.Expect(paramsUnderTest.ValidationErrors()).To(Not(BeNil()))
+}
+
+func TestInitialiseModel_Encoding_MatchesSolution(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestingModel(g)
+	encoding := encodingActivating(buildTestingModel(g), 3)
+	paramsUnderTest := buildParameters(g, parameters.Map{InitialState: SolutionInitialState, InitialSolution: encoding})
+	g.Expect(paramsUnderTest.ValidationErrors()).To(BeNil())
+
+	// when
+	_, initialiseError := InitialiseModel(modelUnderTest, paramsUnderTest, nil)
+
+	// then
+	g.Expect(initialiseError).To(BeNil())
+	var compressor archive.ModelCompressor
+	g.Expect(compressor.Compress(modelUnderTest).Encoding()).To(Equal(encoding))
+}
+
+func TestInitialiseModel_SolutionSet_LowestScoreChosen(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	encodingModel := buildTestingModel(g)
+	sparse, dense := encodingActivating(encodingModel, 5), encodingActivating(encodingModel, 1)
+	solutionSetPath := writeSolutionSet(g, t.TempDir(), sparse, dense)
+
+	modelUnderTest := buildTestingModel(g)
+	paramsUnderTest := buildParameters(g,
+		parameters.Map{InitialState: SolutionInitialState, InitialSolution: solutionSetPath})
+	g.Expect(paramsUnderTest.ValidationErrors()).To(BeNil())
+
+	scoresSeen := 0
+	sediment := func() float64 {
+		scoresSeen++
+		return modelUnderTest.DecisionVariable(sedimentVariable).Value()
+	}
+
+	// when
+	note, initialiseError := InitialiseModel(modelUnderTest, paramsUnderTest, sediment)
+
+	// then
+	g.Expect(initialiseError).To(BeNil())
+	g.Expect(scoresSeen).To(Equal(2))
+	g.Expect(note).To(ContainSubstring("Solution (2/2)"))
+	g.Expect(modelUnderTest.ActiveManagementActions()).To(HaveLen(len(modelUnderTest.ManagementActions())))
+}
+
+func TestApplyEach_WrongSizedEncoding_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestingModel(g)
+	paramsUnderTest := buildParameters(g,
+		parameters.Map{InitialState: SolutionInitialState, InitialSolution: "0:0:0:0:0:0"})
+	g.Expect(paramsUnderTest.ValidationErrors()).To(BeNil())
+
+	// when
+	solutionNumber, applyError := ApplyEach(modelUnderTest, paramsUnderTest, func(set.Solution) {})
+
+	// then
+	g.Expect(applyError).To(Not(BeNil()))
+	g.Expect(solutionNumber).To(BeZero())
+}
+
+func TestCheckParameters_MissingSolutionFile_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	missingPath := filepath.Join(t.TempDir(), "SolutonSet.csv")
+
+	// when
+	paramsUnderTest := buildParameters(g,
+		parameters.Map{InitialState: SolutionInitialState, InitialSolution: missingPath})
+
+	// then
+	g.Expect(paramsUnderTest.ValidationErrors()).To(Not(BeNil()))
+	g.Expect(paramsUnderTest.ValidationErrors().Error()).To(ContainSubstring("does not exist"))
+}
+
+func TestCheckParameters_MalformedEncoding_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	paramsUnderTest := buildParameters(g,
+		parameters.Map{InitialState: SolutionInitialState, InitialSolution: "0:10000000000000000"})
+
+	// then
+	g.Expect(paramsUnderTest.ValidationErrors()).To(Not(BeNil()))
+	g.Expect(paramsUnderTest.ValidationErrors().Error()).To(ContainSubstring("decoding actions"))
+}
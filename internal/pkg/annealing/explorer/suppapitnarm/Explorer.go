@@ -6,13 +6,19 @@ import (
 	"fmt"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/suppapitnarm"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooperation"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/constraint"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/seeding"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/moves"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/rand"
 	"github.com/LindsayBradford/crem/pkg/attributes"
+	"github.com/LindsayBradford/crem/pkg/dominance"
 	errors2 "github.com/LindsayBradford/crem/pkg/errors"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
 	"github.com/LindsayBradford/crem/pkg/name"
@@ -28,6 +34,9 @@ const (
 	IterationsUntilNextReturnToBase = "IterationsUntilNextReturnToBase"
 	ModelArchive                    = "ModelArchive"
 	LastReturnedToBase              = "LastReturnedToBase"
+	FrontHypervolume                = "FrontHypervolume"
+	FrontSpacing                    = "FrontSpacing"
+	FrontSpread                     = "FrontSpread"
 )
 
 type Explorer struct {
@@ -36,6 +45,7 @@ type Explorer struct {
 
 	currentModel   model.Model
 	potentialModel model.Model
+	makingMoves    bool
 
 	loggers.ContainedLogger
 
@@ -48,17 +58,25 @@ type Explorer struct {
 	objectiveVariableName string
 
 	modelArchive         archive.NonDominanceModelArchive
+	cooperation          *cooperation.Cooperation
 	archiveStorageResult archive.StorageResult
+	frontReference       archive.FrontReference
+	frontQuality         archive.FrontQuality
+	penalty              constraint.Penalty
 
 	currentIteration   uint64
 	lastReturnedToBase uint64
 
+	seed    int64
+	runSeed int64
+
 	iterationsUntilReturnToBase   uint64
 	returnToBaseStep              float64
 	returnToBaseIsolationFraction float64
 
 	changeIsDesirable    bool
 	changeAccepted       bool
+	changeProgressed     bool
 	objectiveValueChange float64
 
 	observer.SynchronousAnnealingEventNotifier
@@ -85,15 +103,19 @@ func New() *Explorer {
 func (ke *Explorer) Initialise() {
 	ke.LogHandler().Debug(ke.scenarioId + ": Initialising Solution Explorer")
 	ke.modelArchive.Initialise()
-	ke.coolant.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	ke.potentialModel = moves.Wrap(ke.potentialModel, moves.StrategyFrom(&ke.parameters.Parameters))
+	_, ke.makingMoves = ke.potentialModel.(*moves.Model)
+	ke.seedRandomNumbers()
 
-	ke.currentModel.Initialise(model.Random)
-	ke.currentModel.Randomize()
+	ke.initialiseCurrentModel()
+	ke.penalty.Initialise(meanMagnitudeOf(ke.modelArchive.Compress(ke.currentModel).Variables))
 
 	ke.potentialModel.Initialise(model.Random)
 
 	ke.deriveIterationsUntilReturnToBase()
 	ke.currentIteration = 1
+	ke.deriveFrontReference()
+	ke.frontQuality = archive.FrontQuality{}
 
 	ke.baseAttributes = new(attributes.Attributes).
 		Add(explorer.Temperature, ke.coolant.Temperature()).
@@ -101,20 +123,70 @@ func (ke *Explorer) Initialise() {
 
 	ke.desirableAcceptanceEvent = observer.NewEvent(observer.Explorer).
 		WithNote("Accepting Desirable Change").
-		WithAttribute(explorer.AcceptanceProbability, ke.coolant.AcceptanceProbability())
+		WithAttribute(explorer.AcceptanceProbability, ke.coolant.AcceptanceProbability()).
+		WithAttribute(explorer.ChangeIsDesirable, true).
+		WithAttribute(explorer.ChangeAccepted, true)
 
 	ke.undesirableAcceptanceEvent = observer.NewEvent(observer.Explorer).
 		WithNote("Accepting Undesirable Change").
-		WithAttribute(explorer.AcceptanceProbability, ke.coolant.AcceptanceProbability())
+		WithAttribute(explorer.AcceptanceProbability, ke.coolant.AcceptanceProbability()).
+		WithAttribute(explorer.ChangeIsDesirable, false).
+		WithAttribute(explorer.ChangeAccepted, true)
 
 	ke.undesirableReversionEvent = observer.NewEvent(observer.Explorer).
 		WithNote("Reverting Undesirable Change").
-		WithAttribute(explorer.AcceptanceProbability, ke.coolant.AcceptanceProbability())
+		WithAttribute(explorer.AcceptanceProbability, ke.coolant.AcceptanceProbability()).
+		WithAttribute(explorer.ChangeIsDesirable, false).
+		WithAttribute(explorer.ChangeAccepted, false)
 
 	ke.noteEvent = observer.NewEvent(observer.Explorer).
 		WithAttribute(observer.Note.String(), "")
 }
 
+// SeedRandomNumbers has the explorer drive all random number generation of its next annealing run from seed.
+func (ke *Explorer) SeedRandomNumbers(seed int64) {
+	ke.seed = seed
+}
+
+// seedRandomNumbers drives the coolant, the archive, and both the current and potential models (with any moves
+// made of them) from a single run seed, being that supplied to SeedRandomNumbers, or one drawn from the system time
+// where none was.
+func (ke *Explorer) seedRandomNumbers() {
+	ke.runSeed = rand.SeedOrTime(ke.seed)
+	seeds := rand.NewSeeded(ke.runSeed)
+	rand.Seed(ke.coolant, seeds.Int63())
+	ke.modelArchive.SeedRandomNumbers(seeds.Int63())
+	rand.Seed(ke.currentModel, seeds.Int63())
+	rand.Seed(ke.potentialModel, seeds.Int63())
+}
+
+// initialiseCurrentModel starts the current model from the state configured.  Where starting from prior solutions,
+// each is offered to the archive first, so exploration refines the prior solution set rather than restarting.
+func (ke *Explorer) initialiseCurrentModel() {
+	if seeding.StartsFromSolutions(&ke.parameters.Parameters) {
+		ke.initialiseFromSolutions()
+		return
+	}
+	seedingNote, seedingError := seeding.InitialiseModel(ke.currentModel, &ke.parameters.Parameters, nil)
+	if seedingError != nil {
+		panic(errors.Wrap(seedingError, "initialising current model"))
+	}
+	ke.LogHandler().Debug(ke.scenarioId + ": " + seedingNote)
+}
+
+func (ke *Explorer) initialiseFromSolutions() {
+	solutionNumber, seedingError := seeding.ApplyEach(ke.currentModel, &ke.parameters.Parameters, func(set.Solution) {
+		ke.modelArchive.AttemptToArchive(ke.currentModel)
+	})
+	if seedingError != nil {
+		panic(errors.Wrap(seedingError, "initialising current model from prior solutions"))
+	}
+	ke.modelArchive.Decompress(ke.modelArchive.SelectRandomModel(), ke.currentModel)
+
+	ke.LogHandler().Debug(fmt.Sprintf("%s: Seeded archive with [%d] model states from [%d] prior solutions",
+		ke.scenarioId, ke.modelArchive.Len(), solutionNumber))
+}
+
 func (ke *Explorer) WithName(name string) *Explorer {
 	ke.SetName(name)
 	return ke
@@ -149,6 +221,10 @@ func (ke *Explorer) SetParameters(params parameters.Map) error {
 	ke.parameters.AssignOnlyEnforcedUserValues(params)
 	ke.coolant.SetParameters(params)
 
+	seeding.CheckParameters(&ke.parameters.Parameters)
+	checkFrontReferenceParameters(&ke.parameters.Parameters)
+	ke.penalty = constraint.PenaltyFrom(&ke.parameters.Parameters)
+
 	ke.returnToBaseStep = float64(ke.parameters.GetInt64(InitialReturnToBaseStep))
 	ke.returnToBaseIsolationFraction = 1
 
@@ -194,26 +270,89 @@ func (ke *Explorer) TryRandomChange() {
 
 	variableDifferences := compressedChangedModelState.VariableDifferences(compressedInitialModelState)
 
-	event := observer.NewEvent(observer.Explorer).
-		WithNote("Attempting to Archive Changed Model").
-		WithAttribute("Model Encoding", compressedChangedModelState.Encoding())
+	if constraint.IsFeasible(ke.potentialModel) {
+		event := observer.NewEvent(observer.Explorer).
+			WithNote("Attempting to Archive Changed Model").
+			WithAttribute("Model Encoding", compressedChangedModelState.Encoding())
 
-	ke.NotifyObserversOfEvent(*event)
+		ke.NotifyObserversOfEvent(*event)
 
-	ke.archiveStorageResult = ke.modelArchive.AttemptToArchiveState(compressedChangedModelState)
+		ke.archiveStorageResult = ke.modelArchive.AttemptToArchiveState(compressedChangedModelState)
+		ke.AcceptOrRevertChange(variableDifferences)
+	} else {
+		ke.archiveStorageResult = archive.RejectedAsInfeasible
+		ke.AcceptOrRevertInfeasibleChange(variableDifferences)
+	}
 
-	ke.AcceptOrRevertChange(variableDifferences)
+	ke.checkProgress()
+	ke.penalty.Observe(constraint.IsFeasible(ke.currentModel))
 	ke.ReturnToBaseIfRequired(compressedChangedModelState)
 
 	ke.checkNonDominanceIfRequired()
+	ke.exchangeArchiveIfRequired()
+	ke.assessFrontQualityIfRequired()
 
 	ke.currentIteration++
 }
 
+// Cooperate has the explorer periodically exchange non-dominated model states with the explorers of other runs.
+func (ke *Explorer) Cooperate(cooperation *cooperation.Cooperation, replica int) {
+	ke.cooperation = cooperation
+}
+
+func (ke *Explorer) exchangeArchiveIfRequired() {
+	if ke.cooperation == nil || !ke.cooperation.ExchangeDue(ke.currentIteration) {
+		return
+	}
+
+	sharedStates := ke.cooperation.Archive().Exchange(ke.modelArchive.Archive())
+	for _, sharedState := range sharedStates {
+		ke.modelArchive.AttemptToArchiveState(sharedState)
+	}
+	ke.note(fmt.Sprintf("Exchanged archive with other runs, archive now holds [%d] model states", ke.modelArchive.Len()))
+}
+
+// deriveFrontReference fixes the objectives and bounds front quality is assessed against for the run, once the
+// current model offers its decision variables.  Front quality is left unassessed where no objectives are configured.
+func (ke *Explorer) deriveFrontReference() {
+	var unknownObjectives []string
+	ke.frontReference, unknownObjectives = frontReferenceFrom(&ke.parameters.Parameters, ke.currentModel)
+	for _, objective := range unknownObjectives {
+		ke.LogHandler().Warn(ke.scenarioId + ": Front quality objective [" + objective +
+			"] not recognised by model, so not assessed")
+	}
+}
+
+func (ke *Explorer) assessFrontQualityIfRequired() {
+	interval := uint64(ke.parameters.GetInt64(FrontQualityInterval))
+	if ke.frontReference.IsDefined() && interval > 0 && ke.currentIteration%interval == 0 {
+		ke.frontQuality = ke.modelArchive.FrontQuality(ke.frontReference)
+	}
+}
+
+// checkProgress notes whether the change just tried altered the archive of non-dominated solutions.
+func (ke *Explorer) checkProgress() {
+	switch ke.archiveStorageResult {
+	case archive.StoredWithNoDominanceDetected, archive.StoredReplacingDominatedEntries,
+		archive.StoredForcingDominatingStateRemoval:
+		ke.changeProgressed = true
+	default:
+		ke.changeProgressed = false
+	}
+}
+
+func (ke *Explorer) ChangeProgressed() bool {
+	return ke.changeProgressed
+}
+
 func (ke *Explorer) generatePotentialModel() {
 	ke.note("Creating and Randomizing potential new model off old.")
 	ke.potentialModel.SynchroniseTo(ke.currentModel)
-	ke.potentialModel.Randomize()
+	if ke.makingMoves {
+		ke.potentialModel.TryRandomChange()
+	} else {
+		ke.potentialModel.Randomize()
+	}
 	ke.note("Finished creating and Randomizing potential new model.")
 }
 
@@ -237,10 +376,49 @@ func (ke *Explorer) AcceptOrRevertChange(variableDifferences []float64) {
 	}
 }
 
+// AcceptOrRevertInfeasibleChange handles a change to a model state breaking decision variable bounds, which is never
+// archived.  The change is reverted, unless penalising bound violations, where any increase in penalty counts as the
+// change in an extra objective when deciding whether to accept it.
+func (ke *Explorer) AcceptOrRevertInfeasibleChange(variableDifferences []float64) {
+	ke.changeIsDesirable = false
+	if !ke.penalty.Enabled() {
+		ke.notifyInfeasibleReversion()
+		ke.RevertLastChange()
+		return
+	}
+
+	penaltyIncrease := ke.penalty.Of(constraint.Violation(ke.potentialModel)) -
+		ke.penalty.Of(constraint.Violation(ke.currentModel))
+	penalisedDifferences := append(variableDifferences, math.Max(0, penaltyIncrease))
+
+	if ke.coolant.DecideIfAcceptable(penalisedDifferences) {
+		ke.notifyUndesirableAcceptance()
+		ke.AcceptInfeasibleChange()
+	} else {
+		ke.notifyUndesirableReversion()
+		ke.RevertLastChange()
+	}
+}
+
+func (ke *Explorer) notifyInfeasibleReversion() {
+	event := observer.NewEvent(observer.Explorer).
+		WithNote("Reverting Infeasible Change").
+		WithAttribute("ArchiveStorageResult", ke.archiveStorageResult.String())
+
+	ke.NotifyObserversOfEvent(*event)
+}
+
+func (ke *Explorer) AcceptInfeasibleChange() {
+	ke.changeAccepted = true
+	ke.currentModel.SynchroniseTo(ke.potentialModel)
+	ke.concludeMove()
+}
+
 func (ke *Explorer) AcceptDesirableChange() {
 	ke.setAcceptanceProbability(explorer.Guaranteed)
 	ke.changeAccepted = true
 	ke.currentModel.SynchroniseTo(ke.potentialModel)
+	ke.concludeMove()
 }
 
 func (ke *Explorer) notifyDesirableAcceptance() {
@@ -280,6 +458,7 @@ func (ke *Explorer) AcceptUndesirableChange() {
 	ke.archiveStorageResult = ke.modelArchive.ForceIntoArchive(ke.potentialModel)
 	ke.changeAccepted = true
 	ke.currentModel.SynchroniseTo(ke.potentialModel)
+	ke.concludeMove()
 
 	event := observer.NewEvent(observer.Explorer).
 		WithNote("Forcing Model into Archive").
@@ -291,6 +470,19 @@ func (ke *Explorer) AcceptUndesirableChange() {
 func (ke *Explorer) RevertLastChange() {
 	// we just ignore potential model state.
 	ke.changeAccepted = false
+	ke.concludeMove()
+}
+
+// concludeMove informs the move strategy of the potential model (if any) of whether its move was accepted.
+func (ke *Explorer) concludeMove() {
+	if !ke.makingMoves {
+		return
+	}
+	if ke.changeAccepted {
+		ke.potentialModel.AcceptChange()
+	} else {
+		ke.potentialModel.RevertChange()
+	}
 }
 
 func (ke *Explorer) ReturnToBaseIfRequired(state *archive.CompressedModelState) {
@@ -410,7 +602,8 @@ func (ke *Explorer) checkNonDominanceIfRequired() {
 
 func (ke *Explorer) DeepClone() explorer.Explorer {
 	clone := *ke
-	clone.coolant.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	clone.coolant = ke.coolant.DeepClone()
+	clone.seed = 0
 	clone.currentModel = ke.currentModel.DeepClone()
 	clone.potentialModel = ke.currentModel.DeepClone()
 	return &clone
@@ -447,19 +640,32 @@ func (ke *Explorer) EventAttributes(eventType observer.EventType) attributes.Att
 			Replace(explorer.Temperature, ke.coolant.Temperature()).
 			Replace(ArchiveSize, ke.modelArchive.Len())
 	case observer.FinishedAnnealing:
-		return ke.baseAttributes.
+		ke.frontQuality = ke.modelArchive.FrontQuality(ke.frontReference)
+		return ke.withFrontQuality(ke.baseAttributes.
 			Replace(explorer.Temperature, ke.coolant.Temperature()).
 			Replace(ArchiveSize, ke.modelArchive.Len()).
-			Add(ModelArchive, ke.modelArchive)
+			Add(explorer.RandomNumberSeed, ke.runSeed).
+			Add(ModelArchive, ke.modelArchive))
 	case observer.FinishedIteration:
-		return ke.baseAttributes.
+		return ke.withFrontQuality(ke.baseAttributes.
 			Replace(explorer.Temperature, ke.coolant.Temperature()).
 			Replace(ArchiveSize, ke.modelArchive.Len()).
-			Add(LastReturnedToBase, ke.lastReturnedToBase)
+			Add(LastReturnedToBase, ke.lastReturnedToBase))
 	}
 	return nil
 }
 
+// withFrontQuality adds the front quality last assessed to eventAttributes, where front quality is being assessed.
+func (ke *Explorer) withFrontQuality(eventAttributes attributes.Attributes) attributes.Attributes {
+	if !ke.frontReference.IsDefined() {
+		return eventAttributes
+	}
+	return eventAttributes.
+		Add(FrontHypervolume, ke.frontQuality.Hypervolume).
+		Add(FrontSpacing, ke.frontQuality.Spacing).
+		Add(FrontSpread, ke.frontQuality.Spread)
+}
+
 func (ke *Explorer) CoolDown() {
 	ke.coolant.CoolDown()
 	ke.notifyCoolDown()
@@ -472,3 +678,16 @@ func (ke *Explorer) notifyCoolDown() {
 
 	ke.NotifyObserversOfEvent(*event)
 }
+
+// meanMagnitudeOf returns the mean absolute value of the decision variable values given, against which penalties for
+// bound violations are scaled.
+func meanMagnitudeOf(values dominance.Float64Vector) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += math.Abs(value)
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package suppapitnarm
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+)
+
+// checkFrontReferenceParameters notes, as validation errors of params, any FrontQualityIdealPoint or
+// FrontQualityNadirPoint parameter not giving a decimal value for each objective named by FrontQualityObjectives.
+func checkFrontReferenceParameters(params *parameters.Parameters) {
+	objectiveNumber := len(splitList(params.GetString(FrontQualityObjectives)))
+	if objectiveNumber == 0 {
+		return
+	}
+	for _, key := range []string{FrontQualityIdealPoint, FrontQualityNadirPoint} {
+		if _, decimalsError := decimalsFrom(params.GetString(key), objectiveNumber); decimalsError != "" {
+			params.AddValidationErrorMessage("Parameter [" + key + "] " + decimalsError)
+		}
+	}
+}
+
+// frontReferenceFrom returns the reference the front quality of model is assessed against, as named by the
+// FrontQualityObjectives, FrontQualityIdealPoint and FrontQualityNadirPoint parameters, along with any objectives
+// named that model does not offer as decision variables, and so are left out.  Ideal and nadir values are given in
+// each objective's own sense, and are oriented here for minimisation, as archived.
+func frontReferenceFrom(params *parameters.Parameters, model model.Model) (archive.FrontReference, []string) {
+	objectiveNames := splitList(params.GetString(FrontQualityObjectives))
+	idealValues, idealError := decimalsFrom(params.GetString(FrontQualityIdealPoint), len(objectiveNames))
+	nadirValues, nadirError := decimalsFrom(params.GetString(FrontQualityNadirPoint), len(objectiveNames))
+	if len(objectiveNames) == 0 || idealError != "" || nadirError != "" {
+		return archive.FrontReference{}, nil
+	}
+
+	variableKeys := model.NameMappedVariables().SortedKeys()
+	reference := archive.FrontReference{}
+	unknownObjectives := make([]string, 0)
+	for index, objectiveName := range objectiveNames {
+		objective := indexOf(objectiveName, variableKeys)
+		if objective < 0 {
+			unknownObjectives = append(unknownObjectives, objectiveName)
+			continue
+		}
+
+		ideal, nadir := idealValues[index], nadirValues[index]
+		if variable.SenseOf(model.DecisionVariable(objectiveName)) == variable.Maximised {
+			ideal, nadir = -ideal, -nadir
+		}
+		reference.Objectives = append(reference.Objectives, objective)
+		reference.Ideal = append(reference.Ideal, math.Min(ideal, nadir))
+		reference.Nadir = append(reference.Nadir, math.Max(ideal, nadir))
+	}
+	return reference, unknownObjectives
+}
+
+func decimalsFrom(list string, expectedNumber int) ([]float64, string) {
+	entries := splitList(list)
+	if len(entries) != expectedNumber {
+		return nil, "must list a decimal value for each of the [" + strconv.Itoa(expectedNumber) +
+			"] objectives of parameter [" + FrontQualityObjectives + "]"
+	}
+
+	values := make([]float64, len(entries))
+	for index, entry := range entries {
+		value, parseError := strconv.ParseFloat(entry, 64)
+		if parseError != nil {
+			return nil, "has value [" + entry + "] that is not a decimal"
+		}
+		values[index] = value
+	}
+	return values, ""
+}
+
+func splitList(list string) []string {
+	entries := make([]string, 0)
+	for _, entry := range strings.Split(list, nameSeparator) {
+		if trimmedEntry := strings.TrimSpace(entry); trimmedEntry != "" {
+			entries = append(entries, trimmedEntry)
+		}
+	}
+	return entries
+}
+
+func indexOf(key string, keys []string) int {
+	for index := range keys {
+		if keys[index] == key {
+			return index
+		}
+	}
+	return -1
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package suppapitnarm
+
+import (
+	"testing"
+
+	catchmentTesting "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/test"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/implementationcost"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/sedimentproduction"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	. "github.com/onsi/gomega"
+)
+
+func TestFrontReferenceFrom_ObjectivesNamedAndBounded(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	testingModel, modelError := catchmentTesting.NewTestingModel(nil)
+	g.Expect(modelError).To(BeNil())
+
+	explorerParameters := new(Parameters).Initialise()
+	explorerParameters.AssignOnlyEnforcedUserValues(parameters.Map{
+		FrontQualityObjectives: sedimentproduction.VariableName + ", " + implementationcost.VariableName + ", Unknown",
+		FrontQualityIdealPoint: "0, 0, 0",
+		FrontQualityNadirPoint: "2000, 50000, 1",
+	})
+	checkFrontReferenceParameters(&explorerParameters.Parameters)
+
+	// when
+	reference, unknownObjectives := frontReferenceFrom(&explorerParameters.Parameters, testingModel)
+
+	// then
+	variableKeys := testingModel.NameMappedVariables().SortedKeys()
+	g.Expect(explorerParameters.ValidationErrors()).To(BeNil())
+	g.Expect(reference.IsDefined()).To(BeTrue())
+	g.Expect(reference.Objectives).To(Equal([]int{
+		indexOf(sedimentproduction.VariableName, variableKeys), indexOf(implementationcost.VariableName, variableKeys),
+	}))
+	g.Expect(reference.Ideal).To(Equal(dominance.Float64Vector{0, 0}))
+	g.Expect(reference.Nadir).To(Equal(dominance.Float64Vector{2000, 50000}))
+	g.Expect(unknownObjectives).To(Equal([]string{"Unknown"}))
+}
+
+func TestCheckFrontReferenceParameters_UnboundedObjectives_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerParameters := new(Parameters).Initialise()
+	explorerParameters.AssignOnlyEnforcedUserValues(parameters.Map{
+		FrontQualityObjectives: sedimentproduction.VariableName + "," + implementationcost.VariableName,
+		FrontQualityIdealPoint: "0",
+		FrontQualityNadirPoint: "2000, lots",
+	})
+
+	// when
+	checkFrontReferenceParameters(&explorerParameters.Parameters)
+
+	// then
+	validationErrors := explorerParameters.ValidationErrors()
+	g.Expect(validationErrors).To(Not(BeNil()))
+	g.Expect(validationErrors.Error()).To(ContainSubstring(FrontQualityIdealPoint))
+	g.Expect(validationErrors.Error()).To(ContainSubstring("[lots]"))
+}
+
+func TestFrontReferenceFrom_NoObjectives_Undefined(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	explorerParameters := new(Parameters).Initialise()
+
+	// when
+	reference, unknownObjectives := frontReferenceFrom(&explorerParameters.Parameters, nil)
+
+	// then
+	g.Expect(reference.IsDefined()).To(BeFalse())
+	g.Expect(unknownObjectives).To(BeEmpty())
+}
@@ -3,6 +3,9 @@
 package suppapitnarm
 
 import (
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/constraint"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/seeding"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/moves"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
 	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
 )
@@ -24,6 +27,10 @@ const (
 	MinimumReturnToBaseRate       = "MinimumReturnToBaseRate"
 	ReturnToBaseIsolationFraction = "ReturnToBaseIsolationFraction"
 	CheckNonDominance             = "CheckNonDominance"
+	FrontQualityInterval          = "FrontQualityInterval"
+	FrontQualityObjectives        = "FrontQualityObjectives"
+	FrontQualityIdealPoint        = "FrontQualityIdealPoint"
+	FrontQualityNadirPoint        = "FrontQualityNadirPoint"
 )
 
 type optimisationDirection int
@@ -66,6 +73,31 @@ func ParameterSpecifications() *Specifications {
 			Validator:    IsBoolean,
 			DefaultValue: false, // following initial CRP hard-coded default
 		},
+	).Add(
+		Specification{
+			Key:          FrontQualityInterval,
+			Validator:    IsNonNegativeInteger,
+			DefaultValue: int64(1_000), // 0 reports front quality only once annealing has finished
+		},
+	).Add(
+		Specification{
+			Key:          FrontQualityObjectives,
+			Validator:    IsString,
+			DefaultValue: "", // no objectives leaves front quality unassessed
+		},
+	).Add(
+		Specification{
+			Key:          FrontQualityIdealPoint,
+			Validator:    IsString,
+			DefaultValue: "",
+		},
+	).Add(
+		Specification{
+			Key:          FrontQualityNadirPoint,
+			Validator:    IsString,
+			DefaultValue: "",
+		},
 	)
-	return specs
+	return constraint.WithParameterSpecifications(
+		seeding.WithParameterSpecifications(moves.WithParameterSpecifications(specs)))
 }
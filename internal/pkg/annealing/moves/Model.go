@@ -0,0 +1,150 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package moves
+
+import (
+	"fmt"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+)
+
+var _ model.Model = new(Model)
+
+// Model wraps a base model, replacing its random changes with moves proposed by a Strategy.  A move may toggle a
+// number of management actions, so is applied to the base model outright, with the model reporting decision variable
+// changes against the values held before the move, and undoing the move on reversion.
+type Model struct {
+	model.Model
+
+	strategy Strategy
+
+	move         []int
+	moving       bool
+	valuesBefore map[string]float64
+}
+
+// Wrap returns base wrapped to make the moves strategy proposes.  Any existing wrapping of base is replaced. A nil
+// strategy leaves the (unwrapped) base model to make its own random changes.
+func Wrap(base model.Model, strategy Strategy) model.Model {
+	if wrappedBase, isWrapped := base.(*Model); isWrapped {
+		base = wrappedBase.Model
+	}
+	if strategy == nil {
+		return base
+	}
+	return &Model{Model: base, strategy: strategy}
+}
+
+func (m *Model) Strategy() Strategy {
+	return m.strategy
+}
+
+func (m *Model) TryRandomChange() {
+	if len(m.Model.ManagementActions()) == 0 {
+		m.Model.TryRandomChange()
+		return
+	}
+
+	m.valuesBefore = m.decisionVariableValues()
+	m.move = m.strategy.Propose(m.Model)
+	m.toggle(m.move...)
+	m.moving = true
+}
+
+func (m *Model) decisionVariableValues() map[string]float64 {
+	values := make(map[string]float64)
+	for name, decisionVariable := range *m.Model.NameMappedVariables() {
+		values[name] = decisionVariable.Value()
+	}
+	return values
+}
+
+func (m *Model) toggle(indexes ...int) {
+	actions := m.Model.ManagementActions()
+	for _, index := range indexes {
+		m.Model.SetManagementAction(index, !actions[index].IsActive())
+	}
+}
+
+func (m *Model) undoMove() {
+	for index := len(m.move) - 1; index >= 0; index-- {
+		m.toggle(m.move[index])
+	}
+}
+
+func (m *Model) DecisionVariableChange(decisionVariableName string) float64 {
+	if !m.moving {
+		return m.Model.DecisionVariableChange(decisionVariableName)
+	}
+	return m.Model.DecisionVariable(decisionVariableName).Value() - m.valuesBefore[decisionVariableName]
+}
+
+// ChangeIsValid checks the values of decision variables, as changed by any move made, against their bounds.
+func (m *Model) ChangeIsValid() (bool, *compositeErrors.CompositeError) {
+	if !m.moving {
+		return m.Model.ChangeIsValid()
+	}
+
+	validationErrors := compositeErrors.New("Validation Errors")
+	for name, decisionVariable := range *m.Model.NameMappedVariables() {
+		boundedVariable, isBounded := decisionVariable.(variable.Bounded)
+		if isBounded && !boundedVariable.WithinBounds(decisionVariable.Value()) {
+			validationErrors.AddMessage(
+				fmt.Sprintf("%s %s", name, boundedVariable.BoundErrorAsText(decisionVariable.Value())))
+		}
+	}
+
+	if validationErrors.Size() > 0 {
+		return false, validationErrors
+	}
+	return true, nil
+}
+
+func (m *Model) AcceptChange() {
+	if !m.moving {
+		m.Model.AcceptChange()
+		return
+	}
+	m.moving = false
+	m.strategy.Reward(true)
+}
+
+func (m *Model) RevertChange() {
+	if !m.moving {
+		m.Model.RevertChange()
+		return
+	}
+	m.undoMove()
+	m.move = nil
+	m.moving = false
+	m.strategy.Reward(false)
+}
+
+func (m *Model) DoRandomChange() {
+	m.TryRandomChange()
+	m.AcceptChange()
+}
+
+// UndoChange undoes the last move accepted.
+func (m *Model) UndoChange() {
+	if len(m.move) == 0 {
+		m.Model.UndoChange()
+		return
+	}
+	m.undoMove()
+	m.move = nil
+}
+
+// SeedRandomNumbers drives the random choices of both the base model and the strategy from seed.
+func (m *Model) SeedRandomNumbers(seed int64) {
+	seeds := rand.NewSeeded(seed)
+	rand.Seed(m.Model, seeds.Int63())
+	rand.Seed(m.strategy, seeds.Int63())
+}
+
+func (m *Model) DeepClone() model.Model {
+	return &Model{Model: m.Model.DeepClone(), strategy: m.strategy.DeepClone()}
+}
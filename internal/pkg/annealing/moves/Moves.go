@@ -0,0 +1,133 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package moves offers strategies for proposing the management action changes (moves) explorers try when
+// searching the neighbourhood of a model's current state, beyond a model's own single uniformly random toggle.
+package moves
+
+import (
+	"strings"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+const (
+	MoveStrategy        = "MoveStrategy"
+	MoveFlipNumber      = "MoveFlipNumber"
+	MoveSampleSize      = "MoveSampleSize"
+	MoveCostVariable    = "MoveCostVariable"
+	MoveBenefitVariable = "MoveBenefitVariable"
+)
+
+const (
+	RandomStrategy        = "Random"
+	SwapStrategy          = "Swap"
+	MultiFlipStrategy     = "MultiFlip"
+	CostEffectiveStrategy = "CostEffective"
+	AdaptiveStrategy      = "Adaptive"
+)
+
+var strategyNames = []string{
+	RandomStrategy, SwapStrategy, MultiFlipStrategy, CostEffectiveStrategy, AdaptiveStrategy,
+}
+
+// Strategy proposes moves for a model, learning from whether the moves it proposed were accepted.
+type Strategy interface {
+	// Propose returns the indexes of the model's management actions to toggle as a single move.
+	Propose(model model.Model) []int
+
+	// Reward informs the strategy of whether the last move it proposed was accepted.
+	Reward(accepted bool)
+
+	DeepClone() Strategy
+}
+
+// WithParameterSpecifications adds the specifications of move strategy parameters to those of an explorer.
+func WithParameterSpecifications(specs *Specifications) *Specifications {
+	specs.Add(
+		Specification{
+			Key:          MoveStrategy,
+			Validator:    isMoveStrategy,
+			DefaultValue: RandomStrategy,
+		},
+	).Add(
+		Specification{
+			Key:          MoveFlipNumber,
+			Validator:    isPositiveInteger,
+			DefaultValue: int64(2),
+		},
+	).Add(
+		Specification{
+			Key:          MoveSampleSize,
+			Validator:    isPositiveInteger,
+			DefaultValue: int64(8),
+		},
+	).Add(
+		Specification{
+			Key:          MoveCostVariable,
+			Validator:    IsString,
+			DefaultValue: "ImplementationCost",
+		},
+	).Add(
+		Specification{
+			Key:          MoveBenefitVariable,
+			Validator:    IsString,
+			DefaultValue: "SedimentProduction",
+		},
+	)
+	return specs
+}
+
+func isMoveStrategy(key string, value interface{}) error {
+	valueAsString, typeIsOk := value.(string)
+	if !typeIsOk {
+		return NewInvalidSpecificationError("Parameter [" + key + "] must be a string value")
+	}
+	for _, name := range strategyNames {
+		if valueAsString == name {
+			return NewValidSpecificationError(key, value)
+		}
+	}
+	return NewInvalidSpecificationError("Parameter [" + key + "] must be one of [" +
+		strings.Join(strategyNames, ", ") + "], but was supplied [" + valueAsString + "]")
+}
+
+func isPositiveInteger(key string, value interface{}) error {
+	return IsIntegerWithInclusiveBounds(key, value, 1, 1_000)
+}
+
+// StrategyFrom builds the move strategy named in an explorer's parameters. The default Random strategy leaves
+// moves to the model, so returns nil.
+func StrategyFrom(params *parameters.Parameters) Strategy {
+	settings := settingsFrom(params)
+
+	switch params.GetString(MoveStrategy) {
+	case SwapStrategy:
+		return newSwap(settings)
+	case MultiFlipStrategy:
+		return newMultiFlip(settings)
+	case CostEffectiveStrategy:
+		return newCostEffective(settings)
+	case AdaptiveStrategy:
+		return newAdaptive(settings)
+	default:
+		return nil
+	}
+}
+
+type settings struct {
+	flipNumber      int
+	sampleSize      int
+	costVariable    string
+	benefitVariable string
+}
+
+func settingsFrom(params *parameters.Parameters) settings {
+	return settings{
+		flipNumber:      int(params.GetInt64(MoveFlipNumber)),
+		sampleSize:      int(params.GetInt64(MoveSampleSize)),
+		costVariable:    params.GetString(MoveCostVariable),
+		benefitVariable: params.GetString(MoveBenefitVariable),
+	}
+}
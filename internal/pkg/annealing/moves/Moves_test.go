@@ -0,0 +1,225 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package moves
+
+import (
+	"math"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/modumb"
+	modumbParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/modumb/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+	. "github.com/onsi/gomega"
+)
+
+// testActionNumber covers all actions of the test model, so sampling strategies never fall back to single toggles.
+const testActionNumber = 30
+
+func buildParameters(userValues parameters.Map) *parameters.Parameters {
+	params := new(parameters.Parameters).
+		Initialise("Moves Test Parameters").
+		Enforcing(WithParameterSpecifications(specification.NewSpecifications()))
+	params.AssignOnlyEnforcedUserValues(userValues)
+	return params
+}
+
+func buildModel(strategyName string) *Model {
+	params := buildParameters(parameters.Map{
+		MoveStrategy:        strategyName,
+		MoveFlipNumber:      int64(3),
+		MoveSampleSize:      int64(testActionNumber),
+		MoveCostVariable:    modumb.Objectives[0],
+		MoveBenefitVariable: modumb.Objectives[1],
+	})
+
+	base := modumb.NewModel().WithParameters(parameters.Map{modumbParameters.NumberOfPlanningUnits: int64(10)})
+	base.Initialise(model.AsIs)
+
+	return Wrap(base, StrategyFrom(params)).(*Model)
+}
+
+func valuesOf(modelUnderTest model.Model) map[string]float64 {
+	values := make(map[string]float64)
+	for name, decisionVariable := range *modelUnderTest.NameMappedVariables() {
+		values[name] = decisionVariable.Value()
+	}
+	return values
+}
+
+func TestStrategyFrom_Random_LeavesModelUnwrapped(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	base := modumb.NewModel()
+	params := buildParameters(parameters.Map{})
+
+	// when
+	strategy := StrategyFrom(params)
+
+	// then
+	g.Expect(params.ValidationErrors()).To(BeNil())
+	g.Expect(strategy).To(BeNil())
+	g.Expect(Wrap(base, strategy)).To(BeIdenticalTo(base))
+	g.Expect(Wrap(Wrap(base, newMultiFlip(settings{})), nil)).To(BeIdenticalTo(base))
+}
+
+func TestParameters_UnknownStrategy_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	params := buildParameters(parameters.Map{MoveStrategy: "Teleport"})
+	t.Log(params.ValidationErrors())
+
+	// then
+	g.Expect(params.ValidationErrors()).To(Not(BeNil()))
+}
+
+func TestModel_MultiFlip_ChangesReportedAndReverted(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildModel(MultiFlipStrategy)
+	valuesBefore := valuesOf(modelUnderTest)
+
+	// when
+	modelUnderTest.TryRandomChange()
+
+	// then
+	g.Expect(modelUnderTest.ActiveManagementActions()).To(HaveLen(3))
+	for name, valueBefore := range valuesBefore {
+		g.Expect(modelUnderTest.DecisionVariableChange(name)).To(
+			BeNumerically("~", modelUnderTest.DecisionVariable(name).Value()-valueBefore, 1e-9))
+	}
+	isValid, _ := modelUnderTest.ChangeIsValid()
+	g.Expect(isValid).To(BeTrue())
+
+	// when
+	modelUnderTest.RevertChange()
+
+	// then
+	g.Expect(modelUnderTest.ActiveManagementActions()).To(BeEmpty())
+	g.Expect(valuesOf(modelUnderTest)).To(Equal(valuesBefore))
+}
+
+func TestModel_Swap_KeepsActiveActionNumber(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildModel(SwapStrategy)
+	for index := 0; index < len(modelUnderTest.ManagementActions()); index += 2 {
+		modelUnderTest.SetManagementAction(index, true)
+	}
+	activeNumber := len(modelUnderTest.ActiveManagementActions())
+
+	for iteration := 0; iteration < 20; iteration++ {
+		// when
+		modelUnderTest.DoRandomChange()
+
+		// then
+		g.Expect(modelUnderTest.ActiveManagementActions()).To(HaveLen(activeNumber))
+	}
+}
+
+func TestModel_CostEffective_TogglesSingleAction(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildModel(CostEffectiveStrategy)
+
+	// when
+	modelUnderTest.DoRandomChange()
+
+	// then
+	g.Expect(modelUnderTest.ActiveManagementActions()).To(HaveLen(1))
+
+	// when
+	modelUnderTest.UndoChange()
+
+	// then
+	g.Expect(modelUnderTest.ActiveManagementActions()).To(BeEmpty())
+}
+
+func TestModel_RevertChange_ClearsMove(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildModel(MultiFlipStrategy)
+	modelUnderTest.TryRandomChange()
+
+	// when
+	modelUnderTest.RevertChange()
+
+	// then
+	g.Expect(modelUnderTest.move).To(BeNil())
+	g.Expect(modelUnderTest.ActiveManagementActions()).To(BeEmpty())
+}
+
+func TestCostEffective_EffectivenessOf_SignedByOptimisationSense(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildModel(CostEffectiveStrategy)
+	strategyUnderTest := modelUnderTest.Strategy().(*costEffective)
+
+	benefitVariable := modelUnderTest.DecisionVariable(strategyUnderTest.benefitVariable)
+	improvementSign := 1.0
+	if variable.SenseOf(benefitVariable) == variable.Minimised {
+		improvementSign = -1.0
+	}
+
+	expectedEffectivenessOf := func(index int, activeSign float64) float64 {
+		deltas := modelUnderTest.EvaluateManagementActionToggle(index)
+		cost := math.Abs(deltas.Delta(strategyUnderTest.costVariable))
+		return activeSign * improvementSign * deltas.Delta(strategyUnderTest.benefitVariable) / cost
+	}
+
+	for index := range modelUnderTest.ManagementActions() {
+		// when
+		expectedInactiveEffectiveness := expectedEffectivenessOf(index, 1)
+		inactiveEffectiveness := strategyUnderTest.effectivenessOf(modelUnderTest, index)
+
+		modelUnderTest.SetManagementAction(index, true)
+		expectedActiveEffectiveness := expectedEffectivenessOf(index, -1)
+		activeEffectiveness := strategyUnderTest.effectivenessOf(modelUnderTest, index)
+		modelUnderTest.SetManagementAction(index, false)
+
+		// then
+		if !math.IsInf(expectedInactiveEffectiveness, 0) && !math.IsNaN(expectedInactiveEffectiveness) {
+			g.Expect(inactiveEffectiveness).To(BeNumerically("~", expectedInactiveEffectiveness, 1e-9))
+		}
+		if !math.IsInf(expectedActiveEffectiveness, 0) && !math.IsNaN(expectedActiveEffectiveness) {
+			g.Expect(activeEffectiveness).To(BeNumerically("~", expectedActiveEffectiveness, 1e-9))
+		}
+	}
+}
+
+func TestAdaptive_Reward_FavoursAcceptedOperators(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	strategyUnderTest := newAdaptive(settings{flipNumber: 2, sampleSize: 4})
+	initialProbabilities := strategyUnderTest.OperatorProbabilities()
+
+	// when
+	for iteration := 0; iteration < 20; iteration++ {
+		strategyUnderTest.lastOperator = 1
+		strategyUnderTest.Reward(true)
+		strategyUnderTest.lastOperator = 2
+		strategyUnderTest.Reward(false)
+	}
+
+	// then
+	probabilities := strategyUnderTest.OperatorProbabilities()
+	g.Expect(probabilities[1]).To(BeNumerically(">", initialProbabilities[1]))
+	g.Expect(probabilities[2]).To(BeNumerically(">=", minimumOperatorChance))
+	g.Expect(probabilities[2]).To(BeNumerically("<", initialProbabilities[2]))
+
+	totalProbability := 0.0
+	for _, probability := range probabilities {
+		totalProbability += probability
+	}
+	g.Expect(totalProbability).To(BeNumerically("~", 1, 1e-9))
+}
@@ -0,0 +1,306 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package moves
+
+import (
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+)
+
+// sampler offers strategies a source of randomly chosen management action indexes.
+type sampler struct {
+	settings
+	rand.RandContainer
+}
+
+func newSampler(settings settings) sampler {
+	newSampler := sampler{settings: settings}
+	newSampler.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	return newSampler
+}
+
+func (s *sampler) clone() sampler {
+	clone := *s
+	clone.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	return clone
+}
+
+func (s *sampler) randomIndex(model model.Model) int {
+	return s.RandomNumberGenerator().Intn(len(model.ManagementActions()))
+}
+
+// distinctIndexes returns up to number distinct, randomly chosen management action indexes.
+func (s *sampler) distinctIndexes(model model.Model, number int) []int {
+	actionNumber := len(model.ManagementActions())
+	if number > actionNumber {
+		number = actionNumber
+	}
+
+	chosen := make(map[int]bool, number)
+	indexes := make([]int, 0, number)
+	for len(indexes) < number {
+		index := s.RandomNumberGenerator().Intn(actionNumber)
+		if !chosen[index] {
+			chosen[index] = true
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes
+}
+
+// partitionedSample returns a sample of management action indexes, split into those of active and inactive actions.
+func (s *sampler) partitionedSample(model model.Model) (active []int, inactive []int) {
+	actions := model.ManagementActions()
+	for _, index := range s.distinctIndexes(model, s.sampleSize) {
+		if actions[index].IsActive() {
+			active = append(active, index)
+		} else {
+			inactive = append(inactive, index)
+		}
+	}
+	return active, inactive
+}
+
+// single toggles one uniformly random management action, as models typically do.
+type single struct {
+	sampler
+}
+
+func newSingle(settings settings) *single {
+	return &single{sampler: newSampler(settings)}
+}
+
+func (s *single) Propose(model model.Model) []int {
+	return []int{s.randomIndex(model)}
+}
+
+func (s *single) Reward(accepted bool) {}
+
+func (s *single) DeepClone() Strategy {
+	return &single{sampler: s.clone()}
+}
+
+// multiFlip toggles a number of distinct, uniformly random management actions at once.
+type multiFlip struct {
+	sampler
+}
+
+func newMultiFlip(settings settings) *multiFlip {
+	return &multiFlip{sampler: newSampler(settings)}
+}
+
+func (mf *multiFlip) Propose(model model.Model) []int {
+	return mf.distinctIndexes(model, mf.flipNumber)
+}
+
+func (mf *multiFlip) Reward(accepted bool) {}
+
+func (mf *multiFlip) DeepClone() Strategy {
+	return &multiFlip{sampler: mf.clone()}
+}
+
+// swap deactivates an active management action and activates the sampled inactive action closest to it in cost,
+// keeping the model near any budget bound it sits at.  Where a sample offers no action to swap with, a single
+// sampled action is toggled instead.
+type swap struct {
+	sampler
+}
+
+func newSwap(settings settings) *swap {
+	return &swap{sampler: newSampler(settings)}
+}
+
+func (s *swap) Propose(model model.Model) []int {
+	active, inactive := s.partitionedSample(model)
+	if len(active) == 0 || len(inactive) == 0 {
+		return []int{s.randomIndex(model)}
+	}
+
+	deactivated := active[0]
+	if !model.OffersDecisionVariable(s.costVariable) {
+		return []int{deactivated, inactive[0]}
+	}
+
+	deactivatedCost := costOf(model, deactivated, s.costVariable)
+	activated, closestDifference := inactive[0], math.Inf(1)
+	for _, candidate := range inactive {
+		difference := math.Abs(costOf(model, candidate, s.costVariable) - deactivatedCost)
+		if difference < closestDifference {
+			activated, closestDifference = candidate, difference
+		}
+	}
+	return []int{deactivated, activated}
+}
+
+func (s *swap) Reward(accepted bool) {}
+
+func (s *swap) DeepClone() Strategy {
+	return &swap{sampler: s.clone()}
+}
+
+func costOf(model model.Model, index int, costVariable string) float64 {
+	return math.Abs(model.EvaluateManagementActionToggle(index).Delta(costVariable))
+}
+
+// costEffective biases moves by cost-effectiveness (benefit variable change per unit of cost variable change),
+// picking between activating the most cost-effective, or deactivating the least cost-effective, sampled action.
+type costEffective struct {
+	sampler
+}
+
+func newCostEffective(settings settings) *costEffective {
+	return &costEffective{sampler: newSampler(settings)}
+}
+
+func (ce *costEffective) Propose(model model.Model) []int {
+	if !model.OffersDecisionVariable(ce.costVariable) || !model.OffersDecisionVariable(ce.benefitVariable) {
+		return []int{ce.randomIndex(model)}
+	}
+
+	active, inactive := ce.partitionedSample(model)
+	activating := len(active) == 0 || (len(inactive) > 0 && ce.RandomNumberGenerator().Intn(2) == 0)
+
+	if activating {
+		return []int{ce.mostEffectiveOf(model, inactive, 1)}
+	}
+	return []int{ce.mostEffectiveOf(model, active, -1)}
+}
+
+// mostEffectiveOf returns the candidate with the greatest cost-effectiveness when direction is 1, or the least
+// when direction is -1.
+func (ce *costEffective) mostEffectiveOf(model model.Model, candidates []int, direction float64) int {
+	chosen, chosenScore := candidates[0], math.Inf(-1)
+	for _, candidate := range candidates {
+		score := direction * ce.effectivenessOf(model, candidate)
+		if score > chosenScore {
+			chosen, chosenScore = candidate, score
+		}
+	}
+	return chosen
+}
+
+// effectivenessOf returns the benefit of having the action at index active, per unit of cost. Benefit is the change
+// in the benefit variable signed by its optimisation sense, so that actions that worsen it are the least effective.
+func (ce *costEffective) effectivenessOf(model model.Model, index int) float64 {
+	deltas := model.EvaluateManagementActionToggle(index)
+	benefit := deltas.Delta(ce.benefitVariable)
+	if variable.SenseOf(model.DecisionVariable(ce.benefitVariable)) == variable.Minimised {
+		benefit = -benefit
+	}
+	if model.ManagementActions()[index].IsActive() {
+		benefit = -benefit
+	}
+
+	cost := math.Abs(deltas.Delta(ce.costVariable))
+	if cost == 0 {
+		return math.Copysign(math.MaxFloat64, benefit)
+	}
+	return benefit / cost
+}
+
+func (ce *costEffective) Reward(accepted bool) {}
+
+func (ce *costEffective) DeepClone() Strategy {
+	return &costEffective{sampler: ce.clone()}
+}
+
+const (
+	adaptationRate         = 0.1
+	minimumOperatorChance  = 0.05
+	initialOperatorQuality = 1.0
+)
+
+// adaptive selects between the other strategies by probability matching, favouring those whose moves have more
+// often been accepted, while giving every strategy some minimum chance of selection.
+type adaptive struct {
+	sampler
+
+	operators    []Strategy
+	qualities    []float64
+	lastOperator int
+}
+
+func newAdaptive(settings settings) *adaptive {
+	newStrategy := &adaptive{
+		sampler: newSampler(settings),
+		operators: []Strategy{
+			newSingle(settings), newSwap(settings), newMultiFlip(settings), newCostEffective(settings),
+		},
+	}
+	newStrategy.qualities = make([]float64, len(newStrategy.operators))
+	for index := range newStrategy.qualities {
+		newStrategy.qualities[index] = initialOperatorQuality
+	}
+	return newStrategy
+}
+
+// SeedRandomNumbers drives the selection of strategies, and the moves each proposes, from seed.
+func (a *adaptive) SeedRandomNumbers(seed int64) {
+	seeds := rand.NewSeeded(seed)
+	a.sampler.SeedRandomNumbers(seeds.Int63())
+	for _, operator := range a.operators {
+		rand.Seed(operator, seeds.Int63())
+	}
+}
+
+func (a *adaptive) Propose(model model.Model) []int {
+	a.lastOperator = a.selectOperator()
+	return a.operators[a.lastOperator].Propose(model)
+}
+
+func (a *adaptive) selectOperator() int {
+	probabilities := a.OperatorProbabilities()
+
+	threshold := a.RandomNumberGenerator().Float64Unitary()
+	cumulativeProbability := 0.0
+	for index, probability := range probabilities {
+		cumulativeProbability += probability
+		if threshold <= cumulativeProbability {
+			return index
+		}
+	}
+	return len(probabilities) - 1
+}
+
+// OperatorProbabilities returns the chance of each strategy being selected for the next move.
+func (a *adaptive) OperatorProbabilities() []float64 {
+	totalQuality := 0.0
+	for _, quality := range a.qualities {
+		totalQuality += quality
+	}
+
+	operatorNumber := float64(len(a.operators))
+	probabilities := make([]float64, len(a.operators))
+	for index, quality := range a.qualities {
+		share := 1 / operatorNumber
+		if totalQuality > 0 {
+			share = quality / totalQuality
+		}
+		probabilities[index] = minimumOperatorChance + (1-operatorNumber*minimumOperatorChance)*share
+	}
+	return probabilities
+}
+
+func (a *adaptive) Reward(accepted bool) {
+	reward := 0.0
+	if accepted {
+		reward = 1
+	}
+	a.qualities[a.lastOperator] += adaptationRate * (reward - a.qualities[a.lastOperator])
+	a.operators[a.lastOperator].Reward(accepted)
+}
+
+func (a *adaptive) DeepClone() Strategy {
+	clone := *a
+	clone.sampler = a.clone()
+	clone.operators = make([]Strategy, len(a.operators))
+	for index, operator := range a.operators {
+		clone.operators[index] = operator.DeepClone()
+	}
+	clone.qualities = make([]float64, len(a.qualities))
+	copy(clone.qualities, a.qualities)
+	return &clone
+}
@@ -37,14 +37,15 @@ func (aao *AnnealingAttributeObserver) ObserveEvent(event observer.Event) {
 	logAttributes = append(logAttributes, attributes.NameValuePair{Name: "Id", Value: event.Id()})
 	logAttributes = append(logAttributes, attributes.NameValuePair{Name: "Event", Value: event.EventType.String()})
 
+	logHandler := aao.logHandlerFor(event)
 	if event.EventType.IsAnnealingState() {
-		aao.observeAnnealingEvent(event, logAttributes)
+		aao.observeAnnealingEvent(event, logAttributes, logHandler)
 	} else {
-		aao.observeEvent(event, logAttributes)
+		aao.observeEvent(event, logAttributes, logHandler)
 	}
 }
 
-func (aao *AnnealingAttributeObserver) observeAnnealingEvent(event observer.Event, logAttributes attributes.Attributes) {
+func (aao *AnnealingAttributeObserver) observeAnnealingEvent(event observer.Event, logAttributes attributes.Attributes, logHandler logging.Logger) {
 	switch event.EventType {
 	case observer.StartedAnnealing:
 		logAttributes = append(logAttributes,
@@ -65,10 +66,10 @@ func (aao *AnnealingAttributeObserver) observeAnnealingEvent(event observer.Even
 	default:
 		// deliberately does nothing extra
 	}
-	aao.logHandler.LogAtLevelWithAttributes(AnnealingLogLevel, logAttributes)
+	logHandler.LogAtLevelWithAttributes(AnnealingLogLevel, logAttributes)
 }
 
-func (aao *AnnealingAttributeObserver) observeEvent(event observer.Event, logAttributes attributes.Attributes) {
+func (aao *AnnealingAttributeObserver) observeEvent(event observer.Event, logAttributes attributes.Attributes, logHandler logging.Logger) {
 	switch event.EventType {
 	case observer.Note:
 		logAttributes = append(logAttributes, event.AttributesNamed("Note")...)
@@ -79,5 +80,5 @@ func (aao *AnnealingAttributeObserver) observeEvent(event observer.Event, logAtt
 	default:
 		// deliberately does nothing extra
 	}
-	aao.logHandler.LogAtLevelWithAttributes(model.LogLevel, logAttributes)
+	logHandler.LogAtLevelWithAttributes(model.LogLevel, logAttributes)
 }
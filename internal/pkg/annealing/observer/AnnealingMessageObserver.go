@@ -50,6 +50,10 @@ func (amo *AnnealingMessageObserver) ObserveEvent(event observer.Event) {
 		return
 	}
 
+	logHandler := amo.logHandlerFor(event)
+	event = detachedCopyOf(event)
+	event.RemoveAttribute("DecisionVariables")
+
 	var builder strings.FluentBuilder
 	if event.HasAttribute("Id'") {
 		builder.Add("Id [", event.Id(), "], ")
@@ -57,10 +61,10 @@ func (amo *AnnealingMessageObserver) ObserveEvent(event observer.Event) {
 	}
 
 	builder.Add("Event [", event.EventType.String(), "]: ")
-	amo.observeEvent(event, &builder)
+	amo.observeEvent(event, &builder, logHandler)
 }
 
-func (amo *AnnealingMessageObserver) observeEvent(event observer.Event, builder *strings.FluentBuilder) {
+func (amo *AnnealingMessageObserver) observeEvent(event observer.Event, builder *strings.FluentBuilder, logHandler logging.Logger) {
 	switch event.EventType {
 	case observer.StartedAnnealing:
 		amo.stringifyEvent(event, builder)
@@ -74,7 +78,15 @@ func (amo *AnnealingMessageObserver) observeEvent(event observer.Event, builder
 		amo.stringifyEvent(fusedIterationsEvent, builder)
 	}
 
-	amo.logHandler.LogAtLevel(AnnealingLogLevel, builder.String())
+	logHandler.LogAtLevel(AnnealingLogLevel, builder.String())
+}
+
+// detachedCopyOf returns a copy of event whose attributes can be removed or replaced in formatting them, without
+// altering the attributes of event as seen by other observers.
+func detachedCopyOf(event observer.Event) observer.Event {
+	detachedEvent := observer.NewEvent(event.EventType)
+	detachedEvent.JoiningAttributes(event.AllAttributes())
+	return *detachedEvent
 }
 
 const leftBrace = " ["
@@ -21,3 +21,25 @@ type AnnealingObserver struct {
 
 // Allows for the receipt of Event instances, but deliberately takes no action in observer those events.
 func (l *AnnealingObserver) ObserveAnnealingEvent(event observer.Event) {}
+
+// logHandlerFor returns the log handler for entries about the event given, being that of the event's annealing run
+// where the log handler can scope itself to runs.
+func (l *AnnealingObserver) logHandlerFor(event observer.Event) logging.Logger {
+	runScopedHandler, isRunScoped := l.logHandler.(logging.RunScopedLogger)
+	runId, hasRunId := event.Attribute("Id").(string)
+	if !isRunScoped || !hasRunId {
+		return l.logHandler
+	}
+	return runScopedHandler.ForRun(runId)
+}
+
+// FinishRun releases the log handler of the annealing run identified, once that run has finished.
+func (l *AnnealingObserver) FinishRun(runId string) {
+	runScopedHandler, isRunScoped := l.logHandler.(logging.RunScopedLogger)
+	if !isRunScoped {
+		return
+	}
+	if finishError := runScopedHandler.FinishRun(runId); finishError != nil {
+		l.logHandler.Error(finishError)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package solution
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+	"github.com/pkg/errors"
+)
+
+// Provenance records how a solution, or set of solutions, was produced: by which executable, from what
+// configuration and data set, and by which annealing run.  Run-specific fields are left empty for solutions
+// combined across runs.  RandomNumberSeed is the single seed driving all random number generation of the run's
+// annealer, so configuring a scenario with it reproduces the run (other than for cooperative runs). The parameters
+// of robust models are sampled before any run, with SamplingSeed (derived from the scenario's seed where configured
+// without one of its own).  Sweep designs record the parameter values of each combination they sample instead.
+type Provenance struct {
+	ExecutableName    string
+	ExecutableVersion string
+
+	ScenarioName string `json:",omitempty"`
+	ConfigFile   string
+	ConfigHash   string
+	Annealer     ComponentProvenance
+	Model        ComponentProvenance
+
+	DataSetFile       string `json:",omitempty"`
+	DataSetChecksum   string `json:",omitempty"`
+	ManagementActions int    `json:",omitempty"`
+	SamplingSeed      int64  `json:",omitempty"`
+
+	RunId            string `json:",omitempty"`
+	RandomNumberSeed int64  `json:",omitempty"`
+	Iterations       uint64 `json:",omitempty"`
+	ElapsedTime      string `json:",omitempty"`
+
+	Host    string
+	Created string
+}
+
+// ComponentProvenance records the type of an annealer or model, and the parameters it was resolved with.
+type ComponentProvenance struct {
+	Type       string
+	Parameters map[string]interface{}
+}
+
+// RestoredParameters returns the parameters recorded, with any numbers decoded from JSON as json.Number restored
+// to whichever of the integer or decimal types the validator of their specification in specs accepts.
+func (cp ComponentProvenance) RestoredParameters(specs *specification.Specifications) parameters.Map {
+	restored := make(parameters.Map)
+	for key, value := range cp.Parameters {
+		number, isNumber := value.(json.Number)
+		if !isNumber {
+			restored[key] = value
+			continue
+		}
+
+		var spec *specification.Specification
+		if specs != nil {
+			if keySpec, hasSpec := (*specs)[key]; hasSpec {
+				spec = &keySpec
+			}
+		}
+		restored[key] = restoredNumber(number, spec)
+	}
+	return restored
+}
+
+// restoredNumber returns the first of number's integer and decimal readings that spec accepts, or the first
+// reading number parses to where no spec is given or none is accepted.
+func restoredNumber(number json.Number, spec *specification.Specification) interface{} {
+	candidates := make([]interface{}, 0, 2)
+	if value, parseError := number.Int64(); parseError == nil {
+		candidates = append(candidates, value)
+	}
+	if value, parseError := number.Float64(); parseError == nil {
+		candidates = append(candidates, value)
+	}
+	if len(candidates) == 0 {
+		return number.String()
+	}
+
+	if spec != nil {
+		for _, candidate := range candidates {
+			if spec.Accepts(candidate) {
+				return candidate
+			}
+		}
+	}
+	return candidates[0]
+}
+
+// ForRun returns a copy of the provenance, completed with details of the annealing run that produced a solution.
+func (p *Provenance) ForRun(runId string, seed int64, iterations uint64, elapsedTime time.Duration) *Provenance {
+	runProvenance := p.Stamped()
+	runProvenance.RunId = runId
+	runProvenance.RandomNumberSeed = seed
+	runProvenance.Iterations = iterations
+	runProvenance.ElapsedTime = elapsedTime.String()
+	return runProvenance
+}
+
+// Stamped returns a copy of the provenance, noting it as created now.
+func (p *Provenance) Stamped() *Provenance {
+	stampedProvenance := *p
+	stampedProvenance.Created = time.Now().Format(time.RFC3339)
+	return &stampedProvenance
+}
+
+// ProvenanceEntry is a single named value of a Provenance, for encodings that record provenance as a table.
+type ProvenanceEntry struct {
+	Name  string
+	Value string
+}
+
+// Entries flattens the provenance into named values, with annealer and model parameters named by their
+// component and sorted by key.
+func (p *Provenance) Entries() []ProvenanceEntry {
+	entries := []ProvenanceEntry{
+		{"ExecutableName", p.ExecutableName},
+		{"ExecutableVersion", p.ExecutableVersion},
+		{"ScenarioName", p.ScenarioName},
+		{"ConfigFile", p.ConfigFile},
+		{"ConfigHash", p.ConfigHash},
+	}
+	entries = append(entries, p.Annealer.entries("Annealer")...)
+	entries = append(entries, p.Model.entries("Model")...)
+	entries = append(entries,
+		ProvenanceEntry{"DataSetFile", p.DataSetFile},
+		ProvenanceEntry{"DataSetChecksum", p.DataSetChecksum},
+		ProvenanceEntry{"ManagementActions", fmt.Sprintf("%d", p.ManagementActions)},
+		ProvenanceEntry{"SamplingSeed", fmt.Sprintf("%d", p.SamplingSeed)},
+		ProvenanceEntry{"RunId", p.RunId},
+		ProvenanceEntry{"RandomNumberSeed", fmt.Sprintf("%d", p.RandomNumberSeed)},
+		ProvenanceEntry{"Iterations", fmt.Sprintf("%d", p.Iterations)},
+		ProvenanceEntry{"ElapsedTime", p.ElapsedTime},
+		ProvenanceEntry{"Host", p.Host},
+		ProvenanceEntry{"Created", p.Created},
+	)
+	return entries
+}
+
+func (cp ComponentProvenance) entries(component string) []ProvenanceEntry {
+	entries := []ProvenanceEntry{{component + ".Type", cp.Type}}
+
+	keys := make([]string, 0, len(cp.Parameters))
+	for key := range cp.Parameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		entries = append(entries, ProvenanceEntry{component + ".Parameters." + key, fmt.Sprintf("%v", cp.Parameters[key])})
+	}
+	return entries
+}
+
+// ChecksumOf returns the hex-encoded SHA-256 checksum of content.
+func ChecksumOf(content []byte) string {
+	checksum := sha256.Sum256(content)
+	return hex.EncodeToString(checksum[:])
+}
+
+// ChecksumOfFiles returns the hex-encoded SHA-256 checksum of the content of the files at filePaths, taken in order.
+func ChecksumOfFiles(filePaths ...string) (string, error) {
+	hash := sha256.New()
+	for _, filePath := range filePaths {
+		if hashError := hashFile(hash, filePath); hashError != nil {
+			return "", hashError
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func hashFile(hash io.Writer, filePath string) error {
+	file, openError := os.Open(filePath)
+	if openError != nil {
+		return errors.Wrap(openError, "opening file for checksum")
+	}
+	defer file.Close()
+
+	if _, copyError := io.Copy(hash, file); copyError != nil {
+		return errors.Wrap(copyError, "reading file for checksum")
+	}
+	return nil
+}
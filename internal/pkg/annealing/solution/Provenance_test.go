@@ -0,0 +1,94 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package solution
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestProvenance_ForRun_CompletesCopyWithRunDetails(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	provenanceUnderTest := &Provenance{ExecutableName: "CREMExplorer", ExecutableVersion: "0.23"}
+
+	// when
+	runProvenance := provenanceUnderTest.ForRun("Scenario (1/2)", 42, 1000, 3*time.Second)
+
+	// then
+	g.Expect(runProvenance.ExecutableName).To(Equal("CREMExplorer"))
+	g.Expect(runProvenance.RunId).To(Equal("Scenario (1/2)"))
+	g.Expect(runProvenance.RandomNumberSeed).To(BeNumerically("==", 42))
+	g.Expect(runProvenance.Iterations).To(BeNumerically("==", 1000))
+	g.Expect(runProvenance.ElapsedTime).To(Equal("3s"))
+	g.Expect(runProvenance.Created).To(Not(BeEmpty()))
+
+	g.Expect(provenanceUnderTest.RunId).To(BeEmpty())
+	g.Expect(provenanceUnderTest.Created).To(BeEmpty())
+}
+
+func TestProvenance_Entries_NamesComponentParametersInKeyOrder(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	provenanceUnderTest := &Provenance{
+		Annealer: ComponentProvenance{
+			Type:       "Kirkpatrick",
+			Parameters: map[string]interface{}{"MaximumIterations": int64(200), "CoolingFactor": 0.99},
+		},
+		Model: ComponentProvenance{Type: "CatchmentModel"},
+	}
+
+	// when
+	entries := provenanceUnderTest.Entries()
+
+	// then
+	g.Expect(entries).To(ContainElement(ProvenanceEntry{"Annealer.Type", "Kirkpatrick"}))
+	g.Expect(entries).To(ContainElement(ProvenanceEntry{"Model.Type", "CatchmentModel"}))
+
+	coolingIndex, iterationsIndex := -1, -1
+	for index, entry := range entries {
+		switch entry.Name {
+		case "Annealer.Parameters.CoolingFactor":
+			coolingIndex = index
+			g.Expect(entry.Value).To(Equal("0.99"))
+		case "Annealer.Parameters.MaximumIterations":
+			iterationsIndex = index
+			g.Expect(entry.Value).To(Equal("200"))
+		}
+	}
+	g.Expect(coolingIndex).To(BeNumerically(">=", 0))
+	g.Expect(iterationsIndex).To(BeNumerically(">", coolingIndex))
+}
+
+func TestChecksumOfFiles_MatchesChecksumOfContentConcatenated(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	directory := t.TempDir()
+	firstPath, secondPath := filepath.Join(directory, "first.csv"), filepath.Join(directory, "second.csv")
+	g.Expect(os.WriteFile(firstPath, []byte("first"), 0666)).To(Succeed())
+	g.Expect(os.WriteFile(secondPath, []byte("second"), 0666)).To(Succeed())
+
+	// when
+	checksum, checksumError := ChecksumOfFiles(firstPath, secondPath)
+
+	// then
+	g.Expect(checksumError).To(BeNil())
+	g.Expect(checksum).To(Equal(ChecksumOf([]byte("firstsecond"))))
+}
+
+func TestChecksumOfFiles_MissingFile_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	_, checksumError := ChecksumOfFiles(filepath.Join(t.TempDir(), "missing.csv"))
+
+	// then
+	g.Expect(checksumError).To(Not(BeNil()))
+}
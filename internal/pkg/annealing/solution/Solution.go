@@ -54,7 +54,8 @@ type Solution struct {
 	ActiveManagementActions   map[planningunit.Id]ManagementActions
 	InactiveManagementActions map[planningunit.Id]ManagementActions `json:"-"`
 
-	EncodedActions string `json:"-"`
+	EncodedActions string      `json:"-"`
+	Provenance     *Provenance `json:",omitempty"`
 	attributes.ContainedAttributes
 }
 
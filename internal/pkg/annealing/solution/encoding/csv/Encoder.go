@@ -4,6 +4,7 @@ package csv
 
 import (
 	"bufio"
+	"encoding/json"
 	"github.com/LindsayBradford/crem/pkg/logging"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
 	"os"
@@ -16,6 +17,8 @@ import (
 const fileType = "csv"
 const fileTypeExtension = "." + fileType
 
+const provenanceFileTypeExtension = ".json"
+
 type Encoder struct {
 	loggers.ContainedLogger
 	decisionVariableMarshaler DecisionVariableMarshaler
@@ -41,6 +44,9 @@ func (e Encoder) Encode(solution *solution.Solution) error {
 	if managementActionError := e.encodeManagementActions(solution); managementActionError != nil {
 		return errors.Wrap(managementActionError, fileType+" encoding of solution decision variables")
 	}
+	if provenanceError := e.encodeProvenance(solution); provenanceError != nil {
+		return errors.Wrap(provenanceError, fileType+" encoding of solution provenance")
+	}
 	return nil
 }
 
@@ -66,6 +72,23 @@ func (e Encoder) encodeManagementActions(solution *solution.Solution) error {
 	return e.encodeMarshaled(marshaledSolution, outputPath)
 }
 
+// encodeProvenance encodes any provenance of the solution as JSON beside its CSV files, CSV tables having no room
+// for it.
+func (e Encoder) encodeProvenance(solution *solution.Solution) error {
+	if solution.Provenance == nil {
+		return nil
+	}
+
+	marshaledProvenance, marshalError := json.MarshalIndent(solution.Provenance, "", "  ")
+	if marshalError != nil {
+		return errors.Wrap(marshalError, "json marshaling of solution provenance")
+	}
+
+	outputPath := e.deriveProvenanceOutputPath(solution)
+	e.LogHandler().Debug("Encoding [" + solution.Id + "] provenance to [" + outputPath + "]")
+	return e.encodeMarshaled(marshaledProvenance, outputPath)
+}
+
 func (e Encoder) encodeMarshaled(marshaledSolution []byte, outputPath string) error {
 	os.Remove(outputPath)
 
@@ -92,6 +115,11 @@ func (e Encoder) deriveManagementActionOutputPath(solution *solution.Solution) (
 	return e.deriveOutputPath(solution, "ManagementActions")
 }
 
+func (e Encoder) deriveProvenanceOutputPath(solution *solution.Solution) (outputPath string) {
+	safeIdBasedFileName := solution.FileNameSafeId() + "-Provenance" + provenanceFileTypeExtension
+	return path.Join(e.outputPath, safeIdBasedFileName)
+}
+
 func (e Encoder) deriveOutputPath(solution *solution.Solution, contentType string) (outputPath string) {
 	safeIdBasedFileName := solution.FileNameSafeId() + "-" + contentType + fileTypeExtension
 	return path.Join(e.outputPath, safeIdBasedFileName)
@@ -11,6 +11,7 @@ import (
 const (
 	DecisionVariablesTableName = "NameMappedVariables"
 	ManagementActionsTableName = "ManagementActions"
+	ProvenanceTableName        = "Provenance"
 )
 
 const (
@@ -44,9 +45,32 @@ func (m *Marshaler) Marshal(solution *solution.Solution, dataSet *excel.DataSet)
 		return variableErr
 	}
 
+	MarshalProvenance(solution.Provenance, dataSet)
 	return nil
 }
 
+// MarshalProvenance adds a table of the provenance's named values to dataSet, where there is provenance to add.
+func MarshalProvenance(provenance *solution.Provenance, dataSet *excel.DataSet) {
+	if provenance == nil {
+		return
+	}
+
+	entries := provenance.Entries()
+
+	table := new(tables.CsvTableImpl)
+	table.SetHeader([]string{nameHeading, valueHeading})
+	table.SetName(ProvenanceTableName)
+	table.SetColumnAndRowSize(2, uint(len(entries)))
+
+	for index, entry := range entries {
+		rowIndex := uint(index)
+		table.SetCell(nameColumn, rowIndex, entry.Name)
+		table.SetCell(valueColumn, rowIndex, entry.Value)
+	}
+
+	dataSet.AddTable(table.Name(), table)
+}
+
 func (m *Marshaler) marshalDecisionVariables(solution *solution.Solution, dataSet *excel.DataSet) error {
 	table := emptyDecisionVariableTable(solution)
 
@@ -0,0 +1,110 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package set
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	idHeading      = "Solution"
+	actionsHeading = "Actions"
+	summaryHeading = "Summary"
+
+	jsonExtension = ".json"
+)
+
+// Solution identifies a member of a solution set, and the encoding of which management actions it has active.
+type Solution struct {
+	Id       string
+	Encoding string
+}
+
+// ReadFromFile retrieves the solutions listed in a CSV or JSON solution set summary, as produced by a
+// scenario run.  Files ending in '.json' are read as JSON, and all others as CSV.
+func ReadFromFile(filePath string) ([]Solution, error) {
+	fileHandle, openError := os.Open(filePath)
+	if openError != nil {
+		return nil, errors.Wrap(openError, "opening solution set file")
+	}
+	defer fileHandle.Close()
+
+	if strings.EqualFold(filepath.Ext(filePath), jsonExtension) {
+		return readJsonSolutionSet(fileHandle)
+	}
+	return readCsvSolutionSet(fileHandle)
+}
+
+func readCsvSolutionSet(fileHandle *os.File) ([]Solution, error) {
+	reader := csv.NewReader(fileHandle)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	records, readError := reader.ReadAll()
+	if readError != nil {
+		return nil, errors.Wrap(readError, "reading solution set file")
+	}
+
+	return deriveSolutionsFromRecords(records)
+}
+
+func deriveSolutionsFromRecords(records [][]string) ([]Solution, error) {
+	if len(records) == 0 {
+		return nil, errors.New("solution set has no header row")
+	}
+
+	header := records[0]
+	headerLength := len(header)
+
+	if headerLength < 3 || header[0] != idHeading ||
+		header[headerLength-2] != actionsHeading || header[headerLength-1] != summaryHeading {
+		return nil, errors.Errorf("solution set header expected to take the form [%s, <variables>, %s, %s]",
+			idHeading, actionsHeading, summaryHeading)
+	}
+
+	actionsIndex := headerLength - 2
+	solutions := make([]Solution, 0, len(records)-1)
+
+	for rowIndex, record := range records[1:] {
+		if len(record) < headerLength {
+			return nil, errors.Errorf("solution set row [%d] has fewer entries than its header", rowIndex+1)
+		}
+		solutions = append(solutions, Solution{Id: record[0], Encoding: record[actionsIndex]})
+	}
+
+	return solutions, nil
+}
+
+// jsonSolutionSet holds those parts of a JSON solution set summary needed to identify its solutions.
+type jsonSolutionSet struct {
+	Solutions []struct {
+		Id      string
+		Actions string
+	}
+}
+
+func readJsonSolutionSet(fileHandle *os.File) ([]Solution, error) {
+	var solutionSet jsonSolutionSet
+	if decodeError := json.NewDecoder(fileHandle).Decode(&solutionSet); decodeError != nil {
+		return nil, errors.Wrap(decodeError, "reading solution set file")
+	}
+
+	if len(solutionSet.Solutions) == 0 {
+		return nil, errors.New("solution set lists no solutions")
+	}
+
+	solutions := make([]Solution, len(solutionSet.Solutions))
+	for index, solution := range solutionSet.Solutions {
+		if solution.Id == "" {
+			return nil, errors.Errorf("solution set entry [%d] has no Id", index)
+		}
+		solutions[index] = Solution{Id: solution.Id, Encoding: solution.Actions}
+	}
+	return solutions, nil
+}
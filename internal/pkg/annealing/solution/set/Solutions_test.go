@@ -0,0 +1,60 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package set
+
+import (
+	"testing"
+
+	catchmenttest "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/test"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testSolutionSetPath = "testdata/SolutionSet.csv"
+	testJsonSolutionSet = "testdata/SolutionSet.json"
+)
+
+func TestReadFromFile_ValidFile_AllSolutionsRead(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	solutions, readError := ReadFromFile(testSolutionSetPath)
+
+	// then
+	g.Expect(readError).To(BeNil())
+	g.Expect(solutions).To(Equal(
+		[]Solution{
+			{Id: "As-Is", Encoding: "0"},
+			{Id: "Solution (1/2)", Encoding: "3F"},
+			{Id: "Solution (2/2)", Encoding: "FFFF"},
+		},
+	))
+}
+
+func TestReadFromFile_JsonFile_AllSolutionsRead(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	solutions, readError := ReadFromFile(testJsonSolutionSet)
+
+	// then
+	g.Expect(readError).To(BeNil())
+	g.Expect(solutions).To(Equal(
+		[]Solution{
+			{Id: "As-Is", Encoding: "0"},
+			{Id: "Solution (1/2)", Encoding: "3F"},
+			{Id: "Solution (2/2)", Encoding: "FFFF"},
+		},
+	))
+}
+
+func TestReadFromFile_NotASolutionSet_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	_, readError := ReadFromFile(catchmenttest.TestingModelPath)
+	t.Log(readError)
+
+	// then
+	g.Expect(readError).To(Not(BeNil()))
+}
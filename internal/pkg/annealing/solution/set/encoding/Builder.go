@@ -3,6 +3,7 @@
 package encoding
 
 import (
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set/encoding/csv"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set/encoding/excel"
@@ -17,6 +18,7 @@ type Builder struct {
 	loggers.ContainedLogger
 	outputType encoding.OutputType
 	outputPath string
+	provenance *solution.Provenance
 }
 
 func (b *Builder) ForOutputType(encoderType encoding.OutputType) *Builder {
@@ -29,6 +31,12 @@ func (b *Builder) WithOutputPath(outputPath string) *Builder {
 	return b
 }
 
+// WithProvenance has the encoder built record how the solution set summarised was produced.
+func (b *Builder) WithProvenance(provenance *solution.Provenance) *Builder {
+	b.provenance = provenance
+	return b
+}
+
 func (b *Builder) WithLogHandler(logHandler logging.Logger) *Builder {
 	b.SetLogHandler(logHandler)
 	return b
@@ -37,11 +45,11 @@ func (b *Builder) WithLogHandler(logHandler logging.Logger) *Builder {
 func (b *Builder) Build() Encoder {
 	switch b.outputType {
 	case encoding.UndefinedOutput, encoding.CsvOutput:
-		return new(csv.Encoder).WithOutputPath(b.outputPath).WithLogHandler(b.LogHandler())
+		return new(csv.Encoder).WithOutputPath(b.outputPath).WithProvenance(b.provenance).WithLogHandler(b.LogHandler())
 	case encoding.JsonOutput:
-		return new(json.Encoder).WithOutputPath(b.outputPath).WithLogHandler(b.LogHandler())
+		return new(json.Encoder).WithOutputPath(b.outputPath).WithProvenance(b.provenance).WithLogHandler(b.LogHandler())
 	case encoding.ExcelOutput:
-		return new(excel.Encoder).WithOutputPath(b.outputPath).WithLogHandler(b.LogHandler())
+		return new(excel.Encoder).WithOutputPath(b.outputPath).WithProvenance(b.provenance).WithLogHandler(b.LogHandler())
 	default:
 		return NullEncoder
 	}
@@ -4,6 +4,8 @@ package csv
 
 import (
 	"bufio"
+	"encoding/json"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
 	"github.com/LindsayBradford/crem/pkg/logging"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
@@ -16,10 +18,13 @@ import (
 const fileType = "csv"
 const fileTypeExtension = "." + fileType
 
+const provenanceFileTypeExtension = ".json"
+
 type Encoder struct {
 	loggers.ContainedLogger
 	summaryMarshaler SummaryMarshaler
 	outputPath       string
+	provenance       *solution.Provenance
 }
 
 func (e *Encoder) WithOutputPath(outputPath string) *Encoder {
@@ -27,6 +32,11 @@ func (e *Encoder) WithOutputPath(outputPath string) *Encoder {
 	return e
 }
 
+func (e *Encoder) WithProvenance(provenance *solution.Provenance) *Encoder {
+	e.provenance = provenance
+	return e
+}
+
 func (e *Encoder) WithLogHandler(logHandler logging.Logger) *Encoder {
 	e.SetLogHandler(logHandler)
 	return e
@@ -37,6 +47,9 @@ func (e Encoder) Encode(summary *set.Summary) error {
 	if decisionVariableError := e.encodeDecisionVariables(summary); decisionVariableError != nil {
 		return errors.Wrap(decisionVariableError, fileType+" encoding of solution decision variables")
 	}
+	if provenanceError := e.encodeProvenance(summary); provenanceError != nil {
+		return errors.Wrap(provenanceError, fileType+" encoding of solution set provenance")
+	}
 	return nil
 }
 
@@ -54,6 +67,23 @@ func (e Encoder) encodeDecisionVariables(summary *set.Summary) error {
 	return e.encodeMarshaled(marshaledSolution, outputPath)
 }
 
+// encodeProvenance encodes any provenance of the solution set as JSON beside its summary, the summary's CSV table
+// having no room for it.
+func (e Encoder) encodeProvenance(summary *set.Summary) error {
+	if e.provenance == nil {
+		return nil
+	}
+
+	marshaledProvenance, marshalError := json.MarshalIndent(e.provenance, "", "  ")
+	if marshalError != nil {
+		return errors.Wrap(marshalError, "json marshaling of solution set provenance")
+	}
+
+	outputPath := e.deriveProvenanceOutputPath(summary)
+	e.LogHandler().Debug("Encoding [" + summary.Id() + "] provenance to [" + outputPath + "]")
+	return e.encodeMarshaled(marshaledProvenance, outputPath)
+}
+
 func (e Encoder) encodeMarshaled(marshaledSummary []byte, outputPath string) error {
 	os.Remove(outputPath)
 
@@ -78,6 +108,11 @@ func (e Encoder) deriveSummaryOutputPath(summary *set.Summary) (outputPath strin
 	return e.deriveOutputPath(summary, "Summary")
 }
 
+func (e Encoder) deriveProvenanceOutputPath(summary *set.Summary) (outputPath string) {
+	safeIdBasedFileName := summary.FileNameSafeId() + "-Provenance" + provenanceFileTypeExtension
+	return path.Join(e.outputPath, safeIdBasedFileName)
+}
+
 func (e Encoder) deriveOutputPath(summary *set.Summary, contentType string) (outputPath string) {
 	safeIdBasedFileName := summary.FileNameSafeId() + "-" + contentType + fileTypeExtension
 	return path.Join(e.outputPath, safeIdBasedFileName)
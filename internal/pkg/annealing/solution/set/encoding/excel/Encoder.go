@@ -3,6 +3,7 @@
 package excel
 
 import (
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/excel"
 	"github.com/LindsayBradford/crem/pkg/logging"
@@ -27,6 +28,11 @@ func (e *Encoder) WithOutputPath(outputPath string) *Encoder {
 	return e
 }
 
+func (e *Encoder) WithProvenance(provenance *solution.Provenance) *Encoder {
+	e.marshaler.WithProvenance(provenance)
+	return e
+}
+
 func (e *Encoder) WithLogHandler(logHandler logging.Logger) *Encoder {
 	e.SetLogHandler(logHandler)
 	return e
@@ -4,6 +4,7 @@ package excel
 
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	solutionExcel "github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding/excel"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/excel"
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
@@ -17,7 +18,14 @@ const (
 	SummaryTableName = "Summary"
 )
 
-type Marshaler struct{}
+type Marshaler struct {
+	provenance *solution.Provenance
+}
+
+func (m *Marshaler) WithProvenance(provenance *solution.Provenance) *Marshaler {
+	m.provenance = provenance
+	return m
+}
 
 func (m *Marshaler) Marshal(summary *set.Summary, dataSet *excel.DataSet) error {
 	table := emptySummaryTable(summary)
@@ -40,6 +48,7 @@ func (m *Marshaler) Marshal(summary *set.Summary, dataSet *excel.DataSet) error
 	}
 
 	dataSet.AddTable(table.Name(), table)
+	solutionExcel.MarshalProvenance(m.provenance, dataSet)
 	return nil
 }
 
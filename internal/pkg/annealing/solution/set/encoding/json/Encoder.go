@@ -4,6 +4,7 @@ package json
 
 import (
 	"bufio"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
 	"github.com/LindsayBradford/crem/pkg/logging"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
@@ -27,6 +28,11 @@ func (e *Encoder) WithOutputPath(outputPath string) *Encoder {
 	return e
 }
 
+func (e *Encoder) WithProvenance(provenance *solution.Provenance) *Encoder {
+	e.marshaler.WithProvenance(provenance)
+	return e
+}
+
 func (e *Encoder) WithLogHandler(logHandler logging.Logger) *Encoder {
 	e.SetLogHandler(logHandler)
 	return e
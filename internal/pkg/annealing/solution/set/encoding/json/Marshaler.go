@@ -9,7 +9,14 @@ import (
 	"regexp"
 )
 
-type Marshaler struct{}
+type Marshaler struct {
+	provenance *solution.Provenance
+}
+
+func (m *Marshaler) WithProvenance(provenance *solution.Provenance) *Marshaler {
+	m.provenance = provenance
+	return m
+}
 
 const (
 	newLinePrefix = ""
@@ -18,11 +25,13 @@ const (
 
 type SolutionSummaries struct {
 	SolutionSet string
+	Provenance  *solution.Provenance `json:",omitempty"`
 	Solutions   []solution.Summary
 }
 
 func (m *Marshaler) Marshal(summary *set.Summary) ([]byte, error) {
 	dataToMarshal := deriveSolutionSummaries(summary)
+	dataToMarshal.Provenance = m.provenance
 
 	marshalling, marshalError := json.MarshalIndent(dataToMarshal, newLinePrefix, indent)
 	if marshalError != nil {
@@ -0,0 +1,176 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package catalogue describes the parameters accepted by every registered annealer and model type, as specified
+// by their parameter specifications.
+package catalogue
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+	"github.com/pkg/errors"
+)
+
+type Kind string
+
+const (
+	AnnealerKind Kind = "Annealer"
+	ModelKind    Kind = "Model"
+)
+
+const (
+	IntegerType = "Integer"
+	DecimalType = "Decimal"
+	StringType  = "String"
+	BooleanType = "Boolean"
+	UnknownType = "Unknown"
+)
+
+// Parameter describes a single parameter key.  Default is absent for optional parameters without a default value,
+// and Minimum or Maximum are absent for parameters whose specification does not limit them.
+type Parameter struct {
+	Key        string
+	ValueType  string
+	Default    interface{} `json:",omitempty"`
+	IsOptional bool
+	Minimum    *float64 `json:",omitempty"`
+	Maximum    *float64 `json:",omitempty"`
+}
+
+// Component describes the parameters of a single annealer or model type, sorted by key.
+type Component struct {
+	Kind       Kind
+	Type       string
+	Parameters []Parameter
+}
+
+type Catalogue struct {
+	Components []Component
+}
+
+// Describe returns a catalogue of the parameters of the annealer or model type named (matched case-insensitively),
+// or of every registered annealer and model type, where componentType is empty.
+func Describe(componentType string) (*Catalogue, error) {
+	catalogue := &Catalogue{Components: make([]Component, 0)}
+
+	annealerInterpreter := interpreter.NewAnnealerConfigInterpreter()
+	for _, annealerType := range annealerInterpreter.RegisteredTypes() {
+		if isDescribed(annealerType.String(), componentType) {
+			specs, _ := annealerInterpreter.ParameterSpecifications(annealerType)
+			catalogue.Components = append(catalogue.Components, describe(AnnealerKind, annealerType.String(), specs))
+		}
+	}
+
+	modelInterpreter := interpreter.NewModelConfigInterpreter()
+	for _, modelType := range modelInterpreter.RegisteredTypes() {
+		if isDescribed(modelType, componentType) {
+			specs, _ := modelInterpreter.ParameterSpecifications(modelType)
+			catalogue.Components = append(catalogue.Components, describe(ModelKind, modelType, specs))
+		}
+	}
+
+	if len(catalogue.Components) == 0 {
+		return nil, errors.Errorf("no annealer or model type [%s] is registered", componentType)
+	}
+	return catalogue, nil
+}
+
+func isDescribed(registeredType string, componentType string) bool {
+	return componentType == "" || strings.EqualFold(registeredType, componentType)
+}
+
+func describe(kind Kind, componentType string, specs *specification.Specifications) Component {
+	component := Component{Kind: kind, Type: componentType, Parameters: make([]Parameter, 0, len(*specs))}
+
+	for _, key := range sortedKeysOf(specs) {
+		spec := (*specs)[key]
+		parameter := Parameter{
+			Key:        key,
+			ValueType:  valueTypeOf(spec),
+			Default:    spec.DefaultValue,
+			IsOptional: spec.IsOptional,
+		}
+		parameter.Minimum, parameter.Maximum = boundsOf(spec)
+		component.Parameters = append(component.Parameters, parameter)
+	}
+
+	return component
+}
+
+// validatorBounds are the bounds of values accepted by the shared bounding validators, keyed by the validator's
+// code pointer, as validators are functions, and so cannot be compared directly. A nil bound is unlimited.
+var validatorBounds = map[uintptr][2]*float64{
+	validatorPointerOf(specification.IsDecimalBetweenZeroAndOne): {
+		&specification.UnitInterval.Minimum, &specification.UnitInterval.Maximum,
+	},
+	validatorPointerOf(specification.IsNonNegativeDecimal): {new(float64), nil},
+	validatorPointerOf(specification.IsNonNegativeInteger): {new(float64), nil},
+}
+
+func validatorPointerOf(validator specification.SpecValidator) uintptr {
+	return reflect.ValueOf(validator).Pointer()
+}
+
+// boundsOf returns the minimum and maximum values a specification accepts, being its recorded bounds, or where none
+// are recorded, those of its validator.  Either is nil where the specification does not limit it.
+func boundsOf(spec specification.Specification) (minimum *float64, maximum *float64) {
+	if spec.Bounds.IsBounded() {
+		recordedMinimum, recordedMaximum := spec.Bounds.Minimum, spec.Bounds.Maximum
+		return &recordedMinimum, &recordedMaximum
+	}
+
+	if spec.Validator == nil {
+		return nil, nil
+	}
+	if bounds, isBounding := validatorBounds[validatorPointerOf(spec.Validator)]; isBounding {
+		return copyOf(bounds[0]), copyOf(bounds[1])
+	}
+	return nil, nil
+}
+
+func copyOf(value *float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	valueCopy := *value
+	return &valueCopy
+}
+
+func sortedKeysOf(specs *specification.Specifications) []string {
+	keys := specs.Keys()
+	sort.Strings(keys)
+	return keys
+}
+
+// valueTypeOf returns the type of value the specification accepts, being that of its default value, or where it
+// has none, that of the first sample value its validator accepts.
+func valueTypeOf(spec specification.Specification) string {
+	if spec.DefaultValue != nil {
+		return typeNameOf(spec.DefaultValue)
+	}
+
+	for _, sampleValue := range []interface{}{float64(0), int64(0), "", false} {
+		if validationError, isValidationError := spec.Validator(spec.Key, sampleValue).(specification.ValidationError); isValidationError && validationError.IsValid() {
+			return typeNameOf(sampleValue)
+		}
+	}
+	return UnknownType
+}
+
+func typeNameOf(value interface{}) string {
+	switch value.(type) {
+	case int64:
+		return IntegerType
+	case float64:
+		return DecimalType
+	case string:
+		return StringType
+	case bool:
+		return BooleanType
+	default:
+		return UnknownType
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package catalogue
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
+	kirkpatrickCoolant "github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/kirkpatrick"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/kirkpatrick"
+	"github.com/LindsayBradford/crem/internal/pkg/config/interpreter"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	. "github.com/onsi/gomega"
+)
+
+func TestDescribe_NoType_DescribesAllRegisteredTypes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	catalogueUnderTest, describeError := Describe("")
+
+	// then
+	g.Expect(describeError).To(BeNil())
+
+	expectedComponentNumber := len(interpreter.NewAnnealerConfigInterpreter().RegisteredTypes()) +
+		len(interpreter.NewModelConfigInterpreter().RegisteredTypes())
+	g.Expect(catalogueUnderTest.Components).To(HaveLen(expectedComponentNumber))
+}
+
+func TestDescribe_AnnealerType_DescribesParameters(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	catalogueUnderTest, describeError := Describe("kirkpatrick")
+
+	// then
+	g.Expect(describeError).To(BeNil())
+	g.Expect(catalogueUnderTest.Components).To(HaveLen(1))
+
+	component := catalogueUnderTest.Components[0]
+	g.Expect(component.Kind).To(Equal(AnnealerKind))
+	g.Expect(component.Type).To(Equal("Kirkpatrick"))
+	g.Expect(component.Parameters).To(ContainElement(Parameter{
+		Key: kirkpatrick.DecisionVariableName, ValueType: StringType, Default: "ObjectiveValue",
+	}))
+}
+
+func TestDescribe_ModelType_DescribesOptionalAndBoundedParameters(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	catalogueUnderTest, describeError := Describe("CatchmentModel")
+
+	// then
+	g.Expect(describeError).To(BeNil())
+	parameters := parametersByKey(catalogueUnderTest.Components[0])
+
+	optionalParameter := parameters[catchmentParameters.MaximumSedimentProduction]
+	g.Expect(optionalParameter.ValueType).To(Equal(DecimalType))
+	g.Expect(optionalParameter.IsOptional).To(BeTrue())
+	g.Expect(optionalParameter.Default).To(BeNil())
+
+	boundedParameter := parameters[catchmentParameters.GullySedimentReductionTarget]
+	g.Expect(*boundedParameter.Minimum).To(BeNumerically("==", 0))
+	g.Expect(*boundedParameter.Maximum).To(BeNumerically("==", 1))
+}
+
+func TestDescribe_AnnealerType_DerivesBoundsFromValidators(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	catalogueUnderTest, describeError := Describe("Kirkpatrick")
+
+	// then
+	g.Expect(describeError).To(BeNil())
+	parameters := parametersByKey(catalogueUnderTest.Components[0])
+
+	unitIntervalParameter := parameters[kirkpatrickCoolant.CoolingFactor]
+	g.Expect(*unitIntervalParameter.Minimum).To(BeNumerically("==", 0))
+	g.Expect(*unitIntervalParameter.Maximum).To(BeNumerically("==", 1))
+
+	for _, nonNegativeKey := range []string{kirkpatrickCoolant.StartingTemperature, annealers.MaximumIterations} {
+		nonNegativeParameter := parameters[nonNegativeKey]
+		g.Expect(*nonNegativeParameter.Minimum).To(BeNumerically("==", 0))
+		g.Expect(nonNegativeParameter.Maximum).To(BeNil())
+	}
+
+	unboundedParameter := parameters[kirkpatrick.DecisionVariableName]
+	g.Expect(unboundedParameter.Minimum).To(BeNil())
+	g.Expect(unboundedParameter.Maximum).To(BeNil())
+}
+
+func TestDescribe_UnregisteredType_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	catalogueUnderTest, describeError := Describe("NoSuchType")
+
+	// then
+	g.Expect(catalogueUnderTest).To(BeNil())
+	g.Expect(describeError).To(Not(BeNil()))
+}
+
+func TestTomlMarshaler_Marshal_DecodesToDefaults(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	annealerCatalogue, _ := Describe("Kirkpatrick")
+	modelCatalogue, _ := Describe("CatchmentModel")
+	catalogueUnderTest := &Catalogue{
+		Components: append(annealerCatalogue.Components, modelCatalogue.Components...),
+	}
+
+	// when
+	marshaledCatalogue, marshalError := new(TomlMarshaler).Marshal(catalogueUnderTest)
+
+	// then
+	g.Expect(marshalError).To(BeNil())
+
+	var decodedTemplate map[string]map[string]interface{}
+	_, decodeError := toml.Decode(string(marshaledCatalogue), &decodedTemplate)
+	g.Expect(decodeError).To(BeNil())
+
+	g.Expect(decodedTemplate["Annealer"]["Type"]).To(Equal("Kirkpatrick"))
+	annealerParameters := decodedTemplate["Annealer"]["Parameters"].(map[string]interface{})
+	g.Expect(annealerParameters[kirkpatrick.DecisionVariableName]).To(Equal("ObjectiveValue"))
+
+	modelParameters := decodedTemplate["Model"]["Parameters"].(map[string]interface{})
+	g.Expect(modelParameters[catchmentParameters.GullySedimentReductionTarget]).To(Equal(0.8))
+	g.Expect(modelParameters).To(Not(HaveKey(catchmentParameters.MaximumSedimentProduction)))
+}
+
+func TestJsonMarshaler_Marshal_RoundTrips(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	catalogueUnderTest, _ := Describe("DumbModel")
+
+	// when
+	marshaledCatalogue, marshalError := new(JsonMarshaler).Marshal(catalogueUnderTest)
+
+	// then
+	g.Expect(marshalError).To(BeNil())
+
+	var unmarshaledCatalogue Catalogue
+	g.Expect(json.Unmarshal(marshaledCatalogue, &unmarshaledCatalogue)).To(BeNil())
+	g.Expect(unmarshaledCatalogue.Components[0].Type).To(Equal("DumbModel"))
+	g.Expect(unmarshaledCatalogue.Components[0].Parameters).To(HaveLen(len(catalogueUnderTest.Components[0].Parameters)))
+}
+
+func parametersByKey(component Component) map[string]Parameter {
+	parameters := make(map[string]Parameter)
+	for _, parameter := range component.Parameters {
+		parameters[parameter.Key] = parameter
+	}
+	return parameters
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package catalogue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	cremstrings "github.com/LindsayBradford/crem/pkg/strings"
+)
+
+type Marshaler interface {
+	Marshal(catalogue *Catalogue) ([]byte, error)
+}
+
+// TextMarshaler marshals a Catalogue into a table of parameters per annealer or model type.
+type TextMarshaler struct{}
+
+func (tm *TextMarshaler) Marshal(catalogue *Catalogue) ([]byte, error) {
+	var buffer bytes.Buffer
+	for index, component := range catalogue.Components {
+		if index > 0 {
+			buffer.WriteString("\n")
+		}
+		fmt.Fprintf(&buffer, "%s type [%s]\n", component.Kind, component.Type)
+		if len(component.Parameters) == 0 {
+			buffer.WriteString("  (no parameters)\n")
+			continue
+		}
+
+		table := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "  Key\tType\tDefault\tBounds")
+		for _, parameter := range component.Parameters {
+			fmt.Fprintf(table, "  %s\t%s\t%s\t%s\n",
+				parameter.Key, parameter.ValueType, defaultTextOf(parameter), boundsTextOf(parameter))
+		}
+		if flushError := table.Flush(); flushError != nil {
+			return nil, flushError
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+func defaultTextOf(parameter Parameter) string {
+	switch {
+	case parameter.Default != nil:
+		return tomlValueOf(parameter.Default)
+	case parameter.IsOptional:
+		return "(optional)"
+	default:
+		return ""
+	}
+}
+
+// boundsTextOf describes the inclusive range of values parameter accepts, an unlimited bound shown as infinite.
+func boundsTextOf(parameter Parameter) string {
+	if parameter.Minimum == nil && parameter.Maximum == nil {
+		return ""
+	}
+	return fmt.Sprintf("[%s, %s]", boundTextOf(parameter.Minimum, "-inf"), boundTextOf(parameter.Maximum, "inf"))
+}
+
+func boundTextOf(bound *float64, unlimitedText string) string {
+	if bound == nil {
+		return unlimitedText
+	}
+	return decimalTextOf(*bound)
+}
+
+// JsonMarshaler marshals a Catalogue into JSON.
+type JsonMarshaler struct{}
+
+func (jm *JsonMarshaler) Marshal(catalogue *Catalogue) ([]byte, error) {
+	return json.MarshalIndent(catalogue, "", "  ")
+}
+
+// TomlMarshaler marshals a Catalogue into an annotated scenario file template, with an '[Annealer]' or '[Model]'
+// section per type, each parameter assigned its default value.  Optional parameters without defaults are commented
+// out.  Cataloguing a single annealer and a single model type gives a template usable as-is.
+type TomlMarshaler struct{}
+
+const tomlCommentColumn = 55
+
+func (tm *TomlMarshaler) Marshal(catalogue *Catalogue) ([]byte, error) {
+	builder := new(cremstrings.FluentBuilder)
+	for index, component := range catalogue.Components {
+		if index > 0 {
+			builder.Add("\n")
+		}
+		builder.Add("[", string(component.Kind), "]\n")
+		builder.Add("Type = ", strconv.Quote(component.Type), "\n")
+		if len(component.Parameters) == 0 {
+			continue
+		}
+
+		builder.Add("\n[", string(component.Kind), ".Parameters]\n")
+		for _, parameter := range component.Parameters {
+			builder.Add(tomlLineOf(parameter), "\n")
+		}
+	}
+	return ([]byte)(builder.String()), nil
+}
+
+func tomlLineOf(parameter Parameter) string {
+	assignment := parameter.Key + " = " + tomlValueOf(parameter.Default)
+	if parameter.Default == nil {
+		assignment = "# " + parameter.Key + " = " + tomlValueOf(sampleValueOf(parameter.ValueType))
+	}
+
+	annotation := parameter.ValueType
+	if parameter.IsOptional {
+		annotation += ", optional"
+	}
+	if bounds := boundsTextOf(parameter); bounds != "" {
+		annotation += ", within " + bounds
+	}
+
+	padding := tomlCommentColumn - 1 - len(assignment)
+	if padding < 1 {
+		padding = 1
+	}
+	return assignment + strings.Repeat(" ", padding) + "# " + annotation
+}
+
+func sampleValueOf(valueType string) interface{} {
+	switch valueType {
+	case IntegerType:
+		return int64(0)
+	case DecimalType:
+		return float64(0)
+	case BooleanType:
+		return false
+	default:
+		return ""
+	}
+}
+
+func tomlValueOf(value interface{}) string {
+	switch typedValue := value.(type) {
+	case string:
+		return strconv.Quote(typedValue)
+	case float64:
+		return decimalTextOf(typedValue)
+	default:
+		return fmt.Sprintf("%v", typedValue)
+	}
+}
+
+// decimalTextOf returns value as TOML float text, always having a decimal point.
+func decimalTextOf(value float64) string {
+	text := strconv.FormatFloat(value, 'f', -1, 64)
+	if !strings.Contains(text, ".") {
+		text += ".0"
+	}
+	return text
+}
@@ -7,9 +7,12 @@ import (
 )
 
 type AnnealerConfig struct {
-	Type          AnnealerType
-	EventNotifier EventNotifierType
-	Parameters    parameters.Map
+	Type                AnnealerType
+	EventNotifier       EventNotifierType
+	EventBufferSize     uint64
+	EventBackPressure   EventBackPressureType
+	EventSampleInterval uint64
+	Parameters          parameters.Map
 }
 
 type AnnealerType struct {
@@ -25,13 +28,14 @@ var (
 	Kirkpatrick             = AnnealerType{"Kirkpatrick"}
 	Suppapitnarm            = AnnealerType{"Suppapitnarm"}
 	AveragedSuppapitnarm    = AnnealerType{"AveragedSuppapitnarm"}
+	Greedy                  = AnnealerType{"Greedy"}
 )
 
 func (at *AnnealerType) UnmarshalText(text []byte) error {
 	context := UnmarshalContext{
 		ConfigKey: "Annealer.Type",
 		ValidValues: []string{
-			Kirkpatrick.Value, Suppapitnarm.Value, AveragedSuppapitnarm.Value,
+			Kirkpatrick.Value, Suppapitnarm.Value, AveragedSuppapitnarm.Value, Greedy.Value,
 		},
 		TextToValidate: string(text),
 		AssignmentFunction: func() {
@@ -66,3 +70,35 @@ func (ent *EventNotifierType) UnmarshalText(text []byte) error {
 
 	return ProcessUnmarshalContext(context)
 }
+
+// EventBackPressureType names what a "Concurrent" event notifier does with events for observers that have fallen
+// EventBufferSize events behind.
+type EventBackPressureType struct {
+	value string
+}
+
+func (ebpt EventBackPressureType) String() string {
+	return ebpt.value
+}
+
+var (
+	UnspecifiedEventBackPressureType = EventBackPressureType{""}
+	BlockBackPressure                = EventBackPressureType{"Block"}
+	DropOldestBackPressure           = EventBackPressureType{"DropOldest"}
+	SampleBackPressure               = EventBackPressureType{"Sample"}
+)
+
+func (ebpt *EventBackPressureType) UnmarshalText(text []byte) error {
+	context := UnmarshalContext{
+		ConfigKey: "Annealer.EventBackPressure",
+		ValidValues: []string{
+			BlockBackPressure.value, DropOldestBackPressure.value, SampleBackPressure.value,
+		},
+		TextToValidate: string(text),
+		AssignmentFunction: func() {
+			ebpt.value = string(text)
+		},
+	}
+
+	return ProcessUnmarshalContext(context)
+}
@@ -6,6 +6,44 @@ type LoggingConfig struct {
 	Type                 LoggerType
 	Formatter            FormatterType
 	LogLevelDestinations map[string]string
+	LogFiles             LogFileConfig
+}
+
+// LogFileConfig decides how log files named by 'file:<path>' log level destinations are rotated, and whether each
+// annealing run writes to a log file of its own.
+type LogFileConfig struct {
+	MaximumSizeInMegabytes float64
+	RotationPeriod         RotationPeriodType
+	MaximumBackups         uint64
+	Compress               bool
+	SplitByRun             bool
+}
+
+type RotationPeriodType struct {
+	Value string
+}
+
+var (
+	UnspecifiedRotationPeriod = RotationPeriodType{""}
+	NeverRotated              = RotationPeriodType{"Never"}
+	HourlyRotated             = RotationPeriodType{"Hourly"}
+	DailyRotated              = RotationPeriodType{"Daily"}
+	WeeklyRotated             = RotationPeriodType{"Weekly"}
+)
+
+func (rpt *RotationPeriodType) UnmarshalText(text []byte) error {
+	context := UnmarshalContext{
+		ConfigKey: "LogFiles.RotationPeriod",
+		ValidValues: []string{
+			NeverRotated.Value, HourlyRotated.Value, DailyRotated.Value, WeeklyRotated.Value,
+		},
+		TextToValidate: string(text),
+		AssignmentFunction: func() {
+			rpt.Value = string(text)
+		},
+	}
+
+	return ProcessUnmarshalContext(context)
 }
 
 type LoggerType struct {
@@ -16,13 +54,14 @@ var (
 	UnspecifiedLoggerType = LoggerType{""}
 	NativeLibrary         = LoggerType{"NativeLibrary"}
 	BareBones             = LoggerType{"BareBones"}
+	JsonLinesLogger       = LoggerType{"JSONLines"}
 )
 
 func (lt *LoggerType) UnmarshalText(text []byte) error {
 	context := UnmarshalContext{
 		ConfigKey: "Type",
 		ValidValues: []string{
-			NativeLibrary.Value, BareBones.Value,
+			NativeLibrary.Value, BareBones.Value, JsonLinesLogger.Value,
 		},
 		TextToValidate: string(text),
 		AssignmentFunction: func() {
@@ -42,13 +81,14 @@ var (
 	RawMessage               = FormatterType{"RawMessage"}
 	Json                     = FormatterType{"JSON"}
 	NameValuePair            = FormatterType{"NameValuePair"}
+	JsonLines                = FormatterType{"JSONLines"}
 )
 
 func (ft *FormatterType) UnmarshalText(text []byte) error {
 	context := UnmarshalContext{
 		ConfigKey: "Formatter",
 		ValidValues: []string{
-			RawMessage.Value, Json.Value, NameValuePair.Value,
+			RawMessage.Value, Json.Value, NameValuePair.Value, JsonLines.Value,
 		},
 		TextToValidate: string(text),
 		AssignmentFunction: func() {
@@ -6,4 +6,7 @@ type MetaDataConfig struct {
 	FilePath          string
 	ExecutableName    string
 	ExecutableVersion string
+
+	// ResolvedContent is the configuration as decoded, once any inheritance and variable substitution is resolved.
+	ResolvedContent string
 }
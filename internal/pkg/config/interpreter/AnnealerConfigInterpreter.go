@@ -3,14 +3,20 @@
 package interpreter
 
 import (
+	"sort"
+
 	"github.com/LindsayBradford/crem/internal/pkg/annealing"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/averaged"
+	coolingKirkpatrick "github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/kirkpatrick"
 	coolingSuppapitnarm "github.com/LindsayBradford/crem/internal/pkg/annealing/cooling/coolants/suppapitnarm"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/greedy"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/kirkpatrick"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/suppapitnarm"
 	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
 	assert "github.com/LindsayBradford/crem/pkg/assert/debug"
 	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
 	"github.com/pkg/errors"
@@ -19,12 +25,14 @@ import (
 type AnnealerConfigInterpreter struct {
 	errors              *compositeErrors.CompositeError
 	registeredAnnealers map[data.AnnealerType]AnnealerConfigFunction
+	registeredSpecs     map[data.AnnealerType]SpecificationFunction
 
 	annealer annealing.Annealer
 }
 
 func (i *AnnealerConfigInterpreter) initialise() *AnnealerConfigInterpreter {
 	i.registeredAnnealers = make(map[data.AnnealerType]AnnealerConfigFunction, 0)
+	i.registeredSpecs = make(map[data.AnnealerType]SpecificationFunction, 0)
 	i.errors = compositeErrors.New("Annealer Configuration")
 	i.annealer = &annealers.NullAnnealer{}
 	return i
@@ -41,6 +49,7 @@ func NewAnnealerConfigInterpreter() *AnnealerConfigInterpreter {
 				newAnnealer.Initialise()
 				return newAnnealer
 			},
+			specification.NewSpecifications,
 		).RegisteringAnnealer(
 		data.Kirkpatrick,
 		func(config data.AnnealerConfig) annealing.Annealer {
@@ -54,6 +63,9 @@ func NewAnnealerConfigInterpreter() *AnnealerConfigInterpreter {
 
 			return newAnnealer
 		},
+		combinedSpecifications(
+			annealers.DefineSpecifications, kirkpatrick.ParameterSpecifications, coolingKirkpatrick.ParameterSpecifications,
+		),
 	).RegisteringAnnealer(
 		data.Suppapitnarm,
 		func(config data.AnnealerConfig) annealing.Annealer {
@@ -68,6 +80,9 @@ func NewAnnealerConfigInterpreter() *AnnealerConfigInterpreter {
 
 			return newAnnealer
 		},
+		combinedSpecifications(
+			annealers.DefineSpecifications, suppapitnarm.ParameterSpecifications, coolingSuppapitnarm.ParameterSpecifications,
+		),
 	).RegisteringAnnealer(
 		data.AveragedSuppapitnarm,
 		func(config data.AnnealerConfig) annealing.Annealer {
@@ -82,6 +97,23 @@ func NewAnnealerConfigInterpreter() *AnnealerConfigInterpreter {
 
 			return newAnnealer
 		},
+		combinedSpecifications(
+			annealers.DefineSpecifications, suppapitnarm.ParameterSpecifications, averaged.ParameterSpecifications,
+		),
+	).RegisteringAnnealer(
+		data.Greedy,
+		func(config data.AnnealerConfig) annealing.Annealer {
+			newAnnealer := new(annealers.ElapsedTimeTrackingAnnealer)
+			newAnnealer.Initialise()
+
+			newExplorer := greedy.New()
+			newAnnealer.SetSolutionExplorer(newExplorer)
+
+			newAnnealer.SetParameters(config.Parameters)
+
+			return newAnnealer
+		},
+		combinedSpecifications(annealers.DefineSpecifications, greedy.ParameterSpecifications),
 	)
 	return newInterpreter
 }
@@ -99,6 +131,9 @@ func (i *AnnealerConfigInterpreter) Interpret(config *data.AnnealerConfig) *Anne
 
 	configFunction := i.registeredAnnealers[config.Type]
 	newAnnealer := configFunction(*config)
+	if config.EventNotifier == data.Concurrent {
+		newAnnealer.SetEventNotifier(newConcurrentEventNotifier(config))
+	}
 	if parameterisedModel, hasParameters := newAnnealer.(parameters.Container); hasParameters {
 		if paramErrors := parameterisedModel.ParameterErrors(); paramErrors != nil {
 			wrappedErrors := errors.Wrap(paramErrors, "building annealer ["+config.Type.String()+"]")
@@ -110,11 +145,51 @@ func (i *AnnealerConfigInterpreter) Interpret(config *data.AnnealerConfig) *Anne
 	return i
 }
 
-func (i *AnnealerConfigInterpreter) RegisteringAnnealer(annealerType data.AnnealerType, configFunction AnnealerConfigFunction) *AnnealerConfigInterpreter {
+func newConcurrentEventNotifier(config *data.AnnealerConfig) *observer.ConcurrentAnnealingEventNotifier {
+	return observer.NewConcurrentAnnealingEventNotifier().
+		WithBufferSize(int(config.EventBufferSize)).
+		WithBackPressure(backPressurePolicies[config.EventBackPressure]).
+		WithSampleInterval(config.EventSampleInterval)
+}
+
+var backPressurePolicies = map[data.EventBackPressureType]observer.BackPressurePolicy{
+	data.UnspecifiedEventBackPressureType: observer.Block,
+	data.BlockBackPressure:                observer.Block,
+	data.DropOldestBackPressure:           observer.DropOldest,
+	data.SampleBackPressure:               observer.Sample,
+}
+
+// RegisteringAnnealer registers the configFunction building annealers of annealerType, and the specificationFunction
+// specifying the parameters they accept.
+func (i *AnnealerConfigInterpreter) RegisteringAnnealer(annealerType data.AnnealerType, configFunction AnnealerConfigFunction,
+	specificationFunction SpecificationFunction) *AnnealerConfigInterpreter {
 	i.registeredAnnealers[annealerType] = configFunction
+	i.registeredSpecs[annealerType] = specificationFunction
 	return i
 }
 
+// ParameterSpecifications returns the specifications registered for annealerType, across the annealer, its explorer
+// and its coolant.  The boolean returned is false for unregistered annealer types.
+func (i *AnnealerConfigInterpreter) ParameterSpecifications(annealerType data.AnnealerType) (*specification.Specifications, bool) {
+	specificationFunction, isRegistered := i.registeredSpecs[annealerType]
+	if !isRegistered {
+		return specification.NewSpecifications(), false
+	}
+	return specificationFunction(), true
+}
+
+// RegisteredTypes returns the annealer types registered, sorted by name.
+func (i *AnnealerConfigInterpreter) RegisteredTypes() []data.AnnealerType {
+	annealerTypes := make([]data.AnnealerType, 0, len(i.registeredAnnealers))
+	for annealerType := range i.registeredAnnealers {
+		annealerTypes = append(annealerTypes, annealerType)
+	}
+	sort.Slice(annealerTypes, func(first, second int) bool {
+		return annealerTypes[first].Value < annealerTypes[second].Value
+	})
+	return annealerTypes
+}
+
 func (i *AnnealerConfigInterpreter) Annealer() annealing.Annealer {
 	return i.annealer
 }
@@ -7,8 +7,10 @@ import (
 	"testing"
 
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/greedy"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer/kirkpatrick"
 	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
 	. "github.com/onsi/gomega"
 )
@@ -161,3 +163,54 @@ func TestConfigInterpreter_SuppapitnarmAnnealer_NoErrors(t *testing.T) {
 	expectedExplorerType := &suppapitnarm.Explorer{}
 	g.Expect(actualExplorer).To(BeAssignableToTypeOf(expectedExplorerType))
 }
+
+func TestConfigInterpreter_GreedyAnnealer_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configUnderTest := data.AnnealerConfig{
+		Type: data.Greedy,
+		Parameters: parameters.Map{
+			"MaximumIterations": int64(100),
+			"GreedyBudget":      float64(500_000),
+		},
+	}
+
+	// when
+	interpreterUnderTest := NewAnnealerConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+
+	actualExplorer := interpreterUnderTest.Annealer().SolutionExplorer()
+	expectedExplorerType := &greedy.Explorer{}
+	g.Expect(actualExplorer).To(BeAssignableToTypeOf(expectedExplorerType))
+}
+
+func TestConfigInterpreter_ConcurrentEventNotifier_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configUnderTest := data.AnnealerConfig{
+		Type:              data.Suppapitnarm,
+		EventNotifier:     data.Concurrent,
+		EventBufferSize:   100,
+		EventBackPressure: data.DropOldestBackPressure,
+	}
+
+	// when
+	interpreterUnderTest := NewAnnealerConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	if interpreterUnderTest.Errors() != nil {
+		t.Log(interpreterUnderTest.Errors())
+	}
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+
+	actualNotifier := interpreterUnderTest.Annealer().EventNotifier()
+	expectedNotifierType := &observer.ConcurrentAnnealingEventNotifier{}
+	g.Expect(actualNotifier).To(BeAssignableToTypeOf(expectedNotifierType))
+}
@@ -4,6 +4,9 @@ package interpreter
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
 
 	annealingObserver "github.com/LindsayBradford/crem/internal/pkg/annealing/observer"
 	"github.com/LindsayBradford/crem/internal/pkg/config/data"
@@ -13,6 +16,7 @@ import (
 	"github.com/LindsayBradford/crem/pkg/logging"
 	"github.com/LindsayBradford/crem/pkg/logging/formatters"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	"github.com/pkg/errors"
 )
 
 type LoggingConfigInterpreter struct {
@@ -20,6 +24,9 @@ type LoggingConfigInterpreter struct {
 
 	loggerBuilder *loggers.Builder
 	logger        logging.Logger
+
+	fileOptions      logging.FileOptions
+	fileDestinations map[string]*logging.FileDestination
 }
 
 func NewLoggingConfigInterpreter() *LoggingConfigInterpreter {
@@ -31,6 +38,7 @@ func (i *LoggingConfigInterpreter) initialise() *LoggingConfigInterpreter {
 	i.errors = compositeErrors.New("Logging Configuration")
 
 	i.loggerBuilder = new(loggers.Builder)
+	i.fileDestinations = make(map[string]*logging.FileDestination)
 
 	var buildErrors error
 
@@ -52,6 +60,7 @@ func (i *LoggingConfigInterpreter) Interpret(config *data.LoggingConfig) *Loggin
 	}
 
 	i.deriveLogHandler(config)
+	i.deriveFileOptions(&config.LogFiles)
 	i.deriveLogLevelDestinations(config)
 	i.logger, _ = i.loggerBuilder.Build()
 
@@ -75,11 +84,26 @@ func (i *LoggingConfigInterpreter) deriveLogHandler(config *data.LoggingConfig)
 			WithFormatter(formatter).
 			WithLogLevelDestination(annealingObserver.AnnealingLogLevel, logging.STDOUT).
 			WithLogLevelDestination(model.LogLevel, logging.DISCARD)
+	case data.JsonLinesLogger:
+		i.checkJsonLinesFormatter(config.Formatter)
+		i.loggerBuilder.
+			ForJsonLinesLogHandler().
+			WithName("Configuration supplied JSONLines LogHandler").
+			WithLogLevelDestination(annealingObserver.AnnealingLogLevel, logging.STDOUT).
+			WithLogLevelDestination(model.LogLevel, logging.DISCARD)
 	default:
 		panic("Should not reach here")
 	}
 }
 
+// checkJsonLinesFormatter insists JSONLines loggers keep their own formatter, lest their entries stop being JSON.
+func (i *LoggingConfigInterpreter) checkJsonLinesFormatter(formatterType data.FormatterType) {
+	if formatterType != data.UnspecifiedFormatterType && formatterType != data.JsonLines {
+		i.errors.Add(fmt.Errorf("logger type [%s] only supports formatter [%s], not [%s]",
+			data.JsonLinesLogger.Value, data.JsonLines.Value, formatterType.Value))
+	}
+}
+
 func (i *LoggingConfigInterpreter) deriveLogLevelDestinations(config *data.LoggingConfig) {
 	for configLogLevel, configDestination := range config.LogLevelDestinations {
 		logLevel, destination := i.deriveLogLevelAndDestination(configLogLevel, configDestination)
@@ -87,6 +111,30 @@ func (i *LoggingConfigInterpreter) deriveLogLevelDestinations(config *data.Loggi
 	}
 }
 
+const bytesPerMegabyte = 1 << 20
+
+var rotationIntervals = map[data.RotationPeriodType]time.Duration{
+	data.UnspecifiedRotationPeriod: 0,
+	data.NeverRotated:              0,
+	data.HourlyRotated:             time.Hour,
+	data.DailyRotated:              24 * time.Hour,
+	data.WeeklyRotated:             7 * 24 * time.Hour,
+}
+
+func (i *LoggingConfigInterpreter) deriveFileOptions(config *data.LogFileConfig) {
+	if config.MaximumSizeInMegabytes < 0 {
+		i.errors.Add(fmt.Errorf("log file maximum size [%v] cannot be negative", config.MaximumSizeInMegabytes))
+	}
+
+	i.fileOptions = logging.FileOptions{
+		MaximumSize:      int64(config.MaximumSizeInMegabytes * bytesPerMegabyte),
+		RotationInterval: rotationIntervals[config.RotationPeriod],
+		MaximumBackups:   int(config.MaximumBackups),
+		Compress:         config.Compress,
+		SplitByRun:       config.SplitByRun,
+	}
+}
+
 func deriveLogFormatter(formatterType data.FormatterType) logging.Formatter {
 	switch formatterType {
 	case data.RawMessage, data.UnspecifiedFormatterType:
@@ -95,6 +143,8 @@ func deriveLogFormatter(formatterType data.FormatterType) logging.Formatter {
 		return new(formatters.JsonFormatter)
 	case data.NameValuePair:
 		return new(formatters.NameValuePairFormatter)
+	case data.JsonLines:
+		return new(formatters.JsonLinesFormatter)
 	default:
 		panic("Should not reach here")
 	}
@@ -133,6 +183,9 @@ func (i *LoggingConfigInterpreter) deriveDestination(configDestination string, c
 	case "Discarded":
 		derivedDestination = logging.DISCARD
 	default:
+		if filePath, isFile := filePathOf(configDestination); isFile {
+			return i.deriveFileDestination(filePath, configLogLevel)
+		}
 		i.errors.Add(
 			fmt.Errorf("attempted to map log level [%s] to unrecognised destination [%s]",
 				configLogLevel, configDestination))
@@ -140,6 +193,32 @@ func (i *LoggingConfigInterpreter) deriveDestination(configDestination string, c
 	return derivedDestination
 }
 
+func filePathOf(configDestination string) (string, bool) {
+	if len(configDestination) <= len(logging.FilePrefix) ||
+		!strings.EqualFold(configDestination[:len(logging.FilePrefix)], logging.FilePrefix) {
+		return "", false
+	}
+	return configDestination[len(logging.FilePrefix):], true
+}
+
+// deriveFileDestination returns the file destination for the path given, log levels sharing a path sharing its
+// destination.
+func (i *LoggingConfigInterpreter) deriveFileDestination(filePath string, configLogLevel string) logging.Destination {
+	cleanPath := filepath.Clean(filePath)
+	if existingDestination, exists := i.fileDestinations[cleanPath]; exists {
+		return existingDestination
+	}
+
+	fileDestination, openError := logging.NewFileDestination(cleanPath, i.fileOptions)
+	if openError != nil {
+		i.errors.Add(errors.Wrapf(openError, "attempted to map log level [%s] to file [%s]", configLogLevel, filePath))
+		return logging.DISCARD
+	}
+
+	i.fileDestinations[cleanPath] = fileDestination
+	return fileDestination
+}
+
 func (i *LoggingConfigInterpreter) LogHandler() logging.Logger {
 	return i.logger
 }
@@ -3,11 +3,18 @@
 package interpreter
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	annealingObserver "github.com/LindsayBradford/crem/internal/pkg/annealing/observer"
 	"github.com/LindsayBradford/crem/internal/pkg/config/data"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/pkg/attributes"
+	"github.com/LindsayBradford/crem/pkg/logging"
 	"github.com/LindsayBradford/crem/pkg/logging/formatters"
 	"github.com/LindsayBradford/crem/pkg/logging/loggers"
 	. "github.com/onsi/gomega"
@@ -174,3 +181,116 @@ func TestConfigInterpreter_InvalidLogLevelDestinations_Errors(t *testing.T) {
 	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
 	t.Log(interpreterUnderTest.Errors())
 }
+
+func TestConfigInterpreter_FileLogLevelDestinations_SplitByRun(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	logDirectory := t.TempDir()
+	logPath := filepath.Join(logDirectory, "annealing.log")
+
+	configUnderTest := data.LoggingConfig{
+		Type:      data.NativeLibrary,
+		Formatter: data.RawMessage,
+		LogLevelDestinations: map[string]string{
+			"Information": "file:" + logPath,
+			"Annealing":   "file:" + logPath,
+		},
+		LogFiles: data.LogFileConfig{
+			MaximumSizeInMegabytes: 10,
+			RotationPeriod:         data.DailyRotated,
+			SplitByRun:             true,
+		},
+	}
+
+	// when
+	interpreterUnderTest := NewLoggingConfigInterpreter().Interpret(&configUnderTest)
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+
+	actualLogger := interpreterUnderTest.LogHandler()
+	actualLogger.Info("scenario message")
+
+	runScopedLogger, isRunScoped := actualLogger.(logging.RunScopedLogger)
+	g.Expect(isRunScoped).To(BeTrue())
+	runScopedLogger.ForRun("Scenario (1/2)").LogAtLevel(annealingObserver.AnnealingLogLevel, "run message")
+
+	// then
+	scenarioContent, scenarioReadError := os.ReadFile(logPath)
+	g.Expect(scenarioReadError).To(BeNil())
+	g.Expect(string(scenarioContent)).To(ContainSubstring("scenario message"))
+	g.Expect(string(scenarioContent)).To(Not(ContainSubstring("run message")))
+
+	runContent, runReadError := os.ReadFile(filepath.Join(logDirectory, "annealing-Scenario_1_2.log"))
+	g.Expect(runReadError).To(BeNil())
+	g.Expect(string(runContent)).To(ContainSubstring("run message"))
+}
+
+func TestConfigInterpreter_JsonLinesLoggingConfig_WritesJsonLines(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	logPath := filepath.Join(t.TempDir(), "annealing.jsonl")
+
+	configUnderTest := data.LoggingConfig{
+		Type: data.JsonLinesLogger,
+		LogLevelDestinations: map[string]string{
+			"Information": "file:" + logPath,
+			"Annealing":   "file:" + logPath,
+		},
+	}
+
+	// when
+	interpreterUnderTest := NewLoggingConfigInterpreter().Interpret(&configUnderTest)
+	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
+
+	actualLogger := interpreterUnderTest.LogHandler()
+	actualLogger.Info("a \"quoted\" message")
+	actualLogger.LogAtLevelWithAttributes(annealingObserver.AnnealingLogLevel, attributes.Attributes{
+		{Name: "Id", Value: "Scenario (1/2)"},
+		{Name: "CurrentIteration", Value: uint64(42)},
+	})
+
+	// then
+	g.Expect(actualLogger).To(BeAssignableToTypeOf(&loggers.JsonLinesLogger{}))
+	g.Expect(actualLogger.Formatter()).To(BeAssignableToTypeOf(&formatters.JsonLinesFormatter{}))
+
+	logContent, readError := os.ReadFile(logPath)
+	g.Expect(readError).To(BeNil())
+
+	logLines := strings.Split(strings.TrimSpace(string(logContent)), "\n")
+	g.Expect(logLines).To(HaveLen(2))
+
+	infoEntry := make(map[string]interface{})
+	g.Expect(json.Unmarshal([]byte(logLines[0]), &infoEntry)).To(Succeed())
+	g.Expect(infoEntry).To(HaveKeyWithValue("Level", string(logging.INFO)))
+	g.Expect(infoEntry).To(HaveKeyWithValue("Logger", "Configuration supplied JSONLines LogHandler"))
+	g.Expect(infoEntry).To(HaveKeyWithValue("Message", "a \"quoted\" message"))
+	g.Expect(infoEntry).To(Not(HaveKey("RunId")))
+
+	_, timeError := time.Parse(time.RFC3339, infoEntry["Time"].(string))
+	g.Expect(timeError).To(BeNil())
+
+	annealingEntry := make(map[string]interface{})
+	g.Expect(json.Unmarshal([]byte(logLines[1]), &annealingEntry)).To(Succeed())
+	g.Expect(annealingEntry).To(HaveKeyWithValue("Level", string(annealingObserver.AnnealingLogLevel)))
+	g.Expect(annealingEntry).To(HaveKeyWithValue("RunId", "Scenario (1/2)"))
+	g.Expect(annealingEntry).To(HaveKeyWithValue("CurrentIteration", 42.0))
+	g.Expect(annealingEntry).To(Not(HaveKey("Id")))
+}
+
+func TestConfigInterpreter_JsonLinesLoggerWithOtherFormatter_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configUnderTest := data.LoggingConfig{
+		Type:      data.JsonLinesLogger,
+		Formatter: data.NameValuePair,
+	}
+
+	// when
+	interpreterUnderTest := NewLoggingConfigInterpreter().Interpret(&configUnderTest)
+
+	// then
+	g.Expect(interpreterUnderTest.Errors()).To(Not(BeNil()))
+	t.Log(interpreterUnderTest.Errors())
+}
@@ -3,12 +3,17 @@
 package interpreter
 
 import (
+	"sort"
+
 	"github.com/LindsayBradford/crem/internal/pkg/config/data"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/dumb"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/modumb"
+	modumbParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/modumb/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
 	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
 	"github.com/LindsayBradford/crem/pkg/threading"
 	"github.com/pkg/errors"
@@ -24,6 +29,7 @@ const (
 type ModelConfigInterpreter struct {
 	errors           *compositeErrors.CompositeError
 	registeredModels map[string]ModelConfigFunction
+	registeredSpecs  map[string]SpecificationFunction
 
 	model model.Model
 }
@@ -37,18 +43,21 @@ func NewModelConfigInterpreter() *ModelConfigInterpreter {
 			func(config data.ModelConfig) model.Model {
 				return model.NewNullModel()
 			},
+			specification.NewSpecifications,
 		).RegisteringModel(
 		DumbModel,
 		func(config data.ModelConfig) model.Model {
 			return dumb.NewModel().
 				WithParameters(config.Parameters)
 		},
+		dumb.ParameterSpecifications,
 	).RegisteringModel(
 		MultiObjectiveDumbModel,
 		func(config data.ModelConfig) model.Model {
 			return modumb.NewModel().
 				WithParameters(config.Parameters)
 		},
+		modumbParameters.ParameterSpecifications,
 	).RegisteringModel(
 		CatchmentModel,
 		func(config data.ModelConfig) model.Model {
@@ -56,6 +65,7 @@ func NewModelConfigInterpreter() *ModelConfigInterpreter {
 				WithOleFunctionWrapper(threading.GetMainThreadChannel().Call).
 				WithParameters(config.Parameters)
 		},
+		catchmentParameters.ParameterSpecifications,
 	)
 
 	return newInterpreter
@@ -63,6 +73,7 @@ func NewModelConfigInterpreter() *ModelConfigInterpreter {
 
 func (i *ModelConfigInterpreter) initialise() *ModelConfigInterpreter {
 	i.registeredModels = make(map[string]ModelConfigFunction, 0)
+	i.registeredSpecs = make(map[string]SpecificationFunction, 0)
 	i.errors = compositeErrors.New("Model Configuration")
 	i.model = model.NullModel
 	return i
@@ -90,11 +101,35 @@ func (i *ModelConfigInterpreter) Interpret(modelConfig *data.ModelConfig) *Model
 	return i
 }
 
-func (i *ModelConfigInterpreter) RegisteringModel(modelType string, configFunction ModelConfigFunction) *ModelConfigInterpreter {
+// RegisteringModel registers the configFunction building models of modelType, and the specificationFunction
+// specifying the parameters they accept.
+func (i *ModelConfigInterpreter) RegisteringModel(modelType string, configFunction ModelConfigFunction,
+	specificationFunction SpecificationFunction) *ModelConfigInterpreter {
 	i.registeredModels[modelType] = configFunction
+	i.registeredSpecs[modelType] = specificationFunction
 	return i
 }
 
+// ParameterSpecifications returns the specifications registered for modelType.  The boolean returned is false for
+// unregistered model types.
+func (i *ModelConfigInterpreter) ParameterSpecifications(modelType string) (*specification.Specifications, bool) {
+	specificationFunction, isRegistered := i.registeredSpecs[modelType]
+	if !isRegistered {
+		return specification.NewSpecifications(), false
+	}
+	return specificationFunction(), true
+}
+
+// RegisteredTypes returns the model types registered, sorted by name.
+func (i *ModelConfigInterpreter) RegisteredTypes() []string {
+	modelTypes := make([]string, 0, len(i.registeredModels))
+	for modelType := range i.registeredModels {
+		modelTypes = append(modelTypes, modelType)
+	}
+	sort.Strings(modelTypes)
+	return modelTypes
+}
+
 func (i *ModelConfigInterpreter) Model() model.Model {
 	return i.model
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/dumb"
 	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
 	. "github.com/onsi/gomega"
 )
 
@@ -89,7 +90,7 @@ func TestModelConfigInterpreter_RegisteringValidDummyModel_NoErrors(t *testing.T
 
 	// when
 	interpreterUnderTest := NewModelConfigInterpreter().
-		RegisteringModel("dummyModel", dummyModelConfigFunctions).
+		RegisteringModel("dummyModel", dummyModelConfigFunctions, specification.NewSpecifications).
 		Interpret(&configUnderTest)
 
 	// then
@@ -97,6 +98,28 @@ func TestModelConfigInterpreter_RegisteringValidDummyModel_NoErrors(t *testing.T
 	g.Expect(interpreterUnderTest.Errors()).To(BeNil())
 }
 
+func TestModelConfigInterpreter_RegisteringModel_ParameterSpecificationsRegistered(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	dummySpecifications := func() *specification.Specifications {
+		specs := specification.NewSpecifications()
+		specs.Add(specification.Specification{Key: "DummyParameter", IsOptional: true})
+		return specs
+	}
+
+	// when
+	interpreterUnderTest := NewModelConfigInterpreter().
+		RegisteringModel("dummyModel", func(config data.ModelConfig) model.Model { return new(dummyModel) }, dummySpecifications)
+	specs, isRegistered := interpreterUnderTest.ParameterSpecifications("dummyModel")
+	_, isUnregisteredRegistered := interpreterUnderTest.ParameterSpecifications("unregisteredModel")
+
+	// then
+	g.Expect(isRegistered).To(BeTrue())
+	g.Expect(*specs).To(HaveKey("DummyParameter"))
+	g.Expect(isUnregisteredRegistered).To(BeFalse())
+}
+
 type dummyModel struct {
 	dumb.Model
 }
@@ -0,0 +1,37 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package interpreter
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/config/data"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+)
+
+// SpecificationFunction returns the specifications of the parameters a registered annealer or model type accepts.
+type SpecificationFunction func() *specification.Specifications
+
+// combinedSpecifications returns a SpecificationFunction merging the specifications of each function supplied, as
+// needed where an annealer type is assembled from an annealer, an explorer and a coolant.
+func combinedSpecifications(specificationFunctions ...SpecificationFunction) SpecificationFunction {
+	return func() *specification.Specifications {
+		specs := specification.NewSpecifications()
+		for _, specificationFunction := range specificationFunctions {
+			for _, spec := range *specificationFunction() {
+				specs.Add(spec)
+			}
+		}
+		return specs
+	}
+}
+
+// AnnealerParameterSpecifications returns the specifications of every parameter the annealer type accepts, as
+// registered with NewAnnealerConfigInterpreter.  The boolean returned is false for unregistered annealer types.
+func AnnealerParameterSpecifications(annealerType data.AnnealerType) (*specification.Specifications, bool) {
+	return NewAnnealerConfigInterpreter().ParameterSpecifications(annealerType)
+}
+
+// ModelParameterSpecifications returns the specifications of every parameter the model type accepts, as registered
+// with NewModelConfigInterpreter.  The boolean returned is false for unregistered model types.
+func ModelParameterSpecifications(modelType string) (*specification.Specifications, bool) {
+	return NewModelConfigInterpreter().ParameterSpecifications(modelType)
+}
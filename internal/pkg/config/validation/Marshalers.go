@@ -0,0 +1,74 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cremstrings "github.com/LindsayBradford/crem/pkg/strings"
+)
+
+type Marshaler interface {
+	Marshal(report *Report) ([]byte, error)
+}
+
+// TextMarshaler marshals a Report into human-readable text, grouping checks under their section, and ending with
+// a summary of check outcomes.
+type TextMarshaler struct{}
+
+func (tm *TextMarshaler) Marshal(report *Report) ([]byte, error) {
+	builder := new(cremstrings.FluentBuilder)
+	builder.Add("Validation of [", report.Source, "]\n")
+
+	currentSection := ""
+	for _, check := range report.Checks {
+		if check.Section != currentSection {
+			currentSection = check.Section
+			builder.Add("\n[", currentSection, "]\n")
+		}
+		builder.Add("  ", fmt.Sprintf("%-7s", strings.ToUpper(string(check.Outcome))), "  ", check.Subject)
+		if check.Message != "" {
+			builder.Add(": ", check.Message)
+		}
+		builder.Add("\n")
+	}
+
+	builder.Add("\n", fmt.Sprintf("Summary: %d check(s), %d passed, %d failed, %d skipped\n",
+		len(report.Checks), report.Number(Passed), report.Number(Failed), report.Number(Skipped)))
+
+	return ([]byte)(builder.String()), nil
+}
+
+// JsonMarshaler marshals a Report into JSON, with a summary of check outcomes alongside the checks made.
+type JsonMarshaler struct{}
+
+type jsonSummary struct {
+	Checks  int
+	Passed  int
+	Failed  int
+	Skipped int
+}
+
+type jsonReport struct {
+	Source  string
+	IsValid bool
+	Summary jsonSummary
+	Checks  []Check
+}
+
+func (jm *JsonMarshaler) Marshal(report *Report) ([]byte, error) {
+	dataToMarshal := jsonReport{
+		Source:  report.Source,
+		IsValid: !report.HasFailures(),
+		Summary: jsonSummary{
+			Checks:  len(report.Checks),
+			Passed:  report.Number(Passed),
+			Failed:  report.Number(Failed),
+			Skipped: report.Number(Skipped),
+		},
+		Checks: report.Checks,
+	}
+	return json.MarshalIndent(dataToMarshal, "", "  ")
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package validation records the outcome of checking a configuration, without acting upon it.
+package validation
+
+import (
+	"strings"
+
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+type Outcome string
+
+const (
+	Passed  Outcome = "Passed"
+	Failed  Outcome = "Failed"
+	Skipped Outcome = "Skipped"
+)
+
+// Check records the outcome of checking a single subject (typically a parameter) of a configuration section.
+type Check struct {
+	Section string
+	Subject string
+	Outcome Outcome
+	Message string `json:",omitempty"`
+}
+
+// Report records the outcome of every check made of a configuration, in the order made.
+type Report struct {
+	Source string
+	Checks []Check
+}
+
+func NewReport(source string) *Report {
+	return &Report{Source: source, Checks: make([]Check, 0)}
+}
+
+func (r *Report) Pass(section string, subject string, message string) {
+	r.add(section, subject, Passed, message)
+}
+
+// Fail records a failed check per error composing checkError, where checkError is a composite error.
+func (r *Report) Fail(section string, subject string, checkError error) {
+	for _, message := range messagesOf(checkError) {
+		r.add(section, subject, Failed, message)
+	}
+}
+
+func (r *Report) Skip(section string, subject string, reason string) {
+	r.add(section, subject, Skipped, reason)
+}
+
+func (r *Report) add(section string, subject string, outcome Outcome, message string) {
+	r.Checks = append(r.Checks, Check{Section: section, Subject: subject, Outcome: outcome, Message: message})
+}
+
+// Number returns the number of checks reported with the outcome given.
+func (r *Report) Number(outcome Outcome) int {
+	number := 0
+	for _, check := range r.Checks {
+		if check.Outcome == outcome {
+			number++
+		}
+	}
+	return number
+}
+
+func (r *Report) HasFailures() bool {
+	return r.Number(Failed) > 0
+}
+
+type errorWithCause interface {
+	Cause() error
+}
+
+// messagesOf returns the message of every non-composite error composing checkError, flattening nested composite
+// errors.
+func messagesOf(checkError error) []string {
+	if checkError == nil {
+		return []string{""}
+	}
+
+	if _, hasCause := checkError.(errorWithCause); hasCause {
+		if composite, isComposite := errors.Cause(checkError).(*compositeErrors.CompositeError); isComposite {
+			checkError = composite
+		}
+	}
+
+	composite, isComposite := checkError.(*compositeErrors.CompositeError)
+	if !isComposite {
+		return []string{strings.TrimSpace(checkError.Error())}
+	}
+
+	messages := make([]string, 0)
+	for index := 0; index < composite.Size(); index++ {
+		messages = append(messages, messagesOf(composite.SubError(index))...)
+	}
+	if len(messages) == 0 {
+		return []string{composite.Error()}
+	}
+	return messages
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package validation
+
+import (
+	"encoding/json"
+	"testing"
+
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func TestReport_Fail_CompositeError_CheckPerSubError(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	nestedErrors := compositeErrors.New("nested")
+	nestedErrors.AddMessage("second problem")
+	nestedErrors.AddMessage("third problem")
+
+	checkErrors := compositeErrors.New("checking")
+	checkErrors.AddMessage("first problem")
+	checkErrors.Add(errors.Wrap(nestedErrors, "wrapped"))
+
+	reportUnderTest := NewReport("test.toml")
+
+	// when
+	reportUnderTest.Pass("Section", "Passing", "")
+	reportUnderTest.Fail("Section", "Failing", checkErrors)
+	reportUnderTest.Skip("Section", "Skipped", "not checked")
+
+	// then
+	g.Expect(reportUnderTest.HasFailures()).To(BeTrue())
+	g.Expect(reportUnderTest.Number(Passed)).To(BeNumerically("==", 1))
+	g.Expect(reportUnderTest.Number(Failed)).To(BeNumerically("==", 3))
+	g.Expect(reportUnderTest.Number(Skipped)).To(BeNumerically("==", 1))
+	g.Expect(reportUnderTest.Checks[3].Message).To(Equal("third problem"))
+}
+
+func TestReport_NoFailures_IsValid(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	reportUnderTest := NewReport("test.toml")
+
+	// when
+	reportUnderTest.Pass("Section", "Passing", "")
+	reportUnderTest.Skip("Section", "Skipped", "not checked")
+
+	// then
+	g.Expect(reportUnderTest.HasFailures()).To(BeFalse())
+}
+
+func TestTextMarshaler_Marshal_GroupsChecksBySection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	reportUnderTest := NewReport("test.toml")
+	reportUnderTest.Pass("Annealer", "Type", "Kirkpatrick")
+	reportUnderTest.Fail("Annealer", "Unknown", errors.New("Parameter [Unknown] is not supported"))
+	reportUnderTest.Pass("Model", "Type", "DumbModel")
+
+	// when
+	marshaledReport, marshalError := new(TextMarshaler).Marshal(reportUnderTest)
+
+	// then
+	g.Expect(marshalError).To(BeNil())
+	expectedText := "Validation of [test.toml]\n" +
+		"\n[Annealer]\n" +
+		"  PASSED   Type: Kirkpatrick\n" +
+		"  FAILED   Unknown: Parameter [Unknown] is not supported\n" +
+		"\n[Model]\n" +
+		"  PASSED   Type: DumbModel\n" +
+		"\nSummary: 3 check(s), 2 passed, 1 failed, 0 skipped\n"
+	g.Expect(string(marshaledReport)).To(Equal(expectedText))
+}
+
+func TestJsonMarshaler_Marshal_SummarisesChecks(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	reportUnderTest := NewReport("test.toml")
+	reportUnderTest.Pass("Annealer", "Type", "Kirkpatrick")
+	reportUnderTest.Fail("Annealer", "Unknown", errors.New("Parameter [Unknown] is not supported"))
+
+	// when
+	marshaledReport, marshalError := new(JsonMarshaler).Marshal(reportUnderTest)
+
+	// then
+	g.Expect(marshalError).To(BeNil())
+
+	var unmarshaledReport jsonReport
+	g.Expect(json.Unmarshal(marshaledReport, &unmarshaledReport)).To(BeNil())
+	g.Expect(unmarshaledReport.IsValid).To(BeFalse())
+	g.Expect(unmarshaledReport.Summary.Failed).To(BeNumerically("==", 1))
+	g.Expect(unmarshaledReport.Checks).To(Equal(reportUnderTest.Checks))
+}
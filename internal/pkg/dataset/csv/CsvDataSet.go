@@ -142,6 +142,34 @@ func (ds *DataSet) verifyMetaTableRows(metaTable tables.CsvTable) {
 	}
 }
 
+// FilePathsOf returns the path of the csv meta-file at baseCsvFilePath, followed by the path of each table file it
+// names, resolved against the meta-file's directory.
+func FilePathsOf(baseCsvFilePath string) ([]string, error) {
+	records, loadError := loadCsvRecords(baseCsvFilePath)
+	if loadError != nil {
+		return nil, loadError
+	}
+	if len(records) == 0 {
+		return nil, errors.Errorf("csv meta-file [%s] is empty", baseCsvFilePath)
+	}
+
+	const filePathCol = 1
+	dataSetPath := filepath.Dir(baseCsvFilePath)
+
+	filePaths := []string{baseCsvFilePath}
+	for _, record := range records[1:] {
+		if len(record) <= filePathCol {
+			return nil, errors.Errorf("csv meta-file [%s] has a row without a file path", baseCsvFilePath)
+		}
+		tableFilePath := record[filePathCol]
+		if !filepath.IsAbs(tableFilePath) {
+			tableFilePath = filepath.Join(dataSetPath, tableFilePath)
+		}
+		filePaths = append(filePaths, tableFilePath)
+	}
+	return filePaths, nil
+}
+
 func (ds *DataSet) loadCsvIntoTable(csvFilePath string) tables.CsvTable {
 	records, loadError := loadCsvRecords(csvFilePath)
 	if loadError != nil {
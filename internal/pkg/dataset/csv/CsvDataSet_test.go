@@ -172,3 +172,27 @@ func loadTextFromFile(g *GomegaWithT, filePath string) string {
 	text := string(fileContent)
 	return text
 }
+
+func TestFilePathsOf_ValidDataSet_ListsMetaFileThenTableFiles(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	testFixturePath := "testdata/validDataSet.csv"
+
+	// when
+	filePaths, pathsError := FilePathsOf(testFixturePath)
+
+	// then
+	g.Expect(pathsError).To(BeNil())
+	g.Expect(filePaths).To(Equal([]string{testFixturePath, "testdata/validCsvFile.csv"}))
+}
+
+func TestFilePathsOf_MissingFile_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	_, pathsError := FilePathsOf("testdata/missingCsvFile.csv")
+
+	// then
+	g.Expect(pathsError).To(Not(BeNil()))
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package model
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+)
+
+// DecisionVariableDeltas maps decision variable names to the change in value some model change would induce.
+type DecisionVariableDeltas map[string]float64
+
+// Delta returns the change induced for the named decision variable, or 0 if it has none recorded.
+func (d DecisionVariableDeltas) Delta(variableName string) float64 {
+	return d[variableName]
+}
+
+// EvaluateToggleOf evaluates toggling a management action for models whose decision variables stage
+// per-planning-unit change commands on observing the action.  The action is toggled, each variable's staged change
+// recorded, then the staged changes rejected and the action toggled back unobserved, as per a model reverting a
+// tried change. No decision variable value is changed in doing so.
+func EvaluateToggleOf(toggledAction action.ManagementAction, variables variable.UndoableDecisionVariables) DecisionVariableDeltas {
+	toggledAction.ToggleActivation()
+
+	deltas := make(DecisionVariableDeltas, len(variables))
+	for _, stagedVariable := range variables {
+		deltas[stagedVariable.Name()] = stagedVariable.DifferenceInValues()
+	}
+
+	variables.RejectAll()
+	toggledAction.ToggleActivationUnobserved()
+
+	return deltas
+}
@@ -3,6 +3,7 @@
 package model
 
 import (
+	"github.com/LindsayBradford/crem/internal/pkg/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/model/action"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
@@ -48,9 +49,32 @@ type Model interface {
 	SetManagementAction(index int, value bool)
 	SetManagementActionUnobserved(index int, value bool)
 
+	// EvaluateManagementActionToggle reports the change in each decision variable's value that toggling the
+	// management action at index would induce, leaving the model as it found it.  It expects no change to be
+	// awaiting acceptance or reversion.
+	EvaluateManagementActionToggle(index int) DecisionVariableDeltas
+
 	PlanningUnits() planningunit.Ids
 }
 
+// DataSourceValidator is a Model able to check the source data it is built from, before being initialised.
+type DataSourceValidator interface {
+	ValidateDataSource() error
+}
+
+// DataSourceLocator is a Model built from source data held in files, able to report their paths, that of the file
+// the model is configured with first.
+type DataSourceLocator interface {
+	DataSourceFilePaths() ([]string, error)
+}
+
+// DataSourceSharer is a Model able to share the source data set it loads with other models built from the same
+// source data, sparing them loading it again.
+type DataSourceSharer interface {
+	SharedDataSource() (dataset.DataSet, error)
+	ShareDataSource(dataSet dataset.DataSet)
+}
+
 // ContainedLogger defines an interface embedding a Model
 type Container interface {
 	Model() Model
@@ -122,6 +146,8 @@ func (nm *nullModel) ActiveManagementActions() []action.ManagementAction  { retu
 func (nm *nullModel) SetManagementAction(index int, value bool)           {}
 func (nm *nullModel) SetManagementActionUnobserved(index int, value bool) {}
 
+func (nm *nullModel) EvaluateManagementActionToggle(index int) DecisionVariableDeltas { return nil }
+
 func (nm *nullModel) PlanningUnits() planningunit.Ids { return nil }
 
 func (nm *nullModel) IsEquivalentTo(Model) bool { return false }
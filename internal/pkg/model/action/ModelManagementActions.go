@@ -20,7 +20,7 @@ type ManagementActions []ManagementAction
 
 func (m *ModelManagementActions) Initialise() {
 	m.actions = make([]ManagementAction, 0)
-	m.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	m.ResetRandomNumberGenerator()
 }
 
 // Add allows onr ore management actions to be added to the set of actions under management.
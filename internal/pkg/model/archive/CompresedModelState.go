@@ -39,7 +39,7 @@ func (c *CompressedModelState) VariableDifferences(otherState *CompressedModelSt
 }
 
 func (c *CompressedModelState) variableValuesMatch(index int, model model.Model, variableKeys []string) bool {
-	return c.Variables[index] == model.DecisionVariable(variableKeys[index]).Value()
+	return c.Variables[index] == compressedValueOf(model.DecisionVariable(variableKeys[index]))
 }
 
 func (c *CompressedModelState) actionsMatch(model model.Model) bool {
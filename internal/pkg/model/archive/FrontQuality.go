@@ -0,0 +1,121 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package archive
+
+import (
+	"fmt"
+
+	"github.com/LindsayBradford/crem/pkg/dominance"
+)
+
+// normalisedReferenceValue places the hypervolume reference point just beyond the nadir point of a normalised front,
+// so that the front's extreme points still contribute to its hypervolume.
+const normalisedReferenceValue = 1.1
+
+// FrontReference fixes what the quality of an archive's front is assessed against, so that fronts assessed against
+// the same reference can be compared.  Objectives index the (minimisation oriented) decision variables of archived
+// model states to assess the front over, and Ideal and Nadir give the bounds, per objective, the front is normalised
+// to.  Front, where given, is a reference set of (normalised) points the front's generational distances are taken to.
+type FrontReference struct {
+	Objectives []int
+	Ideal      dominance.Float64Vector
+	Nadir      dominance.Float64Vector
+	Front      []dominance.Float64Vector
+}
+
+// NewFrontReference returns a reference over objectives, bounded by the ideal and nadir points of all the fronts
+// given, and holding the non-dominated points of those fronts, normalised, as its reference set.
+func NewFrontReference(objectives []int, fronts ...[]dominance.Float64Vector) FrontReference {
+	combinedFront := make([]dominance.Float64Vector, 0)
+	for _, front := range fronts {
+		combinedFront = append(combinedFront, projectedOnto(front, objectives)...)
+	}
+
+	reference := FrontReference{
+		Objectives: objectives,
+		Ideal:      dominance.IdealPoint(combinedFront),
+		Nadir:      dominance.NadirPoint(combinedFront),
+	}
+	if len(combinedFront) > 0 {
+		reference.Front = reference.normalise(dominance.NonDominated(combinedFront))
+	}
+	return reference
+}
+
+// IsDefined reports whether the reference names any objectives, and bounds each of them.
+func (fr FrontReference) IsDefined() bool {
+	return len(fr.Objectives) > 0 && len(fr.Ideal) == len(fr.Objectives) && len(fr.Nadir) == len(fr.Objectives)
+}
+
+func (fr FrontReference) normalise(front []dominance.Float64Vector) []dominance.Float64Vector {
+	return dominance.Normalise(front, fr.Ideal, fr.Nadir)
+}
+
+// FrontQuality holds quality indicators of an archive's non-dominated front, taken over the objectives of a
+// FrontReference, and normalised to its bounds.  GenerationalDistance and InvertedGenerationalDistance are only
+// taken where the reference holds a reference set, and are otherwise zero.
+type FrontQuality struct {
+	Hypervolume                  float64
+	Spacing                      float64
+	Spread                       float64
+	GenerationalDistance         float64
+	InvertedGenerationalDistance float64
+}
+
+func (fq FrontQuality) String() string {
+	return fmt.Sprintf("hypervolume %.3f; spacing %.3f; spread %.3f", fq.Hypervolume, fq.Spacing, fq.Spread)
+}
+
+// FrontQuality returns quality indicators for the archive's current front of non-dominated model states, assessed
+// against reference.  An empty archive, or an undefined reference, has a zero quality.
+func (a *NonDominanceModelArchive) FrontQuality(reference FrontReference) FrontQuality {
+	if !reference.IsDefined() {
+		return FrontQuality{}
+	}
+	return FrontQualityOf(projectedOnto(a.Front(), reference.Objectives), reference)
+}
+
+// FrontQualityOf returns quality indicators for objectiveFront, a front of points holding (minimisation oriented)
+// values for just the objectives of reference, in order, assessed against reference.  Only the non-dominated points
+// of objectiveFront are assessed.  An empty front, or an undefined reference, has a zero quality.
+func FrontQualityOf(objectiveFront []dominance.Float64Vector, reference FrontReference) FrontQuality {
+	if len(objectiveFront) == 0 || !reference.IsDefined() {
+		return FrontQuality{}
+	}
+
+	normalisedFront := reference.normalise(dominance.NonDominated(objectiveFront))
+
+	referencePoint := make(dominance.Float64Vector, len(reference.Objectives))
+	for index := range referencePoint {
+		referencePoint[index] = normalisedReferenceValue
+	}
+
+	return FrontQuality{
+		Hypervolume:                  dominance.Hypervolume(normalisedFront, referencePoint),
+		Spacing:                      dominance.Spacing(normalisedFront),
+		Spread:                       dominance.Spread(normalisedFront),
+		GenerationalDistance:         dominance.GenerationalDistance(normalisedFront, reference.Front),
+		InvertedGenerationalDistance: dominance.InvertedGenerationalDistance(normalisedFront, reference.Front),
+	}
+}
+
+// Front returns the (minimisation oriented) decision variable values of each model state in the archive.
+func (a *NonDominanceModelArchive) Front() []dominance.Float64Vector {
+	front := make([]dominance.Float64Vector, len(a.archive))
+	for index, modelState := range a.archive {
+		front[index] = modelState.Variables
+	}
+	return front
+}
+
+func projectedOnto(front []dominance.Float64Vector, objectives []int) []dominance.Float64Vector {
+	projectedFront := make([]dominance.Float64Vector, len(front))
+	for pointIndex, point := range front {
+		projectedPoint := make(dominance.Float64Vector, len(objectives))
+		for index, objective := range objectives {
+			projectedPoint[index] = point[objective]
+		}
+		projectedFront[pointIndex] = projectedPoint
+	}
+	return projectedFront
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package archive
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/pkg/archive"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	. "github.com/onsi/gomega"
+)
+
+func buildModelState(activeAction int, variables ...float64) *CompressedModelState {
+	state := new(CompressedModelState)
+	state.Variables = variables
+	state.Actions = *archive.New(3)
+	state.Actions.SetValue(activeAction, true)
+	return state
+}
+
+func TestNonDominanceModelArchive_FrontQuality(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	archiveUnderTest := New()
+	reference := FrontReference{
+		Objectives: []int{0, 2},
+		Ideal:      dominance.Float64Vector{0, 0},
+		Nadir:      dominance.Float64Vector{400, 4000},
+	}
+
+	// then
+	g.Expect(archiveUnderTest.FrontQuality(reference)).To(Equal(FrontQuality{}))
+
+	// when
+	archiveUnderTest.AttemptToArchiveState(buildModelState(0, 100, 7, 3000))
+	archiveUnderTest.AttemptToArchiveState(buildModelState(1, 200, 8, 2000))
+	archiveUnderTest.AttemptToArchiveState(buildModelState(2, 300, 9, 1000))
+
+	actualQuality := archiveUnderTest.FrontQuality(reference)
+	t.Log(actualQuality)
+
+	// then
+	g.Expect(archiveUnderTest.Front()).To(ConsistOf(
+		dominance.Float64Vector{100, 7, 3000}, dominance.Float64Vector{200, 8, 2000}, dominance.Float64Vector{300, 9, 1000}))
+
+	// normalised front of {0.25,0.75}, {0.5,0.5}, {0.75,0.25} against reference point {1.1,1.1}
+	g.Expect(actualQuality.Hypervolume).To(BeNumerically("~", 0.85*0.35+0.6*0.25+0.35*0.25, 1e-9))
+	g.Expect(actualQuality.Spacing).To(BeNumerically("~", 0, 1e-9))
+	g.Expect(actualQuality.Spread).To(BeNumerically("~", 0, 1e-9))
+	g.Expect(actualQuality.GenerationalDistance).To(BeZero())
+	g.Expect(actualQuality.InvertedGenerationalDistance).To(BeZero())
+
+	// then
+	g.Expect(archiveUnderTest.FrontQuality(FrontReference{})).To(Equal(FrontQuality{}))
+}
+
+func TestNonDominanceModelArchive_FrontQuality_ComparableAgainstSharedReference(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	betterArchive := New()
+	betterArchive.AttemptToArchiveState(buildModelState(0, 100, 3000))
+	betterArchive.AttemptToArchiveState(buildModelState(1, 300, 1000))
+
+	worseArchive := New()
+	worseArchive.AttemptToArchiveState(buildModelState(0, 200, 4000))
+	worseArchive.AttemptToArchiveState(buildModelState(1, 400, 2000))
+
+	// when
+	reference := NewFrontReference([]int{0, 1}, betterArchive.Front(), worseArchive.Front())
+	betterQuality := betterArchive.FrontQuality(reference)
+	worseQuality := worseArchive.FrontQuality(reference)
+
+	// then
+	g.Expect(reference.Ideal).To(Equal(dominance.Float64Vector{100, 1000}))
+	g.Expect(reference.Nadir).To(Equal(dominance.Float64Vector{400, 4000}))
+	g.Expect(reference.Front).To(HaveLen(2))
+
+	g.Expect(betterQuality.Hypervolume).To(BeNumerically(">", worseQuality.Hypervolume))
+	g.Expect(betterQuality.GenerationalDistance).To(BeZero())
+	g.Expect(worseQuality.GenerationalDistance).To(BeNumerically(">", 0))
+	g.Expect(worseQuality.InvertedGenerationalDistance).To(BeNumerically(">", 0))
+}
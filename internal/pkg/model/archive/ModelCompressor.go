@@ -4,6 +4,7 @@ package archive
 
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
 	"github.com/LindsayBradford/crem/pkg/archive"
 	"github.com/LindsayBradford/crem/pkg/dominance"
 )
@@ -21,12 +22,20 @@ func compressVariables(model model.Model) dominance.Float64Vector {
 	variableKeys := model.NameMappedVariables().SortedKeys()
 	compressedVariables := *dominance.NewFloat64(len(variableKeys))
 	for index := range variableKeys {
-		valueToCompress := model.DecisionVariable(variableKeys[index]).Value()
-		compressedVariables[index] = valueToCompress
+		variableToCompress := model.DecisionVariable(variableKeys[index])
+		compressedVariables[index] = compressedValueOf(variableToCompress)
 	}
 	return compressedVariables
 }
 
+// compressedValueOf orients the variable's value for minimisation, as required for dominance checking.
+func compressedValueOf(decisionVariable variable.DecisionVariable) float64 {
+	if variable.SenseOf(decisionVariable) == variable.Maximised {
+		return -1 * decisionVariable.Value()
+	}
+	return decisionVariable.Value()
+}
+
 func compressActions(model model.Model) archive.BooleanArchive {
 	actions := model.ManagementActions()
 	compressedActions := *archive.New(len(actions))
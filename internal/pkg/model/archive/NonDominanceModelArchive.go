@@ -20,6 +20,7 @@ const (
 	RejectedWithDuplicateEntryDetected
 	canBeStored
 	StoredForcingDominatingStateRemoval
+	RejectedAsInfeasible
 )
 
 func (sr StorageResult) String() string {
@@ -34,6 +35,8 @@ func (sr StorageResult) String() string {
 		return "Rejected, solution is already archived"
 	case StoredForcingDominatingStateRemoval:
 		return "Stored, forcing dominating solutions out of archive"
+	case RejectedAsInfeasible:
+		return "Rejected, solution breaks decision variable bounds"
 	}
 	return "Can be stored -- but why are you seeing this?!?"
 }
@@ -55,7 +58,7 @@ type NonDominanceModelArchive struct {
 
 func (a *NonDominanceModelArchive) Initialise() *NonDominanceModelArchive {
 	a.archive = make([]*CompressedModelState, 0)
-	a.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	a.ResetRandomNumberGenerator()
 	return a
 }
 
@@ -6,12 +6,18 @@ import (
 	errors2 "errors"
 	"fmt"
 	catchmentDataSet "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/biodiversity"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/carbonsequestration"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/dissolvednitrogen"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/dissolvedphosphorus"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/opportunitycost"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/particulatephosphorus"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/totalnitrogen"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/totalphosphorus"
 	assert "github.com/LindsayBradford/crem/pkg/assert/debug"
 	"github.com/LindsayBradford/crem/pkg/attributes"
 	"math"
+	"strings"
 
 	"github.com/LindsayBradford/crem/internal/pkg/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
@@ -91,35 +97,39 @@ func (m *CoreModel) SetParameters(params baseParameters.Map) error {
 	return m.parameters.ValidationErrors()
 }
 
+// variableLimitParameters lists the model parameters that bound a decision variable, of which only one may be set.
+var variableLimitParameters = []string{
+	parameters.MaximumSedimentProduction,
+	parameters.MaximumParticulateNitrogenProduction,
+	parameters.MaximumDissolvedNitrogenProduction,
+	parameters.MaximumTotalNitrogenProduction,
+	parameters.MaximumParticulatePhosphorusProduction,
+	parameters.MaximumDissolvedPhosphorusProduction,
+	parameters.MaximumTotalPhosphorusProduction,
+	parameters.MaximumImplementationCost,
+	parameters.MaximumOpportunityCost,
+	parameters.MinimumCarbonSequestration,
+	parameters.MinimumBiodiversityScore,
+}
+
 func (m *CoreModel) validateModelParameters() {
 	boundVariableNumber := 0
-	if m.parameters.HasEntry(parameters.MaximumSedimentProduction) {
-		boundVariableNumber++
-	}
-	if m.parameters.HasEntry(parameters.MaximumParticulateNitrogenProduction) {
-		boundVariableNumber++
-	}
-	if m.parameters.HasEntry(parameters.MaximumDissolvedNitrogenProduction) {
-		boundVariableNumber++
-	}
-	if m.parameters.HasEntry(parameters.MaximumTotalNitrogenProduction) {
-		boundVariableNumber++
-	}
-	if m.parameters.HasEntry(parameters.MaximumImplementationCost) {
-		boundVariableNumber++
-	}
-	if m.parameters.HasEntry(parameters.MaximumOpportunityCost) {
-		boundVariableNumber++
+	for _, limitParameter := range variableLimitParameters {
+		if m.parameters.HasEntry(limitParameter) {
+			boundVariableNumber++
+		}
 	}
 
 	if boundVariableNumber > 1 {
-		errorText := fmt.Sprintf("Only one of [%s], [%s], [%s], [%s], [%s] or [%s] allowed as variable limit.",
-			parameters.MaximumSedimentProduction,
-			parameters.MaximumParticulateNitrogenProduction,
-			parameters.MaximumDissolvedNitrogenProduction,
-			parameters.MaximumTotalNitrogenProduction,
-			parameters.MaximumImplementationCost,
-			parameters.MaximumOpportunityCost,
+		lastLimit := len(variableLimitParameters) - 1
+		quotedLimits := make([]string, lastLimit)
+		for index, limitParameter := range variableLimitParameters[:lastLimit] {
+			quotedLimits[index] = fmt.Sprintf("[%s]", limitParameter)
+		}
+
+		errorText := fmt.Sprintf("Only one of %s or [%s] allowed as variable limit.",
+			strings.Join(quotedLimits, ", "),
+			variableLimitParameters[lastLimit],
 		)
 
 		m.parameters.AddValidationErrorMessage(errorText)
@@ -216,6 +226,84 @@ func (m *CoreModel) buildDecisionVariables() {
 		particulateNitrogen, dissolvedNitrogen, totalNitrogen,
 		implementationCost, opportunityCost,
 	)
+
+	m.buildPhosphorusDecisionVariables()
+	m.buildCoBenefitDecisionVariables()
+}
+
+func (m *CoreModel) buildPhosphorusDecisionVariables() {
+	actionColumns := new(actions.Container).WithActionsTable(m.actionsTable)
+
+	if !actionColumns.HasPhosphorusAttributes() {
+		m.panicIfLimitWithoutColumn(parameters.MaximumParticulatePhosphorusProduction, actions.ParticulatePhosphorusOriginalAttribute)
+		m.panicIfLimitWithoutColumn(parameters.MaximumDissolvedPhosphorusProduction, actions.DissolvedPhosphorusOriginalAttribute)
+		m.panicIfLimitWithoutColumn(parameters.MaximumTotalPhosphorusProduction, actions.ParticulatePhosphorusOriginalAttribute)
+		return
+	}
+
+	particulatePhosphorus := new(particulatephosphorus.ParticulatePhosphorusProduction).
+		Initialise(m.planningUnitTable, m.actionsTable, m.parameters).
+		WithObservers(m)
+
+	if m.parameters.HasEntry(parameters.MaximumParticulatePhosphorusProduction) {
+		particulatePhosphorus.SetMaximum(m.parameters.GetFloat64(parameters.MaximumParticulatePhosphorusProduction))
+	}
+
+	dissolvedPhosphorus := new(dissolvedphosphorus.DissolvedPhosphorusProduction).
+		Initialise(m.planningUnitTable, m.actionsTable, m.parameters).
+		WithObservers(m)
+
+	if m.parameters.HasEntry(parameters.MaximumDissolvedPhosphorusProduction) {
+		dissolvedPhosphorus.SetMaximum(m.parameters.GetFloat64(parameters.MaximumDissolvedPhosphorusProduction))
+	}
+
+	totalPhosphorus := new(totalphosphorus.TotalPhosphorusProduction).
+		WithBasePhosphorusVariables(particulatePhosphorus, dissolvedPhosphorus).
+		Initialise(m.planningUnitTable, m.actionsTable, m.parameters).
+		WithObservers(m)
+
+	if m.parameters.HasEntry(parameters.MaximumTotalPhosphorusProduction) {
+		totalPhosphorus.SetMaximum(m.parameters.GetFloat64(parameters.MaximumTotalPhosphorusProduction))
+	}
+
+	m.ContainedDecisionVariables.Add(particulatePhosphorus, dissolvedPhosphorus, totalPhosphorus)
+}
+
+func (m *CoreModel) buildCoBenefitDecisionVariables() {
+	actionColumns := new(actions.Container).WithActionsTable(m.actionsTable)
+
+	if actionColumns.HasOptionalAttribute(actions.CarbonSequestrationAttribute) {
+		carbonSequestration := new(carbonsequestration.CarbonSequestration).
+			Initialise().WithObservers(m)
+
+		if m.parameters.HasEntry(parameters.MinimumCarbonSequestration) {
+			carbonSequestration.SetMinimum(m.parameters.GetFloat64(parameters.MinimumCarbonSequestration))
+		}
+
+		m.ContainedDecisionVariables.Add(carbonSequestration)
+	} else {
+		m.panicIfLimitWithoutColumn(parameters.MinimumCarbonSequestration, actions.CarbonSequestrationAttribute)
+	}
+
+	if actionColumns.HasOptionalAttribute(actions.BiodiversityScoreAttribute) {
+		biodiversityScore := new(biodiversity.BiodiversityScore).
+			Initialise().WithObservers(m)
+
+		if m.parameters.HasEntry(parameters.MinimumBiodiversityScore) {
+			biodiversityScore.SetMinimum(m.parameters.GetFloat64(parameters.MinimumBiodiversityScore))
+		}
+
+		m.ContainedDecisionVariables.Add(biodiversityScore)
+	} else {
+		m.panicIfLimitWithoutColumn(parameters.MinimumBiodiversityScore, actions.BiodiversityScoreAttribute)
+	}
+}
+
+func (m *CoreModel) panicIfLimitWithoutColumn(limitParameter string, columnName string) {
+	if m.parameters.HasEntry(limitParameter) {
+		panic(errors.New("Parameter [" + limitParameter + "] requires data set table [" +
+			catchmentDataSet.ActionsTableName + "] to have a [" + columnName + "] column"))
+	}
 }
 
 func (m *CoreModel) buildAndObserveManagementActions() {
@@ -323,6 +411,21 @@ func (m *CoreModel) InitialiseActions(initialisationType model.InitialisationTyp
 	} else if m.parameters.HasEntry(parameters.MaximumTotalNitrogenProduction) {
 		m.note("Randomly initialising for Maximum total nitrogen production limit.")
 		m.InitialiseAllActionsToActive()
+	} else if m.parameters.HasEntry(parameters.MaximumParticulatePhosphorusProduction) {
+		m.note("Randomly initialising for Maximum particulate phosphorus production limit.")
+		m.InitialiseAllActionsToActive()
+	} else if m.parameters.HasEntry(parameters.MaximumDissolvedPhosphorusProduction) {
+		m.note("Randomly initialising for Maximum dissolved phosphorus production limit.")
+		m.InitialiseAllActionsToActive()
+	} else if m.parameters.HasEntry(parameters.MaximumTotalPhosphorusProduction) {
+		m.note("Randomly initialising for Maximum total phosphorus production limit.")
+		m.InitialiseAllActionsToActive()
+	} else if m.parameters.HasEntry(parameters.MinimumCarbonSequestration) {
+		m.note("Randomly initialising for Minimum carbon sequestration limit.")
+		m.InitialiseAllActionsToActive()
+	} else if m.parameters.HasEntry(parameters.MinimumBiodiversityScore) {
+		m.note("Randomly initialising for Minimum biodiversity score limit.")
+		m.InitialiseAllActionsToActive()
 	}
 
 	m.initialising = false
@@ -351,6 +454,21 @@ func (m *CoreModel) Randomize() {
 	} else if m.parameters.HasEntry(parameters.MaximumTotalNitrogenProduction) {
 		m.note("Randomly initialising for Maximum total nitrogen production limit.")
 		m.RandomlyValidlyDeactivateActions()
+	} else if m.parameters.HasEntry(parameters.MaximumParticulatePhosphorusProduction) {
+		m.note("Randomly initialising for Maximum particulate phosphorus production limit.")
+		m.RandomlyValidlyDeactivateActions()
+	} else if m.parameters.HasEntry(parameters.MaximumDissolvedPhosphorusProduction) {
+		m.note("Randomly initialising for Maximum dissolved phosphorus production limit.")
+		m.RandomlyValidlyDeactivateActions()
+	} else if m.parameters.HasEntry(parameters.MaximumTotalPhosphorusProduction) {
+		m.note("Randomly initialising for Maximum total phosphorus production limit.")
+		m.RandomlyValidlyDeactivateActions()
+	} else if m.parameters.HasEntry(parameters.MinimumCarbonSequestration) {
+		m.note("Randomly initialising for Minimum carbon sequestration limit.")
+		m.RandomlyValidlyDeactivateActions()
+	} else if m.parameters.HasEntry(parameters.MinimumBiodiversityScore) {
+		m.note("Randomly initialising for Minimum biodiversity score limit.")
+		m.RandomlyValidlyDeactivateActions()
 	} else {
 		m.note("Randomly initialising for unbounded (no limits).")
 		m.randomlyInitialiseActionsUnbounded()
@@ -487,6 +605,12 @@ func (m *CoreModel) SetManagementActionUnobserved(index int, value bool) {
 	}
 }
 
+// EvaluateManagementActionToggle reports the decision variable changes staged by the per-planning-unit commands of
+// each variable on observing the toggle of the management action at index, without applying them.
+func (m *CoreModel) EvaluateManagementActionToggle(index int) model.DecisionVariableDeltas {
+	return model.EvaluateToggleOf(m.ManagementActions()[index], m.ContainedDecisionVariables.UndoableDecisionVariables)
+}
+
 func (m *CoreModel) PlanningUnits() planningunit.Ids {
 	_, rows := m.planningUnitTable.ColumnAndRowSize()
 	planningUnits := make(planningunit.Ids, rows)
@@ -606,6 +730,11 @@ func (m *CoreModel) capChangeOverRange(value float64) float64 {
 	return math.Max(0, value)
 }
 
+// SeedRandomNumbers drives the random selection of management actions to toggle from seed.
+func (m *CoreModel) SeedRandomNumbers(seed int64) {
+	m.managementActions.SeedRandomNumbers(seed)
+}
+
 func (m *CoreModel) DeepClone() model.Model {
 	clone := *m
 	clone.managementActions.SetRandomNumberGenerator(rand.NewTimeSeeded())
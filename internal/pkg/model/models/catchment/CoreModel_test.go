@@ -5,10 +5,15 @@ package catchment
 import (
 	model2 "github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/biodiversity"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/carbonsequestration"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/dissolvednitrogen"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/dissolvedphosphorus"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/opportunitycost"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/particulatenitrogen"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/particulatephosphorus"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/totalnitrogen"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/totalphosphorus"
 	"testing"
 
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
@@ -28,6 +33,8 @@ import (
 const expectedName = "CatchmentModel"
 const expectedMaximumImplementationCost = 65_000.0
 const expectedMaximumSedimentProduction = 65_000.0
+const expectedMinimumCarbonSequestration = 30.0
+const expectedMaximumTotalPhosphorusProduction = 2.0
 
 const equalTo = "=="
 
@@ -608,6 +615,251 @@ func TestCoreModel_MoreThanOneBoundedParameter_ParameterErrors(t *testing.T) {
 	g.Expect(errors).To(Not(BeNil()))
 }
 
+func TestCoreModel_NoCoBenefitColumns_NoCoBenefitVariables(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	modelUnderTest := buildTestingModel(g)
+	actualVariables := *modelUnderTest.NameMappedVariables()
+
+	g.Expect(actualVariables).To(Not(HaveKey(carbonsequestration.VariableName)))
+	g.Expect(actualVariables).To(Not(HaveKey(biodiversity.VariableName)))
+}
+
+func TestCoreModel_CoBenefitLimitWithoutColumn_Panics(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	sourceDataSet := buildTestingModelDataSet(g)
+	parametersUnderTest := parameters.Map{
+		"MinimumCarbonSequestration": expectedMinimumCarbonSequestration,
+	}
+
+	newModelRunner := func() {
+		buildModelUnderTest(sourceDataSet, parametersUnderTest, g)
+	}
+
+	g.Expect(newModelRunner).To(Panic())
+}
+
+func TestCoreModel_CoBenefits_ToggleAsExpected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	modelUnderTest := buildCoBenefitsTestingModel(g, parameters.Map{})
+
+	carbonSequestration := modelUnderTest.DecisionVariable(carbonsequestration.VariableName)
+	biodiversityScore := modelUnderTest.DecisionVariable(biodiversity.VariableName)
+
+	g.Expect(carbonSequestration.Value()).To(BeNumerically(equalTo, 0))
+	g.Expect(biodiversityScore.Value()).To(BeNumerically(equalTo, 0))
+
+	modelUnderTest.ToggleAction(17, actions.HillSlopeRestorationType)
+	modelUnderTest.AcceptChange()
+
+	g.Expect(carbonSequestration.Value()).To(BeNumerically(equalTo, 12.5))
+	g.Expect(biodiversityScore.Value()).To(BeNumerically(equalTo, 1.5))
+
+	modelUnderTest.ToggleAction(17, actions.GullyRestorationType)
+	modelUnderTest.AcceptChange()
+
+	g.Expect(carbonSequestration.Value()).To(BeNumerically(equalTo, 12.5))
+	g.Expect(biodiversityScore.Value()).To(BeNumerically(equalTo, 2))
+
+	modelUnderTest.ToggleAction(17, actions.HillSlopeRestorationType)
+	modelUnderTest.RevertChange()
+
+	g.Expect(carbonSequestration.Value()).To(BeNumerically(equalTo, 12.5))
+	g.Expect(biodiversityScore.Value()).To(BeNumerically(equalTo, 2))
+
+	verifyActionToggle(t, modelUnderTest, planningunit.Id(18), actions.RiverBankRestorationType, g)
+}
+
+func TestCoreModel_EvaluateManagementActionToggle_MatchesAppliedToggle(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildPhosphorusTestingModel(g, parameters.Map{})
+
+	for index, actionUnderTest := range modelUnderTest.ManagementActions() {
+		valuesBefore := decisionVariableValuesOf(modelUnderTest)
+		wasActive := actionUnderTest.IsActive()
+
+		// when
+		deltas := modelUnderTest.EvaluateManagementActionToggle(index)
+
+		// then
+		g.Expect(actionUnderTest.IsActive()).To(Equal(wasActive))
+		g.Expect(decisionVariableValuesOf(modelUnderTest)).To(Equal(valuesBefore))
+		g.Expect(deltas).To(HaveLen(len(valuesBefore)))
+
+		// when
+		modelUnderTest.SetManagementAction(index, !wasActive)
+
+		// then
+		for variableName, valueBefore := range valuesBefore {
+			appliedChange := modelUnderTest.DecisionVariable(variableName).Value() - valueBefore
+			g.Expect(deltas.Delta(variableName)).To(BeNumerically("~", appliedChange, 1e-9),
+				"variable [%s], action [%d]", variableName, index)
+		}
+	}
+}
+
+func decisionVariableValuesOf(modelUnderTest *CoreModel) map[string]float64 {
+	values := make(map[string]float64)
+	for _, decisionVariable := range modelUnderTest.CreationOrderedVariables() {
+		values[decisionVariable.Name()] = decisionVariable.Value()
+	}
+	return values
+}
+
+func TestCoreModel_CoBenefits_CompressedForMinimisation(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	modelUnderTest := buildCoBenefitsTestingModel(g, parameters.Map{})
+
+	modelUnderTest.ToggleAction(17, actions.HillSlopeRestorationType)
+	modelUnderTest.AcceptChange()
+
+	compressedModel := new(archive.ModelCompressor).Compress(modelUnderTest)
+	variableKeys := modelUnderTest.NameMappedVariables().SortedKeys()
+
+	for index, key := range variableKeys {
+		if key == carbonsequestration.VariableName {
+			g.Expect(compressedModel.Variables[index]).To(BeNumerically(equalTo, -12.5))
+		}
+	}
+	g.Expect(compressedModel.MatchesStateOf(modelUnderTest)).To(BeTrue())
+}
+
+func TestCoreModel_MinimumCarbonSequestration_RandomisationStaysValid(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	parametersUnderTest := parameters.Map{
+		"MinimumCarbonSequestration": expectedMinimumCarbonSequestration,
+	}
+
+	modelUnderTest := buildCoBenefitsTestingModel(g, parametersUnderTest)
+
+	modelUnderTest.InitialiseActions(model2.Random)
+	modelUnderTest.Randomize()
+
+	state, stateErrors := modelUnderTest.StateIsValid()
+
+	if stateErrors != nil {
+		t.Log(stateErrors)
+	}
+	g.Expect(state).To(BeTrue())
+
+	carbonSequestration := modelUnderTest.DecisionVariable(carbonsequestration.VariableName)
+	g.Expect(carbonSequestration.Value()).To(BeNumerically(">=", expectedMinimumCarbonSequestration))
+}
+
+func TestCoreModel_NoPhosphorusColumns_NoPhosphorusVariables(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	modelUnderTest := buildTestingModel(g)
+	actualVariables := *modelUnderTest.NameMappedVariables()
+
+	g.Expect(actualVariables).To(Not(HaveKey(particulatephosphorus.VariableName)))
+	g.Expect(actualVariables).To(Not(HaveKey(dissolvedphosphorus.VariableName)))
+	g.Expect(actualVariables).To(Not(HaveKey(totalphosphorus.VariableName)))
+}
+
+func TestCoreModel_PhosphorusLimitWithoutColumns_Panics(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	sourceDataSet := buildTestingModelDataSet(g)
+	parametersUnderTest := parameters.Map{
+		"MaximumTotalPhosphorusProduction": expectedMaximumTotalPhosphorusProduction,
+	}
+
+	newModelRunner := func() {
+		buildModelUnderTest(sourceDataSet, parametersUnderTest, g)
+	}
+
+	g.Expect(newModelRunner).To(Panic())
+}
+
+func TestCoreModel_PhosphorusPlanningUnitValues_AsExpected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	modelUnderTest := buildPhosphorusTestingModel(g, parameters.Map{})
+
+	g.Expect(modelUnderTest.DecisionVariable(particulatephosphorus.VariableName).Value()).To(BeNumerically(equalTo, 0.557))
+	g.Expect(modelUnderTest.DecisionVariable(dissolvedphosphorus.VariableName).Value()).To(BeNumerically(equalTo, 2.016))
+	g.Expect(modelUnderTest.DecisionVariable(totalphosphorus.VariableName).Value()).To(BeNumerically(equalTo, 2.573))
+
+	verifyActionToggle(t, modelUnderTest, planningunit.Id(18), actions.RiverBankRestorationType, g)
+	verifyActionToggle(t, modelUnderTest, planningunit.Id(18), actions.GullyRestorationType, g)
+	verifyActionToggle(t, modelUnderTest, planningunit.Id(18), actions.HillSlopeRestorationType, g)
+	verifyActionToggle(t, modelUnderTest, planningunit.Id(22), actions.WetlandsEstablishmentType, g)
+}
+
+func TestCoreModel_TotalPhosphorus_NoRoundingErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+	const planningUnitUnderTest = 18
+	const wetlandsPlanningUnitUnderTest = 22
+
+	modelUnderTest := buildPhosphorusTestingModel(g, parameters.Map{})
+
+	planningUnit := planningunit.Id(planningUnitUnderTest)
+	wetlandsPlanningUnit := planningunit.Id(wetlandsPlanningUnitUnderTest)
+
+	for index := 0; index < 1_000; index++ {
+		modelUnderTest.ToggleAction(planningUnit, actions.RiverBankRestorationType)
+		modelUnderTest.AcceptChange()
+
+		modelUnderTest.ToggleAction(planningUnit, actions.HillSlopeRestorationType)
+		modelUnderTest.AcceptChange()
+
+		modelUnderTest.ToggleAction(wetlandsPlanningUnit, actions.WetlandsEstablishmentType)
+		modelUnderTest.AcceptChange()
+	}
+
+	totalValue := modelUnderTest.DecisionVariable(totalphosphorus.VariableName).Value()
+	particulateValue := modelUnderTest.DecisionVariable(particulatephosphorus.VariableName).Value()
+	dissolvedValue := modelUnderTest.DecisionVariable(dissolvedphosphorus.VariableName).Value()
+
+	g.Expect(totalValue).To(BeNumerically(equalTo, math.RoundFloat(particulateValue+dissolvedValue, 3)))
+}
+
+func TestCoreModel_MaximumTotalPhosphorus_RandomisationStaysValid(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	parametersUnderTest := parameters.Map{
+		"MaximumTotalPhosphorusProduction": expectedMaximumTotalPhosphorusProduction,
+	}
+
+	modelUnderTest := buildPhosphorusTestingModel(g, parametersUnderTest)
+
+	modelUnderTest.InitialiseActions(model2.Random)
+	modelUnderTest.Randomize()
+
+	state, stateErrors := modelUnderTest.StateIsValid()
+
+	if stateErrors != nil {
+		t.Log(stateErrors)
+	}
+	g.Expect(state).To(BeTrue())
+
+	totalPhosphorus := modelUnderTest.DecisionVariable(totalphosphorus.VariableName)
+	g.Expect(totalPhosphorus.Value()).To(BeNumerically("<=", expectedMaximumTotalPhosphorusProduction))
+}
+
+func buildPhosphorusTestingModel(g *GomegaWithT, parametersUnderTest parameters.Map) *CoreModel {
+	sourceDataSet := csv.NewDataSet("CatchmentModel")
+	loadError := sourceDataSet.Load("testdata/PhosphorusModel.csv")
+	g.Expect(loadError).To(BeNil())
+
+	return buildModelUnderTest(sourceDataSet, parametersUnderTest, g)
+}
+
+func buildCoBenefitsTestingModel(g *GomegaWithT, parametersUnderTest parameters.Map) *CoreModel {
+	sourceDataSet := csv.NewDataSet("CatchmentModel")
+	loadError := sourceDataSet.Load("testdata/CoBenefitsModel.csv")
+	g.Expect(loadError).To(BeNil())
+
+	return buildModelUnderTest(sourceDataSet, parametersUnderTest, g)
+}
+
 func buildTestingModel(g *GomegaWithT) *CoreModel {
 	sourceDataSet := buildTestingModelDataSet(g)
 
@@ -13,13 +13,18 @@ import (
 
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/excel"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
+	catchmentDataSet "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	baseParameters "github.com/LindsayBradford/crem/internal/pkg/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/rand"
 	"github.com/LindsayBradford/crem/pkg/threading"
 )
 
-var _ model.Model = NewModel()
+var (
+	_ model.Model               = NewModel()
+	_ model.DataSourceValidator = NewModel()
+	_ model.DataSourceSharer    = NewModel()
+)
 
 func NewModel() *Model {
 	newModel := new(Model)
@@ -35,6 +40,7 @@ func NewModel() *Model {
 
 type Model struct {
 	sourceDataLoaded   bool
+	sourceDataShared   bool
 	sourceDataSet      dataset.DataSet
 	oleFunctionWrapper threading.MainThreadFunctionWrapper
 	CoreModel
@@ -64,6 +70,39 @@ func (m *Model) Initialise(initialisationType model.InitialisationType) {
 	m.CoreModel.Initialise(initialisationType)
 }
 
+// ValidateDataSource loads the model's source data set, checking it holds the tables and columns the model reads,
+// without initialising the model.
+func (m *Model) ValidateDataSource() error {
+	if !m.sourceDataLoaded {
+		if loadError := m.loadSourceDataSet(); loadError != nil {
+			return loadError
+		}
+		m.sourceDataLoaded = true
+	}
+	return catchmentDataSet.ValidateSchema(m.sourceDataSet)
+}
+
+// SharedDataSource returns the model's source data set, loading it first where not yet loaded, for sharing with
+// other models built from the same source data.
+func (m *Model) SharedDataSource() (dataset.DataSet, error) {
+	if !m.sourceDataLoaded {
+		if loadError := m.loadSourceDataSet(); loadError != nil {
+			return nil, loadError
+		}
+		m.sourceDataLoaded = true
+	}
+	return m.sourceDataSet, nil
+}
+
+// ShareDataSource has the model use dataSet, already loaded by another model built from the same source data, as its
+// source data set.  The data set is left to that other model to tear down.
+func (m *Model) ShareDataSource(dataSet dataset.DataSet) {
+	m.sourceDataSet = dataSet
+	m.WithSourceDataSet(m.sourceDataSet)
+	m.sourceDataLoaded = true
+	m.sourceDataShared = true
+}
+
 func (m *Model) Randomize() {
 	m.note("Randomizing")
 	m.CoreModel.Randomize()
@@ -117,8 +156,21 @@ func (m *Model) loadExcelSourceDataSet(dataSourcePath string) error {
 	return nil
 }
 
+// DataSourceFilePaths returns the path of the file holding the model's source data set, followed by any table
+// files it names.
+func (m *Model) DataSourceFilePaths() ([]string, error) {
+	dataSourcePath := m.deriveDataSourcePath()
+	if strings.ToLower(path.Ext(dataSourcePath)) == ".csv" {
+		return csv.FilePathsOf(dataSourcePath)
+	}
+	return []string{dataSourcePath}, nil
+}
+
 func (m *Model) deriveDataSourcePath() string {
 	relativeFilePath := m.parameters.GetString(parameters.DataSourcePath)
+	if filepath.IsAbs(relativeFilePath) {
+		return relativeFilePath
+	}
 	workingDirectory, _ := os.Getwd()
 	return filepath.Join(workingDirectory, relativeFilePath)
 }
@@ -132,5 +184,8 @@ func (m *Model) DeepClone() model.Model {
 }
 
 func (m *Model) TearDown() {
+	if m.sourceDataSet == nil || m.sourceDataShared {
+		return
+	}
 	m.sourceDataSet.Teardown()
 }
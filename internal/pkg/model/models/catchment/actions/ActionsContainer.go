@@ -5,6 +5,7 @@ package actions
 import (
 	"fmt"
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 	assert "github.com/LindsayBradford/crem/pkg/assert/debug"
 	"strconv"
@@ -15,7 +16,7 @@ type ActionType string
 
 const (
 	subCatchmentIndex                = 0
-	filterIndex                      = 1
+	filterIndex                      = dataset.ActionsTypeColumn
 	opportunityCostIndex             = 2
 	implementationCostIndex          = 3
 	particulateNitrogenOriginalIndex = 4
@@ -29,7 +30,7 @@ const (
 	dissolvedNitrogenActionedIndex            = 11
 	dissolvedNitrogenRemovalEfficiencyIndex   = 12
 	particulateNitrogenRemovalEfficiencyIndex = 13
-	sedimentRemovalEfficiencyIndex            = 14
+	sedimentRemovalEfficiencyIndex            = dataset.ActionsSedimentRemovalEfficiencyColumn
 
 	RiparianType  ActionType = "Riparian"
 	HillSlopeType ActionType = "Hillslope"
@@ -52,11 +53,46 @@ const (
 	DissolvedNitrogenRemovalEfficiency   = "DissolvedNitrogenRemovalEfficiency"
 	ParticulateNitrogenRemovalEfficiency = "ParticulateNitrogenRemovalEfficiency"
 	SedimentRemovalEfficiency            = "SedimentRemovalEfficiency"
+
+	CarbonSequestrationAttribute = "CarbonSequestration"
+	BiodiversityScoreAttribute   = "BiodiversityScore"
+
+	ParticulatePhosphorusOriginalAttribute = "ParticulatePhosphorusOriginal"
+	ParticulatePhosphorusActionedAttribute = "ParticulatePhosphorusActioned"
+	DissolvedPhosphorusOriginalAttribute   = "DissolvedPhosphorusOriginal"
+	DissolvedPhosphorusActionedAttribute   = "DissolvedPhosphorusActioned"
+	DissolvedPhosphorusRemovalEfficiency   = "DissolvedPhosphorusRemovalEfficiency"
+	ParticulatePhosphorusRemovalEfficiency = "ParticulatePhosphorusRemovalEfficiency"
 )
 
+// optionalHeadings maps the optional column headings of an actions table to the attributes they supply.
+var optionalHeadings = map[string]string{
+	"CarbonSequestration": CarbonSequestrationAttribute,
+	"BiodiversityScore":   BiodiversityScoreAttribute,
+
+	"ParticulatePhosphorusOriginal": ParticulatePhosphorusOriginalAttribute,
+	"ParticulatePhosphorusActioned": ParticulatePhosphorusActionedAttribute,
+	"DissolvedPhosphorusOriginal":   DissolvedPhosphorusOriginalAttribute,
+	"DissolvedPhosphorusActioned":   DissolvedPhosphorusActionedAttribute,
+	"DPRemovalEfficiency":           DissolvedPhosphorusRemovalEfficiency,
+	"PPRemovalEfficiency":           ParticulatePhosphorusRemovalEfficiency,
+}
+
+// phosphorusAttributes are the optional attributes that must all be supplied for phosphorus to be modelled.
+var phosphorusAttributes = []string{
+	ParticulatePhosphorusOriginalAttribute,
+	ParticulatePhosphorusActionedAttribute,
+	DissolvedPhosphorusOriginalAttribute,
+	DissolvedPhosphorusActionedAttribute,
+	DissolvedPhosphorusRemovalEfficiency,
+	ParticulatePhosphorusRemovalEfficiency,
+}
+
 type Container struct {
 	filter     ActionType
 	actionsMap map[string]float64
+
+	optionalAttributes map[string]bool
 }
 
 func (c *Container) WithFilter(filter ActionType) *Container {
@@ -68,6 +104,8 @@ func (c *Container) WithActionsTable(actionsTable tables.CsvTable) *Container {
 	_, rowCount := actionsTable.ColumnAndRowSize()
 	c.actionsMap = make(map[string]float64, 0)
 
+	optionalIndexes := c.deriveOptionalAttributeIndexes(actionsTable)
+
 	for rowNumber := uint(0); rowNumber < rowCount; rowNumber++ {
 
 		sourceType := ActionType(actionsTable.CellString(filterIndex, rowNumber))
@@ -101,10 +139,45 @@ func (c *Container) WithActionsTable(actionsTable tables.CsvTable) *Container {
 		mapAttribute(dissolvedNitrogenRemovalEfficiencyIndex, DissolvedNitrogenRemovalEfficiency)
 		mapAttribute(particulateNitrogenRemovalEfficiencyIndex, ParticulateNitrogenRemovalEfficiency)
 		mapAttribute(sedimentRemovalEfficiencyIndex, SedimentRemovalEfficiency)
+
+		for attribute, index := range optionalIndexes {
+			mapAttribute(index, attribute)
+		}
 	}
 	return c
 }
 
+func (c *Container) deriveOptionalAttributeIndexes(actionsTable tables.CsvTable) map[string]uint {
+	c.optionalAttributes = make(map[string]bool, 0)
+	optionalIndexes := make(map[string]uint, 0)
+
+	for index, heading := range actionsTable.Header() {
+		attribute, isOptional := optionalHeadings[strings.TrimSpace(heading)]
+		if !isOptional {
+			continue
+		}
+		optionalIndexes[attribute] = uint(index)
+		c.optionalAttributes[attribute] = true
+	}
+
+	return optionalIndexes
+}
+
+// HasOptionalAttribute reports whether the actions table supplied the optional column for the attribute given.
+func (c *Container) HasOptionalAttribute(attribute string) bool {
+	return c.optionalAttributes[attribute]
+}
+
+// HasPhosphorusAttributes reports whether the actions table supplied every optional column needed to model phosphorus.
+func (c *Container) HasPhosphorusAttributes() bool {
+	for _, attribute := range phosphorusAttributes {
+		if !c.HasOptionalAttribute(attribute) {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *Container) MapValue(key string) float64 {
 	mappedValue := c.actionsMap[key]
 	failureMsg := fmt.Sprintf("Container doesn't have value mapped to key [%s]", key)
@@ -223,6 +296,46 @@ func (c *Container) sedimentNitrogenRemovalEfficiency(planningUnit planningunit.
 	return c.actionsMap[key]
 }
 
+func (c *Container) carbonSequestration(planningUnit planningunit.Id) float64 {
+	key := c.DeriveMapKey(planningUnit, c.filter, CarbonSequestrationAttribute)
+	return c.actionsMap[key]
+}
+
+func (c *Container) biodiversityScore(planningUnit planningunit.Id) float64 {
+	key := c.DeriveMapKey(planningUnit, c.filter, BiodiversityScoreAttribute)
+	return c.actionsMap[key]
+}
+
+func (c *Container) originalParticulatePhosphorus(planningUnit planningunit.Id) float64 {
+	key := c.DeriveMapKey(planningUnit, c.filter, ParticulatePhosphorusOriginalAttribute)
+	return c.actionsMap[key]
+}
+
+func (c *Container) actionedParticulatePhosphorus(planningUnit planningunit.Id) float64 {
+	key := c.DeriveMapKey(planningUnit, c.filter, ParticulatePhosphorusActionedAttribute)
+	return c.actionsMap[key]
+}
+
+func (c *Container) originalDissolvedPhosphorus(planningUnit planningunit.Id) float64 {
+	key := c.DeriveMapKey(planningUnit, c.filter, DissolvedPhosphorusOriginalAttribute)
+	return c.actionsMap[key]
+}
+
+func (c *Container) actionedDissolvedPhosphorus(planningUnit planningunit.Id) float64 {
+	key := c.DeriveMapKey(planningUnit, c.filter, DissolvedPhosphorusActionedAttribute)
+	return c.actionsMap[key]
+}
+
+func (c *Container) dissolvedPhosphorusRemovalEfficiency(planningUnit planningunit.Id) float64 {
+	key := c.DeriveMapKey(planningUnit, c.filter, DissolvedPhosphorusRemovalEfficiency)
+	return c.actionsMap[key]
+}
+
+func (c *Container) particulatePhosphorusRemovalEfficiency(planningUnit planningunit.Id) float64 {
+	key := c.DeriveMapKey(planningUnit, c.filter, ParticulatePhosphorusRemovalEfficiency)
+	return c.actionsMap[key]
+}
+
 func (c *Container) Map() map[string]float64 {
 	return c.actionsMap
 }
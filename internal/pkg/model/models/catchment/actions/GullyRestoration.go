@@ -67,6 +67,26 @@ func (g *GullyRestoration) WithActionedDissolvedNitrogen(costInDollars float64)
 	return g.WithVariable(DissolvedNitrogenActionedAttribute, costInDollars)
 }
 
+func (g *GullyRestoration) WithOriginalParticulatePhosphorus(particulatePhosphorus float64) *GullyRestoration {
+	return g.WithVariable(ParticulatePhosphorusOriginalAttribute, particulatePhosphorus)
+}
+
+func (g *GullyRestoration) WithActionedParticulatePhosphorus(particulatePhosphorus float64) *GullyRestoration {
+	return g.WithVariable(ParticulatePhosphorusActionedAttribute, particulatePhosphorus)
+}
+
+func (g *GullyRestoration) WithOriginalDissolvedPhosphorus(dissolvedPhosphorus float64) *GullyRestoration {
+	return g.WithVariable(DissolvedPhosphorusOriginalAttribute, dissolvedPhosphorus)
+}
+
+func (g *GullyRestoration) WithActionedDissolvedPhosphorus(dissolvedPhosphorus float64) *GullyRestoration {
+	return g.WithVariable(DissolvedPhosphorusActionedAttribute, dissolvedPhosphorus)
+}
+
+func (g *GullyRestoration) WithBiodiversityScore(habitatScore float64) *GullyRestoration {
+	return g.WithVariable(BiodiversityScoreAttribute, habitatScore)
+}
+
 func (g *GullyRestoration) WithVariable(variableName action.ModelVariableName, value float64) *GullyRestoration {
 	g.SimpleManagementAction.WithVariable(variableName, value)
 	return g
@@ -63,6 +63,14 @@ func (g *GullyRestorationGroup) createManagementAction(planningUnit planningunit
 	originalDissolvedNitrogen := g.originalDissolvedNitrogen(planningUnit)
 	actionedDissolvedNitrogen := g.actionedDissolvedNitrogen(planningUnit)
 
+	originalParticulatePhosphorus := g.originalParticulatePhosphorus(planningUnit)
+	actionedParticulatePhosphorus := g.actionedParticulatePhosphorus(planningUnit)
+
+	originalDissolvedPhosphorus := g.originalDissolvedPhosphorus(planningUnit)
+	actionedDissolvedPhosphorus := g.actionedDissolvedPhosphorus(planningUnit)
+
+	biodiversityScore := g.biodiversityScore(planningUnit)
+
 	g.actionMap[planningUnit] =
 		NewGullyRestoration().
 			WithPlanningUnit(planningUnit).
@@ -72,6 +80,11 @@ func (g *GullyRestorationGroup) createManagementAction(planningUnit planningunit
 			WithActionedParticulateNitrogen(actionedParticulateNitrogen).
 			WithOriginalDissolvedNitrogen(originalDissolvedNitrogen).
 			WithActionedDissolvedNitrogen(actionedDissolvedNitrogen).
+			WithOriginalParticulatePhosphorus(originalParticulatePhosphorus).
+			WithActionedParticulatePhosphorus(actionedParticulatePhosphorus).
+			WithOriginalDissolvedPhosphorus(originalDissolvedPhosphorus).
+			WithActionedDissolvedPhosphorus(actionedDissolvedPhosphorus).
+			WithBiodiversityScore(biodiversityScore).
 			WithImplementationCost(costInDollars).
 			WithOpportunityCost(opportunityCostInDollars)
 }
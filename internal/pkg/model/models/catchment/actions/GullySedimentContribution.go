@@ -4,6 +4,7 @@ package actions
 
 import (
 	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/dataset"
 	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
 	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
 )
@@ -12,7 +13,7 @@ const (
 	gullyIdIndex             = 0
 	gulliesPlanningUnitIndex = 1
 	gullyErosionVolumeIndex  = 2
-	gullyChannelLength       = 3
+	gullyChannelLength       = dataset.GulliesChannelLengthColumn
 )
 
 type gullySedimentTracker struct {
@@ -63,6 +63,30 @@ func (h *HillSlopeRestoration) WithActionedDissolvedNitrogen(costInDollars float
 	return h.WithVariable(DissolvedNitrogenActionedAttribute, costInDollars)
 }
 
+func (h *HillSlopeRestoration) WithOriginalParticulatePhosphorus(particulatePhosphorus float64) *HillSlopeRestoration {
+	return h.WithVariable(ParticulatePhosphorusOriginalAttribute, particulatePhosphorus)
+}
+
+func (h *HillSlopeRestoration) WithActionedParticulatePhosphorus(particulatePhosphorus float64) *HillSlopeRestoration {
+	return h.WithVariable(ParticulatePhosphorusActionedAttribute, particulatePhosphorus)
+}
+
+func (h *HillSlopeRestoration) WithOriginalDissolvedPhosphorus(dissolvedPhosphorus float64) *HillSlopeRestoration {
+	return h.WithVariable(DissolvedPhosphorusOriginalAttribute, dissolvedPhosphorus)
+}
+
+func (h *HillSlopeRestoration) WithActionedDissolvedPhosphorus(dissolvedPhosphorus float64) *HillSlopeRestoration {
+	return h.WithVariable(DissolvedPhosphorusActionedAttribute, dissolvedPhosphorus)
+}
+
+func (h *HillSlopeRestoration) WithCarbonSequestration(carbonSequestered float64) *HillSlopeRestoration {
+	return h.WithVariable(CarbonSequestrationAttribute, carbonSequestered)
+}
+
+func (h *HillSlopeRestoration) WithBiodiversityScore(habitatScore float64) *HillSlopeRestoration {
+	return h.WithVariable(BiodiversityScoreAttribute, habitatScore)
+}
+
 func (h *HillSlopeRestoration) WithVariable(variableName action.ModelVariableName, value float64) *HillSlopeRestoration {
 	h.SimpleManagementAction.WithVariable(variableName, value)
 	return h
@@ -77,6 +77,15 @@ func (h *HillSlopeRestorationGroup) createManagementAction(rowNumber uint) {
 	originalDissolvedNitrogen := h.originalDissolvedNitrogen(planningUnitAsId)
 	actionedDissolvedNitrogen := h.actionedDissolvedNitrogen(planningUnitAsId)
 
+	originalParticulatePhosphorus := h.originalParticulatePhosphorus(planningUnitAsId) * hillSlopeDeliveryRatio
+	actionedParticulatePhosphorus := h.actionedParticulatePhosphorus(planningUnitAsId) * hillSlopeDeliveryRatio
+
+	originalDissolvedPhosphorus := h.originalDissolvedPhosphorus(planningUnitAsId)
+	actionedDissolvedPhosphorus := h.actionedDissolvedPhosphorus(planningUnitAsId)
+
+	carbonSequestration := h.carbonSequestration(planningUnitAsId)
+	biodiversityScore := h.biodiversityScore(planningUnitAsId)
+
 	h.actionMap[planningUnitAsId] =
 		NewHillSlopeRestoration().
 			WithPlanningUnit(planningUnitAsId).
@@ -86,6 +95,12 @@ func (h *HillSlopeRestorationGroup) createManagementAction(rowNumber uint) {
 			WithActionedParticulateNitrogen(actionedParticulateNitrogen).
 			WithOriginalDissolvedNitrogen(originalDissolvedNitrogen).
 			WithActionedDissolvedNitrogen(actionedDissolvedNitrogen).
+			WithOriginalParticulatePhosphorus(originalParticulatePhosphorus).
+			WithActionedParticulatePhosphorus(actionedParticulatePhosphorus).
+			WithOriginalDissolvedPhosphorus(originalDissolvedPhosphorus).
+			WithActionedDissolvedPhosphorus(actionedDissolvedPhosphorus).
+			WithCarbonSequestration(carbonSequestration).
+			WithBiodiversityScore(biodiversityScore).
 			WithOpportunityCost(opportunityCostInDollars).
 			WithImplementationCost(implementationCostInDollars)
 }
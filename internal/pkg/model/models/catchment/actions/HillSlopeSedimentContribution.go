@@ -9,7 +9,7 @@ import (
 )
 
 const (
-	hillSlopeAreaIndex = 11
+	hillSlopeAreaIndex = dataset.SubcatchmentsHillSlopeAreaColumn
 )
 
 type hillSlopeSedimentTracker struct {
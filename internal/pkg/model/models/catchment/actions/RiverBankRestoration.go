@@ -91,6 +91,34 @@ func (r *RiverBankRestoration) WithDissolvedNitrogenRemovalEfficiency(removalEff
 	return r.WithVariable(DissolvedNitrogenRemovalEfficiency, removalEfficiency)
 }
 
+func (r *RiverBankRestoration) WithOriginalParticulatePhosphorus(particulatePhosphorus float64) *RiverBankRestoration {
+	return r.WithVariable(ParticulatePhosphorusOriginalAttribute, particulatePhosphorus)
+}
+
+func (r *RiverBankRestoration) WithActionedParticulatePhosphorus(particulatePhosphorus float64) *RiverBankRestoration {
+	return r.WithVariable(ParticulatePhosphorusActionedAttribute, particulatePhosphorus)
+}
+
+func (r *RiverBankRestoration) WithOriginalDissolvedPhosphorus(dissolvedPhosphorus float64) *RiverBankRestoration {
+	return r.WithVariable(DissolvedPhosphorusOriginalAttribute, dissolvedPhosphorus)
+}
+
+func (r *RiverBankRestoration) WithActionedDissolvedPhosphorus(dissolvedPhosphorus float64) *RiverBankRestoration {
+	return r.WithVariable(DissolvedPhosphorusActionedAttribute, dissolvedPhosphorus)
+}
+
+func (r *RiverBankRestoration) WithDissolvedPhosphorusRemovalEfficiency(removalEfficiency float64) *RiverBankRestoration {
+	return r.WithVariable(DissolvedPhosphorusRemovalEfficiency, removalEfficiency)
+}
+
+func (r *RiverBankRestoration) WithCarbonSequestration(carbonSequestered float64) *RiverBankRestoration {
+	return r.WithVariable(CarbonSequestrationAttribute, carbonSequestered)
+}
+
+func (r *RiverBankRestoration) WithBiodiversityScore(habitatScore float64) *RiverBankRestoration {
+	return r.WithVariable(BiodiversityScoreAttribute, habitatScore)
+}
+
 func (r *RiverBankRestoration) WithVariable(variableName action.ModelVariableName, value float64) *RiverBankRestoration {
 	r.SimpleManagementAction.WithVariable(variableName, value)
 	return r
@@ -81,6 +81,17 @@ func (r *RiverBankRestorationGroup) createManagementAction(rowNumber uint) {
 
 	dissolvedNitrogenRemovalEfficiency := r.dissolvedNitrogenRemovalEfficiency(planningUnitAsId)
 
+	originalParticulatePhosphorus := r.originalParticulatePhosphorus(planningUnitAsId)
+	actionedParticulatePhosphorus := r.actionedParticulatePhosphorus(planningUnitAsId)
+
+	originalDissolvedPhosphorus := r.originalDissolvedPhosphorus(planningUnitAsId)
+	actionedDissolvedPhosphorus := r.actionedDissolvedPhosphorus(planningUnitAsId)
+
+	dissolvedPhosphorusRemovalEfficiency := r.dissolvedPhosphorusRemovalEfficiency(planningUnitAsId)
+
+	carbonSequestration := r.carbonSequestration(planningUnitAsId)
+	biodiversityScore := r.biodiversityScore(planningUnitAsId)
+
 	r.actionMap[planningUnitAsId] =
 		NewRiverBankRestoration().
 			WithPlanningUnit(planningUnitAsId).
@@ -95,6 +106,13 @@ func (r *RiverBankRestorationGroup) createManagementAction(rowNumber uint) {
 			WithOriginalDissolvedNitrogen(originalDissolvedNitrogen).
 			WithActionedDissolvedNitrogen(actionedDissolvedNitrogen).
 			WithDissolvedNitrogenRemovalEfficiency(dissolvedNitrogenRemovalEfficiency).
+			WithOriginalParticulatePhosphorus(originalParticulatePhosphorus).
+			WithActionedParticulatePhosphorus(actionedParticulatePhosphorus).
+			WithOriginalDissolvedPhosphorus(originalDissolvedPhosphorus).
+			WithActionedDissolvedPhosphorus(actionedDissolvedPhosphorus).
+			WithDissolvedPhosphorusRemovalEfficiency(dissolvedPhosphorusRemovalEfficiency).
+			WithCarbonSequestration(carbonSequestration).
+			WithBiodiversityScore(biodiversityScore).
 			WithImplementationCost(implementationCostInDollars).
 			WithOpportunityCost(opportunityCostInDollars)
 }
@@ -51,6 +51,18 @@ func (w *WetlandsEstablishment) WithSedimentRemovalEfficiency(removalEfficiency
 	return w.WithVariable(SedimentRemovalEfficiency, removalEfficiency)
 }
 
+func (w *WetlandsEstablishment) WithDissolvedPhosphorusRemovalEfficiency(removalEfficiency float64) *WetlandsEstablishment {
+	return w.WithVariable(DissolvedPhosphorusRemovalEfficiency, removalEfficiency)
+}
+
+func (w *WetlandsEstablishment) WithParticulatePhosphorusRemovalEfficiency(removalEfficiency float64) *WetlandsEstablishment {
+	return w.WithVariable(ParticulatePhosphorusRemovalEfficiency, removalEfficiency)
+}
+
+func (w *WetlandsEstablishment) WithBiodiversityScore(habitatScore float64) *WetlandsEstablishment {
+	return w.WithVariable(BiodiversityScoreAttribute, habitatScore)
+}
+
 func (w *WetlandsEstablishment) WithVariable(variableName action.ModelVariableName, value float64) *WetlandsEstablishment {
 	w.SimpleManagementAction.WithVariable(variableName, value)
 	return w
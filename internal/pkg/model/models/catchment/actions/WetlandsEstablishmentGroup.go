@@ -67,6 +67,11 @@ func (w *WetlandsEstablishmentGroup) createManagementAction(rowNumber uint) {
 	particulateNitrogenRemovalEfficiency := w.particulateNitrogenRemovalEfficiency(planningUnitAsId)
 	sedimentRemovalEfficiency := w.sedimentNitrogenRemovalEfficiency(planningUnitAsId)
 
+	dissolvedPhosphorusRemovalEfficiency := w.dissolvedPhosphorusRemovalEfficiency(planningUnitAsId)
+	particulatePhosphorusRemovalEfficiency := w.particulatePhosphorusRemovalEfficiency(planningUnitAsId)
+
+	biodiversityScore := w.biodiversityScore(planningUnitAsId)
+
 	w.actionMap[planningUnitAsId] =
 		NewWetlandsEstablishment().
 			WithPlanningUnit(planningUnitAsId).
@@ -74,5 +79,8 @@ func (w *WetlandsEstablishmentGroup) createManagementAction(rowNumber uint) {
 			WithOpportunityCost(opportunityCostInDollars).
 			WithDissolvedNitrogenRemovalEfficiency(dissolvedNitrogenRemovalEfficiency).
 			WithParticulateNitrogenRemovalEfficiency(particulateNitrogenRemovalEfficiency).
-			WithSedimentRemovalEfficiency(sedimentRemovalEfficiency)
+			WithSedimentRemovalEfficiency(sedimentRemovalEfficiency).
+			WithDissolvedPhosphorusRemovalEfficiency(dissolvedPhosphorusRemovalEfficiency).
+			WithParticulatePhosphorusRemovalEfficiency(particulatePhosphorusRemovalEfficiency).
+			WithBiodiversityScore(biodiversityScore)
 }
@@ -11,6 +11,15 @@ const (
 	ActionsTableName       = "Actions"
 )
 
+// Columns read by position from the catchment model's tables, from which the model's own column indexes, and the
+// columns each table needs, derive.
+const (
+	SubcatchmentsHillSlopeAreaColumn       = 11
+	GulliesChannelLengthColumn             = 3
+	ActionsTypeColumn                      = 1
+	ActionsSedimentRemovalEfficiencyColumn = 14
+)
+
 type DataSet interface {
 	dataset.DataSet
 	SubCatchmentsTable()
@@ -0,0 +1,112 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package dataset
+
+import (
+	"fmt"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+// tableSchema describes the columns the catchment model reads by position from a data set table.
+type tableSchema struct {
+	requiredColumns uint
+	textColumn      int
+}
+
+var schemas = []struct {
+	tableName string
+	schema    tableSchema
+}{
+	{SubcatchmentsTableName, tableSchema{requiredColumns: SubcatchmentsHillSlopeAreaColumn + 1, textColumn: -1}},
+	{GulliesTableName, tableSchema{requiredColumns: GulliesChannelLengthColumn + 1, textColumn: -1}},
+	{ActionsTableName, tableSchema{requiredColumns: ActionsSedimentRemovalEfficiencyColumn + 1, textColumn: ActionsTypeColumn}},
+}
+
+// ValidateSchema checks that sourceDataSet holds every table the catchment model reads, each with at least one row,
+// the columns read by position, and numbers in every column read but the actions table's action type.  Only the
+// first bad cell of each column is reported.
+func ValidateSchema(sourceDataSet dataset.DataSet) error {
+	schemaErrors := compositeErrors.New("Catchment Data Set Schema")
+	for _, entry := range schemas {
+		if tableError := validateTable(sourceDataSet, entry.tableName, entry.schema); tableError != nil {
+			schemaErrors.Add(tableError)
+		}
+	}
+	if schemaErrors.Size() > 0 {
+		return schemaErrors
+	}
+	return nil
+}
+
+func validateTable(sourceDataSet dataset.DataSet, tableName string, schema tableSchema) (tableError error) {
+	namedTable, namedTableError := sourceDataSet.Table(tableName)
+	if namedTableError != nil {
+		return errors.Errorf("data set has no [%s] table", tableName)
+	}
+	csvTable, isCsvType := namedTable.(tables.CsvTable)
+	if !isCsvType {
+		return errors.Errorf("data set table [%s] is not a CSV type", tableName)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tableError = errors.Errorf("data set table [%s] could not be read: %v", tableName, r)
+		}
+	}()
+
+	columnNumber, rowNumber := sizeOf(csvTable)
+	if rowNumber == 0 {
+		return errors.Errorf("data set table [%s] has no rows", tableName)
+	}
+
+	if columnNumber < schema.requiredColumns {
+		return errors.Errorf("data set table [%s] has [%d] columns, but needs at least [%d]",
+			tableName, columnNumber, schema.requiredColumns)
+	}
+
+	cellErrors := compositeErrors.New(fmt.Sprintf("Data set table [%s]", tableName))
+	for column := uint(0); column < schema.requiredColumns; column++ {
+		if cellError := validateColumn(csvTable, column, rowNumber, int(column) == schema.textColumn); cellError != nil {
+			cellErrors.Add(cellError)
+		}
+	}
+	if cellErrors.Size() > 0 {
+		return cellErrors
+	}
+	return nil
+}
+
+// sizeOf returns the column and row size of table, the size of a table without rows being unavailable.
+func sizeOf(table tables.CsvTable) (columnNumber uint, rowNumber uint) {
+	defer func() {
+		if r := recover(); r != nil {
+			columnNumber, rowNumber = 0, 0
+		}
+	}()
+	return table.ColumnAndRowSize()
+}
+
+func validateColumn(table tables.CsvTable, column uint, rowNumber uint, isText bool) error {
+	if isText {
+		return nil
+	}
+	for row := uint(0); row < rowNumber; row++ {
+		cell := table.Cell(column, row)
+		if _, isNumber := cell.(float64); !isNumber {
+			return errors.Errorf("column [%s] row [%d] value [%v] is not a number", headingOf(table, column), row+1, cell)
+		}
+	}
+	return nil
+}
+
+func headingOf(table tables.CsvTable, column uint) string {
+	header := table.Header()
+	if int(column) < len(header) {
+		return header[column]
+	}
+	return fmt.Sprintf("%d", column+1)
+}
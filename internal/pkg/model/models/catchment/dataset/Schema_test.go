@@ -0,0 +1,105 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package dataset
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/dataset"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/csv"
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateSchema_ValidDataSet_NoErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sourceDataSet := csv.NewDataSet("CatchmentModel")
+	g.Expect(sourceDataSet.Load("../testdata/ValidModel.csv")).To(BeNil())
+
+	// when
+	schemaError := ValidateSchema(sourceDataSet)
+
+	// then
+	g.Expect(schemaError).To(BeNil())
+}
+
+func TestValidateSchema_MissingTables_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sourceDataSet := csv.NewDataSet("CatchmentModel")
+	g.Expect(sourceDataSet.Load("../testdata/InvalidModel.csv")).To(BeNil())
+
+	// when
+	schemaError := ValidateSchema(sourceDataSet)
+
+	// then
+	g.Expect(schemaError).To(Not(BeNil()))
+	g.Expect(schemaError.Error()).To(ContainSubstring("no [" + SubcatchmentsTableName + "] table"))
+	g.Expect(schemaError.Error()).To(ContainSubstring("no [" + ActionsTableName + "] table"))
+}
+
+func TestValidateSchema_BadCells_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sourceDataSet := csv.NewDataSet("CatchmentModel")
+	g.Expect(sourceDataSet.Load("../testdata/BadCellsModel.csv")).To(BeNil())
+
+	// when
+	schemaError := ValidateSchema(sourceDataSet)
+	if schemaError != nil {
+		t.Log(schemaError)
+	}
+
+	// then
+	g.Expect(schemaError).To(Not(BeNil()))
+	g.Expect(schemaError.Error()).To(ContainSubstring("column [OpportunityCost] row [3] value [unknown] is not a number"))
+}
+
+func TestValidateSchema_EmptyTable_ReportsNoRows(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sourceDataSet := dataSetWithGullies(g, new(tables.CsvTableImpl))
+
+	// when
+	schemaError := ValidateSchema(sourceDataSet)
+
+	// then
+	g.Expect(schemaError).To(Not(BeNil()))
+	g.Expect(schemaError.Error()).To(ContainSubstring("table [" + GulliesTableName + "] has no rows"))
+}
+
+func TestValidateSchema_TooFewColumns_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	narrowGullies := new(tables.CsvTableImpl)
+	narrowGullies.SetColumnAndRowSize(GulliesChannelLengthColumn, 1)
+	sourceDataSet := dataSetWithGullies(g, narrowGullies)
+
+	// when
+	schemaError := ValidateSchema(sourceDataSet)
+
+	// then
+	g.Expect(schemaError).To(Not(BeNil()))
+	g.Expect(schemaError.Error()).To(ContainSubstring("has [3] columns, but needs at least [4]"))
+	g.Expect(schemaError.Error()).To(Not(ContainSubstring("no rows")))
+}
+
+func dataSetWithGullies(g *GomegaWithT, gulliesTable tables.CsvTable) dataset.DataSet {
+	validDataSet := csv.NewDataSet("CatchmentModel")
+	g.Expect(validDataSet.Load("../testdata/ValidModel.csv")).To(BeNil())
+
+	sourceDataSet := dataset.NewDataSet("CatchmentModel")
+	for _, tableName := range []string{SubcatchmentsTableName, ActionsTableName} {
+		table, tableError := validDataSet.Table(tableName)
+		g.Expect(tableError).To(BeNil())
+		g.Expect(sourceDataSet.AddTable(tableName, table)).To(BeNil())
+	}
+	g.Expect(sourceDataSet.AddTable(GulliesTableName, gulliesTable)).To(BeNil())
+	return sourceDataSet
+}
@@ -35,12 +35,17 @@ const (
 
 	HillSlopeDeliveryRatio string = "HillSlopeDeliveryRatio"
 
-	MaximumSedimentProduction            = "MaximumSedimentProduction"
-	MaximumImplementationCost            = "MaximumImplementationCost"
-	MaximumOpportunityCost               = "MaximumOpportunityCost"
-	MaximumParticulateNitrogenProduction = "MaximumParticulateNitrogenProduction"
-	MaximumDissolvedNitrogenProduction   = "MaximumDissolvedNitrogenProduction"
-	MaximumTotalNitrogenProduction       = "MaximumTotalNitrogenProduction"
+	MaximumSedimentProduction              = "MaximumSedimentProduction"
+	MaximumImplementationCost              = "MaximumImplementationCost"
+	MaximumOpportunityCost                 = "MaximumOpportunityCost"
+	MaximumParticulateNitrogenProduction   = "MaximumParticulateNitrogenProduction"
+	MaximumDissolvedNitrogenProduction     = "MaximumDissolvedNitrogenProduction"
+	MaximumTotalNitrogenProduction         = "MaximumTotalNitrogenProduction"
+	MaximumParticulatePhosphorusProduction = "MaximumParticulatePhosphorusProduction"
+	MaximumDissolvedPhosphorusProduction   = "MaximumDissolvedPhosphorusProduction"
+	MaximumTotalPhosphorusProduction       = "MaximumTotalPhosphorusProduction"
+	MinimumCarbonSequestration             = "MinimumCarbonSequestration"
+	MinimumBiodiversityScore               = "MinimumBiodiversityScore"
 )
 
 func ParameterSpecifications() *Specifications {
@@ -55,6 +60,7 @@ func ParameterSpecifications() *Specifications {
 		Specification{
 			Key:          BankErosionFudgeFactor,
 			Validator:    validateIsBankErosionFudgeFactor,
+			Bounds:       bankErosionFudgeFactorBounds,
 			DefaultValue: 1.5 * math.Pow(10, -4),
 		},
 	).Add(
@@ -97,18 +103,21 @@ func ParameterSpecifications() *Specifications {
 		Specification{
 			Key:          RiparianBufferVegetationProportionTarget,
 			Validator:    IsDecimalBetweenZeroAndOne,
+			Bounds:       UnitInterval,
 			DefaultValue: float64(0.75),
 		},
 	).Add(
 		Specification{
 			Key:          GullySedimentReductionTarget,
 			Validator:    IsDecimalBetweenZeroAndOne,
+			Bounds:       UnitInterval,
 			DefaultValue: float64(0.8),
 		},
 	).Add(
 		Specification{
 			Key:          HillSlopeDeliveryRatio,
 			Validator:    IsDecimalBetweenZeroAndOne,
+			Bounds:       UnitInterval,
 			DefaultValue: float64(0.05),
 		},
 	).Add(
@@ -135,6 +144,24 @@ func ParameterSpecifications() *Specifications {
 			Validator:  IsNonNegativeDecimal,
 			IsOptional: true,
 		},
+	).Add(
+		Specification{
+			Key:        MaximumParticulatePhosphorusProduction,
+			Validator:  IsNonNegativeDecimal,
+			IsOptional: true,
+		},
+	).Add(
+		Specification{
+			Key:        MaximumDissolvedPhosphorusProduction,
+			Validator:  IsNonNegativeDecimal,
+			IsOptional: true,
+		},
+	).Add(
+		Specification{
+			Key:        MaximumTotalPhosphorusProduction,
+			Validator:  IsNonNegativeDecimal,
+			IsOptional: true,
+		},
 	).Add(
 		Specification{
 			Key:        MaximumImplementationCost,
@@ -147,13 +174,28 @@ func ParameterSpecifications() *Specifications {
 			Validator:  IsNonNegativeDecimal,
 			IsOptional: true,
 		},
+	).Add(
+		Specification{
+			Key:        MinimumCarbonSequestration,
+			Validator:  IsNonNegativeDecimal,
+			IsOptional: true,
+		},
+	).Add(
+		Specification{
+			Key:        MinimumBiodiversityScore,
+			Validator:  IsNonNegativeDecimal,
+			IsOptional: true,
+		},
 	)
 
 	return specs
 }
 
+var bankErosionFudgeFactorBounds = Bounds{
+	Minimum: math.Pow(10, -5),
+	Maximum: 5 * math.Pow(10, -4),
+}
+
 func validateIsBankErosionFudgeFactor(key string, value interface{}) error {
-	minValue := math.Pow(10, -5)
-	maxValue := 5 * math.Pow(10, -4)
-	return IsDecimalWithInclusiveBounds(key, value, minValue, maxValue)
+	return IsDecimalWithInclusiveBounds(key, value, bankErosionFudgeFactorBounds.Minimum, bankErosionFudgeFactorBounds.Maximum)
 }
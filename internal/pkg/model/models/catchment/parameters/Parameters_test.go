@@ -0,0 +1,34 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package parameters
+
+import (
+	"testing"
+
+	. "github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+	. "github.com/onsi/gomega"
+)
+
+func TestParameterSpecifications_Bounds_MatchValidators(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	const outsideBoundsMargin = 1e-9
+
+	for key, spec := range *ParameterSpecifications() {
+		if !spec.Bounds.IsBounded() {
+			continue
+		}
+
+		validAtMinimum := spec.Validator(key, spec.Bounds.Minimum).(ValidationError)
+		validAtMaximum := spec.Validator(key, spec.Bounds.Maximum).(ValidationError)
+		invalidBelowMinimum := spec.Validator(key, spec.Bounds.Minimum-outsideBoundsMargin).(ValidationError)
+		invalidAboveMaximum := spec.Validator(key, spec.Bounds.Maximum+outsideBoundsMargin).(ValidationError)
+
+		g.Expect(validAtMinimum.IsValid()).To(BeTrue(), key)
+		g.Expect(validAtMaximum.IsValid()).To(BeTrue(), key)
+		g.Expect(invalidBelowMinimum.IsValid()).To(BeFalse(), key)
+		g.Expect(invalidAboveMaximum.IsValid()).To(BeFalse(), key)
+
+		g.Expect(spec.Validator(key, spec.DefaultValue).(ValidationError).IsValid()).To(BeTrue(), key)
+	}
+}
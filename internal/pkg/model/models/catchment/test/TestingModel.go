@@ -0,0 +1,39 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package test
+
+import (
+	"path/filepath"
+	"runtime"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+)
+
+// TestingModelPath is the path of the small catchment data set that testing models are built from, shared by the
+// tests of packages working with catchment models.
+var TestingModelPath = deriveTestingModelPath()
+
+func deriveTestingModelPath() string {
+	_, thisFilePath, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(thisFilePath), "..", "testdata", "TestingModel.csv")
+}
+
+// NewTestingModel returns a catchment model of the testing data set, with any further parameters given applied,
+// initialised as-is.
+func NewTestingModel(furtherParameters parameters.Map) (model.Model, error) {
+	modelParameters := parameters.Map{catchmentParameters.DataSourcePath: TestingModelPath}
+	for key, value := range furtherParameters {
+		modelParameters[key] = value
+	}
+
+	newModel := catchment.NewModel().WithParameters(modelParameters)
+	if parameterErrors := newModel.ParameterErrors(); parameterErrors != nil {
+		return nil, parameterErrors
+	}
+
+	newModel.Initialise(model.AsIs)
+	return newModel, nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package biodiversity
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/actions"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/LindsayBradford/crem/pkg/math"
+)
+
+const VariableName = "BiodiversityScore"
+const noHabitatGain float64 = 0
+
+var _ variable.UndoableDecisionVariable = new(BiodiversityScore)
+
+// BiodiversityScore is a co-benefit decision variable totalling the habitat score gained by active management
+// actions. Higher values are preferable.
+type BiodiversityScore struct {
+	variable.PerPlanningUnitDecisionVariable
+	variable.Bounds
+
+	actionObserved action.ManagementAction
+
+	command variable.ChangeCommand
+}
+
+func (bs *BiodiversityScore) Initialise() *BiodiversityScore {
+	bs.PerPlanningUnitDecisionVariable.Initialise()
+
+	bs.command = new(variable.NullChangeCommand)
+
+	bs.SetName(VariableName)
+	bs.SetValue(noHabitatGain)
+	bs.SetUnitOfMeasure(variable.HabitatScore)
+	bs.SetPrecision(2)
+
+	return bs
+}
+
+func (bs *BiodiversityScore) WithObservers(observers ...variable.Observer) *BiodiversityScore {
+	bs.Subscribe(observers...)
+	return bs
+}
+
+func (bs *BiodiversityScore) OptimisationSense() variable.OptimisationSense {
+	return variable.Maximised
+}
+
+func (bs *BiodiversityScore) ObserveAction(action action.ManagementAction) {
+	bs.observeAction(action)
+}
+
+func (bs *BiodiversityScore) ObserveActionInitialising(action action.ManagementAction) {
+	bs.observeAction(action)
+	bs.command.Do()
+}
+
+func (bs *BiodiversityScore) observeAction(action action.ManagementAction) {
+	bs.actionObserved = action
+	switch bs.actionObserved.Type() {
+	case actions.RiverBankRestorationType:
+		bs.handleRiverBankRestorationAction()
+	case actions.GullyRestorationType:
+		bs.handleGullyRestorationAction()
+	case actions.HillSlopeRestorationType:
+		bs.handleHillSlopeRestorationAction()
+	case actions.WetlandsEstablishmentType:
+		bs.handleWetlandsEstablishmentAction()
+	default:
+		panic(errors.New("Unhandled observation of management action type [" + string(action.Type()) + "]"))
+	}
+}
+
+func (bs *BiodiversityScore) handleRiverBankRestorationAction() {
+	bs.command = new(RiverBankRestorationCommand).
+		ForVariable(bs).
+		InPlanningUnit(bs.actionObserved.PlanningUnit()).
+		WithChange(bs.habitatScoreChange())
+}
+
+func (bs *BiodiversityScore) handleGullyRestorationAction() {
+	bs.command = new(GullyRestorationCommand).
+		ForVariable(bs).
+		InPlanningUnit(bs.actionObserved.PlanningUnit()).
+		WithChange(bs.habitatScoreChange())
+}
+
+func (bs *BiodiversityScore) handleHillSlopeRestorationAction() {
+	bs.command = new(HillSlopeRevegetationCommand).
+		ForVariable(bs).
+		InPlanningUnit(bs.actionObserved.PlanningUnit()).
+		WithChange(bs.habitatScoreChange())
+}
+
+func (bs *BiodiversityScore) handleWetlandsEstablishmentAction() {
+	bs.command = new(WetlandsEstablishmentCommand).
+		ForVariable(bs).
+		InPlanningUnit(bs.actionObserved.PlanningUnit()).
+		WithChange(bs.habitatScoreChange())
+}
+
+// habitatScoreChange returns the change in habitat score of the action observed becoming active or inactive.
+func (bs *BiodiversityScore) habitatScoreChange() float64 {
+	habitatScore := bs.actionObserved.ModelVariableValue(actions.BiodiversityScoreAttribute)
+
+	var change float64
+	switch bs.actionObserved.IsActive() {
+	case true:
+		change = habitatScore
+	case false:
+		change = -1 * habitatScore
+	}
+
+	return math.RoundFloat(change, int(bs.Precision()))
+}
+
+func (bs *BiodiversityScore) UndoableValue() float64 {
+	return bs.Value() + bs.command.Change()
+}
+
+func (bs *BiodiversityScore) SetUndoableValue(value float64) {
+	bs.command.SetChange(value)
+}
+
+func (bs *BiodiversityScore) DifferenceInValues() float64 {
+	return bs.command.Change()
+}
+
+func (bs *BiodiversityScore) ApplyDoneValue() {
+	bs.command.Do()
+}
+
+func (bs *BiodiversityScore) ApplyUndoneValue() {
+	bs.command.Undo()
+}
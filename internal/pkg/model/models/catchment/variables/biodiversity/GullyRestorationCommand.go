@@ -0,0 +1,27 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package biodiversity
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+)
+
+type GullyRestorationCommand struct {
+	variable.ChangePerPlanningUnitDecisionVariableCommand
+}
+
+func (c *GullyRestorationCommand) ForVariable(variable variable.PlanningUnitDecisionVariable) *GullyRestorationCommand {
+	c.WithTarget(variable)
+	return c
+}
+
+func (c *GullyRestorationCommand) InPlanningUnit(planningUnit planningunit.Id) *GullyRestorationCommand {
+	c.ChangePerPlanningUnitDecisionVariableCommand.InPlanningUnit(planningUnit)
+	return c
+}
+
+func (c *GullyRestorationCommand) WithChange(changeValue float64) *GullyRestorationCommand {
+	c.ChangePerPlanningUnitDecisionVariableCommand.WithChange(changeValue)
+	return c
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package biodiversity
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+)
+
+type HillSlopeRevegetationCommand struct {
+	variable.ChangePerPlanningUnitDecisionVariableCommand
+}
+
+func (c *HillSlopeRevegetationCommand) ForVariable(variable variable.PlanningUnitDecisionVariable) *HillSlopeRevegetationCommand {
+	c.WithTarget(variable)
+	return c
+}
+
+func (c *HillSlopeRevegetationCommand) InPlanningUnit(planningUnit planningunit.Id) *HillSlopeRevegetationCommand {
+	c.ChangePerPlanningUnitDecisionVariableCommand.InPlanningUnit(planningUnit)
+	return c
+}
+
+func (c *HillSlopeRevegetationCommand) WithChange(changeValue float64) *HillSlopeRevegetationCommand {
+	c.ChangePerPlanningUnitDecisionVariableCommand.WithChange(changeValue)
+	return c
+}
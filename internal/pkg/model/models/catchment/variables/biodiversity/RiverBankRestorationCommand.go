@@ -0,0 +1,27 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package biodiversity
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+)
+
+type RiverBankRestorationCommand struct {
+	variable.ChangePerPlanningUnitDecisionVariableCommand
+}
+
+func (c *RiverBankRestorationCommand) ForVariable(variable variable.PlanningUnitDecisionVariable) *RiverBankRestorationCommand {
+	c.WithTarget(variable)
+	return c
+}
+
+func (c *RiverBankRestorationCommand) InPlanningUnit(planningUnit planningunit.Id) *RiverBankRestorationCommand {
+	c.ChangePerPlanningUnitDecisionVariableCommand.InPlanningUnit(planningUnit)
+	return c
+}
+
+func (c *RiverBankRestorationCommand) WithChange(changeValue float64) *RiverBankRestorationCommand {
+	c.ChangePerPlanningUnitDecisionVariableCommand.WithChange(changeValue)
+	return c
+}
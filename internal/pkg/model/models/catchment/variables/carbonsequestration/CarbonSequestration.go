@@ -0,0 +1,138 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package carbonsequestration
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/actions"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/LindsayBradford/crem/pkg/math"
+)
+
+const VariableName = "CarbonSequestration"
+const notSequestered float64 = 0
+
+var _ variable.UndoableDecisionVariable = new(CarbonSequestration)
+
+// CarbonSequestration is a co-benefit decision variable totalling the carbon sequestered by active riparian
+// and hill-slope revegetation actions. Higher values are preferable.
+type CarbonSequestration struct {
+	variable.PerPlanningUnitDecisionVariable
+	variable.Bounds
+
+	actionObserved action.ManagementAction
+
+	command variable.ChangeCommand
+}
+
+func (cs *CarbonSequestration) Initialise() *CarbonSequestration {
+	cs.PerPlanningUnitDecisionVariable.Initialise()
+
+	cs.command = new(variable.NullChangeCommand)
+
+	cs.SetName(VariableName)
+	cs.SetValue(notSequestered)
+	cs.SetUnitOfMeasure(variable.TonnesOfCarbonDioxideEquivalentPerYear)
+	cs.SetPrecision(3)
+
+	return cs
+}
+
+func (cs *CarbonSequestration) WithObservers(observers ...variable.Observer) *CarbonSequestration {
+	cs.Subscribe(observers...)
+	return cs
+}
+
+func (cs *CarbonSequestration) OptimisationSense() variable.OptimisationSense {
+	return variable.Maximised
+}
+
+func (cs *CarbonSequestration) ObserveAction(action action.ManagementAction) {
+	cs.observeAction(action)
+}
+
+func (cs *CarbonSequestration) ObserveActionInitialising(action action.ManagementAction) {
+	cs.observeAction(action)
+	cs.command.Do()
+}
+
+func (cs *CarbonSequestration) observeAction(action action.ManagementAction) {
+	cs.actionObserved = action
+	switch cs.actionObserved.Type() {
+	case actions.RiverBankRestorationType:
+		cs.handleRiverBankRestorationAction()
+	case actions.GullyRestorationType:
+		cs.handleGullyRestorationAction()
+	case actions.HillSlopeRestorationType:
+		cs.handleHillSlopeRestorationAction()
+	case actions.WetlandsEstablishmentType:
+		cs.handleWetlandsEstablishmentAction()
+	default:
+		panic(errors.New("Unhandled observation of management action type [" + string(action.Type()) + "]"))
+	}
+}
+
+func (cs *CarbonSequestration) handleRiverBankRestorationAction() {
+	cs.command = new(RiverBankRestorationCommand).
+		ForVariable(cs).
+		InPlanningUnit(cs.actionObserved.PlanningUnit()).
+		WithChange(cs.revegetationChange())
+}
+
+func (cs *CarbonSequestration) handleGullyRestorationAction() {
+	cs.command = new(GullyRestorationCommand).
+		ForVariable(cs).
+		InPlanningUnit(cs.actionObserved.PlanningUnit()).
+		WithChange(notSequestered)
+}
+
+func (cs *CarbonSequestration) handleHillSlopeRestorationAction() {
+	cs.command = new(HillSlopeRevegetationCommand).
+		ForVariable(cs).
+		InPlanningUnit(cs.actionObserved.PlanningUnit()).
+		WithChange(cs.revegetationChange())
+}
+
+func (cs *CarbonSequestration) handleWetlandsEstablishmentAction() {
+	cs.command = new(WetlandsEstablishmentCommand).
+		ForVariable(cs).
+		InPlanningUnit(cs.actionObserved.PlanningUnit()).
+		WithChange(notSequestered)
+}
+
+// revegetationChange returns the change in carbon sequestered of the revegetation action observed becoming active
+// or inactive.
+func (cs *CarbonSequestration) revegetationChange() float64 {
+	carbonSequestered := cs.actionObserved.ModelVariableValue(actions.CarbonSequestrationAttribute)
+
+	var change float64
+	switch cs.actionObserved.IsActive() {
+	case true:
+		change = carbonSequestered
+	case false:
+		change = -1 * carbonSequestered
+	}
+
+	return math.RoundFloat(change, int(cs.Precision()))
+}
+
+func (cs *CarbonSequestration) UndoableValue() float64 {
+	return cs.Value() + cs.command.Change()
+}
+
+func (cs *CarbonSequestration) SetUndoableValue(value float64) {
+	cs.command.SetChange(value)
+}
+
+func (cs *CarbonSequestration) DifferenceInValues() float64 {
+	return cs.command.Change()
+}
+
+func (cs *CarbonSequestration) ApplyDoneValue() {
+	cs.command.Do()
+}
+
+func (cs *CarbonSequestration) ApplyUndoneValue() {
+	cs.command.Undo()
+}
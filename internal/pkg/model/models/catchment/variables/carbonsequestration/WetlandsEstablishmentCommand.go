@@ -0,0 +1,27 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package carbonsequestration
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+)
+
+type WetlandsEstablishmentCommand struct {
+	variable.ChangePerPlanningUnitDecisionVariableCommand
+}
+
+func (c *WetlandsEstablishmentCommand) ForVariable(variable variable.PlanningUnitDecisionVariable) *WetlandsEstablishmentCommand {
+	c.WithTarget(variable)
+	return c
+}
+
+func (c *WetlandsEstablishmentCommand) InPlanningUnit(planningUnit planningunit.Id) *WetlandsEstablishmentCommand {
+	c.ChangePerPlanningUnitDecisionVariableCommand.InPlanningUnit(planningUnit)
+	return c
+}
+
+func (c *WetlandsEstablishmentCommand) WithChange(changeValue float64) *WetlandsEstablishmentCommand {
+	c.ChangePerPlanningUnitDecisionVariableCommand.WithChange(changeValue)
+	return c
+}
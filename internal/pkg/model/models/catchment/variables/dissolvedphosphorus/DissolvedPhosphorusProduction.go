@@ -0,0 +1,454 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package dissolvedphosphorus
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	catchmentActions "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/actions"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/pkg/attributes"
+	"github.com/LindsayBradford/crem/pkg/math"
+	"github.com/pkg/errors"
+)
+
+const (
+	VariableName = "DissolvedPhosphorus"
+
+	planningUnitIndex           = 0
+	proportionOfVegetationIndex = 8
+
+	ProportionOfRiparianVegetation               = "ProportionOfRiparianVegetation"
+	RiparianDissolvedPhosphorusRemovalEfficiency = "RiparianDissolvedPhosphorusRemovalEfficiency"
+	WetlandsDissolvedPhosphorusRemovalEfficiency = "WetlandsDissolvedPhosphorusRemovalEfficiency"
+	RiparianPhosphorusContribution               = "RiparianPhosphorusContribution"
+	GullyPhosphorusContribution                  = "GullyPhosphorusContribution"
+	HillSlopePhosphorusContribution              = "HillSlopePhosphorusContribution"
+)
+
+var _ variable.UndoableDecisionVariable = new(DissolvedPhosphorusProduction)
+
+type DissolvedPhosphorusProduction struct {
+	variable.PerPlanningUnitDecisionVariable
+	variable.Bounds
+
+	catchmentActions.Container
+
+	command variable.ChangeCommand
+
+	actionObserved action.ManagementAction
+
+	numberOfSubCatchments uint
+
+	subCatchmentAttributes map[planningunit.Id]attributes.Attributes
+}
+
+func (dp *DissolvedPhosphorusProduction) Initialise(subCatchmentsTable tables.CsvTable, actionsTable tables.CsvTable, parameters catchmentParameters.Parameters) *DissolvedPhosphorusProduction {
+	dp.PerPlanningUnitDecisionVariable.Initialise()
+	dp.Container.WithActionsTable(actionsTable)
+
+	dp.SetName(VariableName)
+	dp.SetUnitOfMeasure(variable.TonnesPerYear)
+	dp.SetPrecision(3)
+
+	dp.command = new(variable.NullChangeCommand)
+
+	dp.deriveInitialState(subCatchmentsTable, parameters)
+
+	return dp
+}
+
+func (dp *DissolvedPhosphorusProduction) WithName(variableName string) *DissolvedPhosphorusProduction {
+	dp.SetName(variableName)
+	return dp
+}
+
+func (dp *DissolvedPhosphorusProduction) WithStartingValue(value float64) *DissolvedPhosphorusProduction {
+	dp.SetPlanningUnitValue(0, value)
+	return dp
+}
+
+func (dp *DissolvedPhosphorusProduction) WithObservers(observers ...variable.Observer) *DissolvedPhosphorusProduction {
+	dp.Subscribe(observers...)
+	return dp
+}
+
+func (dp *DissolvedPhosphorusProduction) deriveInitialState(subCatchmentsTable tables.CsvTable, parameters catchmentParameters.Parameters) {
+	dp.deriveNumberOfSubCatchments(subCatchmentsTable)
+	dp.initialiseSubCatchmentAttributes()
+	dp.deriveInitialPhosphorus(subCatchmentsTable)
+}
+
+func (dp *DissolvedPhosphorusProduction) deriveNumberOfSubCatchments(subCatchmentsTable tables.CsvTable) {
+	_, rowCount := subCatchmentsTable.ColumnAndRowSize()
+	dp.numberOfSubCatchments = rowCount
+}
+
+func (dp *DissolvedPhosphorusProduction) initialiseSubCatchmentAttributes() {
+	dp.subCatchmentAttributes = make(map[planningunit.Id]attributes.Attributes, dp.numberOfSubCatchments)
+	for index, _ := range dp.subCatchmentAttributes {
+		newAttributes := make(attributes.Attributes, 0)
+		dp.subCatchmentAttributes[index] = newAttributes
+	}
+}
+
+func (dp *DissolvedPhosphorusProduction) deriveInitialPhosphorus(subCatchmentsTable tables.CsvTable) {
+	dp.buildDefaultSubCatchmentAttributes(subCatchmentsTable)
+	dp.replaceDefaultAttributeValuesWithActionOriginalValues()
+	dp.calculateInitialPhosphorusPerSubCatchment()
+}
+
+func (dp *DissolvedPhosphorusProduction) buildDefaultSubCatchmentAttributes(subCatchmentsTable tables.CsvTable) {
+	for row := uint(0); row < dp.numberOfSubCatchments; row++ {
+		subCatchmentFloat64 := subCatchmentsTable.CellFloat64(planningUnitIndex, row)
+		subCatchment := Float64ToSubCatchmentId(subCatchmentFloat64)
+
+		riverBankVegetationProportion := subCatchmentsTable.CellFloat64(proportionOfVegetationIndex, row)
+
+		dp.subCatchmentAttributes[subCatchment] =
+			dp.subCatchmentAttributes[subCatchment].
+				Add(ProportionOfRiparianVegetation, riverBankVegetationProportion).
+				Add(RiparianDissolvedPhosphorusRemovalEfficiency, float64(0)).
+				Add(WetlandsDissolvedPhosphorusRemovalEfficiency, float64(0)).
+				Add(RiparianPhosphorusContribution, float64(0)).
+				Add(HillSlopePhosphorusContribution, float64(0)).
+				Add(GullyPhosphorusContribution, float64(0))
+	}
+}
+
+func (dp *DissolvedPhosphorusProduction) replaceDefaultAttributeValuesWithActionOriginalValues() {
+	for key, value := range dp.Map() {
+		components := dp.DeriveMapKeyComponents(key)
+		if components == nil {
+			continue
+		}
+		dp.cacheRiparianAttributes(components, value)
+
+		dp.calculateOriginalDissolvedPhosphorusContributions(components, value)
+	}
+}
+
+func (dp *DissolvedPhosphorusProduction) cacheRiparianAttributes(components *catchmentActions.KeyComponents, value float64) {
+	if components.Action != catchmentActions.RiparianType {
+		return
+	}
+
+	switch components.ElementType {
+	case catchmentActions.DissolvedPhosphorusRemovalEfficiency:
+		dp.subCatchmentAttributes[components.SubCatchment] =
+			dp.subCatchmentAttributes[components.SubCatchment].Replace(RiparianDissolvedPhosphorusRemovalEfficiency, value)
+	default: // Deliberately does nothing
+	}
+}
+
+func (dp *DissolvedPhosphorusProduction) calculateOriginalDissolvedPhosphorusContributions(components *catchmentActions.KeyComponents, value float64) {
+	if components.ElementType != catchmentActions.DissolvedPhosphorusOriginalAttribute {
+		return
+	}
+
+	switch components.Action {
+	case catchmentActions.RiparianType:
+		dp.subCatchmentAttributes[components.SubCatchment] =
+			dp.subCatchmentAttributes[components.SubCatchment].Replace(RiparianPhosphorusContribution, value)
+	case catchmentActions.HillSlopeType:
+		dp.subCatchmentAttributes[components.SubCatchment] =
+			dp.subCatchmentAttributes[components.SubCatchment].Replace(HillSlopePhosphorusContribution, value)
+	case catchmentActions.GullyType:
+		dp.subCatchmentAttributes[components.SubCatchment] =
+			dp.subCatchmentAttributes[components.SubCatchment].Replace(GullyPhosphorusContribution, value)
+	default: // Deliberately does nothing
+	}
+}
+
+func (dp *DissolvedPhosphorusProduction) calculateInitialPhosphorusPerSubCatchment() {
+	for subCatchment, attributes := range dp.subCatchmentAttributes {
+		dp.updateDissolvedPhosphorusFor(subCatchment, attributes)
+	}
+}
+
+type phosphorusContext struct {
+	riparianContribution  float64
+	gullyContribution     float64
+	hillSlopeContribution float64
+
+	riparianBufferVegetation                     float64
+	riparianDissolvedPhosphorusRemovalEfficiency float64
+	wetlandsDissolvedPhosphorusRemovalEfficiency float64
+}
+
+func (dp *DissolvedPhosphorusProduction) updateDissolvedPhosphorusFor(subCatchment planningunit.Id, attributes attributes.Attributes) {
+
+	context := phosphorusContext{
+		riparianContribution: attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:    attributes.Value(GullyPhosphorusContribution).(float64),
+
+		hillSlopeContribution:                        attributes.Value(HillSlopePhosphorusContribution).(float64),
+		riparianBufferVegetation:                     attributes.Value(ProportionOfRiparianVegetation).(float64),
+		riparianDissolvedPhosphorusRemovalEfficiency: attributes.Value(RiparianDissolvedPhosphorusRemovalEfficiency).(float64),
+		wetlandsDissolvedPhosphorusRemovalEfficiency: attributes.Value(WetlandsDissolvedPhosphorusRemovalEfficiency).(float64),
+	}
+
+	phosphorusProduced := dp.calculatePhosphorusProduction(context)
+	dp.SetPlanningUnitValue(subCatchment, phosphorusProduced)
+}
+
+func (dp *DissolvedPhosphorusProduction) calculatePhosphorusProduction(context phosphorusContext) float64 {
+	riparianFilter := 1 - context.riparianBufferVegetation*context.riparianDissolvedPhosphorusRemovalEfficiency
+	wetlandsFilter := 1 - context.wetlandsDissolvedPhosphorusRemovalEfficiency
+
+	filteredHillSlopeContribution := wetlandsFilter * riparianFilter * context.hillSlopeContribution
+	phosphorusProduced := context.riparianContribution + context.gullyContribution + filteredHillSlopeContribution
+
+	roundedPhosphorusProduced := math.RoundFloat(phosphorusProduced, int(dp.Precision()))
+	return roundedPhosphorusProduced
+}
+
+func Float64ToSubCatchmentId(value float64) planningunit.Id {
+	return planningunit.Id(value)
+}
+
+func (dp *DissolvedPhosphorusProduction) ObserveAction(action action.ManagementAction) {
+	dp.observeAction(action)
+}
+
+func (dp *DissolvedPhosphorusProduction) ObserveActionInitialising(action action.ManagementAction) {
+	dp.observeAction(action)
+	dp.command.Do()
+}
+
+func (dp *DissolvedPhosphorusProduction) observeAction(action action.ManagementAction) {
+	dp.actionObserved = action
+	switch dp.actionObserved.Type() {
+	case catchmentActions.RiverBankRestorationType:
+		dp.handleRiverBankRestorationAction()
+	case catchmentActions.GullyRestorationType:
+		dp.handleGullyRestorationAction()
+	case catchmentActions.HillSlopeRestorationType:
+		dp.handleHillSlopeRestorationAction()
+	case catchmentActions.WetlandsEstablishmentType:
+		dp.handleWetlandsEstablishmentAction()
+	default:
+		panic(errors.New("Unhandled observation of management action type [" + string(action.Type()) + "]"))
+	}
+}
+
+func (dp *DissolvedPhosphorusProduction) handleRiverBankRestorationAction() {
+	var asIsPhosphorus, asIsBufferVegetation, toBePhosphorus, toBeBufferVegetation float64
+
+	switch dp.actionObserved.IsActive() {
+	case true:
+		asIsPhosphorus = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusOriginalAttribute)
+		asIsBufferVegetation = dp.actionObserved.ModelVariableValue(catchmentActions.OriginalBufferVegetation)
+
+		toBePhosphorus = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusActionedAttribute)
+		toBeBufferVegetation = dp.actionObserved.ModelVariableValue(catchmentActions.ActionedBufferVegetation)
+	case false:
+		asIsPhosphorus = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusActionedAttribute)
+		asIsBufferVegetation = dp.actionObserved.ModelVariableValue(catchmentActions.ActionedBufferVegetation)
+
+		toBePhosphorus = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusOriginalAttribute)
+		toBeBufferVegetation = dp.actionObserved.ModelVariableValue(catchmentActions.OriginalBufferVegetation)
+	}
+
+	actionSubCatchment := dp.actionObserved.PlanningUnit()
+	attributes := dp.subCatchmentAttributes[actionSubCatchment]
+
+	asIsContext := phosphorusContext{
+		riparianBufferVegetation: asIsBufferVegetation,
+		riparianContribution:     asIsPhosphorus,
+		gullyContribution:        attributes.Value(GullyPhosphorusContribution).(float64),
+
+		hillSlopeContribution:                        attributes.Value(HillSlopePhosphorusContribution).(float64),
+		wetlandsDissolvedPhosphorusRemovalEfficiency: attributes.Value(WetlandsDissolvedPhosphorusRemovalEfficiency).(float64),
+		riparianDissolvedPhosphorusRemovalEfficiency: attributes.Value(RiparianDissolvedPhosphorusRemovalEfficiency).(float64),
+	}
+
+	finalisedAsIsPhosphorus := dp.calculatePhosphorusProduction(asIsContext)
+
+	toBeContext := phosphorusContext{
+		riparianBufferVegetation: toBeBufferVegetation,
+		riparianContribution:     toBePhosphorus,
+		gullyContribution:        attributes.Value(GullyPhosphorusContribution).(float64),
+
+		hillSlopeContribution:                        attributes.Value(HillSlopePhosphorusContribution).(float64),
+		wetlandsDissolvedPhosphorusRemovalEfficiency: attributes.Value(WetlandsDissolvedPhosphorusRemovalEfficiency).(float64),
+		riparianDissolvedPhosphorusRemovalEfficiency: attributes.Value(RiparianDissolvedPhosphorusRemovalEfficiency).(float64),
+	}
+
+	finalisedToBePhosphorus := dp.calculatePhosphorusProduction(toBeContext)
+
+	dp.command = new(RiverBankRestorationCommand).
+		ForVariable(dp).
+		InPlanningUnit(actionSubCatchment).
+		WithVegetationProportion(toBeBufferVegetation).
+		WithPhosphorusContribution(toBePhosphorus).
+		WithChange(finalisedToBePhosphorus - finalisedAsIsPhosphorus)
+}
+
+func (dp *DissolvedPhosphorusProduction) handleGullyRestorationAction() {
+	var asIsPhosphorus, toBePhosphorus float64
+
+	switch dp.actionObserved.IsActive() {
+	case true:
+		asIsPhosphorus = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusOriginalAttribute)
+		toBePhosphorus = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusActionedAttribute)
+	case false:
+		asIsPhosphorus = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusActionedAttribute)
+		toBePhosphorus = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusOriginalAttribute)
+	}
+
+	actionSubCatchment := dp.actionObserved.PlanningUnit()
+	attributes := dp.subCatchmentAttributes[actionSubCatchment]
+
+	asIsContext := phosphorusContext{
+		riparianBufferVegetation: attributes.Value(ProportionOfRiparianVegetation).(float64),
+		riparianContribution:     attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:        asIsPhosphorus,
+
+		hillSlopeContribution:                        attributes.Value(HillSlopePhosphorusContribution).(float64),
+		wetlandsDissolvedPhosphorusRemovalEfficiency: attributes.Value(WetlandsDissolvedPhosphorusRemovalEfficiency).(float64),
+		riparianDissolvedPhosphorusRemovalEfficiency: attributes.Value(RiparianDissolvedPhosphorusRemovalEfficiency).(float64),
+	}
+
+	finalisedAsIsPhosphorus := dp.calculatePhosphorusProduction(asIsContext)
+
+	toBeContext := phosphorusContext{
+		riparianBufferVegetation: attributes.Value(ProportionOfRiparianVegetation).(float64),
+		riparianContribution:     attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:        toBePhosphorus,
+
+		hillSlopeContribution:                        attributes.Value(HillSlopePhosphorusContribution).(float64),
+		wetlandsDissolvedPhosphorusRemovalEfficiency: attributes.Value(WetlandsDissolvedPhosphorusRemovalEfficiency).(float64),
+		riparianDissolvedPhosphorusRemovalEfficiency: attributes.Value(RiparianDissolvedPhosphorusRemovalEfficiency).(float64),
+	}
+
+	finalisedToBePhosphorus := dp.calculatePhosphorusProduction(toBeContext)
+
+	dp.command = new(GullyRestorationCommand).
+		ForVariable(dp).
+		InPlanningUnit(actionSubCatchment).
+		WithPhosphorusContribution(toBePhosphorus).
+		WithChange(finalisedToBePhosphorus - finalisedAsIsPhosphorus)
+}
+
+func (dp *DissolvedPhosphorusProduction) handleHillSlopeRestorationAction() {
+	var asIsPhosphorus, toBePhosphorus float64
+
+	switch dp.actionObserved.IsActive() {
+	case true:
+		asIsPhosphorus = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusOriginalAttribute)
+		toBePhosphorus = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusActionedAttribute)
+	case false:
+		asIsPhosphorus = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusActionedAttribute)
+		toBePhosphorus = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusOriginalAttribute)
+	}
+
+	actionSubCatchment := dp.actionObserved.PlanningUnit()
+	attributes := dp.subCatchmentAttributes[actionSubCatchment]
+
+	asIsContext := phosphorusContext{
+		riparianBufferVegetation: attributes.Value(ProportionOfRiparianVegetation).(float64),
+		riparianContribution:     attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:        attributes.Value(GullyPhosphorusContribution).(float64),
+
+		hillSlopeContribution:                        asIsPhosphorus,
+		wetlandsDissolvedPhosphorusRemovalEfficiency: attributes.Value(WetlandsDissolvedPhosphorusRemovalEfficiency).(float64),
+		riparianDissolvedPhosphorusRemovalEfficiency: attributes.Value(RiparianDissolvedPhosphorusRemovalEfficiency).(float64),
+	}
+
+	finalisedAsIsPhosphorus := dp.calculatePhosphorusProduction(asIsContext)
+
+	toBeContext := phosphorusContext{
+		riparianBufferVegetation: attributes.Value(ProportionOfRiparianVegetation).(float64),
+		riparianContribution:     attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:        attributes.Value(GullyPhosphorusContribution).(float64),
+
+		hillSlopeContribution:                        toBePhosphorus,
+		wetlandsDissolvedPhosphorusRemovalEfficiency: attributes.Value(WetlandsDissolvedPhosphorusRemovalEfficiency).(float64),
+		riparianDissolvedPhosphorusRemovalEfficiency: attributes.Value(RiparianDissolvedPhosphorusRemovalEfficiency).(float64),
+	}
+
+	finalisedToBePhosphorus := dp.calculatePhosphorusProduction(toBeContext)
+
+	dp.command = new(HillSlopeRevegetationCommand).
+		ForVariable(dp).
+		InPlanningUnit(actionSubCatchment).
+		WithPhosphorusContribution(toBePhosphorus).
+		WithChange(finalisedToBePhosphorus - finalisedAsIsPhosphorus)
+}
+
+func (dp *DissolvedPhosphorusProduction) handleWetlandsEstablishmentAction() {
+	var asIsRemovalEfficiency, toBeRemovalEfficiency float64
+
+	switch dp.actionObserved.IsActive() {
+	case true:
+		asIsRemovalEfficiency = 0
+		toBeRemovalEfficiency = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusRemovalEfficiency)
+	case false:
+		asIsRemovalEfficiency = dp.actionObserved.ModelVariableValue(catchmentActions.DissolvedPhosphorusRemovalEfficiency)
+		toBeRemovalEfficiency = 0
+	}
+
+	actionSubCatchment := dp.actionObserved.PlanningUnit()
+	attributes := dp.subCatchmentAttributes[actionSubCatchment]
+
+	asIsContext := phosphorusContext{
+		riparianBufferVegetation: attributes.Value(ProportionOfRiparianVegetation).(float64),
+		riparianContribution:     attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:        attributes.Value(GullyPhosphorusContribution).(float64),
+
+		hillSlopeContribution:                        attributes.Value(HillSlopePhosphorusContribution).(float64),
+		wetlandsDissolvedPhosphorusRemovalEfficiency: asIsRemovalEfficiency,
+		riparianDissolvedPhosphorusRemovalEfficiency: attributes.Value(RiparianDissolvedPhosphorusRemovalEfficiency).(float64),
+	}
+
+	finalisedAsIsPhosphorus := dp.calculatePhosphorusProduction(asIsContext)
+
+	toBeContext := phosphorusContext{
+		riparianBufferVegetation: attributes.Value(ProportionOfRiparianVegetation).(float64),
+		riparianContribution:     attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:        attributes.Value(GullyPhosphorusContribution).(float64),
+
+		hillSlopeContribution:                        attributes.Value(HillSlopePhosphorusContribution).(float64),
+		wetlandsDissolvedPhosphorusRemovalEfficiency: toBeRemovalEfficiency,
+		riparianDissolvedPhosphorusRemovalEfficiency: attributes.Value(RiparianDissolvedPhosphorusRemovalEfficiency).(float64),
+	}
+
+	finalisedToBePhosphorus := dp.calculatePhosphorusProduction(toBeContext)
+
+	dp.command = new(WetlandsEstablishmentCommand).
+		ForVariable(dp).
+		InPlanningUnit(dp.actionObserved.PlanningUnit()).
+		WithRemovalEfficiency(toBeRemovalEfficiency).
+		WithChange(finalisedToBePhosphorus - finalisedAsIsPhosphorus)
+}
+
+// NotifyObservers allows structs embedding a BaseInductiveDecisionVariable to trigger a notification of change
+// to any observers watching for state changes to the variableOld.
+func (dp *DissolvedPhosphorusProduction) NotifyObservers() {
+	for _, observer := range dp.Observers() {
+		observer.ObserveDecisionVariable(dp)
+	}
+}
+
+func (dp *DissolvedPhosphorusProduction) UndoableValue() float64 {
+	return dp.Value() + dp.command.Value()
+}
+
+func (dp *DissolvedPhosphorusProduction) SetUndoableValue(value float64) {
+	dp.command.SetChange(value)
+}
+
+func (dp *DissolvedPhosphorusProduction) DifferenceInValues() float64 {
+	return dp.command.Change()
+}
+
+func (dp *DissolvedPhosphorusProduction) ApplyDoneValue() {
+	dp.command.Do()
+}
+
+func (dp *DissolvedPhosphorusProduction) ApplyUndoneValue() {
+	dp.command.Undo()
+}
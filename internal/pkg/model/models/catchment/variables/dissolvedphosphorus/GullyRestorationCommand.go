@@ -0,0 +1,70 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package dissolvedphosphorus
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/pkg/command"
+)
+
+type GullyRestorationCommand struct {
+	variable.ChangePerPlanningUnitDecisionVariableCommand
+
+	undoneGullyContribution float64
+	doneGullyContribution   float64
+}
+
+func (c *GullyRestorationCommand) ForVariable(variable variable.PlanningUnitDecisionVariable) *GullyRestorationCommand {
+	c.WithTarget(variable)
+	return c
+}
+
+func (c *GullyRestorationCommand) InPlanningUnit(planningUnit planningunit.Id) *GullyRestorationCommand {
+	c.ChangePerPlanningUnitDecisionVariableCommand.InPlanningUnit(planningUnit)
+	return c
+}
+
+func (c *GullyRestorationCommand) WithPhosphorusContribution(contribution float64) *GullyRestorationCommand {
+	c.undoneGullyContribution = c.gullyPhosphorusContribution()
+	c.doneGullyContribution = contribution
+	return c
+}
+
+func (c *GullyRestorationCommand) WithChange(changeValue float64) *GullyRestorationCommand {
+	c.ChangePerPlanningUnitDecisionVariableCommand.WithChange(changeValue)
+	return c
+}
+
+func (c *GullyRestorationCommand) variable() *DissolvedPhosphorusProduction {
+	return c.Target().(*DissolvedPhosphorusProduction)
+}
+
+func (c *GullyRestorationCommand) Do() command.CommandStatus {
+	if c.BaseCommand.Do() == command.NoChange {
+		return command.NoChange
+	}
+	c.ChangePerPlanningUnitDecisionVariableCommand.DoUnguarded()
+	c.setGullyPhosphorusContribution(c.doneGullyContribution)
+	return command.Done
+}
+
+func (c *GullyRestorationCommand) Undo() command.CommandStatus {
+	if c.BaseCommand.Undo() == command.NoChange {
+		return command.NoChange
+	}
+	c.ChangePerPlanningUnitDecisionVariableCommand.UndoUnguarded()
+	c.setGullyPhosphorusContribution(c.undoneGullyContribution)
+
+	return command.UnDone
+}
+
+func (c *GullyRestorationCommand) setGullyPhosphorusContribution(contribution float64) {
+	c.variable().subCatchmentAttributes[c.PlanningUnit()] =
+		c.variable().subCatchmentAttributes[c.PlanningUnit()].Replace(GullyPhosphorusContribution, contribution)
+}
+
+func (c *GullyRestorationCommand) gullyPhosphorusContribution() float64 {
+	planningUnitAttributes := c.variable().subCatchmentAttributes[c.PlanningUnit()]
+	return planningUnitAttributes.Value(GullyPhosphorusContribution).(float64)
+}
@@ -0,0 +1,452 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package particulatephosphorus
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	catchmentActions "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/actions"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/pkg/attributes"
+	"github.com/LindsayBradford/crem/pkg/math"
+	"github.com/pkg/errors"
+)
+
+const (
+	VariableName = "ParticulatePhosphorus"
+
+	planningUnitIndex           = 0
+	proportionOfVegetationIndex = 8
+
+	RiverbankVegetationProportion = "RiverbankVegetationProportion"
+
+	RiparianPhosphorusContribution = "RiparianPhosphorusContribution"
+	GullyPhosphorusContribution    = "GullyPhosphorusContribution"
+
+	WetlandRemovalEfficiency        = "WetlandRemovalEfficiency"
+	HillSlopePhosphorusContribution = "HillSlopePhosphorusContribution"
+)
+
+var _ variable.UndoableDecisionVariable = new(ParticulatePhosphorusProduction)
+
+type ParticulatePhosphorusProduction struct {
+	variable.PerPlanningUnitDecisionVariable
+	variable.Bounds
+
+	catchmentActions.Container
+
+	command variable.ChangeCommand
+
+	actionObserved action.ManagementAction
+
+	numberOfSubCatchments uint
+
+	hillSlopeDeliveryRatio float64
+
+	subCatchmentAttributes map[planningunit.Id]attributes.Attributes
+}
+
+func (pp *ParticulatePhosphorusProduction) Initialise(subCatchmentsTable tables.CsvTable, actionsTable tables.CsvTable, parameters catchmentParameters.Parameters) *ParticulatePhosphorusProduction {
+	pp.PerPlanningUnitDecisionVariable.Initialise()
+	pp.Container.WithActionsTable(actionsTable)
+
+	pp.SetName(VariableName)
+	pp.SetUnitOfMeasure(variable.TonnesPerYear)
+	pp.SetPrecision(3)
+
+	pp.hillSlopeDeliveryRatio = parameters.GetFloat64(catchmentParameters.HillSlopeDeliveryRatio)
+
+	pp.command = new(variable.NullChangeCommand)
+
+	pp.deriveInitialState(subCatchmentsTable, parameters)
+
+	return pp
+}
+
+func (pp *ParticulatePhosphorusProduction) WithName(variableName string) *ParticulatePhosphorusProduction {
+	pp.SetName(variableName)
+	return pp
+}
+
+func (pp *ParticulatePhosphorusProduction) WithStartingValue(value float64) *ParticulatePhosphorusProduction {
+	pp.SetPlanningUnitValue(0, value)
+	return pp
+}
+
+func (pp *ParticulatePhosphorusProduction) WithObservers(observers ...variable.Observer) *ParticulatePhosphorusProduction {
+	pp.Subscribe(observers...)
+	return pp
+}
+
+func (pp *ParticulatePhosphorusProduction) deriveInitialState(subCatchmentsTable tables.CsvTable, parameters catchmentParameters.Parameters) {
+	pp.deriveNumberOfSubCatchments(subCatchmentsTable)
+	pp.initialiseSubCatchmentAttributes()
+	pp.deriveInitialPhosphorus(subCatchmentsTable)
+}
+
+func (pp *ParticulatePhosphorusProduction) deriveNumberOfSubCatchments(subCatchmentsTable tables.CsvTable) {
+	_, rowCount := subCatchmentsTable.ColumnAndRowSize()
+	pp.numberOfSubCatchments = rowCount
+}
+
+func (pp *ParticulatePhosphorusProduction) initialiseSubCatchmentAttributes() {
+	pp.subCatchmentAttributes = make(map[planningunit.Id]attributes.Attributes, pp.numberOfSubCatchments)
+	for index, _ := range pp.subCatchmentAttributes {
+		newAttributes := make(attributes.Attributes, 0)
+		pp.subCatchmentAttributes[index] = newAttributes
+	}
+}
+
+func (pp *ParticulatePhosphorusProduction) deriveInitialPhosphorus(subCatchmentsTable tables.CsvTable) {
+	pp.buildDefaultSubCatchmentAttributes(subCatchmentsTable)
+	pp.replaceDefaultAttributeValuesWithActionValues()
+	pp.calculateInitialParticulatePhosphorusPerSubCatchment()
+}
+
+func (pp *ParticulatePhosphorusProduction) buildDefaultSubCatchmentAttributes(subCatchmentsTable tables.CsvTable) {
+	for row := uint(0); row < pp.numberOfSubCatchments; row++ {
+		subCatchmentFloat64 := subCatchmentsTable.CellFloat64(planningUnitIndex, row)
+		subCatchment := Float64ToSubCatchmentId(subCatchmentFloat64)
+
+		riverBankVegetationProportion := subCatchmentsTable.CellFloat64(proportionOfVegetationIndex, row)
+
+		pp.subCatchmentAttributes[subCatchment] =
+			pp.subCatchmentAttributes[subCatchment].
+				Add(RiverbankVegetationProportion, riverBankVegetationProportion).
+				Add(RiparianPhosphorusContribution, float64(0)).
+				Add(WetlandRemovalEfficiency, float64(0)).
+				Add(HillSlopePhosphorusContribution, float64(0)).
+				Add(GullyPhosphorusContribution, float64(0))
+	}
+}
+
+func (pp *ParticulatePhosphorusProduction) replaceDefaultAttributeValuesWithActionValues() {
+	for key, value := range pp.Map() {
+		components := pp.DeriveMapKeyComponents(key)
+		if components == nil {
+			continue
+		}
+
+		pp.calculateOriginalParticulatePhosphorusContributions(components, value)
+	}
+}
+
+func (pp *ParticulatePhosphorusProduction) calculateOriginalParticulatePhosphorusContributions(components *catchmentActions.KeyComponents, value float64) {
+	if components.ElementType != catchmentActions.ParticulatePhosphorusOriginalAttribute {
+		return
+	}
+
+	switch components.Action {
+	case catchmentActions.RiparianType:
+		pp.subCatchmentAttributes[components.SubCatchment] =
+			pp.subCatchmentAttributes[components.SubCatchment].Replace(RiparianPhosphorusContribution, value)
+	case catchmentActions.HillSlopeType:
+		deliveryAdjustedValue := value * pp.hillSlopeDeliveryRatio
+		pp.subCatchmentAttributes[components.SubCatchment] =
+			pp.subCatchmentAttributes[components.SubCatchment].Replace(HillSlopePhosphorusContribution, deliveryAdjustedValue)
+	case catchmentActions.GullyType:
+		pp.subCatchmentAttributes[components.SubCatchment] =
+			pp.subCatchmentAttributes[components.SubCatchment].Replace(GullyPhosphorusContribution, value)
+	default: // Deliberately does nothing
+	}
+}
+
+func (pp *ParticulatePhosphorusProduction) calculateInitialParticulatePhosphorusPerSubCatchment() {
+	for subCatchment, attributes := range pp.subCatchmentAttributes {
+		pp.updateParticulatePhosphorusFor(subCatchment, attributes)
+	}
+}
+
+type phosphorusContext struct {
+	riparianVegetationProportion float64
+
+	riparianContribution float64
+
+	wetlandRemovalEfficiency float64
+	hillSlopeContribution    float64
+
+	gullyContribution float64
+}
+
+func (pp *ParticulatePhosphorusProduction) updateParticulatePhosphorusFor(subCatchment planningunit.Id, attributes attributes.Attributes) {
+
+	context := phosphorusContext{
+		riparianVegetationProportion: attributes.Value(RiverbankVegetationProportion).(float64),
+		riparianContribution:         attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:            attributes.Value(GullyPhosphorusContribution).(float64),
+		wetlandRemovalEfficiency:     attributes.Value(WetlandRemovalEfficiency).(float64),
+		hillSlopeContribution:        attributes.Value(HillSlopePhosphorusContribution).(float64),
+	}
+
+	phosphorusProduced := pp.calculatePhosphorusProduction(context)
+	pp.SetPlanningUnitValue(subCatchment, phosphorusProduced)
+}
+
+func (pp *ParticulatePhosphorusProduction) calculatePhosphorusProduction(context phosphorusContext) float64 {
+	filteredHillSlopeContribution := pp.deriveHillSlopePhosphorusProduction(context)
+	phosphorusProduced := context.riparianContribution + context.gullyContribution + filteredHillSlopeContribution
+
+	roundedPhosphorusProduced := math.RoundFloat(phosphorusProduced, int(pp.Precision()))
+	return roundedPhosphorusProduced
+}
+
+func (pp *ParticulatePhosphorusProduction) deriveHillSlopePhosphorusProduction(context phosphorusContext) float64 {
+	wetlandMediatedHillSlopeContribution := (1 - context.wetlandRemovalEfficiency) * context.hillSlopeContribution
+
+	riparianFilter := riparianBufferFilter(context.riparianVegetationProportion)
+	filteredHillSlopeContribution := wetlandMediatedHillSlopeContribution * riparianFilter
+
+	return filteredHillSlopeContribution
+}
+
+func Float64ToSubCatchmentId(value float64) planningunit.Id {
+	return planningunit.Id(value)
+}
+
+func riparianBufferFilter(proportionOfRiparianBufferVegetation float64) float64 {
+	if proportionOfRiparianBufferVegetation < 0.25 {
+		return 1
+	}
+	if proportionOfRiparianBufferVegetation > 0.75 {
+		return 0.25
+	}
+	return 1 - proportionOfRiparianBufferVegetation
+}
+
+func (pp *ParticulatePhosphorusProduction) ObserveAction(action action.ManagementAction) {
+	pp.observeAction(action)
+}
+
+func (pp *ParticulatePhosphorusProduction) ObserveActionInitialising(action action.ManagementAction) {
+	pp.observeAction(action)
+	pp.command.Do()
+}
+
+func (pp *ParticulatePhosphorusProduction) observeAction(action action.ManagementAction) {
+	pp.actionObserved = action
+	switch pp.actionObserved.Type() {
+	case catchmentActions.RiverBankRestorationType:
+		pp.handleRiverBankRestorationAction()
+	case catchmentActions.GullyRestorationType:
+		pp.handleGullyRestorationAction()
+	case catchmentActions.HillSlopeRestorationType:
+		pp.handleHillSlopeRestorationAction()
+	case catchmentActions.WetlandsEstablishmentType:
+		pp.handleWetlandsEstablishmentAction()
+	default:
+		panic(errors.New("Unhandled observation of management action type [" + string(action.Type()) + "]"))
+	}
+}
+
+func (pp *ParticulatePhosphorusProduction) handleRiverBankRestorationAction() {
+	var asIsVegetation, asIsRiparianPhosphorus, toBeVegetation, toBeRiparianPhosphorus float64
+
+	switch pp.actionObserved.IsActive() {
+	case true:
+		asIsRiparianPhosphorus = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusOriginalAttribute)
+		asIsVegetation = pp.actionObserved.ModelVariableValue(catchmentActions.OriginalBufferVegetation)
+
+		toBeRiparianPhosphorus = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusActionedAttribute)
+		toBeVegetation = pp.actionObserved.ModelVariableValue(catchmentActions.ActionedBufferVegetation)
+	case false:
+		asIsRiparianPhosphorus = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusActionedAttribute)
+		asIsVegetation = pp.actionObserved.ModelVariableValue(catchmentActions.ActionedBufferVegetation)
+
+		toBeRiparianPhosphorus = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusOriginalAttribute)
+		toBeVegetation = pp.actionObserved.ModelVariableValue(catchmentActions.OriginalBufferVegetation)
+	}
+
+	actionSubCatchment := pp.actionObserved.PlanningUnit()
+	attributes := pp.subCatchmentAttributes[actionSubCatchment]
+
+	asIsContext := phosphorusContext{
+		riparianVegetationProportion: asIsVegetation,
+		wetlandRemovalEfficiency:     attributes.Value(WetlandRemovalEfficiency).(float64),
+
+		riparianContribution:  asIsRiparianPhosphorus,
+		gullyContribution:     attributes.Value(GullyPhosphorusContribution).(float64),
+		hillSlopeContribution: attributes.Value(HillSlopePhosphorusContribution).(float64),
+	}
+
+	asIsPhosphorus := pp.calculatePhosphorusProduction(asIsContext)
+
+	toBeContext := phosphorusContext{
+		riparianVegetationProportion: toBeVegetation,
+		wetlandRemovalEfficiency:     attributes.Value(WetlandRemovalEfficiency).(float64),
+
+		riparianContribution:  toBeRiparianPhosphorus,
+		gullyContribution:     attributes.Value(GullyPhosphorusContribution).(float64),
+		hillSlopeContribution: attributes.Value(HillSlopePhosphorusContribution).(float64),
+	}
+
+	toBePhosphorus := pp.calculatePhosphorusProduction(toBeContext)
+
+	pp.command = new(RiverBankRestorationCommand).
+		ForVariable(pp).
+		InPlanningUnit(actionSubCatchment).
+		WithVegetationProportion(toBeVegetation).
+		WithRiverBankPhosphorusContribution(toBeRiparianPhosphorus).
+		WithChange(toBePhosphorus - asIsPhosphorus)
+}
+
+func (pp *ParticulatePhosphorusProduction) handleGullyRestorationAction() {
+	var asIsGullyPhosphorus, toBeGullyPhosphorus float64
+
+	switch pp.actionObserved.IsActive() {
+	case true:
+		asIsGullyPhosphorus = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusOriginalAttribute)
+		toBeGullyPhosphorus = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusActionedAttribute)
+	case false:
+		asIsGullyPhosphorus = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusActionedAttribute)
+		toBeGullyPhosphorus = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusOriginalAttribute)
+	}
+
+	actionSubCatchment := pp.actionObserved.PlanningUnit()
+	attributes := pp.subCatchmentAttributes[actionSubCatchment]
+
+	asIsContext := phosphorusContext{
+		riparianVegetationProportion: attributes.Value(RiverbankVegetationProportion).(float64),
+		wetlandRemovalEfficiency:     attributes.Value(WetlandRemovalEfficiency).(float64),
+
+		riparianContribution:  attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:     asIsGullyPhosphorus,
+		hillSlopeContribution: attributes.Value(HillSlopePhosphorusContribution).(float64),
+	}
+
+	asIsPhosphorus := pp.calculatePhosphorusProduction(asIsContext)
+
+	toBeContext := phosphorusContext{
+		riparianVegetationProportion: attributes.Value(RiverbankVegetationProportion).(float64),
+		wetlandRemovalEfficiency:     attributes.Value(WetlandRemovalEfficiency).(float64),
+
+		riparianContribution:  attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:     toBeGullyPhosphorus,
+		hillSlopeContribution: attributes.Value(HillSlopePhosphorusContribution).(float64),
+	}
+
+	toBePhosphorus := pp.calculatePhosphorusProduction(toBeContext)
+
+	pp.command = new(GullyRestorationCommand).
+		ForVariable(pp).
+		InPlanningUnit(actionSubCatchment).
+		WithPhosphorusContribution(toBeGullyPhosphorus).
+		WithChange(toBePhosphorus - asIsPhosphorus)
+}
+
+func (pp *ParticulatePhosphorusProduction) handleHillSlopeRestorationAction() {
+	var asIsHillSlopePhosphorus, toBeHillSlopePhosphorus float64
+
+	switch pp.actionObserved.IsActive() {
+	case true:
+		asIsHillSlopePhosphorus = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusOriginalAttribute)
+		toBeHillSlopePhosphorus = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusActionedAttribute)
+	case false:
+		asIsHillSlopePhosphorus = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusActionedAttribute)
+		toBeHillSlopePhosphorus = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusOriginalAttribute)
+	}
+
+	actionSubCatchment := pp.actionObserved.PlanningUnit()
+	attributes := pp.subCatchmentAttributes[actionSubCatchment]
+
+	asIsContext := phosphorusContext{
+		riparianVegetationProportion: attributes.Value(RiverbankVegetationProportion).(float64),
+		wetlandRemovalEfficiency:     attributes.Value(WetlandRemovalEfficiency).(float64),
+
+		riparianContribution:  attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:     attributes.Value(GullyPhosphorusContribution).(float64),
+		hillSlopeContribution: asIsHillSlopePhosphorus,
+	}
+
+	asIsPhosphorus := pp.calculatePhosphorusProduction(asIsContext)
+
+	toBeContext := phosphorusContext{
+		riparianVegetationProportion: attributes.Value(RiverbankVegetationProportion).(float64),
+		wetlandRemovalEfficiency:     attributes.Value(WetlandRemovalEfficiency).(float64),
+
+		riparianContribution:  attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:     attributes.Value(GullyPhosphorusContribution).(float64),
+		hillSlopeContribution: toBeHillSlopePhosphorus,
+	}
+
+	toBePhosphorus := pp.calculatePhosphorusProduction(toBeContext)
+
+	pp.command = new(HillSlopeRevegetationCommand).
+		ForVariable(pp).
+		InPlanningUnit(actionSubCatchment).
+		WithFilteredPhosphorusContribution(toBeHillSlopePhosphorus).
+		WithChange(toBePhosphorus - asIsPhosphorus)
+}
+
+func (pp *ParticulatePhosphorusProduction) handleWetlandsEstablishmentAction() {
+	var asIsRemovalEfficiency, toBeRemovalEfficiency float64
+
+	switch pp.actionObserved.IsActive() {
+	case true:
+		asIsRemovalEfficiency = 0
+		toBeRemovalEfficiency = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusRemovalEfficiency)
+	case false:
+		asIsRemovalEfficiency = pp.actionObserved.ModelVariableValue(catchmentActions.ParticulatePhosphorusRemovalEfficiency)
+		toBeRemovalEfficiency = 0
+	}
+
+	actionSubCatchment := pp.actionObserved.PlanningUnit()
+	attributes := pp.subCatchmentAttributes[actionSubCatchment]
+
+	asIsContext := phosphorusContext{
+		riparianVegetationProportion: attributes.Value(RiverbankVegetationProportion).(float64),
+		wetlandRemovalEfficiency:     asIsRemovalEfficiency,
+
+		riparianContribution:  attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:     attributes.Value(GullyPhosphorusContribution).(float64),
+		hillSlopeContribution: attributes.Value(HillSlopePhosphorusContribution).(float64),
+	}
+
+	asIsPhosphorus := pp.calculatePhosphorusProduction(asIsContext)
+
+	toBeContext := phosphorusContext{
+		riparianVegetationProportion: attributes.Value(RiverbankVegetationProportion).(float64),
+		wetlandRemovalEfficiency:     toBeRemovalEfficiency,
+
+		riparianContribution:  attributes.Value(RiparianPhosphorusContribution).(float64),
+		gullyContribution:     attributes.Value(GullyPhosphorusContribution).(float64),
+		hillSlopeContribution: attributes.Value(HillSlopePhosphorusContribution).(float64),
+	}
+
+	toBePhosphorus := pp.calculatePhosphorusProduction(toBeContext)
+
+	pp.command = new(WetlandsEstablishmentCommand).
+		ForVariable(pp).
+		InPlanningUnit(actionSubCatchment).
+		WithRemovalEfficiency(toBeRemovalEfficiency).
+		WithChange(toBePhosphorus - asIsPhosphorus)
+}
+
+// NotifyObservers allows structs embedding a BaseInductiveDecisionVariable to trigger a notification of change
+// to any observers watching for state changes to the variableOld.
+func (pp *ParticulatePhosphorusProduction) NotifyObservers() {
+	for _, observer := range pp.Observers() {
+		observer.ObserveDecisionVariable(pp)
+	}
+}
+
+func (pp *ParticulatePhosphorusProduction) UndoableValue() float64 {
+	return pp.Value() + pp.command.Value()
+}
+
+func (pp *ParticulatePhosphorusProduction) SetUndoableValue(value float64) {
+	pp.command.SetChange(value)
+}
+
+func (pp *ParticulatePhosphorusProduction) DifferenceInValues() float64 {
+	return pp.command.Change()
+}
+
+func (pp *ParticulatePhosphorusProduction) ApplyDoneValue() {
+	pp.command.Do()
+}
+
+func (pp *ParticulatePhosphorusProduction) ApplyUndoneValue() {
+	pp.command.Undo()
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package totalphosphorus
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/dataset/tables"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	catchmentActions "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/actions"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/dissolvedphosphorus"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/variables/particulatephosphorus"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/pkg/math"
+)
+
+const (
+	VariableName = "TotalPhosphorus"
+
+	planningUnitIndex           = 0
+	proportionOfVegetationIndex = 8
+
+	ProportionOfRiparianVegetation               = "ProportionOfRiparianVegetation"
+	RiparianDissolvedPhosphorusRemovalEfficiency = "RiparianDissolvedPhosphorusRemovalEfficiency"
+	WetlandsDissolvedPhosphorusRemovalEfficiency = "WetlandsDissolvedPhosphorusRemovalEfficiency"
+	RiparianPhosphorusContribution               = "RiparianPhosphorusContribution"
+	GullyPhosphorusContribution                  = "GullyPhosphorusContribution"
+	HillSlopePhosphorusContribution              = "HillSlopePhosphorusContribution"
+)
+
+var _ variable.UndoableDecisionVariable = new(TotalPhosphorusProduction)
+
+type TotalPhosphorusProduction struct {
+	variable.PerPlanningUnitDecisionVariable
+	variable.Bounds
+
+	catchmentActions.Container
+
+	command variable.ChangeCommand
+
+	actionObserved action.ManagementAction
+
+	numberOfSubCatchments uint
+
+	particulatePhosphorus *particulatephosphorus.ParticulatePhosphorusProduction
+	dissolvedPhosphorus   *dissolvedphosphorus.DissolvedPhosphorusProduction
+
+	LastUpdated string
+}
+
+func (tp *TotalPhosphorusProduction) WithBasePhosphorusVariables(particulate *particulatephosphorus.ParticulatePhosphorusProduction, dissolved *dissolvedphosphorus.DissolvedPhosphorusProduction) *TotalPhosphorusProduction {
+	tp.particulatePhosphorus = particulate
+	tp.dissolvedPhosphorus = dissolved
+	return tp
+}
+
+func (tp *TotalPhosphorusProduction) Initialise(subCatchmentsTable tables.CsvTable, actionsTable tables.CsvTable, parameters catchmentParameters.Parameters) *TotalPhosphorusProduction {
+	tp.PerPlanningUnitDecisionVariable.Initialise()
+	tp.Container.WithActionsTable(actionsTable)
+
+	tp.SetName(VariableName)
+	tp.SetUnitOfMeasure(variable.TonnesPerYear)
+	tp.SetPrecision(3)
+
+	tp.deriveInitialState(subCatchmentsTable)
+
+	tp.command = new(variable.NullChangeCommand)
+
+	return tp
+}
+
+func (tp *TotalPhosphorusProduction) deriveInitialState(subCatchmentsTable tables.CsvTable) {
+	tp.deriveNumberOfSubCatchments(subCatchmentsTable)
+	tp.deriveInitialPhosphorus(subCatchmentsTable)
+}
+
+func (tp *TotalPhosphorusProduction) deriveNumberOfSubCatchments(subCatchmentsTable tables.CsvTable) {
+	_, rowCount := subCatchmentsTable.ColumnAndRowSize()
+	tp.numberOfSubCatchments = rowCount
+}
+
+func (tp *TotalPhosphorusProduction) deriveInitialPhosphorus(subCatchmentsTable tables.CsvTable) {
+	for row := uint(0); row < tp.numberOfSubCatchments; row++ {
+		subCatchmentFloat64 := subCatchmentsTable.CellFloat64(planningUnitIndex, row)
+		subCatchment := Float64ToSubCatchmentId(subCatchmentFloat64)
+		tp.calculateTotalPhosphorusForPlanningUnit(subCatchment)
+	}
+}
+
+func Float64ToSubCatchmentId(value float64) planningunit.Id {
+	return planningunit.Id(value)
+}
+
+func (tp *TotalPhosphorusProduction) calculateTotalPhosphorusForPlanningUnit(pu planningunit.Id) {
+	particulateValue := math.RoundFloat(tp.particulatePhosphorus.PlanningUnitValue(pu), int(tp.Precision()))
+	dissolvedValue := math.RoundFloat(tp.dissolvedPhosphorus.PlanningUnitValue(pu), int(tp.Precision()))
+	roundedTotal := math.RoundFloat(particulateValue+dissolvedValue, int(tp.Precision()))
+	tp.SetPlanningUnitValue(pu, roundedTotal)
+}
+
+func (tp *TotalPhosphorusProduction) WithName(variableName string) *TotalPhosphorusProduction {
+	tp.SetName(variableName)
+	return tp
+}
+
+func (tp *TotalPhosphorusProduction) WithStartingValue(value float64) *TotalPhosphorusProduction {
+	tp.SetPlanningUnitValue(0, value)
+	return tp
+}
+
+func (tp *TotalPhosphorusProduction) WithObservers(observers ...variable.Observer) *TotalPhosphorusProduction {
+	tp.Subscribe(observers...)
+	return tp
+}
+
+func (tp *TotalPhosphorusProduction) ObserveAction(action action.ManagementAction) {
+	tp.observeAction(action)
+}
+
+func (tp *TotalPhosphorusProduction) ObserveActionInitialising(action action.ManagementAction) {
+	tp.observeAction(action)
+	tp.command.Do()
+}
+
+func (tp *TotalPhosphorusProduction) observeAction(action action.ManagementAction) {
+	tp.actionObserved = action
+
+	particulateChange := math.RoundFloat(tp.particulatePhosphorus.DifferenceInValues(), int(tp.Precision()))
+	dissolvedChange := math.RoundFloat(tp.dissolvedPhosphorus.DifferenceInValues(), int(tp.Precision()))
+
+	roundedChange := math.RoundFloat(particulateChange+dissolvedChange, int(tp.Precision()))
+
+	tp.command = new(variable.ChangePerPlanningUnitDecisionVariableCommand).
+		ForVariable(tp).
+		InPlanningUnit(tp.actionObserved.PlanningUnit()).
+		WithChange(roundedChange)
+}
+
+// NotifyObservers allows structs embedding a BaseInductiveDecisionVariable to trigger a notification of change
+// to any observers watching for state changes to the variableOld.
+func (tp *TotalPhosphorusProduction) NotifyObservers() {
+	for _, observer := range tp.Observers() {
+		observer.ObserveDecisionVariable(tp)
+	}
+}
+
+func (tp *TotalPhosphorusProduction) UndoableValue() float64 {
+	return tp.Value() + tp.command.Value()
+}
+
+func (tp *TotalPhosphorusProduction) SetUndoableValue(value float64) {
+	tp.command.SetChange(value)
+}
+
+func (tp *TotalPhosphorusProduction) DifferenceInValues() float64 {
+	return tp.command.Change()
+}
+
+func (tp *TotalPhosphorusProduction) ApplyDoneValue() {
+	tp.command.Do()
+}
+
+func (tp *TotalPhosphorusProduction) ApplyUndoneValue() {
+	tp.command.Undo()
+}
@@ -68,7 +68,7 @@ const (
 )
 
 func (m *Model) Initialise(initialisationType model.InitialisationType) {
-	m.SetRandomNumberGenerator(rand.NewTimeSeeded())
+	m.ResetRandomNumberGenerator()
 }
 
 func (m *Model) Randomize() {
@@ -134,6 +134,10 @@ func (m *Model) ActiveManagementActions() []action.ManagementAction  { return ni
 func (m *Model) SetManagementAction(index int, value bool)           {}
 func (m *Model) SetManagementActionUnobserved(index int, value bool) {}
 
+func (m *Model) EvaluateManagementActionToggle(index int) model.DecisionVariableDeltas {
+	return model.DecisionVariableDeltas{}
+}
+
 func (m *Model) PlanningUnits() planningunit.Ids { return nil }
 
 func (m *Model) DeepClone() model.Model {
@@ -201,8 +201,17 @@ func (m *Model) SetManagementActionUnobserved(index int, value bool) {
 	m.managementActions.SetActivationUnobserved(index, value)
 }
 
+func (m *Model) EvaluateManagementActionToggle(index int) model.DecisionVariableDeltas {
+	return model.EvaluateToggleOf(m.ManagementActions()[index], m.ContainedDecisionVariables.UndoableDecisionVariables)
+}
+
 func (m *Model) PlanningUnits() planningunit.Ids { return nil }
 
+// SeedRandomNumbers drives the random selection of management actions to toggle from seed.
+func (m *Model) SeedRandomNumbers(seed int64) {
+	m.managementActions.SeedRandomNumbers(seed)
+}
+
 func (m *Model) DeepClone() model.Model {
 	clone := *m
 	clone.SetRandomNumberGenerator(rand.NewTimeSeeded())
@@ -0,0 +1,105 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package program
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/pkg/errors"
+)
+
+const activationThreshold = 0.5
+
+// Assignment maps the names of program columns to whether a solver activated them.
+type Assignment map[string]bool
+
+// ReadAssignment reads a solver's column values from reader.  Any line holding a column name followed by a number
+// is taken as a value for that column, covering the "name value" (e.g. Gurobi, HiGHS, SCIP) and
+// "index name value cost" (e.g. CBC) layouts of solver solution files.  Columns not listed are taken as inactive.
+func (p *Program) ReadAssignment(reader io.Reader) (Assignment, error) {
+	columnNames := make(map[string]bool, len(p.Columns))
+	for _, column := range p.Columns {
+		columnNames[column.Name] = true
+	}
+
+	assignment := make(Assignment)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		for index := 0; index < len(fields)-1; index++ {
+			if !columnNames[fields[index]] {
+				continue
+			}
+			value, parseError := strconv.ParseFloat(fields[index+1], 64)
+			if parseError != nil {
+				return nil, errors.Wrap(parseError, "reading value of column ["+fields[index]+"]")
+			}
+			assignment[fields[index]] = value > activationThreshold
+			break
+		}
+	}
+
+	if scanError := scanner.Err(); scanError != nil {
+		return nil, errors.Wrap(scanError, "reading solver assignment")
+	}
+	return assignment, nil
+}
+
+// ApplyTo sets the management actions of the model the program was built from to those of the assignment.
+func (p *Program) ApplyTo(model model.Model, assignment Assignment) error {
+	actions := model.ManagementActions()
+	if len(actions) != len(p.Columns) {
+		return errors.Errorf("program has [%d] columns, but model has [%d] management actions",
+			len(p.Columns), len(actions))
+	}
+
+	for _, column := range p.Columns {
+		if actions[column.ActionIndex].IsActive() != assignment[column.Name] {
+			model.SetManagementAction(column.ActionIndex, assignment[column.Name])
+		}
+	}
+	return nil
+}
+
+// PredictedValues returns the decision variable values the program predicts for an assignment, allowing its
+// linearity assumption to be checked against the model.
+func (p *Program) PredictedValues(assignment Assignment) map[string]float64 {
+	values := make(map[string]float64, len(p.Baseline))
+	for name, baseline := range p.Baseline {
+		values[name] = baseline
+	}
+	for _, column := range p.Columns {
+		if !assignment[column.Name] {
+			continue
+		}
+		for name, contribution := range column.Contributions {
+			values[name] += contribution
+		}
+	}
+	return values
+}
+
+// SolutionFrom reads a solver's assignment from reader, applies it to the model, and returns the resulting solution.
+func (p *Program) SolutionFrom(reader io.Reader, model model.Model, solutionId string) (*solution.Solution, error) {
+	assignment, readError := p.ReadAssignment(reader)
+	if readError != nil {
+		return nil, readError
+	}
+	if applyError := p.ApplyTo(model, assignment); applyError != nil {
+		return nil, applyError
+	}
+
+	return new(solution.SolutionBuilder).
+		WithId(solutionId).
+		ForModel(model).
+		Build(), nil
+}
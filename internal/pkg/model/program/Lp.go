@@ -0,0 +1,78 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package program
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+)
+
+const objectiveRowName = "obj"
+
+// WriteLp writes the program to writer in CPLEX LP format.
+func (p *Program) WriteLp(writer io.Writer) error {
+	lpWriter := bufio.NewWriter(writer)
+
+	fmt.Fprintf(lpWriter, "\\ Problem: %s\n", p.Name)
+	fmt.Fprintf(lpWriter, "\\ Objective [%s] excludes its baseline value of %s\n",
+		p.Objective, formatNumber(p.Baseline[p.Objective]))
+
+	if p.Sense == variable.Maximised {
+		fmt.Fprintln(lpWriter, "Maximize")
+	} else {
+		fmt.Fprintln(lpWriter, "Minimize")
+	}
+	fmt.Fprintf(lpWriter, " %s:", objectiveRowName)
+	p.writeLpTerms(lpWriter, p.Objective)
+	fmt.Fprintln(lpWriter)
+
+	fmt.Fprintln(lpWriter, "Subject To")
+	for _, constraint := range p.Constraints {
+		fmt.Fprintf(lpWriter, " %s:", constraint.Name)
+		p.writeLpTerms(lpWriter, constraint.Variable)
+		fmt.Fprintf(lpWriter, "\n   %s %s\n", lpOperatorOf(constraint.Sense), formatNumber(p.RightHandSide(constraint)))
+	}
+
+	fmt.Fprintln(lpWriter, "Binary")
+	for _, column := range p.Columns {
+		fmt.Fprintf(lpWriter, " %s\n", column.Name)
+	}
+	fmt.Fprintln(lpWriter, "End")
+
+	return lpWriter.Flush()
+}
+
+// writeLpTerms writes the non-zero column terms of a decision variable, one per line to keep lines short.
+func (p *Program) writeLpTerms(writer io.Writer, variableName string) {
+	termsWritten := 0
+	for _, column := range p.Columns {
+		coefficient := column.Contributions.Delta(variableName)
+		if coefficient == 0 {
+			continue
+		}
+		sign := "+"
+		if coefficient < 0 {
+			sign, coefficient = "-", -coefficient
+		}
+		fmt.Fprintf(writer, "\n   %s %s %s", sign, formatNumber(coefficient), column.Name)
+		termsWritten++
+	}
+	if termsWritten == 0 {
+		fmt.Fprintf(writer, "\n   0 %s", p.Columns[0].Name)
+	}
+}
+
+func lpOperatorOf(sense ConstraintSense) string {
+	if sense == AtLeast {
+		return ">="
+	}
+	return "<="
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
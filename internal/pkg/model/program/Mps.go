@@ -0,0 +1,65 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package program
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+)
+
+// WriteMps writes the program to writer in free MPS format, marking its columns as integer with bounds of 0 and 1.
+// Integer markers are quoted, as the MPS format requires of its 'MARKER', 'INTORG' and 'INTEND' keywords.
+func (p *Program) WriteMps(writer io.Writer) error {
+	mpsWriter := bufio.NewWriter(writer)
+
+	fmt.Fprintf(mpsWriter, "* Objective [%s] excludes its baseline value of %s\n",
+		p.Objective, formatNumber(p.Baseline[p.Objective]))
+	fmt.Fprintf(mpsWriter, "NAME %s\n", p.Name)
+
+	if p.Sense == variable.Maximised {
+		fmt.Fprintln(mpsWriter, "OBJSENSE")
+		fmt.Fprintln(mpsWriter, "    MAX")
+	}
+
+	fmt.Fprintln(mpsWriter, "ROWS")
+	fmt.Fprintf(mpsWriter, " N %s\n", objectiveRowName)
+	for _, constraint := range p.Constraints {
+		fmt.Fprintf(mpsWriter, " %s %s\n", mpsRowTypeOf(constraint.Sense), constraint.Name)
+	}
+
+	fmt.Fprintln(mpsWriter, "COLUMNS")
+	fmt.Fprintln(mpsWriter, "    MARKER 'MARKER' 'INTORG'")
+	for _, column := range p.Columns {
+		fmt.Fprintf(mpsWriter, "    %s %s %s\n",
+			column.Name, objectiveRowName, formatNumber(column.Contributions.Delta(p.Objective)))
+		for _, constraint := range p.Constraints {
+			if coefficient := column.Contributions.Delta(constraint.Variable); coefficient != 0 {
+				fmt.Fprintf(mpsWriter, "    %s %s %s\n", column.Name, constraint.Name, formatNumber(coefficient))
+			}
+		}
+	}
+	fmt.Fprintln(mpsWriter, "    MARKER 'MARKER' 'INTEND'")
+
+	fmt.Fprintln(mpsWriter, "RHS")
+	for _, constraint := range p.Constraints {
+		fmt.Fprintf(mpsWriter, "    RHS %s %s\n", constraint.Name, formatNumber(p.RightHandSide(constraint)))
+	}
+
+	fmt.Fprintln(mpsWriter, "BOUNDS")
+	for _, column := range p.Columns {
+		fmt.Fprintf(mpsWriter, " BV BND %s\n", column.Name)
+	}
+	fmt.Fprintln(mpsWriter, "ENDATA")
+
+	return mpsWriter.Flush()
+}
+
+func mpsRowTypeOf(sense ConstraintSense) string {
+	if sense == AtLeast {
+		return "G"
+	}
+	return "L"
+}
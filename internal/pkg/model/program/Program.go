@@ -0,0 +1,184 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package program expresses a model as a 0-1 mixed-integer (linear) program, with one binary column per management
+// action, so exact solvers can supply optima against which explorer solution quality can be judged.
+// The program treats decision variables as sums of per-action effects, measured at the model state it is built from.
+// Where a model's actions interact (as catchment sediment and nitrogen do between actions of a planning unit), the
+// program is a linear approximation, whose error for any solver assignment is shown by PredictedValues.
+package program
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/pkg/errors"
+)
+
+// Program is a 0-1 program over the management actions of a model.  Every decision variable is expressed as its
+// baseline value (with no action active), plus the contribution of each active action.
+type Program struct {
+	Name string
+
+	Objective string
+	Sense     variable.OptimisationSense
+
+	Baseline    map[string]float64
+	Columns     []Column
+	Constraints []Constraint
+}
+
+// Column is a binary program variable, activating the model management action at ActionIndex.
+type Column struct {
+	Name          string
+	ActionIndex   int
+	Contributions model.DecisionVariableDeltas
+}
+
+type ConstraintSense int
+
+const (
+	AtMost ConstraintSense = iota
+	AtLeast
+)
+
+// Constraint requires the value of a decision variable to stay at most, or at least, at its Limit.
+type Constraint struct {
+	Name     string
+	Variable string
+	Sense    ConstraintSense
+	Limit    float64
+}
+
+// RightHandSide returns the constraint limit, less the decision variable's baseline value.
+func (p *Program) RightHandSide(constraint Constraint) float64 {
+	return constraint.Limit - p.Baseline[constraint.Variable]
+}
+
+// WriteFor writes the program to writer in free MPS format where fileName has an ".mps" extension, or in CPLEX LP
+// format otherwise.
+func (p *Program) WriteFor(fileName string, writer io.Writer) error {
+	if strings.EqualFold(filepath.Ext(fileName), ".mps") {
+		return p.WriteMps(writer)
+	}
+	return p.WriteLp(writer)
+}
+
+// Builder derives a Program from a model's current state, via the decision variable changes of toggling each of its
+// management actions.
+type Builder struct {
+	model     model.Model
+	objective string
+}
+
+func (b *Builder) ForModel(model model.Model) *Builder {
+	b.model = model
+	return b
+}
+
+func (b *Builder) WithObjective(decisionVariableName string) *Builder {
+	b.objective = decisionVariableName
+	return b
+}
+
+func (b *Builder) Build() (*Program, error) {
+	if b.model == nil || len(b.model.ManagementActions()) == 0 {
+		return nil, errors.New("program requires a model with management actions")
+	}
+	if !b.model.OffersDecisionVariable(b.objective) {
+		return nil, errors.New("model does not offer objective decision variable [" + b.objective + "]")
+	}
+
+	newProgram := &Program{
+		Name:      rowNameOf(b.model.Name()),
+		Objective: b.objective,
+		Sense:     variable.SenseOf(b.model.DecisionVariable(b.objective)),
+		Baseline:  b.currentValues(),
+	}
+
+	b.addColumns(newProgram)
+	b.addConstraints(newProgram)
+
+	return newProgram, nil
+}
+
+func (b *Builder) currentValues() map[string]float64 {
+	values := make(map[string]float64)
+	for name, decisionVariable := range *b.model.NameMappedVariables() {
+		values[name] = decisionVariable.Value()
+	}
+	return values
+}
+
+// addColumns adds a column per management action, removing the contributions of active actions from the program
+// baseline as it goes.
+func (b *Builder) addColumns(program *Program) {
+	usedNames := make(map[string]bool)
+	for index, action := range b.model.ManagementActions() {
+		contributions := b.model.EvaluateManagementActionToggle(index)
+		if action.IsActive() {
+			for name, delta := range contributions {
+				contributions[name] = -delta
+			}
+			for name, contribution := range contributions {
+				program.Baseline[name] -= contribution
+			}
+		}
+
+		columnName := columnNameOf(action, index, usedNames)
+		program.Columns = append(program.Columns,
+			Column{Name: columnName, ActionIndex: index, Contributions: contributions})
+	}
+}
+
+func (b *Builder) addConstraints(program *Program) {
+	for _, name := range sortedNamesOf(program.Baseline) {
+		limitedVariable, isLimited := b.model.DecisionVariable(name).(variable.Limited)
+		if !isLimited {
+			continue
+		}
+		if minimum, hasMinimum := limitedVariable.Minimum(); hasMinimum {
+			program.Constraints = append(program.Constraints,
+				Constraint{Name: rowNameOf(name) + "_Min", Variable: name, Sense: AtLeast, Limit: minimum})
+		}
+		if maximum, hasMaximum := limitedVariable.Maximum(); hasMaximum {
+			program.Constraints = append(program.Constraints,
+				Constraint{Name: rowNameOf(name) + "_Max", Variable: name, Sense: AtMost, Limit: maximum})
+		}
+	}
+}
+
+func sortedNamesOf(values map[string]float64) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var unsafeNameCharacters = regexp.MustCompile(`[^A-Za-z0-9_.]`)
+
+// rowNameOf returns name, stripped of characters LP and MPS readers may not accept.
+func rowNameOf(name string) string {
+	safeName := unsafeNameCharacters.ReplaceAllString(name, "_")
+	if safeName == "" {
+		return "Program"
+	}
+	return safeName
+}
+
+func columnNameOf(managementAction action.ManagementAction, index int, usedNames map[string]bool) string {
+	name := rowNameOf(fmt.Sprintf("%s_%d", managementAction.Type(), managementAction.PlanningUnit()))
+	if usedNames[name] {
+		name = fmt.Sprintf("%s_%d", name, index)
+	}
+	usedNames[name] = true
+	return name
+}
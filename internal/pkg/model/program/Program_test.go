@@ -0,0 +1,209 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package program
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	catchmenttest "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/test"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	costVariable       = "ImplementationCost"
+	sedimentVariable   = "SedimentProduction"
+	maximumCost        = 500_000.0
+	comparisonAccuracy = 1e-6
+)
+
+// additiveVariables are those catchment decision variables without interactions between management actions.
+var additiveVariables = []string{costVariable, "OpportunityCost"}
+
+func buildTestingModel(g *GomegaWithT) model.Model {
+	modelUnderTest, buildError := catchmenttest.NewTestingModel(parameters.Map{catchmentParameters.MaximumImplementationCost: maximumCost})
+	g.Expect(buildError).To(BeNil())
+	return modelUnderTest
+}
+
+func buildProgram(g *GomegaWithT, modelUnderTest model.Model) *Program {
+	programUnderTest, buildError := new(Builder).
+		ForModel(modelUnderTest).
+		WithObjective(sedimentVariable).
+		Build()
+
+	g.Expect(buildError).To(BeNil())
+	return programUnderTest
+}
+
+func TestBuilder_UnknownObjective_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	_, buildError := new(Builder).ForModel(buildTestingModel(g)).WithObjective("Happiness").Build()
+
+	// then
+	g.Expect(buildError).To(Not(BeNil()))
+}
+
+func TestBuilder_AsIsModel_ColumnPerActionAndBoundConstraint(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestingModel(g)
+
+	// when
+	programUnderTest := buildProgram(g, modelUnderTest)
+
+	// then
+	g.Expect(programUnderTest.Columns).To(HaveLen(len(modelUnderTest.ManagementActions())))
+	g.Expect(programUnderTest.Constraints).To(Equal([]Constraint{
+		{Name: costVariable + "_Max", Variable: costVariable, Sense: AtMost, Limit: maximumCost},
+	}))
+	g.Expect(programUnderTest.Baseline[costVariable]).To(BeZero())
+	g.Expect(programUnderTest.RightHandSide(programUnderTest.Constraints[0])).To(Equal(maximumCost))
+}
+
+func TestBuilder_ActiveActions_SameProgramAsAsIs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	asIsProgram := buildProgram(g, buildTestingModel(g))
+
+	modelUnderTest := buildTestingModel(g)
+	for index := range modelUnderTest.ManagementActions() {
+		if index%3 == 0 {
+			modelUnderTest.SetManagementAction(index, true)
+		}
+	}
+
+	// when
+	programUnderTest := buildProgram(g, modelUnderTest)
+
+	// then
+	for _, name := range additiveVariables {
+		g.Expect(programUnderTest.Baseline[name]).To(BeNumerically("~", asIsProgram.Baseline[name], comparisonAccuracy))
+		for index, column := range asIsProgram.Columns {
+			g.Expect(programUnderTest.Columns[index].Name).To(Equal(column.Name))
+			g.Expect(programUnderTest.Columns[index].Contributions.Delta(name)).To(
+				BeNumerically("~", column.Contributions.Delta(name), comparisonAccuracy),
+				"column [%s], variable [%s]", column.Name, name)
+		}
+	}
+}
+
+func TestProgram_WriteLp_AsExpected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	programUnderTest := buildProgram(g, buildTestingModel(g))
+	var written bytes.Buffer
+
+	// when
+	writeError := programUnderTest.WriteFor("benchmark.lp", &written)
+
+	// then
+	g.Expect(writeError).To(BeNil())
+	lp := written.String()
+
+	g.Expect(lp).To(ContainSubstring("Minimize\n obj:"))
+	g.Expect(lp).To(ContainSubstring("Subject To\n " + costVariable + "_Max:"))
+	g.Expect(lp).To(ContainSubstring(fmt.Sprintf("<= %g\n", maximumCost)))
+	g.Expect(lp).To(ContainSubstring("Binary\n " + programUnderTest.Columns[0].Name + "\n"))
+	g.Expect(lp).To(HaveSuffix("End\n"))
+}
+
+func TestProgram_WriteMps_AsExpected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	programUnderTest := buildProgram(g, buildTestingModel(g))
+	var written bytes.Buffer
+
+	// when
+	writeError := programUnderTest.WriteFor("benchmark.MPS", &written)
+
+	// then
+	g.Expect(writeError).To(BeNil())
+	mps := written.String()
+
+	g.Expect(mps).To(ContainSubstring("ROWS\n N obj\n L " + costVariable + "_Max\n"))
+	g.Expect(mps).To(ContainSubstring("    MARKER 'MARKER' 'INTORG'\n"))
+	g.Expect(mps).To(ContainSubstring("    MARKER 'MARKER' 'INTEND'\nRHS\n"))
+	g.Expect(mps).To(ContainSubstring(fmt.Sprintf("    RHS %s_Max %g\n", costVariable, maximumCost)))
+	g.Expect(strings.Count(mps, " BV BND ")).To(Equal(len(programUnderTest.Columns)))
+	g.Expect(mps).To(HaveSuffix("ENDATA\n"))
+}
+
+func TestProgram_ReadAssignment_SolverLayouts(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	programUnderTest := buildProgram(g, buildTestingModel(g))
+	first, second, third := programUnderTest.Columns[0].Name, programUnderTest.Columns[1].Name,
+		programUnderTest.Columns[2].Name
+
+	solverOutputs := map[string]string{
+		"Gurobi": fmt.Sprintf("# Objective value = 12.3\n%s 1\n%s 0\n%s 1\n", first, second, third),
+		"CBC":    fmt.Sprintf("Optimal - objective value 12.3\n      0 %s 1 -2.5\n      2 %s 1 -0.5\n", first, third),
+	}
+
+	for solver, output := range solverOutputs {
+		// when
+		assignment, readError := programUnderTest.ReadAssignment(strings.NewReader(output))
+
+		// then
+		g.Expect(readError).To(BeNil(), solver)
+		g.Expect(assignment[first]).To(BeTrue(), solver)
+		g.Expect(assignment[second]).To(BeFalse(), solver)
+		g.Expect(assignment[third]).To(BeTrue(), solver)
+	}
+}
+
+func TestProgram_ReadAssignment_BadValue_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	programUnderTest := buildProgram(g, buildTestingModel(g))
+	output := programUnderTest.Columns[0].Name + " yes\n"
+
+	// when
+	_, readError := programUnderTest.ReadAssignment(strings.NewReader(output))
+
+	// then
+	g.Expect(readError).To(Not(BeNil()))
+}
+
+func TestProgram_SolutionFrom_MatchesPredictedValues(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := buildTestingModel(g)
+	programUnderTest := buildProgram(g, modelUnderTest)
+
+	var output strings.Builder
+	for index, column := range programUnderTest.Columns {
+		fmt.Fprintf(&output, "%s %d\n", column.Name, index%2)
+	}
+
+	// when
+	importedSolution, importError :=
+		programUnderTest.SolutionFrom(strings.NewReader(output.String()), modelUnderTest, "Program Solution")
+
+	// then
+	g.Expect(importError).To(BeNil())
+	g.Expect(importedSolution.Id).To(Equal("Program Solution"))
+	g.Expect(modelUnderTest.ActiveManagementActions()).To(HaveLen(len(programUnderTest.Columns) / 2))
+
+	assignment, _ := programUnderTest.ReadAssignment(strings.NewReader(output.String()))
+	predictedValues := programUnderTest.PredictedValues(assignment)
+	for _, name := range additiveVariables {
+		g.Expect(modelUnderTest.DecisionVariable(name).Value()).To(
+			BeNumerically("~", predictedValues[name], comparisonAccuracy), name)
+	}
+}
@@ -14,32 +14,47 @@ type Bounded interface {
 	BoundErrorAsText(value float64) string
 }
 
+// Limited is implemented by decision variables that can report the bounds placed on their values.
+type Limited interface {
+	Minimum() (minimum float64, hasMinimum bool)
+	Maximum() (maximum float64, hasMaximum bool)
+}
+
 var converter = strings.NewConverter().Localised().WithFloatingPointPrecision(6).PaddingZeros()
 
 var _ Bounded = new(Bounds)
+var _ Limited = new(Bounds)
 
 type Bounds struct {
-	// hasMinimum bool
-	// minimum    float64
+	hasMinimum bool
+	minimum    float64
 
 	hasMaximum bool
 	maximum    float64
 }
 
-// func (vb *Bounds) SetMinimum(minimum float64) {
-// 	vb.hasMinimum = true
-// 	vb.minimum = minimum
-// }
+func (vb *Bounds) SetMinimum(minimum float64) {
+	vb.hasMinimum = true
+	vb.minimum = minimum
+}
 
 func (vb *Bounds) SetMaximum(maximum float64) {
 	vb.hasMaximum = true
 	vb.maximum = maximum
 }
 
+func (vb *Bounds) Minimum() (minimum float64, hasMinimum bool) {
+	return vb.minimum, vb.hasMinimum
+}
+
+func (vb *Bounds) Maximum() (maximum float64, hasMaximum bool) {
+	return vb.maximum, vb.hasMaximum
+}
+
 func (vb *Bounds) WithinBounds(value float64) bool {
-	// if vb.hasMinimum && value < vb.minimum {
-	// 	return false
-	// }
+	if vb.hasMinimum && value < vb.minimum {
+		return false
+	}
 
 	if vb.hasMaximum && value > vb.maximum {
 		return false
@@ -51,10 +66,10 @@ func (vb *Bounds) WithinBounds(value float64) bool {
 func (vb *Bounds) BoundErrorAsText(value float64) string {
 	boundMessages := make([]string, 0)
 
-	// if vb.hasMinimum && value < vb.minimum {
-	// 	lowerBoundAsString := converter.Convert(vb.minimum)
-	// 	boundMessages = append(boundMessages, fmt.Sprintf("< lower bound %s", lowerBoundAsString))
-	// }
+	if vb.hasMinimum && value < vb.minimum {
+		lowerBoundAsString := converter.Convert(vb.minimum)
+		boundMessages = append(boundMessages, fmt.Sprintf("< lower bound %s", lowerBoundAsString))
+	}
 
 	if vb.hasMaximum && value > vb.maximum {
 		upperBoundAsString := converter.Convert(vb.maximum)
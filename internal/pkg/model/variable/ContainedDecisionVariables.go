@@ -39,7 +39,12 @@ func (c *ContainedDecisionVariables) DecisionVariable(name string) DecisionVaria
 }
 
 func (c *ContainedDecisionVariables) OffersDecisionVariable(name string) bool {
-	return c.UndoableDecisionVariables.Variable(name) != nil
+	for _, variable := range c.UndoableDecisionVariables {
+		if variable.Name() == name {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *ContainedDecisionVariables) DecisionVariableChange(variableName string) float64 {
@@ -33,12 +33,45 @@ const (
 	NotApplicable UnitOfMeasure = "Not Applicable (NA)"
 	TonnesPerYear UnitOfMeasure = "Tonnes per Year (t/y)"
 	Dollars       UnitOfMeasure = "Dollars ($)"
+
+	TonnesOfCarbonDioxideEquivalentPerYear UnitOfMeasure = "Tonnes CO2-e per Year (tCO2-e/y)"
+	HabitatScore                           UnitOfMeasure = "Habitat Score (HS)"
 )
 
 const defaultUnitOfMeasure = NotApplicable
 
 type Precision int
 
+// OptimisationSense identifies whether lower or higher values of a decision variable are considered better.
+type OptimisationSense int
+
+const (
+	Minimised OptimisationSense = iota
+	Maximised
+)
+
+func (sense OptimisationSense) String() string {
+	switch sense {
+	case Maximised:
+		return "Maximised"
+	default:
+		return "Minimised"
+	}
+}
+
+// Sensed is implemented by decision variables that declare which OptimisationSense is preferable for them.
+type Sensed interface {
+	OptimisationSense() OptimisationSense
+}
+
+// SenseOf returns the OptimisationSense of the variable supplied. Variables not implementing Sensed are Minimised.
+func SenseOf(variable DecisionVariable) OptimisationSense {
+	if sensedVariable, isSensed := variable.(Sensed); isSensed {
+		return sensedVariable.OptimisationSense()
+	}
+	return Minimised
+}
+
 const defaultPrecision = 3
 
 var _ DecisionVariable = NewSimpleDecisionVariable("test")
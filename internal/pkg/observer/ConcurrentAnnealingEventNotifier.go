@@ -0,0 +1,352 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package observer
+
+import (
+	"errors"
+	"sync"
+)
+
+// BackPressurePolicy decides what a ConcurrentAnnealingEventNotifier does with events for an observer whose buffer is
+// already full.
+type BackPressurePolicy int
+
+const (
+	// Block has the notifier wait for room in the observer's buffer.
+	Block BackPressurePolicy = iota
+	// DropOldest discards the oldest event buffered for the observer, making room for the new one.
+	DropOldest
+	// Sample keeps only one in every sample interval of the events arriving while the observer's buffer is full,
+	// waiting for room for those kept, and discarding the rest.
+	Sample
+)
+
+func (policy BackPressurePolicy) String() string {
+	labels := [...]string{"Block", "DropOldest", "Sample"}
+	if policy < Block || policy > Sample {
+		return "Unknown"
+	}
+	return labels[policy]
+}
+
+const (
+	DefaultEventBufferSize     = 1_000
+	DefaultEventSampleInterval = 10
+)
+
+// ConcurrentAnnealingEventNotifier delivers events to each of its observers on a goroutine dedicated to that
+// observer, buffering events so that slow observers do not slow annealing.  Each observer receives the events it is
+// given in the order they were notified.  Annealing state events (StartedAnnealing, FinishedAnnealing) are never
+// discarded under back-pressure, and notifying observers of FinishedAnnealing waits until every event notified so far
+// has been delivered, so that observers have finished with an annealer's final state before it moves on.  Each
+// observer's goroutine runs until the notifier is closed, so notifiers must be closed once finished with.
+type ConcurrentAnnealingEventNotifier struct {
+	bufferSize     int
+	backPressure   BackPressurePolicy
+	sampleInterval uint64
+
+	mutex     sync.RWMutex
+	observers []Observer
+	queues    []*observerQueue
+	closed    bool
+}
+
+func NewConcurrentAnnealingEventNotifier() *ConcurrentAnnealingEventNotifier {
+	return &ConcurrentAnnealingEventNotifier{
+		bufferSize:     DefaultEventBufferSize,
+		backPressure:   Block,
+		sampleInterval: DefaultEventSampleInterval,
+	}
+}
+
+// WithBufferSize sets how many events may be waiting on delivery to each observer. It must be set before any
+// observers are added.
+func (notifier *ConcurrentAnnealingEventNotifier) WithBufferSize(bufferSize int) *ConcurrentAnnealingEventNotifier {
+	if bufferSize > 0 {
+		notifier.bufferSize = bufferSize
+	}
+	return notifier
+}
+
+// WithBackPressure sets what happens to events for observers whose buffer is full. It must be set before any
+// observers are added.
+func (notifier *ConcurrentAnnealingEventNotifier) WithBackPressure(policy BackPressurePolicy) *ConcurrentAnnealingEventNotifier {
+	notifier.backPressure = policy
+	return notifier
+}
+
+// WithSampleInterval sets how many of the events arriving for an observer whose buffer is full are represented by
+// each event kept, under the Sample back-pressure policy. It must be set before any observers are added.
+func (notifier *ConcurrentAnnealingEventNotifier) WithSampleInterval(interval uint64) *ConcurrentAnnealingEventNotifier {
+	if interval > 0 {
+		notifier.sampleInterval = interval
+	}
+	return notifier
+}
+
+func (notifier *ConcurrentAnnealingEventNotifier) HasObservers() bool {
+	notifier.mutex.RLock()
+	defer notifier.mutex.RUnlock()
+	return len(notifier.observers) > 0
+}
+
+func (notifier *ConcurrentAnnealingEventNotifier) Observers() []Observer {
+	notifier.mutex.RLock()
+	defer notifier.mutex.RUnlock()
+	if len(notifier.observers) == 0 {
+		return nil
+	}
+	return notifier.observers
+}
+
+func (notifier *ConcurrentAnnealingEventNotifier) AddObserver(newObserver Observer) error {
+	if newObserver == nil {
+		return errors.New("invalid attempt to add non-existent observer to annealing event notifier")
+	}
+
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	if notifier.closed {
+		return errors.New("invalid attempt to add observer to closed annealing event notifier")
+	}
+	newQueue := notifier.newQueueFor(newObserver)
+	notifier.observers = append(notifier.observers, newObserver)
+	notifier.queues = append(notifier.queues, newQueue)
+	return nil
+}
+
+func (notifier *ConcurrentAnnealingEventNotifier) AddObserverAsFirst(newObserver Observer) error {
+	if newObserver == nil {
+		return errors.New("invalid attempt to add non-existent observer to annealing event notifier")
+	}
+
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	if notifier.closed {
+		return errors.New("invalid attempt to add observer to closed annealing event notifier")
+	}
+	newQueue := notifier.newQueueFor(newObserver)
+	notifier.observers = append([]Observer{newObserver}, notifier.observers...)
+	notifier.queues = append([]*observerQueue{newQueue}, notifier.queues...)
+	return nil
+}
+
+func (notifier *ConcurrentAnnealingEventNotifier) newQueueFor(newObserver Observer) *observerQueue {
+	newQueue := &observerQueue{
+		observer:       newObserver,
+		capacity:       notifier.bufferSize,
+		backPressure:   notifier.backPressure,
+		sampleInterval: notifier.sampleInterval,
+	}
+	newQueue.changed = sync.NewCond(&newQueue.mutex)
+	go newQueue.deliver()
+	return newQueue
+}
+
+func (notifier *ConcurrentAnnealingEventNotifier) NotifyObserversOfEvent(event Event) {
+	notifier.mutex.RLock()
+	queues := notifier.queues
+	notifier.mutex.RUnlock()
+
+	for _, queue := range queues {
+		queue.enqueue(event)
+	}
+
+	if event.EventType == FinishedAnnealing {
+		notifier.flush(queues)
+	}
+}
+
+// DeepClone returns a notifier of the same configuration, delivering to the same observers on goroutines of its own,
+// so that concurrent annealing runs neither share buffers nor close each other's goroutines.
+func (notifier *ConcurrentAnnealingEventNotifier) DeepClone() EventNotifier {
+	clone := NewConcurrentAnnealingEventNotifier().
+		WithBufferSize(notifier.bufferSize).
+		WithBackPressure(notifier.backPressure).
+		WithSampleInterval(notifier.sampleInterval)
+
+	for _, observer := range notifier.Observers() {
+		clone.AddObserver(observer)
+	}
+	return clone
+}
+
+// Close delivers every event notified so far, then stops the goroutine of each observer.  Events notified after
+// closing are discarded.  Closing an already closed notifier does nothing.
+func (notifier *ConcurrentAnnealingEventNotifier) Close() {
+	notifier.mutex.Lock()
+	queues := notifier.queues
+	notifier.closed = true
+	notifier.mutex.Unlock()
+
+	for _, queue := range queues {
+		queue.close()
+	}
+}
+
+// Flush waits until every event notified so far has been delivered to (or discarded for) every observer.
+func (notifier *ConcurrentAnnealingEventNotifier) Flush() {
+	notifier.mutex.RLock()
+	queues := notifier.queues
+	notifier.mutex.RUnlock()
+
+	notifier.flush(queues)
+}
+
+func (notifier *ConcurrentAnnealingEventNotifier) flush(queues []*observerQueue) {
+	for _, queue := range queues {
+		queue.flush()
+	}
+}
+
+// Dropped returns how many events have been discarded under back-pressure, across all observers.
+func (notifier *ConcurrentAnnealingEventNotifier) Dropped() uint64 {
+	notifier.mutex.RLock()
+	queues := notifier.queues
+	notifier.mutex.RUnlock()
+
+	dropped := uint64(0)
+	for _, queue := range queues {
+		dropped += queue.droppedCount()
+	}
+	return dropped
+}
+
+// observerQueue buffers the events waiting on delivery to a single observer, delivering them in order on its own
+// goroutine.  Buffered events are numbered in sequence, letting flushing wait on just those events buffered before it,
+// even while others keep arriving.
+type observerQueue struct {
+	observer Observer
+
+	capacity       int
+	backPressure   BackPressurePolicy
+	sampleInterval uint64
+
+	mutex   sync.Mutex
+	changed *sync.Cond
+	events  []sequencedEvent
+
+	lastBuffered  uint64
+	lastDelivered uint64
+	dropped       uint64
+	sampled       uint64
+	closed        bool
+}
+
+type sequencedEvent struct {
+	sequence uint64
+	event    Event
+}
+
+func (queue *observerQueue) enqueue(event Event) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	if queue.closed {
+		return
+	}
+
+	if queue.isFull() && !mustDeliver(event) && !queue.makeRoomFor() {
+		queue.dropped++
+		return
+	}
+
+	for queue.isFull() && !queue.closed {
+		queue.changed.Wait()
+	}
+	if queue.closed {
+		return
+	}
+
+	queue.lastBuffered++
+	queue.events = append(queue.events, sequencedEvent{sequence: queue.lastBuffered, event: event})
+	queue.changed.Broadcast()
+}
+
+func (queue *observerQueue) isFull() bool {
+	return len(queue.events) >= queue.capacity
+}
+
+func mustDeliver(event Event) bool {
+	return event.EventType == StartedAnnealing || event.EventType == FinishedAnnealing
+}
+
+// makeRoomFor applies the back-pressure policy to a full buffer, reporting whether the event arriving should still be
+// buffered.
+func (queue *observerQueue) makeRoomFor() bool {
+	switch queue.backPressure {
+	case DropOldest:
+		return queue.dropOldest()
+	case Sample:
+		queue.sampled++
+		return queue.sampled%queue.sampleInterval == 0
+	default:
+		return true
+	}
+}
+
+// dropOldest discards the oldest buffered event that may be discarded, reporting whether it found one.
+func (queue *observerQueue) dropOldest() bool {
+	for index, buffered := range queue.events {
+		if !mustDeliver(buffered.event) {
+			queue.events = append(queue.events[:index], queue.events[index+1:]...)
+			queue.dropped++
+			return true
+		}
+	}
+	return false
+}
+
+func (queue *observerQueue) deliver() {
+	for {
+		queue.mutex.Lock()
+		for len(queue.events) == 0 && !queue.closed {
+			queue.changed.Wait()
+		}
+		if len(queue.events) == 0 {
+			queue.mutex.Unlock()
+			return
+		}
+		next := queue.events[0]
+		queue.events = queue.events[1:]
+		queue.changed.Broadcast()
+		queue.mutex.Unlock()
+
+		queue.observer.ObserveEvent(next.event)
+
+		queue.mutex.Lock()
+		queue.lastDelivered = next.sequence
+		queue.changed.Broadcast()
+		queue.mutex.Unlock()
+	}
+}
+
+func (queue *observerQueue) flush() {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	target := queue.lastBuffered
+	for queue.lastDelivered < target {
+		queue.changed.Wait()
+	}
+}
+
+// close has the queue's goroutine stop once it has delivered the events already buffered, waiting until it has.
+func (queue *observerQueue) close() {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	queue.closed = true
+	queue.changed.Broadcast()
+
+	target := queue.lastBuffered
+	for queue.lastDelivered < target {
+		queue.changed.Wait()
+	}
+}
+
+func (queue *observerQueue) droppedCount() uint64 {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.dropped
+}
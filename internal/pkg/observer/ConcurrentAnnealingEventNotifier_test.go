@@ -0,0 +1,219 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package observer
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	iterationKey   = "Iteration"
+	testEventCount = 100
+)
+
+type recordingObserver struct {
+	mutex    sync.Mutex
+	received []Event
+	release  chan struct{}
+}
+
+func newRecordingObserver() *recordingObserver {
+	return &recordingObserver{}
+}
+
+// blockedUntilReleased has the observer wait on every event until release is closed.
+func (ro *recordingObserver) blockedUntilReleased() *recordingObserver {
+	ro.release = make(chan struct{})
+	return ro
+}
+
+func (ro *recordingObserver) ObserveEvent(event Event) {
+	if ro.release != nil {
+		<-ro.release
+	}
+	ro.mutex.Lock()
+	defer ro.mutex.Unlock()
+	ro.received = append(ro.received, event)
+}
+
+func (ro *recordingObserver) iterations() []int {
+	ro.mutex.Lock()
+	defer ro.mutex.Unlock()
+
+	iterations := make([]int, 0, len(ro.received))
+	for _, event := range ro.received {
+		if iteration, hasIteration := event.Attribute(iterationKey).(int); hasIteration {
+			iterations = append(iterations, iteration)
+		}
+	}
+	return iterations
+}
+
+func (ro *recordingObserver) lastEventType() EventType {
+	ro.mutex.Lock()
+	defer ro.mutex.Unlock()
+	return ro.received[len(ro.received)-1].EventType
+}
+
+func notifyAnnealing(notifier EventNotifier, iterations int) {
+	notifier.NotifyObserversOfEvent(*NewEvent(StartedAnnealing))
+	for iteration := 1; iteration <= iterations; iteration++ {
+		notifier.NotifyObserversOfEvent(*NewEvent(FinishedIteration).WithAttribute(iterationKey, iteration))
+	}
+	notifier.NotifyObserversOfEvent(*NewEvent(FinishedAnnealing))
+}
+
+func TestConcurrentAnnealingEventNotifier_Block_AllEventsDeliveredInOrder(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	firstObserver, secondObserver := newRecordingObserver(), newRecordingObserver()
+	notifierUnderTest := NewConcurrentAnnealingEventNotifier().WithBufferSize(5)
+	g.Expect(notifierUnderTest.AddObserver(firstObserver)).To(Succeed())
+	g.Expect(notifierUnderTest.AddObserver(secondObserver)).To(Succeed())
+
+	// when
+	notifyAnnealing(notifierUnderTest, testEventCount)
+
+	// then
+	expectedIterations := make([]int, testEventCount)
+	for index := range expectedIterations {
+		expectedIterations[index] = index + 1
+	}
+
+	for _, observer := range []*recordingObserver{firstObserver, secondObserver} {
+		g.Expect(observer.iterations()).To(Equal(expectedIterations))
+		g.Expect(observer.lastEventType()).To(Equal(FinishedAnnealing))
+	}
+	g.Expect(notifierUnderTest.Dropped()).To(BeZero())
+}
+
+func TestConcurrentAnnealingEventNotifier_DropOldest_KeepsNewestAndAnnealingStates(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const bufferSize = 10
+	slowObserver := newRecordingObserver().blockedUntilReleased()
+	notifierUnderTest := NewConcurrentAnnealingEventNotifier().
+		WithBufferSize(bufferSize).
+		WithBackPressure(DropOldest)
+	g.Expect(notifierUnderTest.AddObserver(slowObserver)).To(Succeed())
+
+	// when
+	notifierUnderTest.NotifyObserversOfEvent(*NewEvent(StartedAnnealing))
+	for iteration := 1; iteration <= testEventCount; iteration++ {
+		notifierUnderTest.NotifyObserversOfEvent(*NewEvent(FinishedIteration).WithAttribute(iterationKey, iteration))
+	}
+	close(slowObserver.release)
+	notifierUnderTest.NotifyObserversOfEvent(*NewEvent(FinishedAnnealing))
+
+	// then
+	iterations := slowObserver.iterations()
+	g.Expect(len(iterations)).To(BeNumerically("<=", bufferSize))
+	g.Expect(iterations[len(iterations)-1]).To(Equal(testEventCount))
+	for index := 1; index < len(iterations); index++ {
+		g.Expect(iterations[index]).To(BeNumerically(">", iterations[index-1]))
+	}
+
+	g.Expect(slowObserver.received[0].EventType).To(Equal(StartedAnnealing))
+	g.Expect(slowObserver.lastEventType()).To(Equal(FinishedAnnealing))
+	g.Expect(notifierUnderTest.Dropped()).To(BeNumerically("==", testEventCount-len(iterations)))
+}
+
+func TestConcurrentAnnealingEventNotifier_Sample_KeepsEverySampleIntervalUnderPressure(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const bufferSize = 10
+	const sampleInterval = 5
+	slowObserver := newRecordingObserver().blockedUntilReleased()
+	notifierUnderTest := NewConcurrentAnnealingEventNotifier().
+		WithBufferSize(bufferSize).
+		WithBackPressure(Sample).
+		WithSampleInterval(sampleInterval)
+	g.Expect(notifierUnderTest.AddObserver(slowObserver)).To(Succeed())
+
+	// when
+	go func() {
+		for notifierUnderTest.Dropped() == 0 {
+			runtime.Gosched()
+		}
+		close(slowObserver.release)
+	}()
+	notifyAnnealing(notifierUnderTest, testEventCount)
+
+	// then
+	iterations := slowObserver.iterations()
+	g.Expect(len(iterations)).To(BeNumerically("<", testEventCount))
+	for index := 1; index < len(iterations); index++ {
+		g.Expect(iterations[index]).To(BeNumerically(">", iterations[index-1]))
+	}
+
+	g.Expect(slowObserver.received[0].EventType).To(Equal(StartedAnnealing))
+	g.Expect(slowObserver.lastEventType()).To(Equal(FinishedAnnealing))
+	g.Expect(notifierUnderTest.Dropped()).To(BeNumerically("==", testEventCount-len(iterations)))
+}
+
+func TestConcurrentAnnealingEventNotifier_NilObserver_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	notifierUnderTest := NewConcurrentAnnealingEventNotifier()
+
+	// then
+	g.Expect(notifierUnderTest.AddObserver(nil)).To(Not(Succeed()))
+	g.Expect(notifierUnderTest.AddObserverAsFirst(nil)).To(Not(Succeed()))
+	g.Expect(notifierUnderTest.HasObservers()).To(BeFalse())
+}
+
+func TestConcurrentAnnealingEventNotifier_Close_DeliversBufferedThenStopsGoroutines(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	goroutinesBefore := runtime.NumGoroutine()
+	slowObserver := newRecordingObserver().blockedUntilReleased()
+	notifierUnderTest := NewConcurrentAnnealingEventNotifier()
+	g.Expect(notifierUnderTest.AddObserver(slowObserver)).To(Succeed())
+	g.Expect(notifierUnderTest.AddObserver(newRecordingObserver())).To(Succeed())
+
+	for iteration := 1; iteration <= testEventCount; iteration++ {
+		notifierUnderTest.NotifyObserversOfEvent(*NewEvent(FinishedIteration).WithAttribute(iterationKey, iteration))
+	}
+
+	// when
+	close(slowObserver.release)
+	notifierUnderTest.Close()
+	notifierUnderTest.NotifyObserversOfEvent(*NewEvent(FinishedIteration).WithAttribute(iterationKey, testEventCount+1))
+
+	// then
+	g.Expect(slowObserver.iterations()).To(HaveLen(testEventCount))
+	g.Eventually(runtime.NumGoroutine).Should(BeNumerically("<=", goroutinesBefore))
+	g.Expect(notifierUnderTest.AddObserver(newRecordingObserver())).To(Not(Succeed()))
+
+	notifierUnderTest.Close()
+}
+
+func TestConcurrentAnnealingEventNotifier_DeepClone_IndependentOfOriginal(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sharedObserver := newRecordingObserver()
+	originalNotifier := NewConcurrentAnnealingEventNotifier().WithBufferSize(5)
+	g.Expect(originalNotifier.AddObserver(sharedObserver)).To(Succeed())
+
+	// when
+	cloneUnderTest := originalNotifier.DeepClone()
+	originalNotifier.Close()
+	notifyAnnealing(cloneUnderTest, testEventCount)
+
+	// then
+	g.Expect(cloneUnderTest.Observers()).To(Equal(originalNotifier.Observers()))
+	g.Expect(sharedObserver.iterations()).To(HaveLen(testEventCount))
+	g.Expect(sharedObserver.lastEventType()).To(Equal(FinishedAnnealing))
+
+	Close(cloneUnderTest)
+}
@@ -14,6 +14,34 @@ type EventNotifier interface {
 	NotifyObserversOfEvent(event Event)
 }
 
+// CloneableEventNotifier is an EventNotifier able to copy itself, with the copy notifying the same observers, but
+// otherwise independent of the original.
+type CloneableEventNotifier interface {
+	EventNotifier
+	DeepClone() EventNotifier
+}
+
+// ClosableEventNotifier is an EventNotifier holding resources that must be released once it has finished notifying.
+type ClosableEventNotifier interface {
+	EventNotifier
+	Close()
+}
+
+// DeepCloneOf returns an independent copy of notifier where notifier can be cloned, and notifier itself otherwise.
+func DeepCloneOf(notifier EventNotifier) EventNotifier {
+	if cloneableNotifier, isCloneable := notifier.(CloneableEventNotifier); isCloneable {
+		return cloneableNotifier.DeepClone()
+	}
+	return notifier
+}
+
+// Close releases the resources of notifier, where it holds any.
+func Close(notifier EventNotifier) {
+	if closableNotifier, isClosable := notifier.(ClosableEventNotifier); isClosable {
+		closableNotifier.Close()
+	}
+}
+
 // EventNotifierContainer  defines an interface embedding an EventNotifier
 type EventNotifierContainer interface {
 	EventNotifier() EventNotifier
@@ -68,6 +96,12 @@ func (notifier *SynchronousAnnealingEventNotifier) AddObserverAsFirst(newObserve
 	return nil
 }
 
+func (notifier *SynchronousAnnealingEventNotifier) DeepClone() EventNotifier {
+	return &SynchronousAnnealingEventNotifier{
+		observers: append([]Observer(nil), notifier.observers...),
+	}
+}
+
 func (notifier *SynchronousAnnealingEventNotifier) NotifyObserversOfEvent(event Event) {
 	for _, currObserver := range notifier.observers {
 		currObserver.ObserveEvent(event)
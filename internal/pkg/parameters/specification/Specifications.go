@@ -11,6 +11,31 @@ type Specification struct {
 	Validator    SpecValidator
 	DefaultValue interface{}
 	IsOptional   bool
+
+	// Bounds optionally records the inclusive range of decimal values that Validator accepts.
+	Bounds Bounds
+}
+
+// Bounds describes an inclusive range of decimal values. The zero value describes no bounds at all.
+type Bounds struct {
+	Minimum float64
+	Maximum float64
+}
+
+// UnitInterval bounds decimal values to the range [0,1].
+var UnitInterval = Bounds{Minimum: 0, Maximum: 1}
+
+func (b Bounds) IsBounded() bool {
+	return b.Maximum > b.Minimum
+}
+
+// Accepts reports whether the specification's validator accepts value.
+func (s Specification) Accepts(value interface{}) bool {
+	if s.Validator == nil {
+		return false
+	}
+	validationError, isValidationError := s.Validator(s.Key, value).(ValidationError)
+	return isValidationError && validationError.IsValid()
 }
 
 func NewSpecifications() *Specifications {
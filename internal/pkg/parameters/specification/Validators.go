@@ -51,7 +51,7 @@ func IsDecimal(key string, value interface{}) error {
 }
 
 func IsDecimalBetweenZeroAndOne(key string, value interface{}) error {
-	return IsDecimalWithInclusiveBounds(key, value, 0, 1)
+	return IsDecimalWithInclusiveBounds(key, value, UnitInterval.Minimum, UnitInterval.Maximum)
 }
 
 func IsNonNegativeDecimal(key string, value interface{}) error {
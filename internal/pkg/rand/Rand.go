@@ -18,9 +18,31 @@ type Container interface {
 	SetRandomNumberGenerator(generator *Rand)
 }
 
-// RandContainer offers a struct implementing the Container interface.
+// Seedable defines an interface for components able to drive all of their random number generation from a single
+// seed, so that a component seeded alike behaves alike.
+type Seedable interface {
+	SeedRandomNumbers(seed int64)
+}
+
+// Seed drives the random number generation of component from seed, where component is Seedable.
+func Seed(component interface{}, seed int64) {
+	if seedableComponent, isSeedable := component.(Seedable); isSeedable {
+		seedableComponent.SeedRandomNumbers(seed)
+	}
+}
+
+// SeedOrTime returns seed, or a seed drawn from the system time where seed is zero (unset).
+func SeedOrTime(seed int64) int64 {
+	if seed == 0 {
+		return time.Now().UnixNano()
+	}
+	return seed
+}
+
+// RandContainer offers a struct implementing the Container and Seedable interfaces.
 type RandContainer struct {
-	rand Rand
+	rand   Rand
+	seeded bool
 }
 
 func (g *RandContainer) RandomNumberGenerator() *Rand {
@@ -29,11 +51,30 @@ func (g *RandContainer) RandomNumberGenerator() *Rand {
 
 func (g *RandContainer) SetRandomNumberGenerator(generator *Rand) {
 	g.rand = *generator
+	g.seeded = false
+}
+
+// SeedRandomNumbers replaces the container's generator with one seeded from seed, that seed being kept across any
+// later ResetRandomNumberGenerator.
+func (g *RandContainer) SeedRandomNumbers(seed int64) {
+	g.rand = *NewSeeded(seed)
+	g.seeded = true
+}
+
+// ResetRandomNumberGenerator restarts the container's generator from the seed last supplied to SeedRandomNumbers,
+// or from the system time where it has not been seeded.
+func (g *RandContainer) ResetRandomNumberGenerator() {
+	if g.seeded {
+		g.rand = *NewSeeded(g.rand.seed)
+		return
+	}
+	g.rand = *NewTimeSeeded()
 }
 
 // Rand is a source of project-specific random numbers
 type Rand struct {
 	officialRand rand.Rand
+	seed         int64
 }
 
 // New returns a new Rand that uses random values from src to generate other random values.
@@ -45,7 +86,24 @@ func New(src rand.Source) *Rand {
 // New returns a new Rand that uses random values seeded from a source of the system-time to generate
 // other random values.
 func NewTimeSeeded() *Rand {
-	return New(rand.NewSource(time.Now().UnixNano()))
+	return NewSeeded(time.Now().UnixNano())
+}
+
+// NewSeeded returns a new Rand that uses random values seeded from seed to generate other random values.
+func NewSeeded(seed int64) *Rand {
+	seededRand := New(rand.NewSource(seed))
+	seededRand.seed = seed
+	return seededRand
+}
+
+// Seed returns the seed the Rand was created with, or zero where it was created from an arbitrary source.
+func (r *Rand) Seed() int64 {
+	return r.seed
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64, as suits seeding other generators.
+func (r *Rand) Int63() int64 {
+	return r.officialRand.Int63()
 }
 
 // Uint64 returns a pseudo-random 64-bit value as a uint64 from the default Source.
@@ -70,3 +128,9 @@ func (r *Rand) Float64Unitary() float64 {
 	distributionRange := int64(math.Pow(2, 53))
 	return float64(r.Int63n(distributionRange)) / float64(distributionRange-1)
 }
+
+// NormFloat64 returns a normally distributed float64 in the range [-math.MaxFloat64, +math.MaxFloat64] with
+// standard normal distribution (mean = 0, stddev = 1).
+func (r *Rand) NormFloat64() float64 {
+	return r.officialRand.NormFloat64()
+}
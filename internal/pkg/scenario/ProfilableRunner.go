@@ -3,6 +3,8 @@
 package scenario
 
 import (
+	"context"
+
 	"github.com/LindsayBradford/crem/internal/pkg/annealing"
 	"github.com/LindsayBradford/crem/internal/pkg/scenario/profiling"
 	"github.com/LindsayBradford/crem/pkg/excel"
@@ -32,11 +34,14 @@ func (runner *ProfilingRunner) LogHandler() logging.Logger {
 	return runner.base.LogHandler()
 }
 
-func (runner *ProfilingRunner) Run() error {
+func (runner *ProfilingRunner) Run(ctx context.Context) error {
 	runner.LogHandler().Info("About to collect cpu profiling data to file [" + runner.profilePath + "]")
 	defer runner.LogHandler().Info("Collection of cpu profiling data to file [" + runner.profilePath + "] complete.")
 
-	return profiling.CpuProfileOfFunctionToFile(runner.base.Run, runner.profilePath)
+	runBase := func() error {
+		return runner.base.Run(ctx)
+	}
+	return profiling.CpuProfileOfFunctionToFile(runBase, runner.profilePath)
 }
 
 type SpreadsheetSafeScenarioRunner struct {
@@ -56,7 +61,7 @@ func (runner *SpreadsheetSafeScenarioRunner) LogHandler() logging.Logger {
 	return runner.base.LogHandler()
 }
 
-func (runner *SpreadsheetSafeScenarioRunner) Run() error {
+func (runner *SpreadsheetSafeScenarioRunner) Run(ctx context.Context) error {
 	runner.LogHandler().Debug("Making scenario runner spreadsheet interaction safe")
 
 	if err := excel.EnableSpreadsheetSafeties(); err != nil {
@@ -66,5 +71,5 @@ func (runner *SpreadsheetSafeScenarioRunner) Run() error {
 	defer excel.DisableSpreadsheetSafeties()
 	defer runner.LogHandler().Debug("Released scenario runner spreadsheet interaction safeties")
 
-	return runner.base.Run()
+	return runner.base.Run(ctx)
 }
@@ -3,12 +3,18 @@
 package scenario
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	. "time"
 
 	"github.com/LindsayBradford/crem/internal/pkg/annealing"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/cooperation"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
 	"github.com/LindsayBradford/crem/pkg/logging"
 )
 
@@ -16,7 +22,19 @@ type CallableRunner interface {
 	SetAnnealer(annealer annealing.Annealer)
 	LogHandler() logging.Logger
 
-	Run() error
+	Run(ctx context.Context) error
+}
+
+// RunFinisher is an Observer holding resources for each annealing run, to be released once the run has finished.
+type RunFinisher interface {
+	observer.Observer
+	FinishRun(runId string)
+}
+
+// RunTracker is an Observer of annealing events that needs to know the model of each annealing run before it starts.
+type RunTracker interface {
+	observer.Observer
+	TrackRun(runId string, runModel model.Model)
 }
 
 type Runner struct {
@@ -24,12 +42,20 @@ type Runner struct {
 	logHandler logging.Logger
 	saver      CallableSaver
 
+	runTrackers []RunTracker
+	tracing     bool
+
 	name              string
 	operationType     string
 	runNumber         uint64
 	maxConcurrentRuns uint64
+	randomNumberSeed  int64
 	tearDown          func()
 
+	cooperative            bool
+	exchangeInterval       uint64
+	temperatureLadderRatio float64
+
 	startTime  Time
 	finishTime Time
 }
@@ -57,6 +83,19 @@ func (runner *Runner) WithSaver(saver CallableSaver) *Runner {
 	return runner
 }
 
+// WithTraceRecorder has recorder trace every run of the scenario.
+func (runner *Runner) WithTraceRecorder(recorder *TraceRecorder) *Runner {
+	recorder.SetLogHandler(runner.logHandler)
+	runner.tracing = true
+	return runner.WithRunTracker(recorder)
+}
+
+// WithRunTracker has tracker observe, and track the model of, every run of the scenario.
+func (runner *Runner) WithRunTracker(tracker RunTracker) *Runner {
+	runner.runTrackers = append(runner.runTrackers, tracker)
+	return runner
+}
+
 func (runner *Runner) WithName(name string) *Runner {
 	if name != "" {
 		runner.name = name
@@ -71,6 +110,15 @@ func (runner *Runner) WithRunNumber(runNumber uint64) *Runner {
 	return runner
 }
 
+// WithRandomNumberSeed drives all random number generation of each run from seed, offset by run number (the first
+// run taking seed as is), so a run can be reproduced from the seed its solutions record.  A zero seed leaves each
+// run seeded from the system time.  Cooperative runs also draw the swaps of their replica exchange from seed, but
+// exchange with each other as their concurrent progress allows, so are not guaranteed to reproduce.
+func (runner *Runner) WithRandomNumberSeed(seed int64) *Runner {
+	runner.randomNumberSeed = seed
+	return runner
+}
+
 func (runner *Runner) WithTearDownFunction(tearDown func()) *Runner {
 	if tearDown != nil {
 		runner.tearDown = tearDown
@@ -89,24 +137,43 @@ func (runner *Runner) WithMaximumConcurrentRuns(maxConcurrentRuns uint64) *Runne
 	return runner
 }
 
+// WithCooperativeRuns has the explorers of concurrent runs share their progress every exchangeInterval iterations.
+// Runs only exchange with those underway at the same time, so are best run entirely concurrently.
+func (runner *Runner) WithCooperativeRuns(exchangeInterval uint64, temperatureLadderRatio float64) *Runner {
+	runner.cooperative = true
+	runner.exchangeInterval = exchangeInterval
+	runner.temperatureLadderRatio = temperatureLadderRatio
+	return runner
+}
+
 func (runner *Runner) SetAnnealer(annealer annealing.Annealer) {
 	runner.annealer = annealer
 	annealer.SetLogHandler(runner.logHandler)
 	runner.saver.SetDecompressionModel(annealer.Model())
 	annealer.AddObserver(runner.saver)
+	for _, tracker := range runner.runTrackers {
+		annealer.AddObserver(tracker)
+	}
+	if reporter, canReport := annealer.(annealers.DecisionVariableReporter); canReport && runner.tracing {
+		reporter.ReportDecisionVariables()
+	}
 }
 
-func (runner *Runner) Run() error {
+// Run runs the scenario until all its runs have finished.  Cancelling ctx stops any runs underway early (with
+// the solutions they have found so far still saved), skips any runs yet to start, and has Run return ctx's error.
+func (runner *Runner) Run(ctx context.Context) error {
 	runner.logScenarioStartMessage()
 	runner.startTime = Now()
 
-	runError := runner.runScenario()
+	runError := runner.runScenario(ctx)
+	runner.saveCombinedSolutions()
 
 	runner.finishTime = Now()
 	runner.logHandler.Info("Finished running scenario [" + runner.name + "]")
 
 	runner.logHandler.Info(runner.generateElapsedTimeString())
 
+	observer.Close(runner.annealer.EventNotifier())
 	runner.tearDown()
 
 	return runError
@@ -126,6 +193,16 @@ func (runner *Runner) logScenarioStartMessage() {
 
 	message := fmt.Sprintf("Scenario [%s]: configured for %d run(s), %s", runner.name, runner.runNumber, runTypeText)
 	runner.logHandler.Info(message)
+
+	if runner.cooperative {
+		cooperationMessage := fmt.Sprintf("Scenario [%s]: runs cooperating, exchanging every %d iterations",
+			runner.name, runner.exchangeInterval)
+		runner.logHandler.Info(cooperationMessage)
+	}
+	if runner.cooperative && runner.maxConcurrentRuns < runner.runNumber {
+		runner.logHandler.Warn(fmt.Sprintf("Scenario [%s]: cooperating runs will only exchange with runs underway "+
+			"concurrently, but not all runs can be underway concurrently", runner.name))
+	}
 }
 
 func (runner *Runner) generateElapsedTimeString() string {
@@ -136,39 +213,85 @@ func (runner *Runner) ElapsedTime() Duration {
 	return runner.finishTime.Sub(runner.startTime)
 }
 
-func (runner *Runner) runScenario() error {
+func (runner *Runner) runScenario(ctx context.Context) error {
 	var runWaitGroup sync.WaitGroup
 
 	concurrentRunGuard := make(chan struct{}, runner.maxConcurrentRuns)
+	runCooperation := runner.newCooperation()
 
 	doRun := func(runNumber uint64) {
-		runner.run(runNumber)
+		runner.run(ctx, runNumber, runCooperation)
 		<-concurrentRunGuard
 		runWaitGroup.Done()
 	}
 
-	runWaitGroup.Add(int(runner.runNumber))
-
 	for runNumber := uint64(1); runNumber <= runner.runNumber; runNumber++ {
 		concurrentRunGuard <- struct{}{}
+		if ctx.Err() != nil {
+			runner.logScenarioCancelledMessage(runNumber)
+			break
+		}
+		runWaitGroup.Add(1)
 		go doRun(runNumber)
 	}
 
 	runWaitGroup.Wait()
 
-	return nil
+	return ctx.Err()
 }
 
-func (runner *Runner) run(runNumber uint64) {
+func (runner *Runner) saveCombinedSolutions() {
+	if runner.runNumber == 1 {
+		return
+	}
+	if combiningSaver, saverCombines := runner.saver.(CombiningSaver); saverCombines {
+		combiningSaver.SaveCombinedSolutions(runner.name)
+	}
+}
+
+func (runner *Runner) newCooperation() *cooperation.Cooperation {
+	if !runner.cooperative {
+		return nil
+	}
+	runCooperation := cooperation.New().
+		WithExchangeInterval(runner.exchangeInterval).
+		WithTemperatureLadderRatio(runner.temperatureLadderRatio)
+	if runner.randomNumberSeed != 0 {
+		rand.Seed(runCooperation, runner.randomNumberSeed)
+	}
+	return runCooperation
+}
+
+func (runner *Runner) run(ctx context.Context, runNumber uint64, runCooperation *cooperation.Cooperation) {
 	annealerCopy := runner.annealer.DeepClone()
 
 	runner.assignNewRunId(runNumber, annealerCopy)
+	runner.seedRun(runNumber, annealerCopy)
 	runner.wireObservers(annealerCopy)
+	runner.joinCooperation(annealerCopy, runNumber, runCooperation)
+	runner.trackRun(annealerCopy)
 
-	annealerCopy.Anneal()
+	annealerCopy.Anneal(ctx)
+	observer.Close(annealerCopy.EventNotifier())
+	runner.finishRun(annealerCopy)
 	runner.logRunFinishedMessage(runNumber)
 }
 
+// finishRun has those observers holding resources for the annealer's run release them, now that every event of the
+// run has been delivered.
+func (runner *Runner) finishRun(annealer annealing.Annealer) {
+	for _, runObserver := range annealer.Observers() {
+		if finisher, isFinisher := runObserver.(RunFinisher); isFinisher {
+			finisher.FinishRun(annealer.Id())
+		}
+	}
+}
+
+func (runner *Runner) logScenarioCancelledMessage(firstSkippedRun uint64) {
+	message := fmt.Sprintf("Scenario [%s]: cancelled, skipping run(s) %d to %d", runner.name, firstSkippedRun, runner.runNumber)
+	runner.logHandler.Warn(message)
+}
+
 func (runner *Runner) assignNewRunId(runNumber uint64, annealerCopy annealing.Annealer) {
 	runId := runner.generateCloneId(runNumber)
 	annealerCopy.SetId(runId)
@@ -177,6 +300,24 @@ func (runner *Runner) assignNewRunId(runNumber uint64, annealerCopy annealing.An
 	runner.logRunStartMessage(runNumber)
 }
 
+// seedRun has the annealer's explorer drive all random number generation of the run from the scenario's seed,
+// offset by runNumber, where the scenario is seeded.
+func (runner *Runner) seedRun(runNumber uint64, annealer annealing.Annealer) {
+	if runner.randomNumberSeed == 0 {
+		return
+	}
+	rand.Seed(annealer.SolutionExplorer(), runner.randomNumberSeed+int64(runNumber-1))
+}
+
+func (runner *Runner) joinCooperation(annealer annealing.Annealer, runNumber uint64, runCooperation *cooperation.Cooperation) {
+	if runCooperation == nil {
+		return
+	}
+	if cooperativeExplorer, explorerCooperates := annealer.SolutionExplorer().(explorer.Cooperative); explorerCooperates {
+		cooperativeExplorer.Cooperate(runCooperation, int(runNumber-1))
+	}
+}
+
 func (runner *Runner) wireObservers(annealer annealing.Annealer) {
 	if observingAnnealer, annealerIsObserver := annealer.(observer.Observer); annealerIsObserver {
 		explorer := annealer.SolutionExplorer()
@@ -190,6 +331,12 @@ func (runner *Runner) wireObservers(annealer annealing.Annealer) {
 	}
 }
 
+func (runner *Runner) trackRun(annealer annealing.Annealer) {
+	for _, tracker := range runner.runTrackers {
+		tracker.TrackRun(annealer.Id(), annealer.Model())
+	}
+}
+
 func (runner *Runner) generateCloneId(runNumber uint64) string {
 	if runner.runNumber > 1 {
 		return fmt.Sprintf("%s (%d/%d)", runner.name, runNumber, runner.runNumber)
@@ -216,4 +363,4 @@ type nullRunner struct{}
 
 func (s *nullRunner) SetAnnealer(annealer annealing.Annealer) {}
 func (s *nullRunner) LogHandler() logging.Logger              { return nil }
-func (s *nullRunner) Run() error                              { return nil }
+func (s *nullRunner) Run(ctx context.Context) error           { return nil }
@@ -0,0 +1,118 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package scenario
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func buildRunnerUnderTest() *Runner {
+	runner := NewRunner().
+		WithLogHandler(new(loggers.NullLogger)).
+		WithSaver(NewSaver()).
+		WithRunNumber(2)
+
+	annealer := new(annealers.NullAnnealer)
+	annealer.Initialise()
+	runner.SetAnnealer(annealer)
+
+	return runner
+}
+
+func TestRunner_Run_Completed_NoError(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	runnerUnderTest := buildRunnerUnderTest()
+
+	// when
+	runError := runnerUnderTest.Run(context.Background())
+
+	// then
+	g.Expect(runError).To(BeNil())
+}
+
+func TestRunner_Run_Cancelled_ReturnsContextError(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	runnerUnderTest := buildRunnerUnderTest()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	runError := runnerUnderTest.Run(ctx)
+
+	// then
+	g.Expect(errors.Is(runError, context.Canceled)).To(BeTrue())
+}
+
+func TestRunner_Run_ConcurrentEventNotifier_StopsNotifierGoroutines(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	goroutinesBefore := runtime.NumGoroutine()
+
+	runnerUnderTest := NewRunner().
+		WithLogHandler(new(loggers.NullLogger)).
+		WithSaver(NewSaver()).
+		WithRunNumber(4)
+
+	annealer := new(annealers.NullAnnealer)
+	annealer.Initialise()
+	annealer.SetEventNotifier(observer.NewConcurrentAnnealingEventNotifier())
+	runnerUnderTest.SetAnnealer(annealer)
+
+	// when
+	runError := runnerUnderTest.Run(context.Background())
+
+	// then
+	g.Expect(runError).To(BeNil())
+	g.Eventually(runtime.NumGoroutine).Should(BeNumerically("<=", goroutinesBefore))
+}
+
+type finishedRunRecorder struct {
+	mutex    sync.Mutex
+	finished []string
+}
+
+func (recorder *finishedRunRecorder) ObserveEvent(event observer.Event) {}
+
+func (recorder *finishedRunRecorder) FinishRun(runId string) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.finished = append(recorder.finished, runId)
+}
+
+func TestRunner_Run_FinishesEachRunOfRunFinishers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	runnerUnderTest := NewRunner().
+		WithLogHandler(new(loggers.NullLogger)).
+		WithSaver(NewSaver()).
+		WithName("Scenario").
+		WithRunNumber(2)
+
+	annealer := new(annealers.SimpleAnnealer)
+	annealer.Initialise()
+	recorder := new(finishedRunRecorder)
+	annealer.AddObserver(recorder)
+	runnerUnderTest.SetAnnealer(annealer)
+
+	// when
+	runError := runnerUnderTest.Run(context.Background())
+
+	// then
+	g.Expect(runError).To(BeNil())
+	g.Expect(recorder.finished).To(ConsistOf("Scenario (1/2)", "Scenario (2/2)"))
+}
@@ -10,7 +10,10 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
 	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/encoding"
 	"github.com/LindsayBradford/crem/internal/pkg/model"
@@ -24,6 +27,9 @@ import (
 const (
 	CompressedModel    = "CompressedModel"
 	ModelArchive       = "ModelArchive"
+	FrontHypervolume   = "FrontHypervolume"
+	FrontSpacing       = "FrontSpacing"
+	FrontSpread        = "FrontSpread"
 	defaultOutputPath  = "solutions"
 	defaultOutputLevel = "Summary"
 
@@ -41,14 +47,26 @@ type CallableSaver interface {
 	SetDecompressionModel(model model.Model)
 }
 
+// CombiningSaver is implemented by savers able to combine the solutions of a scenario's runs into a single
+// non-dominated set, once all runs have finished.
+type CombiningSaver interface {
+	SaveCombinedSolutions(scenarioName string)
+}
+
 type Saver struct {
 	loggers.ContainedLogger
 	decompressionModel model.Model
 	outputType         encoding.OutputType
 	outputLevel        OutputLevel
 	outputPath         string
+	provenance         *solution.Provenance
 
 	decompressionMutex sync.Mutex
+
+	combineRuns      bool
+	combinedArchive  *archive.NonDominanceModelArchive
+	contributingRuns map[*archive.CompressedModelState]string
+	combiningMutex   sync.Mutex
 }
 
 func NewSaver() *Saver {
@@ -79,6 +97,26 @@ func (s *Saver) WithOutputLevel(outputLevel OutputLevel) *Saver {
 	return s
 }
 
+// WithCombinedRunSolutions has the saver combine the solutions of every run it observes, for saving as a single
+// non-dominated set via SaveCombinedSolutions.
+func (s *Saver) WithCombinedRunSolutions(combineRuns bool) *Saver {
+	s.combineRuns = combineRuns
+	s.resetCombinedSolutions()
+	return s
+}
+
+func (s *Saver) resetCombinedSolutions() {
+	s.combinedArchive = archive.New()
+	s.contributingRuns = make(map[*archive.CompressedModelState]string)
+}
+
+// WithProvenance has the saver record how every solution it saves was produced, completing the provenance given
+// with details of the run producing each solution.
+func (s *Saver) WithProvenance(provenance *solution.Provenance) *Saver {
+	s.provenance = provenance
+	return s
+}
+
 func (s *Saver) WithLogHandler(logHandler logging.Logger) *Saver {
 	s.SetLogHandler(logHandler)
 	return s
@@ -116,39 +154,134 @@ func (s *Saver) ObserveEvent(event observer.Event) {
 	if event.EventType != observer.FinishedAnnealing {
 		return
 	}
+	provenance := s.runProvenanceOf(event)
 	if event.HasAttribute(CompressedModel) {
 		s.LogHandler().Info("Saving annealing optimised solution")
 		compressedModel := event.Attribute(CompressedModel).(archive.CompressedModelState)
-		s.saveOptimisedModel(&compressedModel)
+		s.saveOptimisedModel(&compressedModel, provenance)
+		s.combine(compressedModel.Id(), &compressedModel)
+	}
+	if event.HasAttribute(explorer.NoFeasibleSolution) {
+		s.LogHandler().Warn("Annealing found no feasible solution, so none saved")
 	}
 	if event.HasAttribute(ModelArchive) {
 		s.LogHandler().Info("Saving annealing solution set")
 		modelArchive := event.Attribute(ModelArchive).(archive.NonDominanceModelArchive)
-		s.saveSolutionSet(modelArchive)
+		s.saveSolutionSet(modelArchive, asIsSolutionSetNote(event), provenance)
+		s.combine(modelArchive.Id(), modelArchive.Archive()...)
 	}
 }
 
-func (s *Saver) saveOptimisedModel(optimisedModel *archive.CompressedModelState) {
+// runProvenanceOf returns the saver's provenance, completed with details of the run finishing in event, or nil
+// where the saver has no provenance to record.  The seed recorded is that the run's explorer drove all of the
+// run's random number generation from.
+func (s *Saver) runProvenanceOf(event observer.Event) *solution.Provenance {
+	if s.provenance == nil {
+		return nil
+	}
+
+	runId, _ := event.Attribute(annealers.Id).(string)
+	seed, _ := event.Attribute(explorer.RandomNumberSeed).(int64)
+	iterations, _ := event.Attribute(annealers.CurrentIteration).(uint64)
+	elapsedTime, _ := event.Attribute(annealers.ElapsedTime).(time.Duration)
+
+	return s.describingModel(s.provenance.ForRun(runId, seed, iterations, elapsedTime))
+}
+
+// combinedProvenance returns the saver's provenance for solutions combined across runs, or nil where the saver has
+// no provenance to record.
+func (s *Saver) combinedProvenance() *solution.Provenance {
+	if s.provenance == nil {
+		return nil
+	}
+	return s.describingModel(s.provenance.Stamped())
+}
+
+// describingModel completes provenance with the number of management actions of the model solutions were
+// decompressed against, so a solution set can later be checked against the model it is loaded for.
+func (s *Saver) describingModel(provenance *solution.Provenance) *solution.Provenance {
+	if s.decompressionModel != nil {
+		provenance.ManagementActions = len(s.decompressionModel.ManagementActions())
+	}
+	return provenance
+}
+
+func (s *Saver) combine(runId string, states ...*archive.CompressedModelState) {
+	if !s.combineRuns {
+		return
+	}
+
+	s.combiningMutex.Lock()
+	defer s.combiningMutex.Unlock()
+
+	for _, state := range states {
+		switch s.combinedArchive.AttemptToArchiveState(state) {
+		case archive.StoredWithNoDominanceDetected, archive.StoredReplacingDominatedEntries:
+			s.contributingRuns[state] = runId
+		}
+	}
+}
+
+// SaveCombinedSolutions saves the non-dominated set of solutions combined from all runs observed, noting the run
+// that contributed each solution.
+func (s *Saver) SaveCombinedSolutions(scenarioName string) {
+	if !s.combineRuns || s.combinedArchive.IsEmpty() {
+		return
+	}
+
+	s.combiningMutex.Lock()
+	defer s.combiningMutex.Unlock()
+
+	s.LogHandler().Info(fmt.Sprintf("Saving solution set combined from [%d] solutions across all runs", s.combinedArchive.Len()))
+
+	s.combinedArchive.SetId(scenarioName + " Combined")
+	s.ensureOutputPathIsUsable()
+	s.encodeCombinedSolutionSet()
+
+	s.resetCombinedSolutions()
+}
+
+func (s *Saver) encodeCombinedSolutionSet() {
+	summary := make(solutionset.Summary, 0)
+	provenance := s.combinedProvenance()
+
+	asIsSolution := s.deriveASsIsSolution(*s.combinedArchive)
+	s.encodeSolutionDetail(*asIsSolution, provenance)
+
+	s.summarise(&summary, asIsSolution, asIsSolutionNote, topSummaryEntry)
+
+	numberOfSolutions := s.combinedArchive.Len()
+	for solutionIndex, compressedModel := range s.combinedArchive.Archive() {
+		currentSolution := s.deriveModelSolution(*s.combinedArchive, solutionIndex, compressedModel)
+		s.encodeSolutionDetail(*currentSolution, provenance)
+		formattedNote := fmt.Sprintf("Combined Pareto front member %d of %d, from run [%s]",
+			solutionIndex+1, numberOfSolutions, s.contributingRuns[compressedModel])
+		s.summarise(&summary, currentSolution, formattedNote, topSummaryEntry+uint64(1+solutionIndex))
+	}
+	s.encodeSummary(&summary, provenance)
+}
+
+func (s *Saver) saveOptimisedModel(optimisedModel *archive.CompressedModelState, provenance *solution.Provenance) {
 	s.ensureOutputPathIsUsable()
-	s.encodeOptimisedModel(optimisedModel)
+	s.encodeOptimisedModel(optimisedModel, provenance)
 }
 
-func (s *Saver) encodeOptimisedModel(optimisedModel *archive.CompressedModelState) {
+func (s *Saver) encodeOptimisedModel(optimisedModel *archive.CompressedModelState, provenance *solution.Provenance) {
 	summary := make(solutionset.Summary, 0)
-	s.encodeAndSummariseAsIsSolution(optimisedModel, summary)
-	s.encodeAndSummariseOptimisedSolution(optimisedModel, summary)
-	s.encodeSummary(&summary)
+	s.encodeAndSummariseAsIsSolution(optimisedModel, summary, provenance)
+	s.encodeAndSummariseOptimisedSolution(optimisedModel, summary, provenance)
+	s.encodeSummary(&summary, provenance)
 }
 
-func (s *Saver) encodeAndSummariseAsIsSolution(optimisedModel *archive.CompressedModelState, summary solutionset.Summary) {
+func (s *Saver) encodeAndSummariseAsIsSolution(optimisedModel *archive.CompressedModelState, summary solutionset.Summary, provenance *solution.Provenance) {
 	asIsSolution := s.deriveASsIsSolutionForOptimised(optimisedModel.Id())
-	s.encodeSolutionDetail(*asIsSolution)
+	s.encodeSolutionDetail(*asIsSolution, provenance)
 	s.summarise(&summary, asIsSolution, asIsSolutionNote, topSummaryEntry)
 }
 
-func (s *Saver) encodeAndSummariseOptimisedSolution(optimisedModel *archive.CompressedModelState, summary solutionset.Summary) {
+func (s *Saver) encodeAndSummariseOptimisedSolution(optimisedModel *archive.CompressedModelState, summary solutionset.Summary, provenance *solution.Provenance) {
 	optimisedSolution := s.deriveSolutionFromCompressedModel(optimisedModel, optimisedModel.Id()+" Solution (1/1)")
-	s.encodeSolutionDetail(*optimisedSolution)
+	s.encodeSolutionDetail(*optimisedSolution, provenance)
 	s.summarise(&summary, optimisedSolution, "Computationally optimised solution", topSummaryEntry+1)
 }
 
@@ -184,10 +317,11 @@ func (s *Saver) deriveAsIsOptimisedSolutionId(solutionId string) string {
 	return solutionId + " Solution (As-Is)"
 }
 
-func (s *Saver) encodeSolutionDetail(modelSolution solution.Solution) {
+func (s *Saver) encodeSolutionDetail(modelSolution solution.Solution, provenance *solution.Provenance) {
 	if s.outputLevel != "Detail" {
 		return
 	}
+	modelSolution.Provenance = provenance
 
 	encoder := new(encoding.Builder).
 		ForOutputType(s.outputType).
@@ -200,27 +334,41 @@ func (s *Saver) encodeSolutionDetail(modelSolution solution.Solution) {
 	}
 }
 
-func (s *Saver) saveSolutionSet(solutionSet archive.NonDominanceModelArchive) {
+func (s *Saver) saveSolutionSet(solutionSet archive.NonDominanceModelArchive, asIsNote string, provenance *solution.Provenance) {
 	s.ensureOutputPathIsUsable()
-	s.encodeSolutionSet(solutionSet)
+	s.encodeSolutionSet(solutionSet, asIsNote, provenance)
 }
 
-func (s *Saver) encodeSolutionSet(solutionSet archive.NonDominanceModelArchive) {
+func (s *Saver) encodeSolutionSet(solutionSet archive.NonDominanceModelArchive, asIsNote string, provenance *solution.Provenance) {
 	summary := make(solutionset.Summary, 0)
 
 	asIsSolution := s.deriveASsIsSolution(solutionSet)
-	s.encodeSolutionDetail(*asIsSolution)
+	s.encodeSolutionDetail(*asIsSolution, provenance)
 
-	s.summarise(&summary, asIsSolution, asIsSolutionNote, topSummaryEntry)
+	s.summarise(&summary, asIsSolution, asIsNote, topSummaryEntry)
 
 	numberOfSolutions := len(solutionSet.Archive())
 	for solutionIndex, compressedModel := range solutionSet.Archive() {
 		currentSolution := s.deriveModelSolution(solutionSet, solutionIndex, compressedModel)
-		s.encodeSolutionDetail(*currentSolution)
+		s.encodeSolutionDetail(*currentSolution, provenance)
 		formattedNote := fmt.Sprintf("Pareto front member %d of %d", solutionIndex+1, numberOfSolutions)
 		s.summarise(&summary, currentSolution, formattedNote, topSummaryEntry+uint64(1+solutionIndex))
 	}
-	s.encodeSummary(&summary)
+	s.encodeSummary(&summary, provenance)
+}
+
+// asIsSolutionSetNote notes the quality of the solution set's front, as reported by the explorer finishing annealing
+// in event, against the As-Is solution heading its summary.  The quality is only noted where the explorer assessed it.
+func asIsSolutionSetNote(event observer.Event) string {
+	if !event.HasAttribute(FrontHypervolume) {
+		return asIsSolutionNote
+	}
+
+	frontQuality := archive.FrontQuality{}
+	frontQuality.Hypervolume, _ = event.Attribute(FrontHypervolume).(float64)
+	frontQuality.Spacing, _ = event.Attribute(FrontSpacing).(float64)
+	frontQuality.Spread, _ = event.Attribute(FrontSpread).(float64)
+	return asIsSolutionNote + "; front quality: " + frontQuality.String()
 }
 
 func (s *Saver) deriveASsIsSolution(solutionSet archive.NonDominanceModelArchive) *solution.Solution {
@@ -293,10 +441,11 @@ var (
 	prettifiedMatcher = regexp.MustCompile("/")
 )
 
-func (s *Saver) encodeSummary(summary *solutionset.Summary) {
+func (s *Saver) encodeSummary(summary *solutionset.Summary, provenance *solution.Provenance) {
 	encoder := new(encoding2.Builder).
 		ForOutputType(s.outputType).
 		WithOutputPath(s.outputPath).
+		WithProvenance(provenance).
 		WithLogHandler(s.LogHandler()).
 		Build()
 
@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package scenario
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	booleanArchive "github.com/LindsayBradford/crem/pkg/archive"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	. "github.com/onsi/gomega"
+)
+
+func buildState(activeAction int, variables ...float64) *archive.CompressedModelState {
+	state := new(archive.CompressedModelState)
+	state.Variables = dominance.Float64Vector(variables)
+	state.Actions = *booleanArchive.New(4)
+	state.Actions.SetValue(activeAction, true)
+	return state
+}
+
+func TestSaver_Combine_KeepsNonDominatedStatesAcrossRuns(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	saverUnderTest := NewSaver().WithCombinedRunSolutions(true)
+
+	firstRunFront := []*archive.CompressedModelState{buildState(0, 1, 4), buildState(1, 3, 3)}
+	secondRunFront := []*archive.CompressedModelState{buildState(2, 2, 2), buildState(3, 4, 1)}
+
+	// when
+	saverUnderTest.combine("Scenario (1/2)", firstRunFront...)
+	saverUnderTest.combine("Scenario (2/2)", secondRunFront...)
+
+	// then
+	combinedFront := saverUnderTest.combinedArchive.Archive()
+	g.Expect(combinedFront).To(ConsistOf(firstRunFront[0], secondRunFront[0], secondRunFront[1]))
+	g.Expect(saverUnderTest.combinedArchive.IsNonDominant()).To(BeTrue())
+
+	g.Expect(saverUnderTest.contributingRuns[firstRunFront[0]]).To(Equal("Scenario (1/2)"))
+	g.Expect(saverUnderTest.contributingRuns[secondRunFront[0]]).To(Equal("Scenario (2/2)"))
+	g.Expect(saverUnderTest.contributingRuns[secondRunFront[1]]).To(Equal("Scenario (2/2)"))
+}
+
+func TestSaver_Combine_NotCombining_Ignored(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	saverUnderTest := NewSaver()
+
+	// when
+	saverUnderTest.combine("Scenario (1/2)", buildState(0, 1, 4))
+
+	// then
+	g.Expect(saverUnderTest.combinedArchive).To(BeNil())
+}
+
+func TestSaver_RunProvenanceOf_CompletesProvenanceFromEvent(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	saverUnderTest := NewSaver().WithProvenance(&solution.Provenance{ExecutableVersion: "0.23"})
+	event := observer.NewEvent(observer.FinishedAnnealing).
+		WithAttribute(annealers.Id, "Scenario (1/2)").
+		WithAttribute(annealers.CurrentIteration, uint64(500)).
+		WithAttribute(annealers.ElapsedTime, 2*time.Minute).
+		WithAttribute(explorer.RandomNumberSeed, int64(1234))
+
+	// when
+	provenance := saverUnderTest.runProvenanceOf(*event)
+
+	// then
+	g.Expect(provenance.ExecutableVersion).To(Equal("0.23"))
+	g.Expect(provenance.RunId).To(Equal("Scenario (1/2)"))
+	g.Expect(provenance.Iterations).To(BeNumerically("==", 500))
+	g.Expect(provenance.ElapsedTime).To(Equal("2m0s"))
+	g.Expect(provenance.RandomNumberSeed).To(BeNumerically("==", 1234))
+}
+
+func TestSaver_RunProvenanceOf_NoProvenance_Nil(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	saverUnderTest := NewSaver()
+	event := observer.NewEvent(observer.FinishedAnnealing).WithAttribute(annealers.Id, "Scenario (1/2)")
+
+	// when
+	provenance := saverUnderTest.runProvenanceOf(*event)
+
+	// then
+	g.Expect(provenance).To(BeNil())
+}
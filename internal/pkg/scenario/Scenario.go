@@ -1,6 +1,8 @@
 package scenario
 
 import (
+	"context"
+
 	"github.com/LindsayBradford/crem/internal/pkg/annealing"
 	"github.com/LindsayBradford/crem/internal/pkg/observer"
 	assert "github.com/LindsayBradford/crem/pkg/assert/debug"
@@ -10,7 +12,7 @@ import (
 type Scenario interface {
 	LogHandler() logging.Logger
 	SetAnnealer(annealer annealing.Annealer)
-	Run() error
+	Run(ctx context.Context) error
 }
 
 func NewBaseScenario() *BaseScenario {
@@ -51,9 +53,9 @@ func (s *BaseScenario) LogHandler() logging.Logger {
 	return s.runner.LogHandler()
 }
 
-func (s *BaseScenario) Run() error {
+func (s *BaseScenario) Run(ctx context.Context) error {
 	assert.That(s.annealer != nil)
-	return s.runner.Run()
+	return s.runner.Run(ctx)
 }
 
 var NullScenario Scenario = new(nullScenario)
@@ -62,4 +64,4 @@ type nullScenario struct{}
 
 func (s *nullScenario) SetAnnealer(annealer annealing.Annealer) {}
 func (s *nullScenario) LogHandler() logging.Logger              { return nil }
-func (s *nullScenario) Run() error                              { return nil }
+func (s *nullScenario) Run(ctx context.Context) error           { return nil }
@@ -0,0 +1,323 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package scenario
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/pkg/logging"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	"github.com/pkg/errors"
+)
+
+type TraceFormat string
+
+const (
+	CsvTrace       TraceFormat = "CSV"
+	JsonLinesTrace TraceFormat = "JSONLines"
+
+	ArchiveSize = "ArchiveSize"
+
+	traceFileSuffix = "-Trace"
+)
+
+var traceFileExtensions = map[TraceFormat]string{
+	CsvTrace:       ".csv",
+	JsonLinesTrace: ".jsonl",
+}
+
+// TraceRecorder records a compact trace of each annealing run it observes, for convergence analysis.  Every
+// recorded iteration notes the temperature, the acceptance probability, whether the change tried was desirable and
+// accepted, the archive size (for explorers keeping one), and the value of every decision variable.  Each run's
+// trace is written to a file of its own in the output path, named for the run.
+//
+// Decision variable values are those the annealer attached to each FinishedIteration event as it was raised (see
+// annealers.DecisionVariableReporter), so are exact however observers are notified.
+type TraceRecorder struct {
+	loggers.ContainedLogger
+
+	outputPath              string
+	format                  TraceFormat
+	everyNumberOfIterations uint64
+
+	mutex sync.Mutex
+	runs  map[string]*runTrace
+}
+
+func NewTraceRecorder() *TraceRecorder {
+	recorder := &TraceRecorder{
+		outputPath:              defaultOutputPath,
+		format:                  CsvTrace,
+		everyNumberOfIterations: 1,
+		runs:                    make(map[string]*runTrace),
+	}
+	recorder.SetLogHandler(new(loggers.NullLogger))
+	return recorder
+}
+
+func (tr *TraceRecorder) WithOutputPath(outputPath string) *TraceRecorder {
+	if outputPath != "" {
+		tr.outputPath = outputPath
+	}
+	return tr
+}
+
+func (tr *TraceRecorder) WithFormat(format TraceFormat) *TraceRecorder {
+	if _, isKnown := traceFileExtensions[format]; isKnown {
+		tr.format = format
+	}
+	return tr
+}
+
+// WithEveryNumberOfIterations has the recorder trace only those iterations that are a multiple of iterations.
+func (tr *TraceRecorder) WithEveryNumberOfIterations(iterations uint64) *TraceRecorder {
+	if iterations > 0 {
+		tr.everyNumberOfIterations = iterations
+	}
+	return tr
+}
+
+func (tr *TraceRecorder) WithLogHandler(logHandler logging.Logger) *TraceRecorder {
+	tr.SetLogHandler(logHandler)
+	return tr
+}
+
+// TrackRun has the recorder trace the decision variables of runModel for the annealing run identified. Only the
+// names of its variables are taken from runModel, before the run starts.
+func (tr *TraceRecorder) TrackRun(runId string, runModel model.Model) {
+	var variableNames []string
+	if runModel.NameMappedVariables() != nil {
+		variableNames = runModel.NameMappedVariables().SortedKeys()
+	}
+
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	tr.runs[runId] = &runTrace{variableNames: variableNames}
+}
+
+func (tr *TraceRecorder) ObserveEvent(event observer.Event) {
+	runId, hasRunId := event.Attribute(annealers.Id).(string)
+	if !hasRunId {
+		return
+	}
+
+	run := tr.runFor(runId)
+	if run == nil {
+		return
+	}
+
+	switch event.EventType {
+	case observer.StartedAnnealing:
+		tr.start(runId, run, event)
+	case observer.StartedIteration:
+		run.entry = traceEntry{}
+	case observer.Explorer:
+		run.noteExplorerEvent(event)
+	case observer.FinishedIteration:
+		tr.recordIteration(runId, run, event)
+	case observer.FinishedAnnealing:
+		tr.finish(runId, run)
+	default:
+		// deliberately does nothing
+	}
+}
+
+func (tr *TraceRecorder) runFor(runId string) *runTrace {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	return tr.runs[runId]
+}
+
+func (tr *TraceRecorder) start(runId string, run *runTrace, event observer.Event) {
+	run.tracksArchiveSize = event.HasAttribute(ArchiveSize)
+
+	if startError := run.open(tr.tracePath(runId), tr.format); startError != nil {
+		tr.abandon(runId, run, startError)
+		return
+	}
+	tr.LogHandler().Info("Tracing run [" + runId + "] to [" + run.file.Name() + "]")
+}
+
+func (tr *TraceRecorder) tracePath(runId string) string {
+	return filepath.Join(tr.outputPath, fileNameSafe(runId)+traceFileSuffix+traceFileExtensions[tr.format])
+}
+
+func fileNameSafe(runId string) string {
+	safeId := strings.Replace(runId, " ", "", -1)
+	return strings.Replace(safeId, "/", "_of_", -1)
+}
+
+func (tr *TraceRecorder) recordIteration(runId string, run *runTrace, event observer.Event) {
+	iteration, hasIteration := event.Attribute(annealers.CurrentIteration).(uint64)
+	if !hasIteration || iteration%tr.everyNumberOfIterations != 0 || run.writer == nil {
+		return
+	}
+
+	run.entry.Iteration = iteration
+	if temperature, hasTemperature := event.Attribute(explorer.Temperature).(float64); hasTemperature {
+		run.entry.Temperature = temperature
+	}
+	if archiveSize, hasArchiveSize := event.Attribute(ArchiveSize).(int); hasArchiveSize {
+		run.entry.ArchiveSize = &archiveSize
+	}
+	if values, hasValues := event.Attribute(annealers.DecisionVariables).(map[string]float64); hasValues {
+		run.entry.DecisionVariables = values
+	}
+
+	if writeError := run.write(); writeError != nil {
+		tr.abandon(runId, run, writeError)
+	}
+}
+
+func (tr *TraceRecorder) finish(runId string, run *runTrace) {
+	tr.mutex.Lock()
+	delete(tr.runs, runId)
+	tr.mutex.Unlock()
+
+	if closeError := run.close(); closeError != nil {
+		tr.LogHandler().Error(errors.Wrap(closeError, "closing trace of run ["+runId+"]"))
+	}
+}
+
+// abandon stops tracing the run after a failure writing its trace, leaving annealing itself unaffected.
+func (tr *TraceRecorder) abandon(runId string, run *runTrace, cause error) {
+	tr.LogHandler().Error(errors.Wrap(cause, "tracing run ["+runId+"], trace abandoned"))
+	run.close()
+}
+
+// traceEntry is a single traced iteration, its fields named as for the event attributes they are drawn from.
+type traceEntry struct {
+	Iteration             uint64
+	Temperature           float64
+	AcceptanceProbability float64
+	ChangeAccepted        bool
+	ChangeIsDesirable     bool
+	ArchiveSize           *int `json:",omitempty"`
+	DecisionVariables     map[string]float64
+}
+
+type runTrace struct {
+	variableNames     []string
+	tracksArchiveSize bool
+
+	format    TraceFormat
+	file      *os.File
+	writer    *bufio.Writer
+	csvWriter *csv.Writer
+
+	entry traceEntry
+}
+
+func (rt *runTrace) open(path string, format TraceFormat) error {
+	if dirError := os.MkdirAll(filepath.Dir(path), os.ModePerm); dirError != nil {
+		return errors.Wrap(dirError, "creating trace output path")
+	}
+
+	file, createError := os.Create(path)
+	if createError != nil {
+		return errors.Wrap(createError, "creating trace file")
+	}
+
+	rt.format = format
+	rt.file = file
+	rt.writer = bufio.NewWriter(file)
+
+	if format == CsvTrace {
+		rt.csvWriter = csv.NewWriter(rt.writer)
+		return rt.csvWriter.Write(rt.csvHeadings())
+	}
+	return nil
+}
+
+func (rt *runTrace) noteExplorerEvent(event observer.Event) {
+	if probability, hasProbability := event.Attribute(explorer.AcceptanceProbability).(float64); hasProbability {
+		rt.entry.AcceptanceProbability = probability
+	}
+	if accepted, hasAccepted := event.Attribute(explorer.ChangeAccepted).(bool); hasAccepted {
+		rt.entry.ChangeAccepted = accepted
+	}
+	if desirable, hasDesirable := event.Attribute(explorer.ChangeIsDesirable).(bool); hasDesirable {
+		rt.entry.ChangeIsDesirable = desirable
+	}
+}
+
+func (rt *runTrace) write() error {
+	if rt.format == CsvTrace {
+		return rt.csvWriter.Write(rt.csvRecord())
+	}
+
+	marshaledEntry, marshalError := json.Marshal(rt.entry)
+	if marshalError != nil {
+		return marshalError
+	}
+	if _, writeError := rt.writer.Write(marshaledEntry); writeError != nil {
+		return writeError
+	}
+	return rt.writer.WriteByte('\n')
+}
+
+func (rt *runTrace) csvHeadings() []string {
+	headings := []string{"Iteration", explorer.Temperature, explorer.AcceptanceProbability,
+		explorer.ChangeAccepted, explorer.ChangeIsDesirable}
+	if rt.tracksArchiveSize {
+		headings = append(headings, ArchiveSize)
+	}
+	return append(headings, rt.variableNames...)
+}
+
+func (rt *runTrace) csvRecord() []string {
+	record := []string{
+		strconv.FormatUint(rt.entry.Iteration, 10),
+		formatFloat(rt.entry.Temperature),
+		formatFloat(rt.entry.AcceptanceProbability),
+		strconv.FormatBool(rt.entry.ChangeAccepted),
+		strconv.FormatBool(rt.entry.ChangeIsDesirable),
+	}
+	if rt.tracksArchiveSize {
+		archiveSize := ""
+		if rt.entry.ArchiveSize != nil {
+			archiveSize = strconv.Itoa(*rt.entry.ArchiveSize)
+		}
+		record = append(record, archiveSize)
+	}
+	for _, name := range rt.variableNames {
+		record = append(record, formatFloat(rt.entry.DecisionVariables[name]))
+	}
+	return record
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func (rt *runTrace) close() error {
+	if rt.file == nil {
+		return nil
+	}
+
+	if rt.csvWriter != nil {
+		rt.csvWriter.Flush()
+	}
+	flushError := rt.writer.Flush()
+	closeError := rt.file.Close()
+
+	rt.file, rt.writer, rt.csvWriter = nil, nil, nil
+
+	if flushError != nil {
+		return flushError
+	}
+	return closeError
+}
+
+var _ RunTracker = new(TraceRecorder)
@@ -0,0 +1,152 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package scenario
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/explorer"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/modumb"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	tracedRunId      = "Traced Scenario (1/2)"
+	tracedIterations = 10
+)
+
+func buildTracedModel() *modumb.Model {
+	tracedModel := modumb.NewModel().WithId(tracedRunId)
+	tracedModel.Initialise(model.AsIs)
+	return tracedModel
+}
+
+func newRunEvent(eventType observer.EventType) *observer.Event {
+	return observer.NewEvent(eventType).WithAttribute(annealers.Id, tracedRunId)
+}
+
+// decisionVariableValuesOf returns the values of tracedModel's decision variables, as an annealer reporting them
+// attaches them to its events.
+func decisionVariableValuesOf(tracedModel model.Model) map[string]float64 {
+	values := make(map[string]float64)
+	for name, decisionVariable := range *tracedModel.NameMappedVariables() {
+		values[name] = decisionVariable.Value()
+	}
+	return values
+}
+
+func notifyTracedRun(recorder *TraceRecorder, tracedModel model.Model) {
+	recorder.ObserveEvent(*newRunEvent(observer.StartedAnnealing).WithAttribute(ArchiveSize, 0))
+	for iteration := uint64(1); iteration <= tracedIterations; iteration++ {
+		recorder.ObserveEvent(*newRunEvent(observer.StartedIteration).
+			WithAttribute(annealers.CurrentIteration, iteration))
+
+		accepted := iteration%2 == 0
+		recorder.ObserveEvent(*newRunEvent(observer.Explorer).
+			WithAttribute(explorer.AcceptanceProbability, 0.5).
+			WithAttribute(explorer.ChangeIsDesirable, false).
+			WithAttribute(explorer.ChangeAccepted, accepted))
+
+		recorder.ObserveEvent(*newRunEvent(observer.FinishedIteration).
+			WithAttribute(annealers.CurrentIteration, iteration).
+			WithAttribute(explorer.Temperature, 100.0/float64(iteration)).
+			WithAttribute(ArchiveSize, int(iteration)).
+			WithAttribute(annealers.DecisionVariables, decisionVariableValuesOf(tracedModel)))
+	}
+	recorder.ObserveEvent(*newRunEvent(observer.FinishedAnnealing))
+}
+
+func TestTraceRecorder_Csv_RecordsEveryNthIteration(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	outputPath := t.TempDir()
+	recorderUnderTest := NewTraceRecorder().
+		WithOutputPath(outputPath).
+		WithEveryNumberOfIterations(5)
+	tracedModel := buildTracedModel()
+	recorderUnderTest.TrackRun(tracedRunId, tracedModel)
+
+	// when
+	notifyTracedRun(recorderUnderTest, tracedModel)
+
+	// then
+	traceFile, openError := os.Open(filepath.Join(outputPath, "TracedScenario(1_of_2)-Trace.csv"))
+	g.Expect(openError).To(BeNil())
+	defer traceFile.Close()
+
+	records, readError := csv.NewReader(traceFile).ReadAll()
+	g.Expect(readError).To(BeNil())
+
+	expectedHeadings := append(
+		[]string{"Iteration", "Temperature", "AcceptanceProbability", "ChangeAccepted", "ChangeIsDesirable", "ArchiveSize"},
+		modumb.Objectives...)
+	g.Expect(records[0]).To(Equal(expectedHeadings))
+
+	g.Expect(records).To(HaveLen(3))
+	g.Expect(records[1][:6]).To(Equal([]string{"5", "20", "0.5", "false", "false", "5"}))
+	g.Expect(records[2][:6]).To(Equal([]string{"10", "10", "0.5", "true", "false", "10"}))
+	g.Expect(records[2][6:]).To(HaveLen(len(modumb.Objectives)))
+
+	g.Expect(recorderUnderTest.runs).To(BeEmpty())
+}
+
+func TestTraceRecorder_JsonLines_RecordsDecisionVariables(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	outputPath := t.TempDir()
+	tracedModel := buildTracedModel()
+	recorderUnderTest := NewTraceRecorder().
+		WithOutputPath(outputPath).
+		WithFormat(JsonLinesTrace)
+	recorderUnderTest.TrackRun(tracedRunId, tracedModel)
+
+	// when
+	notifyTracedRun(recorderUnderTest, tracedModel)
+
+	// then
+	traceFile, openError := os.Open(filepath.Join(outputPath, "TracedScenario(1_of_2)-Trace.jsonl"))
+	g.Expect(openError).To(BeNil())
+	defer traceFile.Close()
+
+	entries := make([]traceEntry, 0)
+	scanner := bufio.NewScanner(traceFile)
+	for scanner.Scan() {
+		var entry traceEntry
+		g.Expect(json.Unmarshal(scanner.Bytes(), &entry)).To(Succeed())
+		entries = append(entries, entry)
+	}
+
+	g.Expect(entries).To(HaveLen(tracedIterations))
+	lastEntry := entries[tracedIterations-1]
+	g.Expect(lastEntry.Iteration).To(BeNumerically("==", tracedIterations))
+	g.Expect(lastEntry.ChangeAccepted).To(BeTrue())
+	g.Expect(*lastEntry.ArchiveSize).To(Equal(tracedIterations))
+	for _, objective := range modumb.Objectives {
+		g.Expect(lastEntry.DecisionVariables).To(HaveKeyWithValue(objective, tracedModel.DecisionVariable(objective).Value()))
+	}
+}
+
+func TestTraceRecorder_UntrackedRun_Ignored(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	outputPath := t.TempDir()
+	recorderUnderTest := NewTraceRecorder().WithOutputPath(outputPath)
+
+	// when
+	notifyTracedRun(recorderUnderTest, buildTracedModel())
+
+	// then
+	tracedFiles, _ := os.ReadDir(outputPath)
+	g.Expect(tracedFiles).To(BeEmpty())
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package sweep runs a scenario once per combination of parameter values drawn from a design of experiments,
+// summarising the results of every run for comparison.
+package sweep
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	GridMethod           = "Grid"
+	LatinHypercubeMethod = "LatinHypercube"
+
+	defaultSampleNumber = 10
+)
+
+// Setting is the value a combination assigns a single parameter, being either an int64 or float64.
+type Setting struct {
+	Parameter string
+	Value     interface{}
+}
+
+// Combination is the set of parameter settings for a single scenario of a sweep, ordered by parameter name.
+type Combination []Setting
+
+func (c Combination) String() string {
+	settingsText := make([]string, len(c))
+	for index, setting := range c {
+		settingsText[index] = fmt.Sprintf("%s=%v", setting.Parameter, setting.Value)
+	}
+	return strings.Join(settingsText, ", ")
+}
+
+// Design decides the combinations of parameter values a sweep runs its scenario over.
+type Design interface {
+	Name() string
+	Parameters() []string
+	Combinations() []Combination
+	Validate() error
+}
+
+// Sampled is implemented by designs that sample their combinations randomly, reporting the random number seed they
+// were sampled with, so the design can be reproduced.
+type Sampled interface {
+	RandomNumberSeed() int64
+}
+
+var _ Design = new(Grid)
+
+// Grid runs a scenario for every combination of the values listed for each parameter (their Cartesian product).
+type Grid struct {
+	values map[string][]interface{}
+}
+
+func NewGrid() *Grid {
+	return &Grid{values: make(map[string][]interface{})}
+}
+
+// WithValues has the grid vary parameter over values, each being an int64 or float64.
+func (g *Grid) WithValues(parameter string, values ...interface{}) *Grid {
+	g.values[parameter] = values
+	return g
+}
+
+func (g *Grid) Name() string {
+	return GridMethod
+}
+
+func (g *Grid) Parameters() []string {
+	parameters := make([]string, 0, len(g.values))
+	for parameter := range g.values {
+		parameters = append(parameters, parameter)
+	}
+	sort.Strings(parameters)
+	return parameters
+}
+
+func (g *Grid) Validate() error {
+	validationErrors := compositeErrors.New("Grid Sweep")
+	if len(g.values) == 0 {
+		validationErrors.AddMessage("no parameter values supplied")
+	}
+	for _, parameter := range g.Parameters() {
+		if len(g.values[parameter]) == 0 {
+			validationErrors.Add(errors.Errorf("parameter [%s] has no values", parameter))
+		}
+		for _, value := range g.values[parameter] {
+			if !isNumeric(value) {
+				validationErrors.Add(errors.Errorf("parameter [%s] value [%v] is not a number", parameter, value))
+			}
+		}
+	}
+	if validationErrors.Size() > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+func isNumeric(value interface{}) bool {
+	switch value.(type) {
+	case int64, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// Combinations returns every combination of parameter values, the last parameter (by name) varying fastest.
+func (g *Grid) Combinations() []Combination {
+	parameters := g.Parameters()
+	if len(parameters) == 0 {
+		return nil
+	}
+
+	combinations := []Combination{{}}
+	for _, parameter := range parameters {
+		expanded := make([]Combination, 0, len(combinations)*len(g.values[parameter]))
+		for _, combination := range combinations {
+			for _, value := range g.values[parameter] {
+				expandedCombination := make(Combination, len(combination), len(combination)+1)
+				copy(expandedCombination, combination)
+				expanded = append(expanded, append(expandedCombination, Setting{Parameter: parameter, Value: value}))
+			}
+		}
+		combinations = expanded
+	}
+	return combinations
+}
+
+// Range is the inclusive range of values a LatinHypercube samples a parameter from.  Integer ranges are sampled
+// for int64 values, others for float64 values.
+type Range struct {
+	Minimum   float64
+	Maximum   float64
+	IsInteger bool
+}
+
+func (r Range) Validate() error {
+	if r.Minimum >= r.Maximum {
+		return errors.Errorf("range minimum [%v] must be less than maximum [%v]", r.Minimum, r.Maximum)
+	}
+	return nil
+}
+
+func (r Range) valueAt(unitValue float64) interface{} {
+	value := r.Minimum + unitValue*(r.Maximum-r.Minimum)
+	if r.IsInteger {
+		return int64(math.Round(value))
+	}
+	return value
+}
+
+var _ Design = new(LatinHypercube)
+var _ Sampled = new(LatinHypercube)
+
+// LatinHypercube runs a scenario for sampleNumber combinations of parameter values, sampled so that each parameter's
+// range is divided into sampleNumber equal strata, with exactly one combination drawn from each stratum.
+type LatinHypercube struct {
+	ranges       map[string]Range
+	sampleNumber int
+	generator    *rand.Rand
+}
+
+func NewLatinHypercube() *LatinHypercube {
+	return &LatinHypercube{
+		ranges:       make(map[string]Range),
+		sampleNumber: defaultSampleNumber,
+		generator:    rand.NewTimeSeeded(),
+	}
+}
+
+func (lh *LatinHypercube) WithRange(parameter string, parameterRange Range) *LatinHypercube {
+	lh.ranges[parameter] = parameterRange
+	return lh
+}
+
+func (lh *LatinHypercube) WithSampleNumber(sampleNumber int) *LatinHypercube {
+	lh.sampleNumber = sampleNumber
+	return lh
+}
+
+func (lh *LatinHypercube) WithRandomNumberGenerator(generator *rand.Rand) *LatinHypercube {
+	lh.generator = generator
+	return lh
+}
+
+// WithRandomNumberSeed has combinations sampled from seed, or from the system time where seed is zero (unset).
+func (lh *LatinHypercube) WithRandomNumberSeed(seed int64) *LatinHypercube {
+	return lh.WithRandomNumberGenerator(rand.NewSeeded(rand.SeedOrTime(seed)))
+}
+
+func (lh *LatinHypercube) RandomNumberSeed() int64 {
+	return lh.generator.Seed()
+}
+
+func (lh *LatinHypercube) Name() string {
+	return LatinHypercubeMethod
+}
+
+func (lh *LatinHypercube) Parameters() []string {
+	parameters := make([]string, 0, len(lh.ranges))
+	for parameter := range lh.ranges {
+		parameters = append(parameters, parameter)
+	}
+	sort.Strings(parameters)
+	return parameters
+}
+
+func (lh *LatinHypercube) Validate() error {
+	validationErrors := compositeErrors.New("Latin Hypercube Sweep")
+	if lh.sampleNumber < 1 {
+		validationErrors.AddMessage("sample number must be at least 1")
+	}
+	if len(lh.ranges) == 0 {
+		validationErrors.AddMessage("no parameter ranges supplied")
+	}
+	for _, parameter := range lh.Parameters() {
+		if rangeError := lh.ranges[parameter].Validate(); rangeError != nil {
+			validationErrors.Add(errors.Wrap(rangeError, "parameter ["+parameter+"]"))
+		}
+	}
+	if validationErrors.Size() > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+func (lh *LatinHypercube) Combinations() []Combination {
+	parameters := lh.Parameters()
+	if len(parameters) == 0 || lh.sampleNumber < 1 {
+		return nil
+	}
+
+	combinations := make([]Combination, lh.sampleNumber)
+	for index := range combinations {
+		combinations[index] = make(Combination, len(parameters))
+	}
+
+	for parameterIndex, parameter := range parameters {
+		strata := lh.shuffledStrata()
+		for sample, stratum := range strata {
+			unitValue := (float64(stratum) + lh.generator.Float64Unitary()) / float64(lh.sampleNumber)
+			combinations[sample][parameterIndex] = Setting{
+				Parameter: parameter,
+				Value:     lh.ranges[parameter].valueAt(math.Min(unitValue, 1)),
+			}
+		}
+	}
+	return combinations
+}
+
+// shuffledStrata returns a random permutation of the strata indices 0 to sampleNumber-1.
+func (lh *LatinHypercube) shuffledStrata() []int {
+	strata := make([]int, lh.sampleNumber)
+	for index := range strata {
+		strata[index] = index
+	}
+	for index := len(strata) - 1; index > 0; index-- {
+		swapIndex := lh.generator.Intn(index + 1)
+		strata[index], strata[swapIndex] = strata[swapIndex], strata[index]
+	}
+	return strata
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package sweep
+
+import (
+	baseRand "math/rand"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	. "github.com/onsi/gomega"
+)
+
+func TestGrid_Combinations_CartesianProduct(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	gridUnderTest := NewGrid().
+		WithValues("Model.B", 0.1, 0.2, 0.3).
+		WithValues("Annealer.A", int64(1), int64(2))
+
+	// when
+	combinations := gridUnderTest.Combinations()
+
+	// then
+	g.Expect(gridUnderTest.Validate()).To(BeNil())
+	g.Expect(gridUnderTest.Parameters()).To(Equal([]string{"Annealer.A", "Model.B"}))
+	g.Expect(combinations).To(HaveLen(6))
+	g.Expect(combinations[0]).To(Equal(Combination{{"Annealer.A", int64(1)}, {"Model.B", 0.1}}))
+	g.Expect(combinations[1]).To(Equal(Combination{{"Annealer.A", int64(1)}, {"Model.B", 0.2}}))
+	g.Expect(combinations[5]).To(Equal(Combination{{"Annealer.A", int64(2)}, {"Model.B", 0.3}}))
+}
+
+func TestGrid_NonNumericValue_Invalid(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	gridUnderTest := NewGrid().WithValues("Annealer.A", "one")
+
+	// when
+	validationError := gridUnderTest.Validate()
+
+	// then
+	g.Expect(validationError).To(Not(BeNil()))
+}
+
+func TestLatinHypercube_Combinations_OneSamplePerStratum(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const sampleNumber = 8
+	designUnderTest := NewLatinHypercube().
+		WithRange("Annealer.A", Range{Minimum: 0, Maximum: 80}).
+		WithRange("Model.B", Range{Minimum: -1, Maximum: 1}).
+		WithSampleNumber(sampleNumber).
+		WithRandomNumberGenerator(rand.New(baseRand.NewSource(42)))
+
+	// when
+	combinations := designUnderTest.Combinations()
+
+	// then
+	g.Expect(designUnderTest.Validate()).To(BeNil())
+	g.Expect(combinations).To(HaveLen(sampleNumber))
+
+	strataSampled := make(map[int]bool)
+	for _, combination := range combinations {
+		g.Expect(combination[0].Parameter).To(Equal("Annealer.A"))
+		value := combination[0].Value.(float64)
+		g.Expect(value).To(BeNumerically(">=", 0))
+		g.Expect(value).To(BeNumerically("<=", 80))
+		strataSampled[int(value/10)] = true
+
+		g.Expect(combination[1].Value.(float64)).To(BeNumerically("~", 0, 1))
+	}
+	g.Expect(strataSampled).To(HaveLen(sampleNumber))
+}
+
+func TestLatinHypercube_IntegerRange_SamplesIntegers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	designUnderTest := NewLatinHypercube().
+		WithRange("Annealer.A", Range{Minimum: 100, Maximum: 1000, IsInteger: true}).
+		WithSampleNumber(5)
+
+	// when
+	combinations := designUnderTest.Combinations()
+
+	// then
+	g.Expect(combinations).To(HaveLen(5))
+	for _, combination := range combinations {
+		g.Expect(combination[0].Value).To(BeAssignableToTypeOf(int64(0)))
+		g.Expect(combination[0].Value).To(BeNumerically(">=", 100))
+		g.Expect(combination[0].Value).To(BeNumerically("<=", 1000))
+	}
+}
+
+func TestLatinHypercube_EmptyRange_Invalid(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	designUnderTest := NewLatinHypercube().WithRange("Annealer.A", Range{Minimum: 1, Maximum: 1})
+
+	// when
+	validationError := designUnderTest.Validate()
+
+	// then
+	g.Expect(validationError).To(Not(BeNil()))
+}
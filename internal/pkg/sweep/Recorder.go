@@ -0,0 +1,186 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package sweep
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/internal/pkg/scenario"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+)
+
+const (
+	Iterations       = "Iterations"
+	ObjectiveValue   = "ObjectiveValue"
+	ArchiveSize      = "ArchiveSize"
+	FrontHypervolume = "FrontHypervolume"
+	FrontSpacing     = "FrontSpacing"
+	FrontSpread      = "FrontSpread"
+
+	FrontGenerationalDistance         = "FrontGenerationalDistance"
+	FrontInvertedGenerationalDistance = "FrontInvertedGenerationalDistance"
+
+	modelArchive = "ModelArchive"
+)
+
+// MetricNames lists the metrics a RunResult may hold, in the order they are reported.
+var MetricNames = []string{
+	Iterations, ObjectiveValue, ArchiveSize,
+	FrontHypervolume, FrontSpacing, FrontSpread, FrontGenerationalDistance, FrontInvertedGenerationalDistance,
+}
+
+// RunResult summarises how a single annealing run of a sweep finished.  Metrics hold only those applying to the
+// run's explorer.  DecisionVariables hold the best value of each decision variable across the run's archive, where
+// the explorer keeps one, or the run's final value of each otherwise.
+type RunResult struct {
+	RunId             string
+	Metrics           map[string]float64
+	DecisionVariables map[string]float64
+
+	objectiveFront []dominance.Float64Vector
+}
+
+var _ scenario.RunTracker = new(recorder)
+
+// recorder records the RunResult of each annealing run of a sweep combination's scenario as the run finishes.
+type recorder struct {
+	mutex      sync.Mutex
+	objectives []string
+	models     map[string]model.Model
+	results    []RunResult
+}
+
+// newRecorder returns a recorder keeping the front of each run's archive over the objectives named, or over all
+// decision variables where none are.
+func newRecorder(objectives []string) *recorder {
+	return &recorder{objectives: objectives, models: make(map[string]model.Model)}
+}
+
+func (r *recorder) TrackRun(runId string, runModel model.Model) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.models[runId] = runModel
+}
+
+func (r *recorder) ObserveEvent(event observer.Event) {
+	if event.EventType != observer.FinishedAnnealing {
+		return
+	}
+
+	runId, hasRunId := event.Attribute(annealers.Id).(string)
+	if !hasRunId {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	runModel, isTracked := r.models[runId]
+	if !isTracked {
+		return
+	}
+	delete(r.models, runId)
+
+	r.results = append(r.results, resultOf(runId, runModel, event, r.objectives))
+}
+
+func (r *recorder) Results() []RunResult {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]RunResult(nil), r.results...)
+}
+
+func resultOf(runId string, runModel model.Model, event observer.Event, objectives []string) RunResult {
+	result := RunResult{
+		RunId:             runId,
+		Metrics:           make(map[string]float64),
+		DecisionVariables: finalDecisionVariables(runModel),
+	}
+
+	if iterations, hasIterations := event.Attribute(annealers.CurrentIteration).(uint64); hasIterations {
+		result.Metrics[Iterations] = float64(iterations)
+	}
+	if objectiveValue, hasObjectiveValue := event.Attribute(ObjectiveValue).(float64); hasObjectiveValue {
+		result.Metrics[ObjectiveValue] = objectiveValue
+	}
+	if runArchive := archiveOf(event); runArchive != nil && !runArchive.IsEmpty() {
+		recordArchive(&result, runModel, runArchive, objectives)
+	}
+	return result
+}
+
+func finalDecisionVariables(runModel model.Model) map[string]float64 {
+	variables := *runModel.NameMappedVariables()
+	values := make(map[string]float64, len(variables))
+	for name, decisionVariable := range variables {
+		values[name] = decisionVariable.Value()
+	}
+	return values
+}
+
+func archiveOf(event observer.Event) *archive.NonDominanceModelArchive {
+	switch eventArchive := event.Attribute(modelArchive).(type) {
+	case *archive.NonDominanceModelArchive:
+		return eventArchive
+	case archive.NonDominanceModelArchive:
+		return &eventArchive
+	default:
+		return nil
+	}
+}
+
+// recordArchive records the size of the archive, its front over the objectives named, and the best value it holds
+// for each decision variable.  Archived decision variables are ordered by name, and oriented for minimisation.
+func recordArchive(result *RunResult, runModel model.Model, runArchive *archive.NonDominanceModelArchive, objectives []string) {
+	result.Metrics[ArchiveSize] = float64(runArchive.Len())
+	result.objectiveFront = objectiveFrontOf(runArchive, runModel.NameMappedVariables().SortedKeys(), objectives)
+
+	summary := runArchive.ArchiveSummary()
+	for index, name := range runModel.NameMappedVariables().SortedKeys() {
+		variableSummary, isSummarised := summary[index]
+		if !isSummarised {
+			continue
+		}
+		bestValue := variableSummary.Minimum
+		if variable.SenseOf(runModel.DecisionVariable(name)) == variable.Maximised {
+			bestValue = -bestValue
+		}
+		result.DecisionVariables[name] = bestValue
+	}
+}
+
+// objectiveFrontOf returns the archive's front over the objectives named, or over all of the decision variables
+// named by variableNames where no objectives are.  No front is returned where any objective is not a decision
+// variable.
+func objectiveFrontOf(runArchive *archive.NonDominanceModelArchive, variableNames []string, objectives []string) []dominance.Float64Vector {
+	objectiveIndexes := make([]int, 0, len(variableNames))
+	if len(objectives) == 0 {
+		for index := range variableNames {
+			objectiveIndexes = append(objectiveIndexes, index)
+		}
+	}
+	for _, objective := range objectives {
+		objectiveIndex := sort.SearchStrings(variableNames, objective)
+		if objectiveIndex == len(variableNames) || variableNames[objectiveIndex] != objective {
+			return nil
+		}
+		objectiveIndexes = append(objectiveIndexes, objectiveIndex)
+	}
+
+	front := runArchive.Front()
+	objectiveFront := make([]dominance.Float64Vector, len(front))
+	for pointIndex, point := range front {
+		objectivePoint := make(dominance.Float64Vector, len(objectiveIndexes))
+		for index, objectiveIndex := range objectiveIndexes {
+			objectivePoint[index] = point[objectiveIndex]
+		}
+		objectiveFront[pointIndex] = objectivePoint
+	}
+	return objectiveFront
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package sweep
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	strings2 "strings"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	"github.com/LindsayBradford/crem/pkg/strings"
+)
+
+// Results holds the outcome of every annealing run of a Sweep, grouped by parameter combination.
+type Results struct {
+	Parameters   []string
+	Combinations []CombinationResult
+}
+
+// CombinationResult holds the results of each annealing run of a single combination's scenario.  Combinations
+// whose scenario failed, or was skipped, have no Runs.
+type CombinationResult struct {
+	Number      int
+	Combination Combination
+	Runs        []RunResult
+}
+
+// scoreFronts records the quality of each run's front, all assessed against one reference shared by every run of the
+// sweep, so runs (and combinations) can be compared.  The reference spans the ideal and nadir points of every run's
+// front, and holds their combined non-dominated points as its reference set.
+func (r *Results) scoreFronts() {
+	runs := make([]*RunResult, 0)
+	fronts := make([][]dominance.Float64Vector, 0)
+	for combinationIndex := range r.Combinations {
+		for runIndex := range r.Combinations[combinationIndex].Runs {
+			run := &r.Combinations[combinationIndex].Runs[runIndex]
+			if len(run.objectiveFront) > 0 {
+				runs = append(runs, run)
+				fronts = append(fronts, run.objectiveFront)
+			}
+		}
+	}
+	if len(fronts) == 0 {
+		return
+	}
+
+	objectives := make([]int, len(fronts[0][0]))
+	for index := range objectives {
+		objectives[index] = index
+	}
+	reference := archive.NewFrontReference(objectives, fronts...)
+
+	for _, run := range runs {
+		frontQuality := archive.FrontQualityOf(run.objectiveFront, reference)
+		run.Metrics[FrontHypervolume] = frontQuality.Hypervolume
+		run.Metrics[FrontSpacing] = frontQuality.Spacing
+		run.Metrics[FrontSpread] = frontQuality.Spread
+		run.Metrics[FrontGenerationalDistance] = frontQuality.GenerationalDistance
+		run.Metrics[FrontInvertedGenerationalDistance] = frontQuality.InvertedGenerationalDistance
+	}
+}
+
+// metricNames returns the names of the metrics recorded for any run, in MetricNames order.
+func (r *Results) metricNames() []string {
+	recorded := make(map[string]bool)
+	for _, combinationResult := range r.Combinations {
+		for _, run := range combinationResult.Runs {
+			for name := range run.Metrics {
+				recorded[name] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(recorded))
+	for _, name := range MetricNames {
+		if recorded[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// decisionVariableNames returns the names of the decision variables recorded for any run, sorted.
+func (r *Results) decisionVariableNames() []string {
+	recorded := make(map[string]bool)
+	for _, combinationResult := range r.Combinations {
+		for _, run := range combinationResult.Runs {
+			for name := range run.DecisionVariables {
+				recorded[name] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(recorded))
+	for name := range recorded {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+const (
+	separator = ", "
+	newline   = "\n"
+
+	combinationHeading = "Combination"
+	runIdHeading       = "RunId"
+)
+
+var defaultConverter = strings.NewConverter().WithFloatingPointPrecision(6).PaddingZeros()
+
+// CsvMarshaler marshals Results into CSV text, with a row per annealing run, listing its combination's parameter
+// values, then the run's metrics and decision variable values.  Values a run did not record are left empty.
+type CsvMarshaler struct{}
+
+func (cm *CsvMarshaler) Marshal(results *Results) ([]byte, error) {
+	metricNames := results.metricNames()
+	variableNames := results.decisionVariableNames()
+
+	builder := new(strings.FluentBuilder)
+	builder.Add(join(deriveHeaders(results.Parameters, metricNames, variableNames)...)).Add(newline)
+
+	for _, combinationResult := range results.Combinations {
+		for _, run := range combinationResult.Runs {
+			row := deriveRow(combinationResult, run, metricNames, variableNames)
+			builder.Add(join(row...)).Add(newline)
+		}
+	}
+
+	return ([]byte)(builder.String()), nil
+}
+
+func deriveHeaders(parameters []string, metricNames []string, variableNames []string) []string {
+	headers := []string{combinationHeading, runIdHeading}
+	headers = append(headers, parameters...)
+	headers = append(headers, metricNames...)
+	return append(headers, variableNames...)
+}
+
+func deriveRow(combinationResult CombinationResult, run RunResult, metricNames []string, variableNames []string) []string {
+	row := []string{fmt.Sprintf("%d", combinationResult.Number), run.RunId}
+	for _, setting := range combinationResult.Combination {
+		row = append(row, convertSetting(setting))
+	}
+	for _, name := range metricNames {
+		row = append(row, convertIfPresent(run.Metrics, name))
+	}
+	for _, name := range variableNames {
+		row = append(row, convertIfPresent(run.DecisionVariables, name))
+	}
+	return row
+}
+
+func convertSetting(setting Setting) string {
+	if integerValue, isInteger := setting.Value.(int64); isInteger {
+		return strconv.FormatInt(integerValue, 10)
+	}
+	return defaultConverter.Convert(setting.Value)
+}
+
+func convertIfPresent(values map[string]float64, name string) string {
+	if value, isPresent := values[name]; isPresent {
+		return defaultConverter.Convert(value)
+	}
+	return ""
+}
+
+func join(entries ...string) string {
+	return strings2.Join(entries, separator)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/LindsayBradford/crem/internal/pkg/scenario"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/LindsayBradford/crem/pkg/logging"
+	"github.com/LindsayBradford/crem/pkg/logging/loggers"
+	"github.com/pkg/errors"
+)
+
+// ScenarioBuilder builds the scenario run for the numbered combination given, having tracker track each of the
+// scenario's annealing runs.
+type ScenarioBuilder func(combinationNumber int, combination Combination, tracker scenario.RunTracker) (scenario.Scenario, error)
+
+// Sweep runs a scenario for every combination of parameter values its Design offers, running a maximum number of
+// combinations' scenarios concurrently.
+type Sweep struct {
+	loggers.ContainedLogger
+
+	name                   string
+	design                 Design
+	scenarioBuilder        ScenarioBuilder
+	maxConcurrentScenarios uint64
+	frontObjectives        []string
+}
+
+func New() *Sweep {
+	newSweep := &Sweep{
+		name:                   "Sweep",
+		maxConcurrentScenarios: 1,
+	}
+	newSweep.SetLogHandler(new(loggers.NullLogger))
+	return newSweep
+}
+
+func (s *Sweep) WithName(name string) *Sweep {
+	if name != "" {
+		s.name = name
+	}
+	return s
+}
+
+func (s *Sweep) WithDesign(design Design) *Sweep {
+	s.design = design
+	return s
+}
+
+func (s *Sweep) WithScenarioBuilder(builder ScenarioBuilder) *Sweep {
+	s.scenarioBuilder = builder
+	return s
+}
+
+func (s *Sweep) WithMaximumConcurrentScenarios(maxConcurrentScenarios uint64) *Sweep {
+	if maxConcurrentScenarios > 0 {
+		s.maxConcurrentScenarios = maxConcurrentScenarios
+	}
+	return s
+}
+
+// WithFrontObjectives has the sweep assess the fronts of runs keeping an archive over the decision variables named,
+// rather than over all decision variables.
+func (s *Sweep) WithFrontObjectives(objectives ...string) *Sweep {
+	s.frontObjectives = objectives
+	return s
+}
+
+func (s *Sweep) WithLogHandler(logHandler logging.Logger) *Sweep {
+	s.SetLogHandler(logHandler)
+	return s
+}
+
+func (s *Sweep) Design() Design {
+	return s.design
+}
+
+func (s *Sweep) Validate() error {
+	if s.design == nil {
+		return errors.New("sweep has no design")
+	}
+	if s.scenarioBuilder == nil {
+		return errors.New("sweep has no scenario builder")
+	}
+	return s.design.Validate()
+}
+
+// Run runs the scenario of every combination, returning the results of each.  The fronts of runs keeping an archive
+// are scored once every combination has finished, against a reference shared by all runs.  A combination whose scenario cannot
+// be built or run is reported as an error, without stopping the remaining combinations.  Cancelling ctx stops any
+// scenarios underway early, keeping the results of runs they finished, and skips any yet to start, leaving them
+// without run results.  Scenarios stopped early are reported as errors.
+func (s *Sweep) Run(ctx context.Context) (*Results, error) {
+	if validationError := s.Validate(); validationError != nil {
+		return nil, validationError
+	}
+
+	combinations := s.design.Combinations()
+	results := &Results{
+		Parameters:   s.design.Parameters(),
+		Combinations: make([]CombinationResult, len(combinations)),
+	}
+	for index, combination := range combinations {
+		results.Combinations[index] = CombinationResult{Number: index + 1, Combination: combination}
+	}
+	runErrors := compositeErrors.New("Sweep [" + s.name + "]")
+	var runErrorsMutex sync.Mutex
+
+	s.LogHandler().Info(fmt.Sprintf("Sweep [%s]: running %d %s combination(s), a maximum of %d concurrently",
+		s.name, len(combinations), s.design.Name(), s.maxConcurrentScenarios))
+	if sampledDesign, isSampled := s.design.(Sampled); isSampled {
+		s.LogHandler().Info(fmt.Sprintf("Sweep [%s]: combinations sampled with random number seed [%d]",
+			s.name, sampledDesign.RandomNumberSeed()))
+	}
+
+	var combinationWaitGroup sync.WaitGroup
+	concurrentScenarioGuard := make(chan struct{}, s.maxConcurrentScenarios)
+
+	for index, combination := range combinations {
+		concurrentScenarioGuard <- struct{}{}
+		if ctx.Err() != nil {
+			s.LogHandler().Warn(fmt.Sprintf("Sweep [%s]: cancelled, skipping combination(s) %d to %d",
+				s.name, index+1, len(combinations)))
+			break
+		}
+
+		combinationWaitGroup.Add(1)
+		go func(number int, combination Combination) {
+			defer combinationWaitGroup.Done()
+			defer func() { <-concurrentScenarioGuard }()
+
+			runs, runError := s.runCombination(ctx, number, combination)
+			results.Combinations[number-1].Runs = runs
+			if runError != nil {
+				runErrorsMutex.Lock()
+				runErrors.Add(errors.Wrapf(runError, "combination [%d] (%s)", number, combination))
+				runErrorsMutex.Unlock()
+			}
+		}(index+1, combination)
+	}
+
+	combinationWaitGroup.Wait()
+	results.scoreFronts()
+
+	if runErrors.Size() > 0 {
+		return results, runErrors
+	}
+	return results, nil
+}
+
+func (s *Sweep) runCombination(ctx context.Context, number int, combination Combination) ([]RunResult, error) {
+	s.LogHandler().Info(fmt.Sprintf("Sweep [%s]: combination %d started (%s)", s.name, number, combination))
+
+	combinationRecorder := newRecorder(s.frontObjectives)
+	combinationScenario, buildError := s.scenarioBuilder(number, combination, combinationRecorder)
+	if buildError != nil {
+		return nil, errors.Wrap(buildError, "building scenario")
+	}
+
+	if runError := combinationScenario.Run(ctx); runError != nil {
+		return combinationRecorder.Results(), errors.Wrap(runError, "running scenario")
+	}
+
+	s.LogHandler().Info(fmt.Sprintf("Sweep [%s]: combination %d finished", s.name, number))
+	return combinationRecorder.Results(), nil
+}
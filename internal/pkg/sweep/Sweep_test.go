@@ -0,0 +1,255 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing"
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/annealers"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/model/models/modumb"
+	"github.com/LindsayBradford/crem/internal/pkg/observer"
+	"github.com/LindsayBradford/crem/internal/pkg/scenario"
+	baseArchive "github.com/LindsayBradford/crem/pkg/archive"
+	"github.com/LindsayBradford/crem/pkg/dominance"
+	"github.com/LindsayBradford/crem/pkg/logging"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+// stubScenario reports a single finished run to its tracker, with an objective value of its combination number.
+type stubScenario struct {
+	number  int
+	tracker scenario.RunTracker
+}
+
+func (s *stubScenario) LogHandler() logging.Logger              { return nil }
+func (s *stubScenario) SetAnnealer(annealer annealing.Annealer) {}
+
+func (s *stubScenario) Run(ctx context.Context) error {
+	runId := fmt.Sprintf("Stub Sweep %d (1/1)", s.number)
+	runModel := modumb.NewModel().WithId(runId)
+	runModel.Initialise(model.AsIs)
+
+	s.tracker.TrackRun(runId, runModel)
+	s.tracker.ObserveEvent(*observer.NewEvent(observer.FinishedAnnealing).
+		WithAttribute(annealers.Id, runId).
+		WithAttribute(annealers.CurrentIteration, uint64(100)).
+		WithAttribute(ObjectiveValue, float64(s.number)))
+	return nil
+}
+
+func buildStubScenario(number int, combination Combination, tracker scenario.RunTracker) (scenario.Scenario, error) {
+	return &stubScenario{number: number, tracker: tracker}, nil
+}
+
+func TestSweep_Run_RecordsEveryCombination(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sweepUnderTest := New().
+		WithName("Stub").
+		WithDesign(NewGrid().WithValues("Annealer.A", int64(1), int64(2), int64(3))).
+		WithScenarioBuilder(buildStubScenario).
+		WithMaximumConcurrentScenarios(2)
+
+	// when
+	results, runError := sweepUnderTest.Run(context.Background())
+
+	// then
+	g.Expect(runError).To(BeNil())
+	g.Expect(results.Parameters).To(Equal([]string{"Annealer.A"}))
+	g.Expect(results.Combinations).To(HaveLen(3))
+	for index, combinationResult := range results.Combinations {
+		g.Expect(combinationResult.Number).To(Equal(index + 1))
+		g.Expect(combinationResult.Runs).To(HaveLen(1))
+		g.Expect(combinationResult.Runs[0].Metrics[ObjectiveValue]).To(BeNumerically("==", index+1))
+		g.Expect(combinationResult.Runs[0].Metrics[Iterations]).To(BeNumerically("==", 100))
+		g.Expect(combinationResult.Runs[0].DecisionVariables).To(Not(BeEmpty()))
+	}
+}
+
+// archivingScenario reports a single finished run to its tracker, with an archive holding model states of the
+// first two objective values given.
+type archivingScenario struct {
+	stubScenario
+	objectiveValues [][2]float64
+}
+
+func (s *archivingScenario) Run(ctx context.Context) error {
+	runId := fmt.Sprintf("Archiving Sweep %d (1/1)", s.number)
+	runModel := modumb.NewModel().WithId(runId)
+	runModel.Initialise(model.AsIs)
+	variableNames := runModel.NameMappedVariables().SortedKeys()
+
+	runArchive := archive.New()
+	for stateIndex, values := range s.objectiveValues {
+		state := new(archive.CompressedModelState)
+		state.Variables = make(dominance.Float64Vector, len(variableNames))
+		state.Variables[indexOf(modumb.Objectives[0], variableNames)] = values[0]
+		state.Variables[indexOf(modumb.Objectives[1], variableNames)] = values[1]
+		state.Actions = *baseArchive.New(len(s.objectiveValues))
+		state.Actions.SetValue(stateIndex, true)
+		runArchive.AttemptToArchiveState(state)
+	}
+
+	s.tracker.TrackRun(runId, runModel)
+	s.tracker.ObserveEvent(*observer.NewEvent(observer.FinishedAnnealing).
+		WithAttribute(annealers.Id, runId).
+		WithAttribute(modelArchive, runArchive))
+	return nil
+}
+
+func indexOf(name string, names []string) int {
+	for index := range names {
+		if names[index] == name {
+			return index
+		}
+	}
+	return -1
+}
+
+func TestSweep_Run_ScoresFrontsAgainstSharedReference(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	combinationFronts := map[int][][2]float64{
+		1: {{1, 3}, {3, 1}},
+		2: {{2, 4}, {4, 2}},
+	}
+	archivingBuilder := func(number int, combination Combination, tracker scenario.RunTracker) (scenario.Scenario, error) {
+		return &archivingScenario{stubScenario{number: number, tracker: tracker}, combinationFronts[number]}, nil
+	}
+
+	sweepUnderTest := New().
+		WithDesign(NewGrid().WithValues("Annealer.A", 0.1, 0.2)).
+		WithScenarioBuilder(archivingBuilder).
+		WithFrontObjectives(modumb.Objectives[0], modumb.Objectives[1])
+
+	// when
+	results, runError := sweepUnderTest.Run(context.Background())
+
+	// then
+	g.Expect(runError).To(BeNil())
+	betterMetrics := results.Combinations[0].Runs[0].Metrics
+	worseMetrics := results.Combinations[1].Runs[0].Metrics
+
+	g.Expect(betterMetrics[ArchiveSize]).To(BeNumerically("==", 2))
+	g.Expect(betterMetrics[FrontHypervolume]).To(BeNumerically(">", worseMetrics[FrontHypervolume]))
+	g.Expect(betterMetrics[FrontGenerationalDistance]).To(BeZero())
+	g.Expect(worseMetrics[FrontGenerationalDistance]).To(BeNumerically(">", 0))
+	g.Expect(worseMetrics[FrontInvertedGenerationalDistance]).To(BeNumerically(">", 0))
+}
+
+func TestSweep_FailingCombination_OthersStillRun(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	failingBuilder := func(number int, combination Combination, tracker scenario.RunTracker) (scenario.Scenario, error) {
+		if number == 2 {
+			return nil, errors.New("deliberately failed")
+		}
+		return buildStubScenario(number, combination, tracker)
+	}
+
+	sweepUnderTest := New().
+		WithDesign(NewGrid().WithValues("Annealer.A", 0.1, 0.2, 0.3)).
+		WithScenarioBuilder(failingBuilder)
+
+	// when
+	results, runError := sweepUnderTest.Run(context.Background())
+
+	// then
+	g.Expect(runError).To(Not(BeNil()))
+	g.Expect(runError.Error()).To(ContainSubstring("deliberately failed"))
+	g.Expect(results.Combinations[0].Runs).To(HaveLen(1))
+	g.Expect(results.Combinations[1].Runs).To(BeEmpty())
+	g.Expect(results.Combinations[2].Runs).To(HaveLen(1))
+}
+
+// cancelledScenario reports a single finished run to its tracker, as a stubScenario does, but is then cancelled.
+type cancelledScenario struct {
+	stubScenario
+}
+
+func (s *cancelledScenario) Run(ctx context.Context) error {
+	s.stubScenario.Run(ctx)
+	return context.Canceled
+}
+
+func TestSweep_CancelledCombination_KeepsFinishedRuns(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	cancelledBuilder := func(number int, combination Combination, tracker scenario.RunTracker) (scenario.Scenario, error) {
+		return &cancelledScenario{stubScenario{number: number, tracker: tracker}}, nil
+	}
+
+	sweepUnderTest := New().
+		WithDesign(NewGrid().WithValues("Annealer.A", 0.1)).
+		WithScenarioBuilder(cancelledBuilder)
+
+	// when
+	results, runError := sweepUnderTest.Run(context.Background())
+
+	// then
+	g.Expect(runError).To(Not(BeNil()))
+	g.Expect(runError.Error()).To(ContainSubstring(context.Canceled.Error()))
+	g.Expect(results.Combinations[0].Runs).To(HaveLen(1))
+}
+
+func TestSweep_NoScenarioBuilder_Invalid(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	sweepUnderTest := New().WithDesign(NewGrid().WithValues("Annealer.A", 0.1))
+
+	// when
+	results, runError := sweepUnderTest.Run(context.Background())
+
+	// then
+	g.Expect(runError).To(Not(BeNil()))
+	g.Expect(results).To(BeNil())
+}
+
+func TestCsvMarshaler_Marshal_RowPerRun(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	results := &Results{
+		Parameters: []string{"Annealer.A", "Model.B"},
+		Combinations: []CombinationResult{
+			{
+				Number:      1,
+				Combination: Combination{{"Annealer.A", int64(10)}, {"Model.B", 0.5}},
+				Runs: []RunResult{
+					{
+						RunId:             "Run (1/1)",
+						Metrics:           map[string]float64{ObjectiveValue: 1.25},
+						DecisionVariables: map[string]float64{"Cost": 2},
+					},
+				},
+			},
+			{
+				Number:      2,
+				Combination: Combination{{"Annealer.A", int64(20)}, {"Model.B", 0.75}},
+			},
+		},
+	}
+
+	// when
+	marshaledResults, marshalError := new(CsvMarshaler).Marshal(results)
+
+	// then
+	g.Expect(marshalError).To(BeNil())
+
+	lines := strings.Split(strings.TrimSpace(string(marshaledResults)), newline)
+	g.Expect(lines).To(HaveLen(2))
+	g.Expect(lines[0]).To(Equal("Combination, RunId, Annealer.A, Model.B, ObjectiveValue, Cost"))
+	g.Expect(lines[1]).To(Equal("1, Run (1/1), 10, 0.500000, 1.250000, 2.000000"))
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultSampleNumber       = 100
+	defaultFragilityThreshold = 0.1
+)
+
+var defaultPercentiles = []float64{5, 50, 95}
+
+// Analysis re-evaluates a set of solutions against models built from parameter values sampled from configured
+// distributions, summarising how much each solution's decision variables vary in response.
+type Analysis struct {
+	modelFactory       ModelFactory
+	distributions      map[string]Distribution
+	sampleNumber       uint64
+	percentiles        []float64
+	fragilityThreshold float64
+	generator          *rand.Rand
+}
+
+func NewAnalysis() *Analysis {
+	newAnalysis := &Analysis{
+		distributions:      make(map[string]Distribution),
+		sampleNumber:       defaultSampleNumber,
+		percentiles:        defaultPercentiles,
+		fragilityThreshold: defaultFragilityThreshold,
+		generator:          rand.NewTimeSeeded(),
+	}
+	return newAnalysis
+}
+
+func (a *Analysis) WithModelFactory(factory ModelFactory) *Analysis {
+	a.modelFactory = factory
+	return a
+}
+
+func (a *Analysis) WithDistribution(parameterName string, distribution Distribution) *Analysis {
+	a.distributions[parameterName] = distribution
+	return a
+}
+
+func (a *Analysis) WithSampleNumber(sampleNumber uint64) *Analysis {
+	a.sampleNumber = sampleNumber
+	return a
+}
+
+func (a *Analysis) WithPercentiles(percentiles ...float64) *Analysis {
+	a.percentiles = percentiles
+	return a
+}
+
+// WithFragilityThreshold sets the coefficient of variation above which a solution's decision variable is
+// reported as fragile.
+func (a *Analysis) WithFragilityThreshold(threshold float64) *Analysis {
+	a.fragilityThreshold = threshold
+	return a
+}
+
+func (a *Analysis) WithRandomNumberGenerator(generator *rand.Rand) *Analysis {
+	a.generator = generator
+	return a
+}
+
+// Validate reports any problems with the analysis configuration that would prevent it being evaluated.
+func (a *Analysis) Validate() error {
+	validationErrors := compositeErrors.New("Uncertainty Analysis")
+
+	if a.modelFactory == nil {
+		validationErrors.AddMessage("no model factory supplied")
+	}
+	if a.sampleNumber < 1 {
+		validationErrors.AddMessage("sample number must be at least 1")
+	}
+	if len(a.distributions) == 0 {
+		validationErrors.AddMessage("no parameter distributions supplied")
+	}
+	for _, parameterName := range a.sortedParameterNames() {
+		if distributionError := a.distributions[parameterName].Validate(); distributionError != nil {
+			validationErrors.Add(errors.Wrap(distributionError, "parameter ["+parameterName+"]"))
+		}
+	}
+	for _, percentile := range a.percentiles {
+		if percentile < 0 || percentile > 100 {
+			validationErrors.AddMessage(fmt.Sprintf("percentile [%v] must be in the range [0,100]", percentile))
+		}
+	}
+
+	if validationErrors.Size() > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+// solutionSamples accumulates a solution's decision variable values across all samples.
+type solutionSamples struct {
+	sampledValues  map[string][]float64
+	unmatchedCount uint64
+}
+
+// Evaluate re-evaluates each solution against a model built for every sampled parameter set, returning a
+// statistical summary of each solution's decision variables.
+func (a *Analysis) Evaluate(solutions []set.Solution) (*Results, error) {
+	if validationError := a.Validate(); validationError != nil {
+		return nil, validationError
+	}
+
+	solutionEvaluator, evaluatorError := newEvaluator(a.modelFactory, solutions)
+	if evaluatorError != nil {
+		return nil, evaluatorError
+	}
+
+	samples := make([]solutionSamples, len(solutions))
+	for index := range samples {
+		samples[index].sampledValues = make(map[string][]float64)
+	}
+
+	for sample := uint64(1); sample <= a.sampleNumber; sample++ {
+		evaluations, sampleError := solutionEvaluator.evaluate(a.sampleParameters())
+		if sampleError != nil {
+			return nil, errors.Wrap(sampleError, fmt.Sprintf("evaluating sample [%d]", sample))
+		}
+		for index, solutionEvaluation := range evaluations {
+			samples[index].unmatchedCount += solutionEvaluation.unmatchedActions
+			for name, value := range solutionEvaluation.values {
+				samples[index].sampledValues[name] = append(samples[index].sampledValues[name], value)
+			}
+		}
+	}
+
+	return a.deriveResults(solutionEvaluator, samples), nil
+}
+
+func (a *Analysis) sampleParameters() parameters.Map {
+	return SampleParameters(a.distributions, a.generator)
+}
+
+// SampleParameters draws a value for every parameter with a distribution, in a stable parameter order so that
+// seeded samples are repeatable.
+func SampleParameters(distributions map[string]Distribution, generator *rand.Rand) parameters.Map {
+	sampledParameters := make(parameters.Map, len(distributions))
+	for _, parameterName := range sortedDistributionNames(distributions) {
+		sampledParameters[parameterName] = distributions[parameterName].Sample(generator)
+	}
+	return sampledParameters
+}
+
+func (a *Analysis) deriveResults(solutionEvaluator *evaluator, samples []solutionSamples) *Results {
+	results := &Results{
+		Percentiles:  a.percentiles,
+		SampleNumber: a.sampleNumber,
+		Solutions:    make([]SolutionResult, len(samples)),
+	}
+
+	for index, solution := range solutionEvaluator.solutions {
+		solutionResult := SolutionResult{
+			Id:               solution.Id,
+			UnmatchedActions: samples[index].unmatchedCount,
+		}
+
+		for _, name := range solutionEvaluator.variableNames {
+			if _, hasNominal := solution.nominalValues[name]; !hasNominal {
+				continue
+			}
+			statistics := Summarise(samples[index].sampledValues[name], a.percentiles...)
+			solutionResult.Variables = append(solutionResult.Variables,
+				VariableResult{
+					Name:       name,
+					Nominal:    solution.nominalValues[name],
+					Statistics: statistics,
+					IsFragile:  statistics.CoefficientOfVariation() > a.fragilityThreshold,
+				},
+			)
+		}
+		results.Solutions[index] = solutionResult
+	}
+
+	return results
+}
+
+func (a *Analysis) sortedParameterNames() []string {
+	return sortedDistributionNames(a.distributions)
+}
+
+func sortedDistributionNames(distributions map[string]Distribution) []string {
+	names := make([]string, 0, len(distributions))
+	for name := range distributions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	baseRand "math/rand"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	catchmenttest "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/test"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testSampleNumber = 20
+
+	sedimentProduction = "SedimentProduction"
+)
+
+func TestAnalysis_NoDistributions_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	analysisUnderTest := NewAnalysis().WithModelFactory(testingModelFactory)
+
+	// when
+	results, evaluateError := analysisUnderTest.Evaluate([]set.Solution{{Id: "As-Is", Encoding: "0"}})
+	t.Log(evaluateError)
+
+	// then
+	g.Expect(evaluateError).To(Not(BeNil()))
+	g.Expect(results).To(BeNil())
+}
+
+func TestAnalysis_FixedParameters_NoSpreadAtNominal(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	analysisUnderTest := NewAnalysis().
+		WithModelFactory(testingModelFactory).
+		WithSampleNumber(testSampleNumber).
+		WithDistribution(catchmentParameters.HillSlopeDeliveryRatio, Uniform{Minimum: 0.05, Maximum: 0.05})
+
+	solutionsUnderTest := []set.Solution{{Id: "All Active", Encoding: allActionsActiveEncoding(g)}}
+
+	// when
+	results, evaluateError := analysisUnderTest.Evaluate(solutionsUnderTest)
+
+	// then
+	g.Expect(evaluateError).To(BeNil())
+	g.Expect(results.Solutions).To(HaveLen(1))
+
+	solutionResult := results.Solutions[0]
+	g.Expect(solutionResult.UnmatchedActions).To(BeNumerically("==", 0))
+	g.Expect(solutionResult.IsFragile()).To(BeFalse())
+
+	for _, variableResult := range solutionResult.Variables {
+		g.Expect(variableResult.Statistics.SampleSize).To(Equal(testSampleNumber))
+		g.Expect(variableResult.Statistics.Mean).To(BeNumerically("~", variableResult.Nominal, 1e-9))
+		g.Expect(variableResult.Statistics.StandardDeviation).To(BeNumerically("~", 0, 1e-9))
+	}
+}
+
+func TestAnalysis_UncertainParameter_SpreadReported(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	analysisUnderTest := NewAnalysis().
+		WithModelFactory(testingModelFactory).
+		WithSampleNumber(testSampleNumber).
+		WithRandomNumberGenerator(rand.New(baseRand.NewSource(1))).
+		WithFragilityThreshold(0.01).
+		WithDistribution(catchmentParameters.BankErosionFudgeFactor, Uniform{Minimum: 1e-4, Maximum: 5e-4})
+
+	solutionsUnderTest := []set.Solution{
+		{Id: "As-Is", Encoding: "0"},
+		{Id: "All Active", Encoding: allActionsActiveEncoding(g)},
+	}
+
+	// when
+	results, evaluateError := analysisUnderTest.Evaluate(solutionsUnderTest)
+
+	// then
+	g.Expect(evaluateError).To(BeNil())
+	g.Expect(results.Solutions).To(HaveLen(2))
+
+	asIsSediment := variableResultNamed(results.Solutions[0], sedimentProduction)
+	g.Expect(asIsSediment.Statistics.StandardDeviation).To(BeNumerically(">", 0))
+	g.Expect(asIsSediment.Statistics.Minimum).To(BeNumerically("<=", asIsSediment.Statistics.Percentiles[0]))
+	g.Expect(asIsSediment.Statistics.Percentiles[2]).To(BeNumerically("<=", asIsSediment.Statistics.Maximum))
+	g.Expect(asIsSediment.IsFragile).To(BeTrue())
+
+	allActiveSediment := variableResultNamed(results.Solutions[1], sedimentProduction)
+	g.Expect(allActiveSediment.Nominal).To(BeNumerically("<", asIsSediment.Nominal))
+}
+
+func TestAnalysis_SameSeed_RepeatableResults(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	solutionsUnderTest := []set.Solution{{Id: "All Active", Encoding: allActionsActiveEncoding(g)}}
+
+	buildAnalysis := func() *Analysis {
+		return NewAnalysis().
+			WithModelFactory(testingModelFactory).
+			WithSampleNumber(5).
+			WithRandomNumberGenerator(rand.New(baseRand.NewSource(42))).
+			WithDistribution(catchmentParameters.GullyCompensationFactor, Triangular{Minimum: 0.25, Mode: 0.5, Maximum: 0.75}).
+			WithDistribution(catchmentParameters.HillSlopeDeliveryRatio, Normal{Mean: 0.05, StandardDeviation: 0.01, Minimum: 0, Maximum: 1})
+	}
+
+	// when
+	firstResults, firstError := buildAnalysis().Evaluate(solutionsUnderTest)
+	secondResults, secondError := buildAnalysis().Evaluate(solutionsUnderTest)
+
+	// then
+	g.Expect(firstError).To(BeNil())
+	g.Expect(secondError).To(BeNil())
+	g.Expect(firstResults).To(Equal(secondResults))
+}
+
+func TestAnalysis_InvalidSampledParameter_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	analysisUnderTest := NewAnalysis().
+		WithModelFactory(testingModelFactory).
+		WithSampleNumber(1).
+		WithDistribution(catchmentParameters.BankErosionFudgeFactor, Uniform{Minimum: 1, Maximum: 2})
+
+	// when
+	_, evaluateError := analysisUnderTest.Evaluate([]set.Solution{{Id: "As-Is", Encoding: "0"}})
+	t.Log(evaluateError)
+
+	// then
+	g.Expect(evaluateError).To(Not(BeNil()))
+}
+
+func TestCsvMarshaler_Marshal_RowPerSolutionVariable(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	resultsUnderTest := &Results{
+		Percentiles: []float64{5, 95},
+		Solutions: []SolutionResult{
+			{
+				Id: "Solution (1/1)",
+				Variables: []VariableResult{
+					{Name: "A", Nominal: 1, Statistics: Summarise([]float64{1, 2, 3}, 5, 95)},
+					{Name: "B", Nominal: 2, Statistics: Summarise([]float64{2, 2, 2}, 5, 95)},
+				},
+			},
+		},
+	}
+
+	// when
+	marshaled, marshalError := new(CsvMarshaler).Marshal(resultsUnderTest)
+
+	// then
+	expectedCsv := "Solution, Variable, Nominal, Mean, StandardDeviation, CoefficientOfVariation, Minimum, P5, P95, Maximum, Fragile, UnmatchedActions\n" +
+		"Solution (1/1), A, 1.000000, 2.000000, 1.000000, 0.500000, 1.000000, 1.100000, 2.900000, 3.000000, false, 0\n" +
+		"Solution (1/1), B, 2.000000, 2.000000, 0.000000, 0.000000, 2.000000, 2.000000, 2.000000, 2.000000, false, 0\n"
+
+	g.Expect(marshalError).To(BeNil())
+	g.Expect(string(marshaled)).To(Equal(expectedCsv))
+}
+
+func testingModelFactory(sampledParameters parameters.Map) (model.Model, error) {
+	return catchmenttest.NewTestingModel(sampledParameters)
+}
+
+func allActionsActiveEncoding(g *GomegaWithT) string {
+	referenceModel, factoryError := testingModelFactory(parameters.Map{})
+	g.Expect(factoryError).To(BeNil())
+	defer referenceModel.TearDown()
+
+	for index := range referenceModel.ManagementActions() {
+		referenceModel.SetManagementAction(index, true)
+	}
+
+	return new(archive.ModelCompressor).Compress(referenceModel).Encoding()
+}
+
+func variableResultNamed(solutionResult SolutionResult, name string) VariableResult {
+	for _, variableResult := range solutionResult.Variables {
+		if variableResult.Name == name {
+			return variableResult
+		}
+	}
+	return VariableResult{}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+// Package uncertainty offers Monte Carlo analysis of how sensitive a set of solutions is to uncertainty in
+// the parameters of the model that produced them, global sensitivity analysis of those parameters, and a
+// RobustModel allowing solutions to be explored directly under that uncertainty.
+
+package uncertainty
+
+import (
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	"github.com/pkg/errors"
+)
+
+// Distribution describes a probability distribution that model parameter values can be sampled from.
+type Distribution interface {
+	Sample(generator *rand.Rand) float64
+	Validate() error
+}
+
+// Uniform is a Distribution where every value in [Minimum, Maximum] is equally likely.
+type Uniform struct {
+	Minimum float64
+	Maximum float64
+}
+
+func (u Uniform) Sample(generator *rand.Rand) float64 {
+	return u.Minimum + generator.Float64Unitary()*(u.Maximum-u.Minimum)
+}
+
+func (u Uniform) Validate() error {
+	if u.Minimum > u.Maximum {
+		return errors.Errorf("uniform distribution minimum [%v] exceeds maximum [%v]", u.Minimum, u.Maximum)
+	}
+	return nil
+}
+
+// Triangular is a Distribution over [Minimum, Maximum] whose likelihood peaks at Mode.
+type Triangular struct {
+	Minimum float64
+	Mode    float64
+	Maximum float64
+}
+
+func (t Triangular) Sample(generator *rand.Rand) float64 {
+	if t.Minimum == t.Maximum {
+		return t.Minimum
+	}
+
+	unitarySample := generator.Float64Unitary()
+	modeCutoff := (t.Mode - t.Minimum) / (t.Maximum - t.Minimum)
+
+	if unitarySample < modeCutoff {
+		return t.Minimum + math.Sqrt(unitarySample*(t.Maximum-t.Minimum)*(t.Mode-t.Minimum))
+	}
+	return t.Maximum - math.Sqrt((1-unitarySample)*(t.Maximum-t.Minimum)*(t.Maximum-t.Mode))
+}
+
+func (t Triangular) Validate() error {
+	if t.Minimum > t.Mode || t.Mode > t.Maximum {
+		return errors.Errorf("triangular distribution requires minimum [%v] <= mode [%v] <= maximum [%v]",
+			t.Minimum, t.Mode, t.Maximum)
+	}
+	return nil
+}
+
+// maximumTruncationAttempts limits how many times a truncated Normal distribution is re-sampled before
+// falling back to clamping the sample into its bounds.
+const maximumTruncationAttempts = 1000
+
+// Normal is a Distribution centred on Mean with the given StandardDeviation.  If Maximum is greater than Minimum,
+// samples are truncated to [Minimum, Maximum].
+type Normal struct {
+	Mean              float64
+	StandardDeviation float64
+	Minimum           float64
+	Maximum           float64
+}
+
+func (n Normal) Sample(generator *rand.Rand) float64 {
+	sample := n.untruncatedSample(generator)
+	if !n.isTruncated() {
+		return sample
+	}
+
+	for attempt := 1; !n.withinBounds(sample) && attempt < maximumTruncationAttempts; attempt++ {
+		sample = n.untruncatedSample(generator)
+	}
+
+	return math.Min(math.Max(sample, n.Minimum), n.Maximum)
+}
+
+func (n Normal) untruncatedSample(generator *rand.Rand) float64 {
+	return n.Mean + generator.NormFloat64()*n.StandardDeviation
+}
+
+func (n Normal) isTruncated() bool {
+	return n.Maximum > n.Minimum
+}
+
+func (n Normal) withinBounds(value float64) bool {
+	return value >= n.Minimum && value <= n.Maximum
+}
+
+func (n Normal) Validate() error {
+	if n.StandardDeviation < 0 {
+		return errors.Errorf("normal distribution standard deviation [%v] is negative", n.StandardDeviation)
+	}
+	if n.isTruncated() && !n.withinBounds(n.Mean) {
+		return errors.Errorf("normal distribution mean [%v] falls outside of truncation bounds [%v, %v]",
+			n.Mean, n.Minimum, n.Maximum)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"math"
+	baseRand "math/rand"
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	. "github.com/onsi/gomega"
+)
+
+const sampleSize = 10_000
+
+func TestUniform_Sample_WithinBounds(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	distributionUnderTest := Uniform{Minimum: 1e-4, Maximum: 5e-4}
+	generator := rand.New(baseRand.NewSource(1))
+
+	// when
+	samples := drawSamples(distributionUnderTest, generator)
+
+	// then
+	g.Expect(distributionUnderTest.Validate()).To(BeNil())
+	for _, sample := range samples {
+		g.Expect(sample).To(BeNumerically(">=", distributionUnderTest.Minimum))
+		g.Expect(sample).To(BeNumerically("<=", distributionUnderTest.Maximum))
+	}
+	g.Expect(Summarise(samples).Mean).To(BeNumerically("~", 3e-4, 1e-5))
+}
+
+func TestTriangular_Sample_WithinBoundsAroundMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	distributionUnderTest := Triangular{Minimum: 0, Mode: 0.25, Maximum: 1}
+	generator := rand.New(baseRand.NewSource(1))
+
+	// when
+	samples := drawSamples(distributionUnderTest, generator)
+
+	// then
+	g.Expect(distributionUnderTest.Validate()).To(BeNil())
+	for _, sample := range samples {
+		g.Expect(sample).To(BeNumerically(">=", distributionUnderTest.Minimum))
+		g.Expect(sample).To(BeNumerically("<=", distributionUnderTest.Maximum))
+	}
+
+	expectedMean := (0 + 0.25 + 1) / 3.0
+	g.Expect(Summarise(samples).Mean).To(BeNumerically("~", expectedMean, 0.01))
+}
+
+func TestTriangular_DegenerateRange_AlwaysSamplesMinimum(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	distributionUnderTest := Triangular{Minimum: 0.5, Mode: 0.5, Maximum: 0.5}
+	generator := rand.New(baseRand.NewSource(1))
+
+	// when
+	sample := distributionUnderTest.Sample(generator)
+
+	// then
+	g.Expect(sample).To(BeNumerically("==", 0.5))
+}
+
+func TestNormal_Truncated_WithinBounds(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	distributionUnderTest := Normal{Mean: 0.05, StandardDeviation: 0.05, Minimum: 0, Maximum: 1}
+	generator := rand.New(baseRand.NewSource(1))
+
+	// when
+	samples := drawSamples(distributionUnderTest, generator)
+
+	// then
+	g.Expect(distributionUnderTest.Validate()).To(BeNil())
+	for _, sample := range samples {
+		g.Expect(sample).To(BeNumerically(">=", distributionUnderTest.Minimum))
+		g.Expect(sample).To(BeNumerically("<=", distributionUnderTest.Maximum))
+	}
+}
+
+func TestNormal_Untruncated_MatchesMoments(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	distributionUnderTest := Normal{Mean: 0.5, StandardDeviation: 0.1}
+	generator := rand.New(baseRand.NewSource(1))
+
+	// when
+	summary := Summarise(drawSamples(distributionUnderTest, generator))
+
+	// then
+	g.Expect(summary.Mean).To(BeNumerically("~", 0.5, 0.01))
+	g.Expect(summary.StandardDeviation).To(BeNumerically("~", 0.1, 0.01))
+}
+
+func TestDistribution_InvalidShapes_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	distributionsUnderTest := []Distribution{
+		Uniform{Minimum: 1, Maximum: 0},
+		Triangular{Minimum: 0, Mode: 2, Maximum: 1},
+		Normal{Mean: 0, StandardDeviation: -1},
+		Normal{Mean: 2, StandardDeviation: 1, Minimum: 0, Maximum: 1},
+	}
+
+	// then
+	for _, distributionUnderTest := range distributionsUnderTest {
+		validationError := distributionUnderTest.Validate()
+		t.Log(validationError)
+		g.Expect(validationError).To(Not(BeNil()))
+	}
+}
+
+func drawSamples(distribution Distribution, generator *rand.Rand) []float64 {
+	samples := make([]float64, sampleSize)
+	for index := range samples {
+		samples[index] = distribution.Sample(generator)
+		if math.IsNaN(samples[index]) {
+			panic("distribution sampled NaN")
+		}
+	}
+	return samples
+}
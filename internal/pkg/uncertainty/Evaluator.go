@@ -0,0 +1,134 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/archive"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	baseArchive "github.com/LindsayBradford/crem/pkg/archive"
+	"github.com/pkg/errors"
+)
+
+// AsIsSolution is the solution with no management actions active.
+var AsIsSolution = set.Solution{Id: "As-Is"}
+
+// ModelFactory builds a model, initialised to its As-Is state, whose default parameters are overridden by any
+// parameter values supplied.
+type ModelFactory func(parameterValues parameters.Map) (model.Model, error)
+
+type actionKey struct {
+	planningUnit planningunit.Id
+	actionType   action.ManagementActionType
+}
+
+func keyOf(managementAction action.ManagementAction) actionKey {
+	return actionKey{planningUnit: managementAction.PlanningUnit(), actionType: managementAction.Type()}
+}
+
+// decodedSolution is a solution decoded against the nominal model, with actions identified independently of
+// their index, as differing parameter values may change which management actions a model offers.
+type decodedSolution struct {
+	set.Solution
+	activeActions map[actionKey]bool
+	nominalValues map[string]float64
+}
+
+// evaluation holds a solution's decision variable values for a single set of parameter values.
+type evaluation struct {
+	values           map[string]float64
+	unmatchedActions uint64
+}
+
+// evaluator re-evaluates a fixed set of solutions against models built for differing parameter values.
+type evaluator struct {
+	modelFactory  ModelFactory
+	variableNames []string
+	solutions     []decodedSolution
+}
+
+func newEvaluator(modelFactory ModelFactory, solutions []set.Solution) (*evaluator, error) {
+	nominalModel, factoryError := modelFactory(parameters.Map{})
+	if factoryError != nil {
+		return nil, errors.Wrap(factoryError, "building nominal model")
+	}
+	defer nominalModel.TearDown()
+
+	newEvaluator := &evaluator{
+		modelFactory:  modelFactory,
+		variableNames: nominalModel.NameMappedVariables().SortedKeys(),
+		solutions:     make([]decodedSolution, len(solutions)),
+	}
+
+	var modelCompressor archive.ModelCompressor
+	for index, solution := range solutions {
+		compressedModel := modelCompressor.Compress(nominalModel)
+		if solution.Encoding == AsIsSolution.Encoding {
+			compressedModel.Actions = *baseArchive.New(len(nominalModel.ManagementActions()))
+		} else if decodeError := compressedModel.Decode(solution.Encoding); decodeError != nil {
+			return nil, errors.Wrap(decodeError, "decoding actions of solution ["+solution.Id+"]")
+		}
+		modelCompressor.Decompress(compressedModel, nominalModel)
+
+		newEvaluator.solutions[index] = decodedSolution{
+			Solution:      solution,
+			activeActions: activeActionsOf(nominalModel),
+			nominalValues: valuesOf(nominalModel, newEvaluator.variableNames),
+		}
+	}
+
+	return newEvaluator, nil
+}
+
+// evaluate returns an evaluation of each solution, in order, against a model built for the parameter values given.
+func (e *evaluator) evaluate(parameterValues parameters.Map) ([]evaluation, error) {
+	evaluatedModel, factoryError := e.modelFactory(parameterValues)
+	if factoryError != nil {
+		return nil, errors.Wrapf(factoryError, "building model with parameters %v", parameterValues)
+	}
+	defer evaluatedModel.TearDown()
+
+	evaluations := make([]evaluation, len(e.solutions))
+	for index, solution := range e.solutions {
+		evaluations[index] = evaluation{
+			unmatchedActions: applySolution(evaluatedModel, solution.activeActions),
+			values:           valuesOf(evaluatedModel, e.variableNames),
+		}
+	}
+	return evaluations, nil
+}
+
+func activeActionsOf(sourceModel model.Model) map[actionKey]bool {
+	activeActions := make(map[actionKey]bool)
+	for _, activeAction := range sourceModel.ActiveManagementActions() {
+		activeActions[keyOf(activeAction)] = true
+	}
+	return activeActions
+}
+
+func valuesOf(sourceModel model.Model, variableNames []string) map[string]float64 {
+	values := make(map[string]float64, len(variableNames))
+	for _, name := range variableNames {
+		if sourceModel.OffersDecisionVariable(name) {
+			values[name] = sourceModel.DecisionVariable(name).Value()
+		}
+	}
+	return values
+}
+
+// applySolution activates exactly those actions of targetModel matching the solution's active actions,
+// returning how many of the solution's active actions the model has no equivalent for.
+func applySolution(targetModel model.Model, activeActions map[actionKey]bool) uint64 {
+	matchedActions := 0
+	for index, managementAction := range targetModel.ManagementActions() {
+		shouldBeActive := activeActions[keyOf(managementAction)]
+		if shouldBeActive {
+			matchedActions++
+		}
+		targetModel.SetManagementAction(index, shouldBeActive)
+	}
+	return uint64(len(activeActions) - matchedActions)
+}
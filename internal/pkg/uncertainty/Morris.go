@@ -0,0 +1,152 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"math"
+
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+)
+
+const (
+	MorrisMethod = "Morris"
+
+	defaultTrajectoryNumber = 10
+	defaultLevels           = 4
+)
+
+var _ SensitivityMethod = new(Morris)
+
+// Morris screens parameters by their elementary effects, measured along random one-at-a-time trajectories through a
+// grid over the parameter ranges.  Parameters are ranked by MuStar, the mean absolute elementary effect. A Sigma high
+// relative to MuStar suggests the parameter's effect is non-linear, or interacts with other parameters.
+type Morris struct {
+	trajectoryNumber int
+	levels           int
+}
+
+func NewMorris() *Morris {
+	return &Morris{
+		trajectoryNumber: defaultTrajectoryNumber,
+		levels:           defaultLevels,
+	}
+}
+
+func (m *Morris) WithTrajectoryNumber(trajectoryNumber int) *Morris {
+	m.trajectoryNumber = trajectoryNumber
+	return m
+}
+
+// WithLevels sets the number of grid levels each parameter range is divided into. It must be even.
+func (m *Morris) WithLevels(levels int) *Morris {
+	m.levels = levels
+	return m
+}
+
+func (m *Morris) Name() string {
+	return MorrisMethod
+}
+
+func (m *Morris) MeasureNames() []string {
+	return []string{"MuStar", "Mu", "Sigma"}
+}
+
+func (m *Morris) Validate() error {
+	validationErrors := compositeErrors.New("Morris Method")
+	if m.trajectoryNumber < 1 {
+		validationErrors.AddMessage("trajectory number must be at least 1")
+	}
+	if m.levels < 2 || m.levels%2 != 0 {
+		validationErrors.AddMessage("levels must be an even number of at least 2")
+	}
+	if validationErrors.Size() > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+func (m *Morris) delta() float64 {
+	return float64(m.levels) / (2 * float64(m.levels-1))
+}
+
+// Design returns trajectoryNumber trajectories of parameterNumber+1 points, each point differing from the last by
+// a step of delta in a single, randomly ordered, parameter.
+func (m *Morris) Design(parameterNumber int, generator *rand.Rand) [][]float64 {
+	design := make([][]float64, 0, m.trajectoryNumber*(parameterNumber+1))
+	for trajectory := 0; trajectory < m.trajectoryNumber; trajectory++ {
+		design = append(design, m.trajectory(parameterNumber, generator)...)
+	}
+	return design
+}
+
+func (m *Morris) trajectory(parameterNumber int, generator *rand.Rand) [][]float64 {
+	point := make([]float64, parameterNumber)
+	for index := range point {
+		point[index] = float64(generator.Intn(m.levels)) / float64(m.levels-1)
+	}
+
+	trajectory := [][]float64{point}
+	for _, parameterIndex := range permutation(parameterNumber, generator) {
+		nextPoint := make([]float64, parameterNumber)
+		copy(nextPoint, point)
+
+		if nextPoint[parameterIndex]+m.delta() <= 1 {
+			nextPoint[parameterIndex] += m.delta()
+		} else {
+			nextPoint[parameterIndex] -= m.delta()
+		}
+
+		trajectory = append(trajectory, nextPoint)
+		point = nextPoint
+	}
+	return trajectory
+}
+
+func permutation(size int, generator *rand.Rand) []int {
+	permuted := make([]int, size)
+	for index := range permuted {
+		permuted[index] = index
+	}
+	for index := size - 1; index > 0; index-- {
+		swapIndex := generator.Intn(index + 1)
+		permuted[index], permuted[swapIndex] = permuted[swapIndex], permuted[index]
+	}
+	return permuted
+}
+
+// Measures derives the MuStar, Mu and Sigma of each parameter's elementary effects on outputs.
+func (m *Morris) Measures(design [][]float64, outputs []float64, parameterNumber int) [][]float64 {
+	effects := make([][]float64, parameterNumber)
+	absoluteEffects := make([][]float64, parameterNumber)
+
+	for trajectoryStart := 0; trajectoryStart < len(design); trajectoryStart += parameterNumber + 1 {
+		for step := trajectoryStart + 1; step <= trajectoryStart+parameterNumber; step++ {
+			parameterIndex, stepSize := changedParameter(design[step-1], design[step])
+			effect := (outputs[step] - outputs[step-1]) / stepSize
+
+			effects[parameterIndex] = append(effects[parameterIndex], effect)
+			absoluteEffects[parameterIndex] = append(absoluteEffects[parameterIndex], math.Abs(effect))
+		}
+	}
+
+	measures := make([][]float64, parameterNumber)
+	for parameterIndex := range measures {
+		effectStatistics := Summarise(effects[parameterIndex])
+		measures[parameterIndex] = []float64{
+			Summarise(absoluteEffects[parameterIndex]).Mean,
+			effectStatistics.Mean,
+			effectStatistics.StandardDeviation,
+		}
+	}
+	return measures
+}
+
+func changedParameter(fromPoint []float64, toPoint []float64) (int, float64) {
+	for index := range fromPoint {
+		if toPoint[index] != fromPoint[index] {
+			return index, toPoint[index] - fromPoint[index]
+		}
+	}
+	panic("consecutive trajectory points expected to differ in one parameter")
+}
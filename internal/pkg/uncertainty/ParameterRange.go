@@ -0,0 +1,62 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters/specification"
+	"github.com/pkg/errors"
+)
+
+// unboundedVariation is the proportion either side of its value that a parameter without declared bounds is varied by.
+const unboundedVariation = 0.5
+
+// ParameterRange is the inclusive range of values a parameter is varied over during sensitivity analysis.
+type ParameterRange struct {
+	Minimum float64
+	Maximum float64
+}
+
+// valueAt maps a value in the unit interval onto the range.
+func (r ParameterRange) valueAt(unitValue float64) float64 {
+	return r.Minimum + unitValue*(r.Maximum-r.Minimum)
+}
+
+func (r ParameterRange) Validate() error {
+	if r.Minimum >= r.Maximum {
+		return errors.Errorf("range minimum [%v] must be less than maximum [%v]", r.Minimum, r.Maximum)
+	}
+	return nil
+}
+
+// DefaultParameterRanges derives a ParameterRange for every mandatory decimal parameter of specifications. Declared
+// specification bounds are used where available. Otherwise, the parameter is varied 50% either side of its configured
+// value, or its default value where not configured.
+func DefaultParameterRanges(specifications *specification.Specifications, configuredValues parameters.Map) map[string]ParameterRange {
+	ranges := make(map[string]ParameterRange)
+	for key, spec := range *specifications {
+		if spec.IsOptional {
+			continue
+		}
+
+		if spec.Bounds.IsBounded() {
+			ranges[key] = ParameterRange{Minimum: spec.Bounds.Minimum, Maximum: spec.Bounds.Maximum}
+			continue
+		}
+
+		value, isDecimal := spec.DefaultValue.(float64)
+		if configuredValue, isConfiguredDecimal := configuredValues[key].(float64); isConfiguredDecimal {
+			value, isDecimal = configuredValue, true
+		}
+		if !isDecimal || value == 0 {
+			continue
+		}
+
+		variation := unboundedVariation * value
+		if variation < 0 {
+			variation = -variation
+		}
+		ranges[key] = ParameterRange{Minimum: value - variation, Maximum: value + variation}
+	}
+	return ranges
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"fmt"
+	strings2 "strings"
+
+	"github.com/LindsayBradford/crem/pkg/strings"
+)
+
+// Results holds the outcome of an uncertainty Analysis for each solution evaluated.
+type Results struct {
+	Percentiles  []float64
+	SampleNumber uint64
+	Solutions    []SolutionResult
+}
+
+// SolutionResult summarises a single solution's decision variables across all samples.
+type SolutionResult struct {
+	Id string
+
+	// UnmatchedActions counts, over all samples, the solution's active actions that a sampled model did not offer.
+	UnmatchedActions uint64
+	Variables        []VariableResult
+}
+
+// IsFragile reports whether any of the solution's decision variables are fragile.
+func (sr SolutionResult) IsFragile() bool {
+	for _, variableResult := range sr.Variables {
+		if variableResult.IsFragile {
+			return true
+		}
+	}
+	return false
+}
+
+// VariableResult compares a decision variable's value under nominal parameters to its Statistics under sampled
+// parameters. IsFragile is set where its coefficient of variation exceeds the analysis fragility threshold.
+type VariableResult struct {
+	Name       string
+	Nominal    float64
+	Statistics Statistics
+	IsFragile  bool
+}
+
+const (
+	idHeading = "Solution"
+	separator = ", "
+	newline   = "\n"
+)
+
+var defaultConverter = strings.NewConverter().WithFloatingPointPrecision(6).PaddingZeros()
+
+// CsvMarshaler marshals Results into CSV text, with a row per solution decision variable.
+type CsvMarshaler struct{}
+
+func (cm *CsvMarshaler) Marshal(results *Results) ([]byte, error) {
+	builder := new(strings.FluentBuilder)
+	builder.Add(join(deriveHeaders(results)...)).Add(newline)
+
+	for _, solutionResult := range results.Solutions {
+		for _, variableResult := range solutionResult.Variables {
+			builder.Add(join(deriveRow(solutionResult, variableResult)...)).Add(newline)
+		}
+	}
+
+	return ([]byte)(builder.String()), nil
+}
+
+func deriveHeaders(results *Results) []string {
+	headers := []string{
+		idHeading, "Variable", "Nominal", "Mean", "StandardDeviation", "CoefficientOfVariation", "Minimum",
+	}
+	for _, percentile := range results.Percentiles {
+		headers = append(headers, fmt.Sprintf("P%v", percentile))
+	}
+	return append(headers, "Maximum", "Fragile", "UnmatchedActions")
+}
+
+func deriveRow(solutionResult SolutionResult, variableResult VariableResult) []string {
+	statistics := variableResult.Statistics
+	row := []string{
+		solutionResult.Id,
+		variableResult.Name,
+		defaultConverter.Convert(variableResult.Nominal),
+		defaultConverter.Convert(statistics.Mean),
+		defaultConverter.Convert(statistics.StandardDeviation),
+		defaultConverter.Convert(statistics.CoefficientOfVariation()),
+		defaultConverter.Convert(statistics.Minimum),
+	}
+	for _, percentileValue := range statistics.Percentiles {
+		row = append(row, defaultConverter.Convert(percentileValue))
+	}
+	return append(row,
+		defaultConverter.Convert(statistics.Maximum),
+		defaultConverter.Convert(variableResult.IsFragile),
+		defaultConverter.Convert(solutionResult.UnmatchedActions),
+	)
+}
+
+func join(entries ...string) string {
+	return strings2.Join(entries, separator)
+}
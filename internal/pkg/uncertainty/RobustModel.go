@@ -0,0 +1,357 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"fmt"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	"github.com/LindsayBradford/crem/internal/pkg/model/action"
+	"github.com/LindsayBradford/crem/internal/pkg/model/planningunit"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/LindsayBradford/crem/pkg/math"
+	"github.com/LindsayBradford/crem/pkg/name"
+	"github.com/pkg/errors"
+)
+
+var (
+	_ model.Model                       = NewRobustModel()
+	_ variable.UndoableDecisionVariable = new(robustDecisionVariable)
+)
+
+// RobustModel wraps a number of member models, each built from a different sampled parameter set, keeping their
+// management actions in lockstep.  It offers each decision variable of its members as the RobustStatistic of that
+// variable's values across all members, allowing explorers to optimise directly for solutions that hold up under
+// parameter uncertainty.
+//
+// The first member leads, deciding on random changes that the remaining members are then synchronised to.
+type RobustModel struct {
+	name.NameContainer
+	name.IdentifiableContainer
+
+	members   []model.Model
+	statistic RobustStatistic
+
+	variable.ContainedDecisionVariables
+}
+
+func NewRobustModel() *RobustModel {
+	newModel := new(RobustModel)
+	newModel.SetName("RobustModel")
+	newModel.statistic = MeanStatistic
+	newModel.ContainedDecisionVariables.Initialise()
+	return newModel
+}
+
+func (rm *RobustModel) WithName(name string) *RobustModel {
+	rm.SetName(name)
+	return rm
+}
+
+func (rm *RobustModel) WithMembers(members ...model.Model) *RobustModel {
+	rm.members = members
+	return rm
+}
+
+func (rm *RobustModel) WithStatistic(statistic RobustStatistic) *RobustModel {
+	rm.statistic = statistic
+	return rm
+}
+
+func (rm *RobustModel) Members() []model.Model {
+	return rm.members
+}
+
+// Validate reports any problems with the model that would prevent its members being kept in lockstep.
+func (rm *RobustModel) Validate() error {
+	validationErrors := compositeErrors.New("Robust Model")
+
+	if len(rm.members) == 0 {
+		validationErrors.AddMessage("no member models supplied")
+	}
+	if statisticError := rm.statistic.Validate(); statisticError != nil {
+		validationErrors.Add(statisticError)
+	}
+	for index := 1; index < len(rm.members); index++ {
+		if layoutError := checkActionLayout(rm.leader(), rm.members[index]); layoutError != nil {
+			validationErrors.Add(errors.Wrap(layoutError, fmt.Sprintf("member [%d]", index+1)))
+		}
+	}
+
+	if validationErrors.Size() > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+// checkActionLayout ensures a member offers the same management actions, in the same order, as the leading member,
+// as sampled parameter values may change which management actions a model offers.
+func checkActionLayout(leader model.Model, member model.Model) error {
+	leaderActions := leader.ManagementActions()
+	memberActions := member.ManagementActions()
+	if len(leaderActions) != len(memberActions) {
+		return errors.Errorf("offers [%d] management actions, but leading member offers [%d]",
+			len(memberActions), len(leaderActions))
+	}
+	for index := range leaderActions {
+		if keyOf(leaderActions[index]) != keyOf(memberActions[index]) {
+			return errors.Errorf("management action [%d] differs from that of leading member", index)
+		}
+	}
+	return nil
+}
+
+func (rm *RobustModel) leader() model.Model {
+	return rm.members[0]
+}
+
+func (rm *RobustModel) followers() []model.Model {
+	return rm.members[1:]
+}
+
+func (rm *RobustModel) Initialise(initialisationType model.InitialisationType) {
+	for _, member := range rm.members {
+		member.Initialise(initialisationType)
+	}
+	rm.synchroniseFollowers()
+	rm.buildDecisionVariables()
+}
+
+func (rm *RobustModel) buildDecisionVariables() {
+	rm.ContainedDecisionVariables.Initialise()
+	for _, variableName := range rm.leader().NameMappedVariables().SortedKeys() {
+		newVariable := newRobustDecisionVariable(rm.leader().DecisionVariable(variableName))
+		newVariable.SetValue(rm.aggregateOf(newVariable))
+		rm.ContainedDecisionVariables.Add(newVariable)
+	}
+}
+
+func (rm *RobustModel) aggregateOf(robustVariable *robustDecisionVariable) float64 {
+	values := make([]float64, len(rm.members))
+	for index, member := range rm.members {
+		values[index] = member.DecisionVariable(robustVariable.Name()).Value()
+	}
+	return rm.statistic.Of(values, robustVariable.sense)
+}
+
+func (rm *RobustModel) synchroniseFollowers() {
+	for _, follower := range rm.followers() {
+		follower.SynchroniseTo(rm.leader())
+	}
+}
+
+// refreshDecisionVariables sets variables to the aggregate of member values outright, with no change to undo.
+func (rm *RobustModel) refreshDecisionVariables() {
+	for _, robustVariable := range rm.robustVariables() {
+		robustVariable.SetValue(rm.aggregateOf(robustVariable))
+	}
+}
+
+// stageDecisionVariables sets variables to the aggregate of member values as an undoable change.
+func (rm *RobustModel) stageDecisionVariables() {
+	for _, robustVariable := range rm.robustVariables() {
+		robustVariable.SetUndoableValue(rm.aggregateOf(robustVariable))
+	}
+}
+
+func (rm *RobustModel) robustVariables() []*robustDecisionVariable {
+	robustVariables := make([]*robustDecisionVariable, 0, len(rm.UndoableDecisionVariables))
+	for _, undoableVariable := range rm.UndoableDecisionVariables {
+		robustVariables = append(robustVariables, undoableVariable.(*robustDecisionVariable))
+	}
+	return robustVariables
+}
+
+func (rm *RobustModel) Randomize() {
+	rm.leader().Randomize()
+	rm.synchroniseFollowers()
+	rm.refreshDecisionVariables()
+}
+
+func (rm *RobustModel) TearDown() {
+	for _, member := range rm.members {
+		member.TearDown()
+	}
+}
+
+func (rm *RobustModel) DoRandomChange() {
+	rm.TryRandomChange()
+	rm.AcceptChange()
+}
+
+func (rm *RobustModel) UndoChange() {
+	rm.leader().UndoChange()
+	rm.leader().AcceptChange()
+	rm.synchroniseFollowers()
+	rm.refreshDecisionVariables()
+}
+
+// TryRandomChange has the leading member make (and commit to) a random change, synchronising the remaining members
+// to it. Only the model's aggregate decision variables are left awaiting acceptance or reversion.
+func (rm *RobustModel) TryRandomChange() {
+	rm.leader().TryRandomChange()
+	rm.leader().AcceptChange()
+	rm.synchroniseFollowers()
+	rm.stageDecisionVariables()
+}
+
+// ChangeIsValid checks the aggregate decision variable values against any bounds their members declare.
+func (rm *RobustModel) ChangeIsValid() (bool, *compositeErrors.CompositeError) {
+	validationErrors := compositeErrors.New("Validation Errors")
+
+	for _, robustVariable := range rm.robustVariables() {
+		value := robustVariable.UndoableValue()
+		if robustVariable.bounds != nil && !robustVariable.bounds.WithinBounds(value) {
+			message := fmt.Sprintf("%s %s", robustVariable.Name(), robustVariable.bounds.BoundErrorAsText(value))
+			validationErrors.AddMessage(message)
+		}
+	}
+
+	if validationErrors.Size() > 0 {
+		return false, validationErrors
+	}
+	return true, nil
+}
+
+func (rm *RobustModel) AcceptChange() {
+	rm.ContainedDecisionVariables.AcceptAll()
+}
+
+func (rm *RobustModel) RevertChange() {
+	rm.ContainedDecisionVariables.RejectAll()
+	rm.leader().UndoChange()
+	rm.leader().AcceptChange()
+	rm.synchroniseFollowers()
+}
+
+func (rm *RobustModel) IsEquivalentTo(otherModel model.Model) bool {
+	myActions := rm.ManagementActions()
+	otherActions := otherModel.ManagementActions()
+	if len(myActions) != len(otherActions) {
+		return false
+	}
+	for index := range myActions {
+		if myActions[index].IsActive() != otherActions[index].IsActive() {
+			return false
+		}
+	}
+
+	for _, robustVariable := range rm.robustVariables() {
+		if robustVariable.Value() != otherModel.DecisionVariable(robustVariable.Name()).Value() {
+			return false
+		}
+	}
+	return true
+}
+
+func (rm *RobustModel) SynchroniseTo(otherModel model.Model) {
+	for index, otherAction := range otherModel.ManagementActions() {
+		rm.leader().SetManagementAction(index, otherAction.IsActive())
+	}
+	rm.synchroniseFollowers()
+	rm.refreshDecisionVariables()
+}
+
+func (rm *RobustModel) DeepClone() model.Model {
+	clone := *rm
+
+	clone.members = make([]model.Model, len(rm.members))
+	for index, member := range rm.members {
+		clone.members[index] = member.DeepClone()
+	}
+
+	clone.buildDecisionVariables()
+	return &clone
+}
+
+func (rm *RobustModel) ManagementActions() []action.ManagementAction {
+	return rm.leader().ManagementActions()
+}
+
+func (rm *RobustModel) ActiveManagementActions() []action.ManagementAction {
+	return rm.leader().ActiveManagementActions()
+}
+
+func (rm *RobustModel) SetManagementAction(index int, value bool) {
+	for _, member := range rm.members {
+		member.SetManagementAction(index, value)
+	}
+	rm.refreshDecisionVariables()
+}
+
+func (rm *RobustModel) SetManagementActionUnobserved(index int, value bool) {
+	for _, member := range rm.members {
+		member.SetManagementActionUnobserved(index, value)
+	}
+	rm.refreshDecisionVariables()
+}
+
+// EvaluateManagementActionToggle evaluates the toggle for each member, reporting the change it would induce in the
+// aggregate of each decision variable across members.
+func (rm *RobustModel) EvaluateManagementActionToggle(index int) model.DecisionVariableDeltas {
+	memberDeltas := make([]model.DecisionVariableDeltas, len(rm.members))
+	for memberIndex, member := range rm.members {
+		memberDeltas[memberIndex] = member.EvaluateManagementActionToggle(index)
+	}
+
+	deltas := make(model.DecisionVariableDeltas, len(rm.UndoableDecisionVariables))
+	for _, robustVariable := range rm.robustVariables() {
+		values := make([]float64, len(rm.members))
+		for memberIndex, member := range rm.members {
+			values[memberIndex] = member.DecisionVariable(robustVariable.Name()).Value() +
+				memberDeltas[memberIndex].Delta(robustVariable.Name())
+		}
+		evaluatedValue := math.RoundFloat(rm.statistic.Of(values, robustVariable.sense), int(robustVariable.Precision()))
+		deltas[robustVariable.Name()] = evaluatedValue - robustVariable.Value()
+	}
+	return deltas
+}
+
+func (rm *RobustModel) PlanningUnits() planningunit.Ids {
+	return rm.leader().PlanningUnits()
+}
+
+// robustDecisionVariable offers the aggregate of a decision variable across member models, sharing the member
+// variable's unit of measure, precision, optimisation sense and bounds.
+type robustDecisionVariable struct {
+	variable.SimpleDecisionVariable
+
+	undoableValue float64
+	sense         variable.OptimisationSense
+	bounds        variable.Bounded
+}
+
+func newRobustDecisionVariable(memberVariable variable.DecisionVariable) *robustDecisionVariable {
+	newVariable := &robustDecisionVariable{sense: variable.SenseOf(memberVariable)}
+
+	newVariable.SetName(memberVariable.Name())
+	newVariable.SetUnitOfMeasure(memberVariable.UnitOfMeasure())
+	newVariable.SetPrecision(memberVariable.Precision())
+
+	if boundedVariable, isBounded := memberVariable.(variable.Bounded); isBounded {
+		newVariable.bounds = boundedVariable
+	}
+
+	return newVariable
+}
+
+func (v *robustDecisionVariable) SetValue(value float64) {
+	v.SimpleDecisionVariable.SetValue(math.RoundFloat(value, int(v.Precision())))
+	v.undoableValue = v.Value()
+}
+
+func (v *robustDecisionVariable) UndoableValue() float64 { return v.undoableValue }
+
+func (v *robustDecisionVariable) SetUndoableValue(value float64) {
+	v.undoableValue = math.RoundFloat(value, int(v.Precision()))
+}
+
+func (v *robustDecisionVariable) DifferenceInValues() float64 { return v.undoableValue - v.Value() }
+
+func (v *robustDecisionVariable) ApplyDoneValue()   { v.SimpleDecisionVariable.SetValue(v.undoableValue) }
+func (v *robustDecisionVariable) ApplyUndoneValue() { v.undoableValue = v.Value() }
+
+func (v *robustDecisionVariable) OptimisationSense() variable.OptimisationSense {
+	return v.sense
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"testing"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model"
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	. "github.com/onsi/gomega"
+)
+
+func TestRobustStatistic_Of_PessimisticForSense(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	values := []float64{4, 1, 3, 2, 5}
+
+	// then
+	g.Expect(MeanStatistic.Of(values, variable.Minimised)).To(BeNumerically("~", 3, 1e-9))
+	g.Expect(MeanStatistic.Of(values, variable.Maximised)).To(BeNumerically("~", 3, 1e-9))
+
+	g.Expect(WorstStatistic.Of(values, variable.Minimised)).To(BeNumerically("==", 5))
+	g.Expect(WorstStatistic.Of(values, variable.Maximised)).To(BeNumerically("==", 1))
+
+	g.Expect(PercentileStatistic(75).Of(values, variable.Minimised)).To(BeNumerically("~", 4, 1e-9))
+	g.Expect(PercentileStatistic(75).Of(values, variable.Maximised)).To(BeNumerically("~", 2, 1e-9))
+}
+
+func TestRobustStatistic_Validate_PercentileOutOfRange_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	validationError := PercentileStatistic(101).Validate()
+	t.Log(validationError)
+
+	// then
+	g.Expect(validationError).To(Not(BeNil()))
+	g.Expect(new(RobustStatistic).Validate()).To(Not(BeNil()))
+	g.Expect(MeanStatistic.Validate()).To(BeNil())
+}
+
+var robustFudgeFactors = []float64{1e-4, 3e-4, 5e-4}
+
+func buildRobustMembers(g *GomegaWithT) []model.Model {
+	members := make([]model.Model, len(robustFudgeFactors))
+	for index, fudgeFactor := range robustFudgeFactors {
+		member, factoryError := testingModelFactory(parameters.Map{catchmentParameters.BankErosionFudgeFactor: fudgeFactor})
+		g.Expect(factoryError).To(BeNil())
+		members[index] = member
+	}
+	return members
+}
+
+func expectMembersInLockstep(g *GomegaWithT, modelUnderTest *RobustModel) {
+	leaderActions := modelUnderTest.Members()[0].ManagementActions()
+	for _, member := range modelUnderTest.Members()[1:] {
+		for index, memberAction := range member.ManagementActions() {
+			g.Expect(memberAction.IsActive()).To(Equal(leaderActions[index].IsActive()))
+		}
+	}
+}
+
+func memberValuesOf(modelUnderTest *RobustModel, variableName string) []float64 {
+	values := make([]float64, 0, len(modelUnderTest.Members()))
+	for _, member := range modelUnderTest.Members() {
+		values = append(values, member.DecisionVariable(variableName).Value())
+	}
+	return values
+}
+
+func TestRobustModel_Initialise_AggregatesMemberVariables(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := NewRobustModel().
+		WithMembers(buildRobustMembers(g)...).
+		WithStatistic(WorstStatistic)
+
+	// when
+	modelUnderTest.Initialise(model.AsIs)
+
+	// then
+	g.Expect(modelUnderTest.Validate()).To(BeNil())
+	g.Expect(modelUnderTest.NameMappedVariables().SortedKeys()).To(
+		Equal(modelUnderTest.Members()[0].NameMappedVariables().SortedKeys()))
+
+	sedimentValues := memberValuesOf(modelUnderTest, sedimentProduction)
+	g.Expect(sedimentValues[2]).To(BeNumerically(">", sedimentValues[0]))
+	g.Expect(modelUnderTest.DecisionVariable(sedimentProduction).Value()).To(
+		BeNumerically("~", sedimentValues[2], 1e-3))
+}
+
+func TestRobustModel_TryRandomChange_MembersInLockstep(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := NewRobustModel().
+		WithMembers(buildRobustMembers(g)...).
+		WithStatistic(MeanStatistic)
+	modelUnderTest.Initialise(model.AsIs)
+
+	initialSediment := modelUnderTest.DecisionVariable(sedimentProduction).Value()
+
+	// when
+	modelUnderTest.TryRandomChange()
+
+	// then
+	expectMembersInLockstep(g, modelUnderTest)
+	g.Expect(modelUnderTest.DecisionVariable(sedimentProduction).Value()).To(Equal(initialSediment))
+
+	expectedSediment := MeanStatistic.Of(memberValuesOf(modelUnderTest, sedimentProduction), variable.Minimised)
+	g.Expect(modelUnderTest.DecisionVariableChange(sedimentProduction)).To(
+		BeNumerically("~", expectedSediment-initialSediment, 1e-3))
+
+	// when
+	modelUnderTest.RevertChange()
+
+	// then
+	expectMembersInLockstep(g, modelUnderTest)
+	g.Expect(modelUnderTest.ActiveManagementActions()).To(BeEmpty())
+	g.Expect(modelUnderTest.DecisionVariable(sedimentProduction).Value()).To(Equal(initialSediment))
+	g.Expect(modelUnderTest.DecisionVariableChange(sedimentProduction)).To(BeZero())
+
+	// when
+	modelUnderTest.DoRandomChange()
+
+	// then
+	expectMembersInLockstep(g, modelUnderTest)
+	g.Expect(modelUnderTest.ActiveManagementActions()).To(HaveLen(1))
+	g.Expect(modelUnderTest.DecisionVariable(sedimentProduction).Value()).To(
+		BeNumerically("~", MeanStatistic.Of(memberValuesOf(modelUnderTest, sedimentProduction), variable.Minimised), 1e-3))
+}
+
+func TestRobustModel_SynchroniseTo_MatchesOtherModel(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := NewRobustModel().WithMembers(buildRobustMembers(g)...)
+	modelUnderTest.Initialise(model.AsIs)
+
+	otherModel := modelUnderTest.DeepClone()
+	otherModel.DoRandomChange()
+	g.Expect(modelUnderTest.IsEquivalentTo(otherModel)).To(BeFalse())
+
+	// when
+	modelUnderTest.SynchroniseTo(otherModel)
+
+	// then
+	expectMembersInLockstep(g, modelUnderTest)
+	g.Expect(modelUnderTest.IsEquivalentTo(otherModel)).To(BeTrue())
+}
+
+func TestRobustModel_EvaluateManagementActionToggle_MatchesAppliedToggle(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	modelUnderTest := NewRobustModel().
+		WithMembers(buildRobustMembers(g)...).
+		WithStatistic(WorstStatistic)
+	modelUnderTest.Initialise(model.AsIs)
+
+	const actionIndex = 0
+	sedimentBefore := modelUnderTest.DecisionVariable(sedimentProduction).Value()
+
+	// when
+	deltas := modelUnderTest.EvaluateManagementActionToggle(actionIndex)
+
+	// then
+	expectMembersInLockstep(g, modelUnderTest)
+	g.Expect(modelUnderTest.ActiveManagementActions()).To(BeEmpty())
+	g.Expect(modelUnderTest.DecisionVariable(sedimentProduction).Value()).To(Equal(sedimentBefore))
+	g.Expect(deltas.Delta(sedimentProduction)).To(Not(BeZero()))
+
+	// when
+	modelUnderTest.SetManagementAction(actionIndex, true)
+
+	// then
+	appliedChange := modelUnderTest.DecisionVariable(sedimentProduction).Value() - sedimentBefore
+	g.Expect(deltas.Delta(sedimentProduction)).To(BeNumerically("~", appliedChange, 1e-9))
+}
+
+func TestRobustModel_Validate_NoMembers_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	validationError := NewRobustModel().Validate()
+	t.Log(validationError)
+
+	// then
+	g.Expect(validationError).To(Not(BeNil()))
+}
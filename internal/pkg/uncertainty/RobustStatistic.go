@@ -0,0 +1,94 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/LindsayBradford/crem/internal/pkg/model/variable"
+	"github.com/pkg/errors"
+)
+
+const (
+	meanStatistic       = "Mean"
+	percentileStatistic = "Percentile"
+	worstStatistic      = "Worst"
+)
+
+// RobustStatistic aggregates the values a decision variable takes across sampled parameter sets into the single
+// value a RobustModel offers for it.  Percentile and Worst statistics are pessimistic, in that they are taken
+// from the unfavourable end of the values, given the variable's OptimisationSense.
+type RobustStatistic struct {
+	name       string
+	percentile float64
+}
+
+var (
+	MeanStatistic  = RobustStatistic{name: meanStatistic}
+	WorstStatistic = RobustStatistic{name: worstStatistic}
+)
+
+// PercentileStatistic returns a RobustStatistic for the percentile (in the range [0,100]) supplied. For example,
+// the 90th percentile of a minimised variable is exceeded by 10% of samples, while the 90th percentile of a
+// maximised variable is undershot by 10% of samples.
+func PercentileStatistic(percentile float64) RobustStatistic {
+	return RobustStatistic{name: percentileStatistic, percentile: percentile}
+}
+
+func (rs RobustStatistic) String() string {
+	if rs.name == percentileStatistic {
+		return fmt.Sprintf("%s(%v)", rs.name, rs.percentile)
+	}
+	return rs.name
+}
+
+func (rs RobustStatistic) Validate() error {
+	switch rs.name {
+	case meanStatistic, worstStatistic:
+		return nil
+	case percentileStatistic:
+		if rs.percentile < 0 || rs.percentile > 100 {
+			return errors.Errorf("percentile [%v] must be in the range [0,100]", rs.percentile)
+		}
+		return nil
+	default:
+		return errors.New("unspecified robust statistic")
+	}
+}
+
+// Of returns the statistic of values, for a decision variable with the OptimisationSense supplied.
+func (rs RobustStatistic) Of(values []float64, sense variable.OptimisationSense) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	switch rs.name {
+	case worstStatistic:
+		return rs.worstOf(values, sense)
+	case percentileStatistic:
+		sortedValues := make([]float64, len(values))
+		copy(sortedValues, values)
+		sort.Float64s(sortedValues)
+
+		if sense == variable.Maximised {
+			return percentileOf(sortedValues, 100-rs.percentile)
+		}
+		return percentileOf(sortedValues, rs.percentile)
+	default:
+		return meanOf(values)
+	}
+}
+
+func (rs RobustStatistic) worstOf(values []float64, sense variable.OptimisationSense) float64 {
+	worst := values[0]
+	for _, value := range values[1:] {
+		if sense == variable.Maximised {
+			worst = math.Min(worst, value)
+		} else {
+			worst = math.Max(worst, value)
+		}
+	}
+	return worst
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/LindsayBradford/crem/internal/pkg/annealing/solution/set"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	compositeErrors "github.com/LindsayBradford/crem/pkg/errors"
+	"github.com/LindsayBradford/crem/pkg/strings"
+	"github.com/pkg/errors"
+)
+
+// SensitivityMethod decides the points in the unit hypercube (a dimension per parameter) a SensitivityAnalysis
+// evaluates, and derives each parameter's sensitivity measures from the outputs at those points.
+type SensitivityMethod interface {
+	Name() string
+
+	// MeasureNames lists the measures returned per parameter, with the measure parameters are ranked by first.
+	MeasureNames() []string
+	Validate() error
+
+	Design(parameterNumber int, generator *rand.Rand) [][]float64
+	Measures(design [][]float64, outputs []float64, parameterNumber int) [][]float64
+}
+
+// SensitivityAnalysis ranks how strongly each model parameter drives the decision variables of a set of solutions
+// (or of the As-Is solution where none are supplied), by evaluating models built from parameter values varied over
+// their ranges.
+type SensitivityAnalysis struct {
+	modelFactory ModelFactory
+	method       SensitivityMethod
+	ranges       map[string]ParameterRange
+	generator    *rand.Rand
+}
+
+func NewSensitivityAnalysis() *SensitivityAnalysis {
+	return &SensitivityAnalysis{
+		method:    NewMorris(),
+		ranges:    make(map[string]ParameterRange),
+		generator: rand.NewTimeSeeded(),
+	}
+}
+
+func (sa *SensitivityAnalysis) WithModelFactory(factory ModelFactory) *SensitivityAnalysis {
+	sa.modelFactory = factory
+	return sa
+}
+
+func (sa *SensitivityAnalysis) WithMethod(method SensitivityMethod) *SensitivityAnalysis {
+	sa.method = method
+	return sa
+}
+
+func (sa *SensitivityAnalysis) WithParameterRange(parameterName string, parameterRange ParameterRange) *SensitivityAnalysis {
+	sa.ranges[parameterName] = parameterRange
+	return sa
+}
+
+func (sa *SensitivityAnalysis) WithRandomNumberGenerator(generator *rand.Rand) *SensitivityAnalysis {
+	sa.generator = generator
+	return sa
+}
+
+// Validate reports any problems with the analysis configuration that would prevent it being evaluated.
+func (sa *SensitivityAnalysis) Validate() error {
+	validationErrors := compositeErrors.New("Sensitivity Analysis")
+
+	if sa.modelFactory == nil {
+		validationErrors.AddMessage("no model factory supplied")
+	}
+	if sa.method == nil {
+		validationErrors.AddMessage("no sensitivity method supplied")
+	} else if methodError := sa.method.Validate(); methodError != nil {
+		validationErrors.Add(methodError)
+	}
+	if len(sa.ranges) == 0 {
+		validationErrors.AddMessage("no parameter ranges supplied")
+	}
+	for _, parameterName := range sa.sortedParameterNames() {
+		if rangeError := sa.ranges[parameterName].Validate(); rangeError != nil {
+			validationErrors.Add(errors.Wrap(rangeError, "parameter ["+parameterName+"]"))
+		}
+	}
+
+	if validationErrors.Size() > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+// Evaluate evaluates each solution at every point of the method's design, returning the parameters ranked by
+// sensitivity for each solution decision variable.
+func (sa *SensitivityAnalysis) Evaluate(solutions []set.Solution) (*SensitivityResults, error) {
+	if validationError := sa.Validate(); validationError != nil {
+		return nil, validationError
+	}
+
+	if len(solutions) == 0 {
+		solutions = []set.Solution{AsIsSolution}
+	}
+
+	solutionEvaluator, evaluatorError := newEvaluator(sa.modelFactory, solutions)
+	if evaluatorError != nil {
+		return nil, evaluatorError
+	}
+
+	parameterNames := sa.sortedParameterNames()
+	design := sa.method.Design(len(parameterNames), sa.generator)
+
+	evaluations := make([][]evaluation, len(design))
+	for pointIndex, point := range design {
+		pointEvaluations, evaluationError := solutionEvaluator.evaluate(sa.parametersAt(parameterNames, point))
+		if evaluationError != nil {
+			return nil, errors.Wrap(evaluationError, fmt.Sprintf("evaluating design point [%d]", pointIndex+1))
+		}
+		evaluations[pointIndex] = pointEvaluations
+	}
+
+	return sa.deriveResults(solutionEvaluator, parameterNames, design, evaluations), nil
+}
+
+func (sa *SensitivityAnalysis) parametersAt(parameterNames []string, point []float64) parameters.Map {
+	parameterValues := make(parameters.Map, len(parameterNames))
+	for index, name := range parameterNames {
+		parameterValues[name] = sa.ranges[name].valueAt(point[index])
+	}
+	return parameterValues
+}
+
+func (sa *SensitivityAnalysis) deriveResults(solutionEvaluator *evaluator, parameterNames []string, design [][]float64, evaluations [][]evaluation) *SensitivityResults {
+	results := &SensitivityResults{
+		Method:           sa.method.Name(),
+		MeasureNames:     sa.method.MeasureNames(),
+		EvaluationNumber: len(design),
+		Solutions:        make([]SolutionSensitivity, len(solutionEvaluator.solutions)),
+	}
+
+	for solutionIndex, solution := range solutionEvaluator.solutions {
+		results.Solutions[solutionIndex].Id = solution.Id
+		for _, variableName := range solutionEvaluator.variableNames {
+			outputs := make([]float64, len(design))
+			for pointIndex := range design {
+				outputs[pointIndex] = evaluations[pointIndex][solutionIndex].values[variableName]
+			}
+
+			measures := sa.method.Measures(design, outputs, len(parameterNames))
+			results.Solutions[solutionIndex].Variables = append(results.Solutions[solutionIndex].Variables,
+				VariableSensitivity{
+					Name:       variableName,
+					Parameters: rankParameters(parameterNames, measures),
+				},
+			)
+		}
+	}
+	return results
+}
+
+// rankParameters orders parameters by descending value of their first measure.
+func rankParameters(parameterNames []string, measures [][]float64) []ParameterSensitivity {
+	ranked := make([]ParameterSensitivity, len(parameterNames))
+	for index, name := range parameterNames {
+		ranked[index] = ParameterSensitivity{Name: name, Measures: measures[index]}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Measures[0] > ranked[j].Measures[0]
+	})
+
+	for index := range ranked {
+		ranked[index].Rank = index + 1
+	}
+	return ranked
+}
+
+func (sa *SensitivityAnalysis) sortedParameterNames() []string {
+	names := make([]string, 0, len(sa.ranges))
+	for name := range sa.ranges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SensitivityResults holds, per solution decision variable, the model parameters ranked by sensitivity.
+type SensitivityResults struct {
+	Method           string
+	MeasureNames     []string
+	EvaluationNumber int
+	Solutions        []SolutionSensitivity
+}
+
+type SolutionSensitivity struct {
+	Id        string
+	Variables []VariableSensitivity
+}
+
+// VariableSensitivity lists the parameters driving a decision variable, most influential first.
+type VariableSensitivity struct {
+	Name       string
+	Parameters []ParameterSensitivity
+}
+
+// ParameterSensitivity holds a parameter's measures, ordered as the SensitivityResults MeasureNames.
+type ParameterSensitivity struct {
+	Name     string
+	Rank     int
+	Measures []float64
+}
+
+// SensitivityCsvMarshaler marshals SensitivityResults into CSV text, with a row per ranked parameter of each
+// solution decision variable.
+type SensitivityCsvMarshaler struct{}
+
+func (scm *SensitivityCsvMarshaler) Marshal(results *SensitivityResults) ([]byte, error) {
+	headers := append([]string{idHeading, "Variable", "Rank", "Parameter"}, results.MeasureNames...)
+
+	builder := new(strings.FluentBuilder)
+	builder.Add(join(headers...)).Add(newline)
+
+	for _, solution := range results.Solutions {
+		for _, variable := range solution.Variables {
+			for _, parameter := range variable.Parameters {
+				row := []string{solution.Id, variable.Name, defaultConverter.Convert(parameter.Rank), parameter.Name}
+				for _, measure := range parameter.Measures {
+					row = append(row, defaultConverter.Convert(measure))
+				}
+				builder.Add(join(row...)).Add(newline)
+			}
+		}
+	}
+
+	return ([]byte)(builder.String()), nil
+}
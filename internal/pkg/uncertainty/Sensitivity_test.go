@@ -0,0 +1,193 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	baseRand "math/rand"
+	"testing"
+
+	catchmentParameters "github.com/LindsayBradford/crem/internal/pkg/model/models/catchment/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/parameters"
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	. "github.com/onsi/gomega"
+)
+
+// linearTestFunction has parameter 0 four times as influential as parameter 1, and parameter 2 not at all.
+func linearTestFunction(point []float64) float64 {
+	return 4*point[0] + point[1]
+}
+
+func evaluateTestFunction(design [][]float64) []float64 {
+	outputs := make([]float64, len(design))
+	for index, point := range design {
+		outputs[index] = linearTestFunction(point)
+	}
+	return outputs
+}
+
+func TestMorris_LinearFunction_ExactElementaryEffects(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const parameterNumber = 3
+	methodUnderTest := NewMorris().WithTrajectoryNumber(5).WithLevels(4)
+	generator := rand.New(baseRand.NewSource(1))
+
+	// when
+	design := methodUnderTest.Design(parameterNumber, generator)
+	measures := methodUnderTest.Measures(design, evaluateTestFunction(design), parameterNumber)
+
+	// then
+	g.Expect(methodUnderTest.Validate()).To(BeNil())
+	g.Expect(design).To(HaveLen(5 * (parameterNumber + 1)))
+	for _, point := range design {
+		for _, value := range point {
+			g.Expect(value).To(BeNumerically(">=", 0))
+			g.Expect(value).To(BeNumerically("<=", 1))
+		}
+	}
+
+	expectedMuStars := []float64{4, 1, 0}
+	for parameterIndex, expectedMuStar := range expectedMuStars {
+		g.Expect(measures[parameterIndex][0]).To(BeNumerically("~", expectedMuStar, 1e-9))
+		g.Expect(measures[parameterIndex][1]).To(BeNumerically("~", expectedMuStar, 1e-9))
+		g.Expect(measures[parameterIndex][2]).To(BeNumerically("~", 0, 1e-9))
+	}
+}
+
+func TestMorris_OddLevels_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	validationError := NewMorris().WithLevels(3).Validate()
+	t.Log(validationError)
+
+	// then
+	g.Expect(validationError).To(Not(BeNil()))
+}
+
+func TestSobol_LinearFunction_IndicesApportionVariance(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	const parameterNumber = 3
+	methodUnderTest := NewSobol().WithSampleNumber(4_000)
+	generator := rand.New(baseRand.NewSource(1))
+
+	// when
+	design := methodUnderTest.Design(parameterNumber, generator)
+	measures := methodUnderTest.Measures(design, evaluateTestFunction(design), parameterNumber)
+
+	// then
+	g.Expect(design).To(HaveLen(4_000 * (parameterNumber + 2)))
+
+	expectedIndices := []float64{16.0 / 17, 1.0 / 17, 0}
+	for parameterIndex, expectedIndex := range expectedIndices {
+		g.Expect(measures[parameterIndex][0]).To(BeNumerically("~", expectedIndex, 0.05))
+		g.Expect(measures[parameterIndex][1]).To(BeNumerically("~", expectedIndex, 0.05))
+	}
+}
+
+func TestDefaultParameterRanges_CatchmentSpecifications_BoundsOrVariation(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	configuredValues := parameters.Map{catchmentParameters.GullyCompensationFactor: 2.0}
+
+	// when
+	ranges := DefaultParameterRanges(catchmentParameters.ParameterSpecifications(), configuredValues)
+
+	// then
+	g.Expect(ranges[catchmentParameters.BankErosionFudgeFactor]).To(Equal(ParameterRange{Minimum: 1e-5, Maximum: 5e-4}))
+	g.Expect(ranges[catchmentParameters.HillSlopeDeliveryRatio]).To(Equal(ParameterRange{Minimum: 0, Maximum: 1}))
+	g.Expect(ranges[catchmentParameters.GullyCompensationFactor]).To(Equal(ParameterRange{Minimum: 1.0, Maximum: 3.0}))
+	g.Expect(ranges[catchmentParameters.WaterDensity]).To(Equal(ParameterRange{Minimum: 0.5, Maximum: 1.5}))
+
+	g.Expect(ranges).To(Not(HaveKey(catchmentParameters.DataSourcePath)))
+	g.Expect(ranges).To(Not(HaveKey(catchmentParameters.YearsOfErosion)))
+	g.Expect(ranges).To(Not(HaveKey(catchmentParameters.MaximumSedimentProduction)))
+}
+
+func TestSensitivityAnalysis_NoRanges_Errors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	analysisUnderTest := NewSensitivityAnalysis().WithModelFactory(testingModelFactory)
+
+	// when
+	_, evaluateError := analysisUnderTest.Evaluate(nil)
+	t.Log(evaluateError)
+
+	// then
+	g.Expect(evaluateError).To(Not(BeNil()))
+}
+
+func TestSensitivityAnalysis_AsIsCatchmentModel_RanksParameters(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	analysisUnderTest := NewSensitivityAnalysis().
+		WithModelFactory(testingModelFactory).
+		WithMethod(NewMorris().WithTrajectoryNumber(3)).
+		WithRandomNumberGenerator(rand.New(baseRand.NewSource(1))).
+		WithParameterRange(catchmentParameters.BankErosionFudgeFactor, ParameterRange{Minimum: 1e-4, Maximum: 5e-4}).
+		WithParameterRange(catchmentParameters.LocalAcceleration, ParameterRange{Minimum: 9.0, Maximum: 10.0})
+
+	// when
+	results, evaluateError := analysisUnderTest.Evaluate(nil)
+
+	// then
+	g.Expect(evaluateError).To(BeNil())
+	g.Expect(results.Method).To(Equal(MorrisMethod))
+	g.Expect(results.EvaluationNumber).To(Equal(3 * 3))
+	g.Expect(results.Solutions).To(HaveLen(1))
+	g.Expect(results.Solutions[0].Id).To(Equal(AsIsSolution.Id))
+
+	var sedimentSensitivity VariableSensitivity
+	for _, variable := range results.Solutions[0].Variables {
+		g.Expect(variable.Parameters).To(HaveLen(2))
+		g.Expect(variable.Parameters[0].Rank).To(Equal(1))
+		g.Expect(variable.Parameters[0].Measures[0]).To(BeNumerically(">=", variable.Parameters[1].Measures[0]))
+		if variable.Name == sedimentProduction {
+			sedimentSensitivity = variable
+		}
+	}
+
+	g.Expect(sedimentSensitivity.Parameters[0].Name).To(Equal(catchmentParameters.BankErosionFudgeFactor))
+	g.Expect(sedimentSensitivity.Parameters[0].Measures[0]).To(BeNumerically(">", 0))
+}
+
+func TestSensitivityCsvMarshaler_Marshal_RowPerRankedParameter(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	resultsUnderTest := &SensitivityResults{
+		Method:       SobolMethod,
+		MeasureNames: NewSobol().MeasureNames(),
+		Solutions: []SolutionSensitivity{
+			{
+				Id: "As-Is",
+				Variables: []VariableSensitivity{
+					{
+						Name: "A",
+						Parameters: []ParameterSensitivity{
+							{Name: "X", Rank: 1, Measures: []float64{0.75, 0.5}},
+							{Name: "Y", Rank: 2, Measures: []float64{0.25, 0.125}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// when
+	marshaled, marshalError := new(SensitivityCsvMarshaler).Marshal(resultsUnderTest)
+
+	// then
+	expectedCsv := "Solution, Variable, Rank, Parameter, TotalOrder, FirstOrder\n" +
+		"As-Is, A, 1, X, 0.750000, 0.500000\n" +
+		"As-Is, A, 2, Y, 0.250000, 0.125000\n"
+
+	g.Expect(marshalError).To(BeNil())
+	g.Expect(string(marshaled)).To(Equal(expectedCsv))
+}
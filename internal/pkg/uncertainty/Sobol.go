@@ -0,0 +1,119 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"github.com/LindsayBradford/crem/internal/pkg/rand"
+	"github.com/pkg/errors"
+)
+
+const (
+	SobolMethod = "Sobol"
+
+	defaultSobolSampleNumber = 64
+)
+
+var _ SensitivityMethod = new(Sobol)
+
+// Sobol apportions the variance of an output to each parameter via Monte Carlo estimates of Sobol indices.
+// FirstOrder is the share of variance due to a parameter alone (Saltelli et al. 2010), and TotalOrder the share
+// including all interactions with other parameters (Jansen 1999). Parameters are ranked by TotalOrder.
+// Evaluation takes sampleNumber * (parameterNumber + 2) model evaluations.
+type Sobol struct {
+	sampleNumber int
+}
+
+func NewSobol() *Sobol {
+	return &Sobol{sampleNumber: defaultSobolSampleNumber}
+}
+
+func (s *Sobol) WithSampleNumber(sampleNumber int) *Sobol {
+	s.sampleNumber = sampleNumber
+	return s
+}
+
+func (s *Sobol) Name() string {
+	return SobolMethod
+}
+
+func (s *Sobol) MeasureNames() []string {
+	return []string{"TotalOrder", "FirstOrder"}
+}
+
+func (s *Sobol) Validate() error {
+	if s.sampleNumber < 2 {
+		return errors.New("Sobol method sample number must be at least 2")
+	}
+	return nil
+}
+
+// Design returns independent sample matrices A and B, followed by a matrix per parameter that is A with that
+// parameter's column taken from B.
+func (s *Sobol) Design(parameterNumber int, generator *rand.Rand) [][]float64 {
+	matrixA := uniformMatrix(s.sampleNumber, parameterNumber, generator)
+	matrixB := uniformMatrix(s.sampleNumber, parameterNumber, generator)
+
+	design := make([][]float64, 0, s.sampleNumber*(parameterNumber+2))
+	design = append(design, matrixA...)
+	design = append(design, matrixB...)
+
+	for parameterIndex := 0; parameterIndex < parameterNumber; parameterIndex++ {
+		for sample := range matrixA {
+			point := make([]float64, parameterNumber)
+			copy(point, matrixA[sample])
+			point[parameterIndex] = matrixB[sample][parameterIndex]
+			design = append(design, point)
+		}
+	}
+	return design
+}
+
+func uniformMatrix(rows int, columns int, generator *rand.Rand) [][]float64 {
+	matrix := make([][]float64, rows)
+	for row := range matrix {
+		matrix[row] = make([]float64, columns)
+		for column := range matrix[row] {
+			matrix[row][column] = generator.Float64Unitary()
+		}
+	}
+	return matrix
+}
+
+// Measures derives the TotalOrder and FirstOrder Sobol indices of each parameter on outputs.
+func (s *Sobol) Measures(design [][]float64, outputs []float64, parameterNumber int) [][]float64 {
+	sampleNumber := len(outputs) / (parameterNumber + 2)
+	outputsA := outputs[:sampleNumber]
+	outputsB := outputs[sampleNumber : 2*sampleNumber]
+
+	variance := populationVarianceOf(outputs[:2*sampleNumber])
+
+	measures := make([][]float64, parameterNumber)
+	for parameterIndex := range measures {
+		measures[parameterIndex] = []float64{0, 0}
+		if variance == 0 {
+			continue
+		}
+
+		matrixStart := (parameterIndex + 2) * sampleNumber
+		outputsAB := outputs[matrixStart : matrixStart+sampleNumber]
+
+		firstOrderSum, totalOrderSum := float64(0), float64(0)
+		for sample := 0; sample < sampleNumber; sample++ {
+			firstOrderSum += outputsB[sample] * (outputsAB[sample] - outputsA[sample])
+			totalOrderSum += (outputsA[sample] - outputsAB[sample]) * (outputsA[sample] - outputsAB[sample])
+		}
+
+		measures[parameterIndex][0] = totalOrderSum / (2 * float64(sampleNumber)) / variance
+		measures[parameterIndex][1] = firstOrderSum / float64(sampleNumber) / variance
+	}
+	return measures
+}
+
+func populationVarianceOf(values []float64) float64 {
+	mean := meanOf(values)
+	sumOfSquares := float64(0)
+	for _, value := range values {
+		sumOfSquares += (value - mean) * (value - mean)
+	}
+	return sumOfSquares / float64(len(values))
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"math"
+	"sort"
+)
+
+// Statistics summarises the spread of values a decision variable took across all sampled parameter sets.
+type Statistics struct {
+	SampleSize        int
+	Mean              float64
+	StandardDeviation float64
+	Minimum           float64
+	Maximum           float64
+
+	// Percentiles holds a value for each percentile requested of Summarise, in the order requested.
+	Percentiles []float64
+}
+
+// CoefficientOfVariation returns the standard deviation relative to the magnitude of the mean, or zero where
+// the mean is zero.
+func (s Statistics) CoefficientOfVariation() float64 {
+	if s.Mean == 0 {
+		return 0
+	}
+	return s.StandardDeviation / math.Abs(s.Mean)
+}
+
+// Summarise derives Statistics for values, including a value for each of the percentiles (in the range [0,100])
+// supplied.
+func Summarise(values []float64, percentiles ...float64) Statistics {
+	summary := Statistics{
+		SampleSize:  len(values),
+		Percentiles: make([]float64, len(percentiles)),
+	}
+
+	if len(values) == 0 {
+		return summary
+	}
+
+	sortedValues := make([]float64, len(values))
+	copy(sortedValues, values)
+	sort.Float64s(sortedValues)
+
+	summary.Minimum = sortedValues[0]
+	summary.Maximum = sortedValues[len(sortedValues)-1]
+	summary.Mean = meanOf(sortedValues)
+	summary.StandardDeviation = standardDeviationOf(sortedValues, summary.Mean)
+
+	for index, percentile := range percentiles {
+		summary.Percentiles[index] = percentileOf(sortedValues, percentile)
+	}
+
+	return summary
+}
+
+func meanOf(values []float64) float64 {
+	total := float64(0)
+	for _, value := range values {
+		total += value
+	}
+	return total / float64(len(values))
+}
+
+func standardDeviationOf(values []float64, mean float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	sumOfSquares := float64(0)
+	for _, value := range values {
+		sumOfSquares += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(sumOfSquares / float64(len(values)-1))
+}
+
+// percentileOf linearly interpolates between the closest ranks of the already sorted values.
+func percentileOf(sortedValues []float64, percentile float64) float64 {
+	rank := percentile / 100 * float64(len(sortedValues)-1)
+	rank = math.Min(math.Max(rank, 0), float64(len(sortedValues)-1))
+
+	lowerIndex := int(math.Floor(rank))
+	upperIndex := int(math.Ceil(rank))
+	fraction := rank - float64(lowerIndex)
+
+	return sortedValues[lowerIndex] + fraction*(sortedValues[upperIndex]-sortedValues[lowerIndex])
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package uncertainty
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSummarise_KnownValues_AsExpected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	valuesUnderTest := []float64{5, 3, 1, 4, 2}
+
+	// when
+	summary := Summarise(valuesUnderTest, 0, 25, 50, 90, 100)
+
+	// then
+	g.Expect(summary.SampleSize).To(Equal(5))
+	g.Expect(summary.Mean).To(BeNumerically("==", 3))
+	g.Expect(summary.StandardDeviation).To(BeNumerically("~", math.Sqrt(2.5), 1e-12))
+	g.Expect(summary.Minimum).To(BeNumerically("==", 1))
+	g.Expect(summary.Maximum).To(BeNumerically("==", 5))
+	g.Expect(summary.Percentiles).To(Equal([]float64{1, 2, 3, 4.6, 5}))
+	g.Expect(summary.CoefficientOfVariation()).To(BeNumerically("~", math.Sqrt(2.5)/3, 1e-12))
+
+	g.Expect(valuesUnderTest).To(Equal([]float64{5, 3, 1, 4, 2}))
+}
+
+func TestSummarise_SingleValue_NoSpread(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	summary := Summarise([]float64{42}, 5, 95)
+
+	// then
+	g.Expect(summary.Mean).To(BeNumerically("==", 42))
+	g.Expect(summary.StandardDeviation).To(BeNumerically("==", 0))
+	g.Expect(summary.Percentiles).To(Equal([]float64{42, 42}))
+}
+
+func TestSummarise_NoValues_ZeroSummary(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// when
+	summary := Summarise(nil, 50)
+
+	// then
+	g.Expect(summary.SampleSize).To(Equal(0))
+	g.Expect(summary.Percentiles).To(Equal([]float64{0}))
+	g.Expect(summary.CoefficientOfVariation()).To(BeNumerically("==", 0))
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package dominance
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// DefaultHypervolumeSampleNumber is the number of samples Hypervolume draws when estimating the hypervolume of
+// fronts with more than three objectives.
+const DefaultHypervolumeSampleNumber = 100_000
+
+const exactHypervolumeObjectiveLimit = 3
+
+// Hypervolume returns the volume of objective space dominated by front (of minimised objectives), and bounded by
+// referencePoint. Points that do not dominate the reference point contribute nothing.  The volume is exact for
+// fronts of up to three objectives, and estimated by sampling for fronts of more.
+func Hypervolume(front []Float64Vector, referencePoint Float64Vector) float64 {
+	if len(referencePoint) > exactHypervolumeObjectiveLimit {
+		generator := rand.New(rand.NewSource(1))
+		return EstimateHypervolume(front, referencePoint, DefaultHypervolumeSampleNumber, generator)
+	}
+
+	boundedFront := boundedBy(front, referencePoint)
+	if len(boundedFront) == 0 {
+		return 0
+	}
+
+	switch len(referencePoint) {
+	case 1:
+		return referencePoint[0] - minimumOf(boundedFront, 0)
+	case 2:
+		return hypervolume2D(boundedFront, referencePoint)
+	default:
+		return hypervolume3D(boundedFront, referencePoint)
+	}
+}
+
+// EstimateHypervolume returns a Monte Carlo estimate of the Hypervolume of front, drawing sampleNumber points
+// uniformly from the box spanning the front's ideal point to referencePoint.
+func EstimateHypervolume(front []Float64Vector, referencePoint Float64Vector, sampleNumber int, generator *rand.Rand) float64 {
+	boundedFront := boundedBy(front, referencePoint)
+	if len(boundedFront) == 0 || sampleNumber <= 0 {
+		return 0
+	}
+
+	ideal := IdealPoint(boundedFront)
+	boxVolume := 1.0
+	for index := range referencePoint {
+		boxVolume *= referencePoint[index] - ideal[index]
+	}
+
+	sample := make(Float64Vector, len(referencePoint))
+	dominatedSamples := 0
+	for sampleIndex := 0; sampleIndex < sampleNumber; sampleIndex++ {
+		for index := range sample {
+			sample[index] = ideal[index] + generator.Float64()*(referencePoint[index]-ideal[index])
+		}
+		if weaklyDominatedByAny(sample, boundedFront) {
+			dominatedSamples++
+		}
+	}
+
+	return boxVolume * float64(dominatedSamples) / float64(sampleNumber)
+}
+
+func boundedBy(front []Float64Vector, referencePoint Float64Vector) []Float64Vector {
+	boundedFront := make([]Float64Vector, 0, len(front))
+	for _, point := range front {
+		if point.allLessThanValuesIn(&referencePoint) {
+			boundedFront = append(boundedFront, point)
+		}
+	}
+	return boundedFront
+}
+
+func weaklyDominatedByAny(sample Float64Vector, front []Float64Vector) bool {
+	for _, point := range front {
+		if point.allEqualOrLessThanValuesIn(&sample) {
+			return true
+		}
+	}
+	return false
+}
+
+// hypervolume2D sweeps the front in order of its first objective, summing the strip each point adds.
+func hypervolume2D(front []Float64Vector, referencePoint Float64Vector) float64 {
+	sortedFront := sortedByObjective(front, 0)
+
+	volume := 0.0
+	upperBound := referencePoint[1]
+	for _, point := range sortedFront {
+		if point[1] < upperBound {
+			volume += (referencePoint[0] - point[0]) * (upperBound - point[1])
+			upperBound = point[1]
+		}
+	}
+	return volume
+}
+
+// hypervolume3D slices the front along its third objective, summing the 2D hypervolume of each slice.
+func hypervolume3D(front []Float64Vector, referencePoint Float64Vector) float64 {
+	sortedFront := sortedByObjective(front, 2)
+	sliceReferencePoint := Float64Vector{referencePoint[0], referencePoint[1]}
+
+	volume := 0.0
+	for index, point := range sortedFront {
+		sliceTop := referencePoint[2]
+		if index+1 < len(sortedFront) {
+			sliceTop = sortedFront[index+1][2]
+		}
+		if sliceTop == point[2] {
+			continue
+		}
+
+		slice := make([]Float64Vector, index+1)
+		for sliceIndex := range slice {
+			slice[sliceIndex] = Float64Vector{sortedFront[sliceIndex][0], sortedFront[sliceIndex][1]}
+		}
+		volume += hypervolume2D(slice, sliceReferencePoint) * (sliceTop - point[2])
+	}
+	return volume
+}
+
+func sortedByObjective(front []Float64Vector, objective int) []Float64Vector {
+	sortedFront := make([]Float64Vector, len(front))
+	copy(sortedFront, front)
+	sort.SliceStable(sortedFront, func(i, j int) bool {
+		return sortedFront[i][objective] < sortedFront[j][objective]
+	})
+	return sortedFront
+}
+
+func minimumOf(front []Float64Vector, objective int) float64 {
+	minimum := math.Inf(1)
+	for _, point := range front {
+		minimum = math.Min(minimum, point[objective])
+	}
+	return minimum
+}
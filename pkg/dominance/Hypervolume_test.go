@@ -0,0 +1,66 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package dominance
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHypervolume_TwoObjectives_Exact(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	front := []Float64Vector{{1, 3}, {2, 2}, {3, 1}}
+	referencePoint := Float64Vector{4, 4}
+
+	// when
+	actualVolume := Hypervolume(front, referencePoint)
+
+	// then
+	g.Expect(actualVolume).To(BeNumerically(equalTo, 6))
+}
+
+func TestHypervolume_ThreeObjectives_Exact(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	front := []Float64Vector{{0, 0, 1}, {1, 1, 0}}
+	referencePoint := Float64Vector{2, 2, 2}
+
+	// when
+	actualVolume := Hypervolume(front, referencePoint)
+
+	// then
+	g.Expect(actualVolume).To(BeNumerically(equalTo, 5))
+}
+
+func TestHypervolume_PointsBeyondReference_Ignored(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	front := []Float64Vector{{1, 1}, {5, 0}}
+	referencePoint := Float64Vector{2, 2}
+
+	// then
+	g.Expect(Hypervolume(front, referencePoint)).To(BeNumerically(equalTo, 1))
+	g.Expect(Hypervolume(nil, referencePoint)).To(BeZero())
+}
+
+func TestHypervolume_FourObjectives_Estimated(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	front := []Float64Vector{{0, 0.5, 0, 0}, {0.5, 0, 0, 0}}
+	referencePoint := Float64Vector{1, 1, 1, 1}
+
+	// when
+	actualVolume := Hypervolume(front, referencePoint)
+	estimatedVolume := EstimateHypervolume(front, referencePoint, 10_000, rand.New(rand.NewSource(42)))
+
+	// then
+	g.Expect(actualVolume).To(BeNumerically("~", 0.75, 0.01))
+	g.Expect(estimatedVolume).To(BeNumerically("~", 0.75, 0.03))
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package dominance
+
+import "math"
+
+// IdealPoint returns the best (minimum) value of each objective across front.
+func IdealPoint(front []Float64Vector) Float64Vector {
+	if len(front) == 0 {
+		return nil
+	}
+	ideal := make(Float64Vector, len(front[0]))
+	copy(ideal, front[0])
+	for _, point := range front[1:] {
+		for index := range ideal {
+			ideal[index] = math.Min(ideal[index], point[index])
+		}
+	}
+	return ideal
+}
+
+// NadirPoint returns the worst (maximum) value of each objective across front.
+func NadirPoint(front []Float64Vector) Float64Vector {
+	if len(front) == 0 {
+		return nil
+	}
+	nadir := make(Float64Vector, len(front[0]))
+	copy(nadir, front[0])
+	for _, point := range front[1:] {
+		for index := range nadir {
+			nadir[index] = math.Max(nadir[index], point[index])
+		}
+	}
+	return nadir
+}
+
+// NonDominated returns the points of front that no other point of front dominates, keeping only the first of any
+// points that match.
+func NonDominated(front []Float64Vector) []Float64Vector {
+	nonDominatedFront := make([]Float64Vector, 0, len(front))
+	for index, point := range front {
+		if !isDominatedWithin(index, front) {
+			nonDominatedFront = append(nonDominatedFront, point)
+		}
+	}
+	return nonDominatedFront
+}
+
+func isDominatedWithin(index int, front []Float64Vector) bool {
+	point := front[index]
+	for otherIndex, otherPoint := range front {
+		if otherIndex == index || !otherPoint.allEqualOrLessThanValuesIn(&point) {
+			continue
+		}
+		if otherIndex < index || otherPoint.anyLessThanValuesIn(&point) {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalise returns front scaled so that, for each objective, ideal maps to 0 and nadir maps to 1. Objectives
+// where ideal and nadir match map to 0.
+func Normalise(front []Float64Vector, ideal Float64Vector, nadir Float64Vector) []Float64Vector {
+	normalisedFront := make([]Float64Vector, len(front))
+	for pointIndex, point := range front {
+		normalisedPoint := make(Float64Vector, len(point))
+		for index := range point {
+			if objectiveRange := nadir[index] - ideal[index]; objectiveRange != 0 {
+				normalisedPoint[index] = (point[index] - ideal[index]) / objectiveRange
+			}
+		}
+		normalisedFront[pointIndex] = normalisedPoint
+	}
+	return normalisedFront
+}
+
+// Spacing returns Schott's spacing metric for front: the standard deviation of the Manhattan distance from each
+// point to its nearest neighbour. Zero indicates evenly spaced points.
+func Spacing(front []Float64Vector) float64 {
+	if len(front) < 2 {
+		return 0
+	}
+
+	distances := nearestNeighbourDistances(front, manhattanDistance)
+	mean := meanOf(distances)
+
+	sumOfSquares := 0.0
+	for _, distance := range distances {
+		sumOfSquares += math.Pow(mean-distance, 2)
+	}
+	return math.Sqrt(sumOfSquares / float64(len(distances)-1))
+}
+
+// Spread returns the spread (or diversity) of front: the mean absolute deviation of the Euclidean distance from each
+// point to its nearest neighbour, relative to the mean of those distances. Zero indicates evenly spread points.
+func Spread(front []Float64Vector) float64 {
+	if len(front) < 2 {
+		return 0
+	}
+
+	distances := nearestNeighbourDistances(front, euclideanDistance)
+	mean := meanOf(distances)
+	if mean == 0 {
+		return 0
+	}
+
+	sumOfDeviations := 0.0
+	for _, distance := range distances {
+		sumOfDeviations += math.Abs(distance - mean)
+	}
+	return sumOfDeviations / (float64(len(distances)) * mean)
+}
+
+// GenerationalDistance returns how far front is from referenceFront, as the root of the summed squared Euclidean
+// distances from each point of front to its nearest point in referenceFront, divided by the size of front.
+func GenerationalDistance(front []Float64Vector, referenceFront []Float64Vector) float64 {
+	if len(front) == 0 || len(referenceFront) == 0 {
+		return 0
+	}
+
+	sumOfSquares := 0.0
+	for _, point := range front {
+		sumOfSquares += math.Pow(nearestDistance(point, referenceFront, euclideanDistance), 2)
+	}
+	return math.Sqrt(sumOfSquares) / float64(len(front))
+}
+
+// InvertedGenerationalDistance returns how well front covers referenceFront, as the mean Euclidean distance from
+// each point of referenceFront to its nearest point in front.
+func InvertedGenerationalDistance(front []Float64Vector, referenceFront []Float64Vector) float64 {
+	if len(front) == 0 || len(referenceFront) == 0 {
+		return 0
+	}
+
+	sumOfDistances := 0.0
+	for _, referencePoint := range referenceFront {
+		sumOfDistances += nearestDistance(referencePoint, front, euclideanDistance)
+	}
+	return sumOfDistances / float64(len(referenceFront))
+}
+
+type distanceFunction func(a Float64Vector, b Float64Vector) float64
+
+func nearestNeighbourDistances(front []Float64Vector, distance distanceFunction) []float64 {
+	distances := make([]float64, len(front))
+	for index := range front {
+		distances[index] = math.Inf(1)
+		for otherIndex := range front {
+			if otherIndex != index {
+				distances[index] = math.Min(distances[index], distance(front[index], front[otherIndex]))
+			}
+		}
+	}
+	return distances
+}
+
+func nearestDistance(point Float64Vector, front []Float64Vector, distance distanceFunction) float64 {
+	nearest := math.Inf(1)
+	for _, otherPoint := range front {
+		nearest = math.Min(nearest, distance(point, otherPoint))
+	}
+	return nearest
+}
+
+func manhattanDistance(a Float64Vector, b Float64Vector) float64 {
+	sum := 0.0
+	for index := range a {
+		sum += math.Abs(a[index] - b[index])
+	}
+	return sum
+}
+
+func euclideanDistance(a Float64Vector, b Float64Vector) float64 {
+	sumOfSquares := 0.0
+	for index := range a {
+		sumOfSquares += math.Pow(a[index]-b[index], 2)
+	}
+	return math.Sqrt(sumOfSquares)
+}
+
+func meanOf(values []float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
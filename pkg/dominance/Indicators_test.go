@@ -0,0 +1,74 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package dominance
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestIdealAndNadirPoints(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	front := []Float64Vector{{1, 30}, {2, 20}, {5, 10}}
+
+	// when
+	ideal := IdealPoint(front)
+	nadir := NadirPoint(front)
+
+	// then
+	g.Expect(ideal).To(Equal(Float64Vector{1, 10}))
+	g.Expect(nadir).To(Equal(Float64Vector{5, 30}))
+	g.Expect(Normalise(front, ideal, nadir)).To(Equal([]Float64Vector{{0, 1}, {0.25, 0.5}, {1, 0}}))
+}
+
+func TestSpacingAndSpread_EvenFront_Zero(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	front := []Float64Vector{{1, 3}, {2, 2}, {3, 1}}
+
+	// then
+	g.Expect(Spacing(front)).To(BeZero())
+	g.Expect(Spread(front)).To(BeZero())
+}
+
+func TestSpacingAndSpread_UnevenFront(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	front := []Float64Vector{{0, 4}, {1, 3}, {4, 0}}
+
+	// then
+	g.Expect(Spacing(front)).To(BeNumerically("~", math.Sqrt(16.0/3.0), 1e-9))
+	g.Expect(Spread(front)).To(BeNumerically(">", 0))
+	g.Expect(Spacing(front[:1])).To(BeZero())
+}
+
+func TestNonDominated(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	front := []Float64Vector{{1, 3}, {2, 2}, {2, 3}, {1, 3}, {3, 1}}
+
+	// then
+	g.Expect(NonDominated(front)).To(Equal([]Float64Vector{{1, 3}, {2, 2}, {3, 1}}))
+}
+
+func TestGenerationalDistances(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	referenceFront := []Float64Vector{{0, 0}, {2, 2}}
+	front := []Float64Vector{{1, 1}}
+
+	// then
+	g.Expect(GenerationalDistance(front, referenceFront)).To(BeNumerically("~", math.Sqrt2, 1e-9))
+	g.Expect(InvertedGenerationalDistance(front, referenceFront)).To(BeNumerically("~", math.Sqrt2, 1e-9))
+
+	g.Expect(GenerationalDistance(referenceFront, referenceFront)).To(BeZero())
+	g.Expect(InvertedGenerationalDistance(referenceFront, referenceFront)).To(BeZero())
+}
@@ -54,3 +54,36 @@ func (d *Destinations) SupportsLogLevel(logLevel Level) bool {
 	_, present := d.Destinations[logLevel]
 	return present
 }
+
+// ForRun returns the destinations for the annealing run identified, each RunScopedDestination replaced by its
+// destination for that run.  It reports false (returning these destinations) if there are no RunScopedDestinations.
+func (d *Destinations) ForRun(runId string) (*Destinations, bool) {
+	runDestinations := &Destinations{Destinations: make(map[Level]Destination, len(d.Destinations))}
+	runScoped := false
+	for logLevel, destination := range d.Destinations {
+		if runScopedDestination, isRunScoped := destination.(RunScopedDestination); isRunScoped {
+			destination = runScopedDestination.ForRun(runId)
+			runScoped = true
+		}
+		runDestinations.Destinations[logLevel] = destination
+	}
+
+	if !runScoped {
+		return d, false
+	}
+	return runDestinations, true
+}
+
+// FinishRun releases the destinations of the annealing run identified, for each RunScopedDestination, returning the
+// first error encountered.
+func (d *Destinations) FinishRun(runId string) error {
+	var finishError error
+	for _, destination := range d.Destinations {
+		if runScopedDestination, isRunScoped := destination.(RunScopedDestination); isRunScoped {
+			if runFinishError := runScopedDestination.FinishRun(runId); finishError == nil {
+				finishError = runFinishError
+			}
+		}
+	}
+	return finishError
+}
@@ -0,0 +1,373 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package logging
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// FilePrefix marks a configured log destination as a file, the remainder of the destination being the file's path.
+const FilePrefix = "file:"
+
+const rotationTimestampLayout = "20060102T150405"
+
+// RunScopedDestination is a Destination able to hand each annealing run a destination of its own, so the log entries
+// of concurrent runs are not interleaved.  FinishRun releases the destination of a run once it has finished.
+type RunScopedDestination interface {
+	Destination
+	ForRun(runId string) Destination
+	FinishRun(runId string) error
+}
+
+// FileOptions decide when a FileDestination rotates its file, and what becomes of the files rotated out.
+type FileOptions struct {
+	// MaximumSize is the size in bytes a file may reach before being rotated. 0 disables size-based rotation.
+	MaximumSize int64
+	// RotationInterval is how long a file may be written to before being rotated, periods starting on the
+	// interval in local time (e.g. at midnight, for 24 hours). 0 disables time-based rotation.
+	RotationInterval time.Duration
+	// MaximumBackups is how many rotated files to keep, the oldest being deleted first. 0 keeps them all.
+	MaximumBackups int
+	// Compress has rotated files compressed with gzip.
+	Compress bool
+	// SplitByRun has each annealing run write to a file of its own, named for the run.
+	SplitByRun bool
+}
+
+// FileDestination is a Destination appending log entries to a file, rotating it out to a timestamped backup as
+// its FileOptions dictate. It is safe for concurrent use.
+type FileDestination struct {
+	path    string
+	options FileOptions
+
+	mutex       sync.Mutex
+	file        *os.File
+	size        int64
+	periodStart time.Time
+
+	runDestinations map[string]*FileDestination
+}
+
+var _ RunScopedDestination = new(FileDestination)
+
+// NewFileDestination opens the file at path for appending log entries, creating it and any missing directories
+// along its path if needed.
+func NewFileDestination(path string, options FileOptions) (*FileDestination, error) {
+	destination := &FileDestination{
+		path:            filepath.Clean(path),
+		options:         options,
+		runDestinations: make(map[string]*FileDestination),
+	}
+	if openError := destination.open(time.Now()); openError != nil {
+		return nil, openError
+	}
+	return destination, nil
+}
+
+func (fd *FileDestination) Path() string {
+	return fd.path
+}
+
+func (fd *FileDestination) open(now time.Time) error {
+	if dirError := os.MkdirAll(filepath.Dir(fd.path), 0755); dirError != nil {
+		return errors.Wrap(dirError, "creating log file directory")
+	}
+
+	file, openError := os.OpenFile(fd.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if openError != nil {
+		return errors.Wrap(openError, "opening log file")
+	}
+
+	fileInfo, statError := file.Stat()
+	if statError != nil {
+		file.Close()
+		return errors.Wrap(statError, "reading log file size")
+	}
+
+	fd.file = file
+	fd.size = fileInfo.Size()
+	fd.periodStart = fd.periodOf(now)
+	return nil
+}
+
+// Write appends p to the file, first rotating the file if p would take it past its maximum size, or if its rotation
+// interval has passed.
+func (fd *FileDestination) Write(p []byte) (int, error) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	if fd.file == nil {
+		return 0, errors.New("writing to closed log file [" + fd.path + "]")
+	}
+
+	if now := time.Now(); fd.rotationDue(now, len(p)) {
+		if rotateError := fd.rotate(now); rotateError != nil {
+			return 0, rotateError
+		}
+	}
+
+	written, writeError := fd.file.Write(p)
+	fd.size += int64(written)
+	return written, writeError
+}
+
+func (fd *FileDestination) rotationDue(now time.Time, pendingBytes int) bool {
+	if fd.options.MaximumSize > 0 && fd.size > 0 && fd.size+int64(pendingBytes) > fd.options.MaximumSize {
+		return true
+	}
+	return fd.options.RotationInterval > 0 && fd.periodOf(now).After(fd.periodStart)
+}
+
+// periodOf returns the start of the rotation period that moment falls in, periods starting on the rotation interval
+// in local time.
+func (fd *FileDestination) periodOf(moment time.Time) time.Time {
+	if fd.options.RotationInterval <= 0 {
+		return time.Time{}
+	}
+	_, zoneOffset := moment.Zone()
+	offset := time.Duration(zoneOffset) * time.Second
+	return moment.Add(offset).Truncate(fd.options.RotationInterval).Add(-offset)
+}
+
+// rotate moves the file out to a timestamped backup, opening a fresh file in its place.  Should rotation fail once
+// the file is closed, the file is reopened, so that later writes are not lost with it.
+func (fd *FileDestination) rotate(now time.Time) error {
+	if closeError := fd.file.Close(); closeError != nil {
+		return errors.Wrap(closeError, "closing log file for rotation")
+	}
+	fd.file = nil
+
+	if rotateError := fd.backUp(now); rotateError != nil {
+		if reopenError := fd.open(now); reopenError != nil {
+			return errors.Wrap(rotateError, reopenError.Error())
+		}
+		return rotateError
+	}
+
+	return fd.open(now)
+}
+
+func (fd *FileDestination) backUp(now time.Time) error {
+	backupPath := fd.uniqueBackupPath(now)
+	if renameError := os.Rename(fd.path, backupPath); renameError != nil {
+		return errors.Wrap(renameError, "rotating log file")
+	}
+
+	if fd.options.Compress {
+		if compressError := compress(backupPath); compressError != nil {
+			return compressError
+		}
+	}
+
+	return fd.pruneBackups()
+}
+
+func (fd *FileDestination) splitPath() (base string, extension string) {
+	extension = filepath.Ext(fd.path)
+	return strings.TrimSuffix(fd.path, extension), extension
+}
+
+func (fd *FileDestination) uniqueBackupPath(now time.Time) string {
+	base, extension := fd.splitPath()
+	stampedBase := base + "-" + now.Format(rotationTimestampLayout)
+
+	candidate := stampedBase + extension
+	for duplicate := 1; backupExists(candidate); duplicate++ {
+		candidate = fmt.Sprintf("%s-%d%s", stampedBase, duplicate, extension)
+	}
+	return candidate
+}
+
+func backupExists(path string) bool {
+	for _, candidate := range []string{path, path + ".gz"} {
+		if _, statError := os.Stat(candidate); statError == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func compress(path string) error {
+	compressedPath := path + ".gz"
+	if compressError := compressTo(path, compressedPath+".tmp"); compressError != nil {
+		os.Remove(compressedPath + ".tmp")
+		return errors.Wrap(compressError, "compressing rotated log file")
+	}
+
+	if renameError := os.Rename(compressedPath+".tmp", compressedPath); renameError != nil {
+		return errors.Wrap(renameError, "compressing rotated log file")
+	}
+	return os.Remove(path)
+}
+
+func compressTo(sourcePath string, targetPath string) error {
+	source, openError := os.Open(sourcePath)
+	if openError != nil {
+		return openError
+	}
+	defer source.Close()
+
+	target, createError := os.Create(targetPath)
+	if createError != nil {
+		return createError
+	}
+	defer target.Close()
+
+	writer := gzip.NewWriter(target)
+	if _, copyError := io.Copy(writer, source); copyError != nil {
+		return copyError
+	}
+	if closeError := writer.Close(); closeError != nil {
+		return closeError
+	}
+	return target.Close()
+}
+
+// Backups returns the paths of the files rotated out of this destination, oldest first.
+func (fd *FileDestination) Backups() ([]string, error) {
+	base, extension := fd.splitPath()
+	backupPattern := regexp.MustCompile("^" + regexp.QuoteMeta(filepath.Base(base)) +
+		`-(\d{8}T\d{6})(?:-(\d+))?` + regexp.QuoteMeta(extension) + `(?:\.gz)?$`)
+
+	entries, readError := os.ReadDir(filepath.Dir(fd.path))
+	if readError != nil {
+		return nil, errors.Wrap(readError, "listing rotated log files")
+	}
+
+	backups := make([]backup, 0)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if matches := backupPattern.FindStringSubmatch(entry.Name()); matches != nil {
+			backupPath := filepath.Join(filepath.Dir(fd.path), entry.Name())
+			backups = append(backups, newBackup(backupPath, matches[1], matches[2]))
+		}
+	}
+	sort.SliceStable(backups, func(i, j int) bool { return backups[i].before(backups[j]) })
+
+	backupPaths := make([]string, len(backups))
+	for index, rotatedBackup := range backups {
+		backupPaths[index] = rotatedBackup.path
+	}
+	return backupPaths, nil
+}
+
+// backup is a file rotated out of a destination, ordered by the time it was rotated, then by the index
+// distinguishing backups rotated in the same second.
+type backup struct {
+	path      string
+	rotated   time.Time
+	duplicate int
+}
+
+func newBackup(path string, timestamp string, duplicate string) backup {
+	rotated, _ := time.ParseInLocation(rotationTimestampLayout, timestamp, time.Local)
+	duplicateIndex, _ := strconv.Atoi(duplicate)
+	return backup{path: path, rotated: rotated, duplicate: duplicateIndex}
+}
+
+func (b backup) before(other backup) bool {
+	if !b.rotated.Equal(other.rotated) {
+		return b.rotated.Before(other.rotated)
+	}
+	return b.duplicate < other.duplicate
+}
+
+func (fd *FileDestination) pruneBackups() error {
+	if fd.options.MaximumBackups <= 0 {
+		return nil
+	}
+
+	backups, listError := fd.Backups()
+	if listError != nil {
+		return listError
+	}
+
+	for excess := len(backups) - fd.options.MaximumBackups; excess > 0; excess-- {
+		if removeError := os.Remove(backups[excess-1]); removeError != nil {
+			return errors.Wrap(removeError, "removing old rotated log file")
+		}
+	}
+	return nil
+}
+
+// ForRun returns the destination for log entries of the annealing run identified.  Where splitting by run, that is a
+// file alongside this one, named for the run (e.g. 'annealing-Scenario_1_3.log' for run 'Scenario (1/3)'), and
+// rotated the same way.  Otherwise, it is this destination.
+func (fd *FileDestination) ForRun(runId string) Destination {
+	if !fd.options.SplitByRun || runId == "" {
+		return fd
+	}
+
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	if runDestination, exists := fd.runDestinations[runId]; exists {
+		return runDestination
+	}
+
+	base, extension := fd.splitPath()
+	runOptions := fd.options
+	runOptions.SplitByRun = false
+
+	runDestination, openError := NewFileDestination(base+"-"+sanitise(runId)+extension, runOptions)
+	if openError != nil {
+		return fd
+	}
+	fd.runDestinations[runId] = runDestination
+	return runDestination
+}
+
+// FinishRun closes the file of the annealing run identified, forgetting it, so that long-lived destinations do not
+// hold a file open for every run they have split off.  Runs logging after finishing have their file reopened.
+func (fd *FileDestination) FinishRun(runId string) error {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	runDestination, exists := fd.runDestinations[runId]
+	if !exists {
+		return nil
+	}
+	delete(fd.runDestinations, runId)
+	return runDestination.Close()
+}
+
+var unsafeFileNameCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func sanitise(runId string) string {
+	return strings.Trim(unsafeFileNameCharacters.ReplaceAllString(runId, "_"), "_")
+}
+
+// Close closes the file, and those of any runs split from it.
+func (fd *FileDestination) Close() error {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	var closeError error
+	for _, runDestination := range fd.runDestinations {
+		if runCloseError := runDestination.Close(); closeError == nil {
+			closeError = runCloseError
+		}
+	}
+
+	if fd.file != nil {
+		if fileCloseError := fd.file.Close(); closeError == nil {
+			closeError = fileCloseError
+		}
+		fd.file = nil
+	}
+	return closeError
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFileDestination_Write_AppendsToFile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	logPath := filepath.Join(t.TempDir(), "logs", "annealing.log")
+	destinationUnderTest, openError := NewFileDestination(logPath, FileOptions{})
+	g.Expect(openError).To(BeNil())
+	defer destinationUnderTest.Close()
+
+	// when
+	_, firstError := destinationUnderTest.Write([]byte("first\n"))
+	_, secondError := destinationUnderTest.Write([]byte("second\n"))
+
+	// then
+	g.Expect(firstError).To(BeNil())
+	g.Expect(secondError).To(BeNil())
+
+	content, readError := os.ReadFile(logPath)
+	g.Expect(readError).To(BeNil())
+	g.Expect(string(content)).To(Equal("first\nsecond\n"))
+}
+
+func TestFileDestination_MaximumSize_RotatesCompressesAndPrunes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	logPath := filepath.Join(t.TempDir(), "annealing.log")
+	destinationUnderTest, openError := NewFileDestination(logPath, FileOptions{
+		MaximumSize:    10,
+		MaximumBackups: 2,
+		Compress:       true,
+	})
+	g.Expect(openError).To(BeNil())
+	defer destinationUnderTest.Close()
+
+	// when
+	for _, entry := range []string{"entry one\n", "entry two\n", "entry three\n", "entry four\n"} {
+		_, writeError := destinationUnderTest.Write([]byte(entry))
+		g.Expect(writeError).To(BeNil())
+	}
+
+	// then
+	content, readError := os.ReadFile(logPath)
+	g.Expect(readError).To(BeNil())
+	g.Expect(string(content)).To(Equal("entry four\n"))
+
+	backups, listError := destinationUnderTest.Backups()
+	g.Expect(listError).To(BeNil())
+	g.Expect(backups).To(HaveLen(2))
+	for _, backup := range backups {
+		g.Expect(strings.HasSuffix(backup, ".log.gz")).To(BeTrue())
+	}
+}
+
+func TestFileDestination_Backups_OrderedByRotationTimeThenDuplicate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	logDirectory := t.TempDir()
+	logPath := filepath.Join(logDirectory, "annealing.log")
+	destinationUnderTest, openError := NewFileDestination(logPath, FileOptions{})
+	g.Expect(openError).To(BeNil())
+	defer destinationUnderTest.Close()
+
+	expectedOrder := []string{
+		"annealing-20210101T120000.log.gz",
+		"annealing-20210101T120000-2.log",
+		"annealing-20210101T120000-10.log.gz",
+		"annealing-20210102T000000.log",
+	}
+	for _, name := range []string{expectedOrder[3], expectedOrder[2], expectedOrder[0], expectedOrder[1]} {
+		g.Expect(os.WriteFile(filepath.Join(logDirectory, name), []byte("entry\n"), 0644)).To(Succeed())
+	}
+
+	// when
+	backups, listError := destinationUnderTest.Backups()
+
+	// then
+	g.Expect(listError).To(BeNil())
+	g.Expect(backups).To(HaveLen(len(expectedOrder)))
+	for index, name := range expectedOrder {
+		g.Expect(filepath.Base(backups[index])).To(Equal(name))
+	}
+}
+
+func TestFileDestination_FailedRotation_ReopensFile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	logDirectory := t.TempDir()
+	logPath := filepath.Join(logDirectory, "annealing.log")
+	destinationUnderTest, openError := NewFileDestination(logPath, FileOptions{MaximumSize: 10, Compress: true})
+	g.Expect(openError).To(BeNil())
+	defer destinationUnderTest.Close()
+
+	now := time.Now()
+	for offset := 0; offset < 5; offset++ {
+		stamp := now.Add(time.Duration(offset) * time.Second).Format(rotationTimestampLayout)
+		blockingPath := filepath.Join(logDirectory, "annealing-"+stamp+".log.gz.tmp")
+		g.Expect(os.Mkdir(blockingPath, 0755)).To(Succeed())
+	}
+
+	// when
+	_, firstError := destinationUnderTest.Write([]byte("entry one\n"))
+	_, rotationError := destinationUnderTest.Write([]byte("entry two\n"))
+	_, laterError := destinationUnderTest.Write([]byte("entry three\n"))
+
+	// then
+	g.Expect(firstError).To(BeNil())
+	g.Expect(rotationError).To(Not(BeNil()))
+	g.Expect(laterError).To(BeNil())
+
+	content, readError := os.ReadFile(logPath)
+	g.Expect(readError).To(BeNil())
+	g.Expect(string(content)).To(ContainSubstring("entry three\n"))
+}
+
+func TestFileDestination_ForRun_SplitsBySanitisedRunId(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	logDirectory := t.TempDir()
+	logPath := filepath.Join(logDirectory, "annealing.log")
+	destinationUnderTest, openError := NewFileDestination(logPath, FileOptions{SplitByRun: true})
+	g.Expect(openError).To(BeNil())
+	defer destinationUnderTest.Close()
+
+	// when
+	firstRunDestination := destinationUnderTest.ForRun("Scenario (1/2)")
+	secondRunDestination := destinationUnderTest.ForRun("Scenario (2/2)")
+
+	firstRunDestination.Write([]byte("first run\n"))
+	secondRunDestination.Write([]byte("second run\n"))
+
+	// then
+	g.Expect(destinationUnderTest.ForRun("Scenario (1/2)")).To(BeIdenticalTo(firstRunDestination))
+	g.Expect(destinationUnderTest.ForRun("")).To(BeIdenticalTo(destinationUnderTest))
+
+	firstContent, firstReadError := os.ReadFile(filepath.Join(logDirectory, "annealing-Scenario_1_2.log"))
+	g.Expect(firstReadError).To(BeNil())
+	g.Expect(string(firstContent)).To(Equal("first run\n"))
+
+	secondContent, secondReadError := os.ReadFile(filepath.Join(logDirectory, "annealing-Scenario_2_2.log"))
+	g.Expect(secondReadError).To(BeNil())
+	g.Expect(string(secondContent)).To(Equal("second run\n"))
+}
+
+func TestFileDestination_FinishRun_ClosesAndForgetsRunFile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	logDirectory := t.TempDir()
+	destinationUnderTest, openError := NewFileDestination(filepath.Join(logDirectory, "annealing.log"), FileOptions{SplitByRun: true})
+	g.Expect(openError).To(BeNil())
+	defer destinationUnderTest.Close()
+
+	finishedRunDestination := destinationUnderTest.ForRun("Scenario (1/2)")
+	finishedRunDestination.Write([]byte("before finishing\n"))
+
+	// when
+	finishError := destinationUnderTest.FinishRun("Scenario (1/2)")
+
+	// then
+	g.Expect(finishError).To(BeNil())
+	_, writeError := finishedRunDestination.Write([]byte("after finishing\n"))
+	g.Expect(writeError).To(Not(BeNil()))
+
+	reopenedRunDestination := destinationUnderTest.ForRun("Scenario (1/2)")
+	g.Expect(reopenedRunDestination).To(Not(BeIdenticalTo(finishedRunDestination)))
+	reopenedRunDestination.Write([]byte("reopened\n"))
+
+	content, readError := os.ReadFile(filepath.Join(logDirectory, "annealing-Scenario_1_2.log"))
+	g.Expect(readError).To(BeNil())
+	g.Expect(string(content)).To(Equal("before finishing\nreopened\n"))
+
+	g.Expect(destinationUnderTest.FinishRun("Unknown Run")).To(Succeed())
+}
+
+func TestFileDestination_ForRun_NotSplitting_ReturnsSelf(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	destinationUnderTest, openError := NewFileDestination(filepath.Join(t.TempDir(), "annealing.log"), FileOptions{})
+	g.Expect(openError).To(BeNil())
+	defer destinationUnderTest.Close()
+
+	// then
+	g.Expect(destinationUnderTest.ForRun("Scenario (1/2)")).To(BeIdenticalTo(destinationUnderTest))
+}
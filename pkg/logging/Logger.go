@@ -42,3 +42,11 @@ type Container interface {
 	SetLogHandler(logger Logger)
 	LogHandler() Logger
 }
+
+// RunScopedLogger is a Logger able to hand each annealing run a Logger of its own, writing to any RunScopedDestination
+// via the destination of that run.  FinishRun releases the Logger of a run, and its destinations, once it has finished.
+type RunScopedLogger interface {
+	Logger
+	ForRun(runId string) Logger
+	FinishRun(runId string) error
+}
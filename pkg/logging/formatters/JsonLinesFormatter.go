@@ -0,0 +1,83 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package formatters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/LindsayBradford/crem/pkg/attributes"
+)
+
+// JsonLinesFormatter formats an Attributes array into a single-line JSON object, suitable for JSON-lines logs.
+// Unlike JsonFormatter, strings are escaped by the standard encoding/json package, numbers are written without
+// localisation, and the object never spans more than one line. Attributes are written in the order given, with any
+// attribute repeating an earlier attribute's name dropped.
+type JsonLinesFormatter struct{}
+
+func (formatter *JsonLinesFormatter) Format(attributes attributes.Attributes) string {
+	var buffer bytes.Buffer
+	namesWritten := make(map[string]bool, len(attributes))
+
+	buffer.WriteString(openBracket)
+	for _, attribute := range attributes {
+		if namesWritten[attribute.Name] {
+			continue
+		}
+		if len(namesWritten) > 0 {
+			buffer.WriteByte(',')
+		}
+		namesWritten[attribute.Name] = true
+
+		buffer.Write(jsonLinesEncode(attribute.Name))
+		buffer.WriteByte(':')
+		buffer.Write(jsonLinesEncode(jsonLinesValue(attribute.Value)))
+	}
+	buffer.WriteString(closeBracket)
+
+	return buffer.String()
+}
+
+// jsonLinesValue converts value into one encoding/json can encode faithfully, falling back on value's default
+// formatting for those it cannot.
+func jsonLinesValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case nil, string, bool,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64:
+		return typedValue
+	case float32:
+		return jsonLinesFloat(float64(typedValue))
+	case float64:
+		return jsonLinesFloat(typedValue)
+	case error:
+		return typedValue.Error()
+	case fmt.Stringer:
+		return typedValue.String()
+	case json.Marshaler:
+		return typedValue
+	default:
+		if _, marshalError := json.Marshal(typedValue); marshalError != nil {
+			return fmt.Sprint(typedValue)
+		}
+		return typedValue
+	}
+}
+
+// jsonLinesFloat returns value, or its text where JSON has no number for it (NaN and infinities).
+func jsonLinesFloat(value float64) interface{} {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Sprint(value)
+	}
+	return value
+}
+
+func jsonLinesEncode(value interface{}) []byte {
+	encodedValue, encodeError := json.Marshal(value)
+	if encodeError != nil {
+		return []byte(nullString)
+	}
+	return encodedValue
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package formatters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/LindsayBradford/crem/pkg/attributes"
+	. "github.com/onsi/gomega"
+)
+
+type jsonLinesStringer struct{}
+
+func (s *jsonLinesStringer) String() string {
+	return "stringerValue"
+}
+
+func ExampleJsonLinesFormatter_Format() {
+	exampleAttributes := attributes.Attributes{
+		{Name: "One", Value: "valueOne"},
+		{Name: "Two", Value: 42},
+		{Name: "Three", Value: uint64(0)},
+		{Name: "Four", Value: 42.42},
+		{Name: "Five", Value: new(jsonLinesStringer)},
+		{Name: "Six", Value: true},
+		{Name: "Seven", Value: 7777.777777},
+	}
+
+	exampleFormatter := new(JsonLinesFormatter)
+
+	exampleJson := exampleFormatter.Format(exampleAttributes)
+	fmt.Print(exampleJson)
+
+	// Output: {"One":"valueOne","Two":42,"Three":0,"Four":42.42,"Five":"stringerValue","Six":true,"Seven":7777.777777}
+}
+
+func TestJsonLinesFormatter_Format_ValidSingleLineJson(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// given
+	formatterUnderTest := new(JsonLinesFormatter)
+	awkwardAttributes := attributes.Attributes{
+		{Name: "Message", Value: "a \"quoted\",\nmulti-line\tmessage"},
+		{Name: "Error", Value: errors.New("failed\r\n")},
+		{Name: "NaN", Value: math.NaN()},
+		{Name: "Infinity", Value: math.Inf(1)},
+		{Name: "Nothing", Value: nil},
+		{Name: "Values", Value: []float64{1, 2}},
+		{Name: "Unencodable", Value: func() {}},
+		{Name: "Message", Value: "repeated name"},
+	}
+
+	// when
+	actualJson := formatterUnderTest.Format(awkwardAttributes)
+
+	// then
+	g.Expect(strings.ContainsAny(actualJson, "\r\n")).To(BeFalse())
+
+	decodedJson := make(map[string]interface{})
+	g.Expect(json.Unmarshal([]byte(actualJson), &decodedJson)).To(Succeed())
+
+	g.Expect(decodedJson).To(HaveLen(len(awkwardAttributes) - 1))
+	g.Expect(decodedJson["Message"]).To(Equal("a \"quoted\",\nmulti-line\tmessage"))
+	g.Expect(decodedJson["Error"]).To(Equal("failed\r\n"))
+	g.Expect(decodedJson["NaN"]).To(Equal("NaN"))
+	g.Expect(decodedJson["Infinity"]).To(Equal("+Inf"))
+	g.Expect(decodedJson["Nothing"]).To(BeNil())
+	g.Expect(decodedJson["Values"]).To(Equal([]interface{}{1.0, 2.0}))
+	g.Expect(decodedJson["Unencodable"]).To(BeAssignableToTypeOf(""))
+}
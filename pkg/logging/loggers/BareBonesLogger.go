@@ -27,6 +27,14 @@ func (bbl *BareBonesLogger) WithFormatter(formatter logging.Formatter) *BareBone
 	return bbl
 }
 
+// ForRun returns the logger for the annealing run identified, writing to the run's own destination wherever a
+// destination is run-scoped.
+func (bbl *BareBonesLogger) ForRun(runId string) logging.Logger {
+	return bbl.loggerForRun(runId, bbl, func(runBase LoggerBase) logging.Logger {
+		return &BareBonesLogger{LoggerBase: runBase}
+	})
+}
+
 func (bbl *BareBonesLogger) Debug(message interface{}) {
 	bbl.LogAtLevel(logging.DEBUG, message)
 }
@@ -76,3 +84,5 @@ func prependLogLevel(logLevel logging.Level, oldSlice []attributes.NameValuePair
 	newPair := attributes.NameValuePair{Name: "Level", Value: string(logLevel)}
 	return append([]attributes.NameValuePair{newPair}, oldSlice...)
 }
+
+var _ logging.RunScopedLogger = new(BareBonesLogger)
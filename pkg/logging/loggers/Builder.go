@@ -54,6 +54,22 @@ func (builder *Builder) ForBareBonesLogHandler() *Builder {
 	return builder
 }
 
+// ForJsonLinesLogHandler instructs Builder to use a JsonLinesLogger, paired with a JsonLinesFormatter, as its
+// Logger
+func (builder *Builder) ForJsonLinesLogHandler() *Builder {
+	builder.buildErrors = cremerrors.New("Failed to build valid Logger")
+
+	newHandler := new(JsonLinesLogger)
+
+	defaultDestinations := new(logging.Destinations).Initialise()
+	newHandler.SetDestinations(defaultDestinations)
+	newHandler.SetFormatter(new(formatters.JsonLinesFormatter))
+	newHandler.Initialise()
+
+	builder.logHandler = newHandler
+	return builder
+}
+
 // WithName instructs Builder to label the Logger being built with the specified human-friendly name.
 func (builder *Builder) WithName(name string) *Builder {
 	handlerBeingBuilt := builder.logHandler
@@ -0,0 +1,103 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package loggers
+
+import (
+	"io"
+	"time"
+
+	"github.com/LindsayBradford/crem/pkg/attributes"
+	"github.com/LindsayBradford/crem/pkg/logging"
+)
+
+const (
+	TimeLabel   = "Time"
+	LevelLabel  = "Level"
+	LoggerLabel = "Logger"
+	RunIdLabel  = "RunId"
+
+	// runIdAttribute names the attribute annealing events carry their run's id in.
+	runIdAttribute = "Id"
+)
+
+// JsonLinesLogger writes each log entry as a single line, leading the entry's attributes with its RFC3339 timestamp,
+// log level, the logger's name and, where known, the id of the annealing run it concerns. Paired with a
+// formatters.JsonLinesFormatter, every line is a JSON object, ready for ingestion by log pipelines.
+//
+// The run id is that of the run the logger was scoped to by ForRun, or that given by an entry's 'Id' attribute,
+// which it replaces.
+type JsonLinesLogger struct {
+	LoggerBase
+
+	runId string
+}
+
+func (jll *JsonLinesLogger) Initialise() {}
+
+func (jll *JsonLinesLogger) WithFormatter(formatter logging.Formatter) *JsonLinesLogger {
+	jll.formatter = formatter
+	return jll
+}
+
+// ForRun returns the logger for the annealing run identified, writing to the run's own destination wherever a
+// destination is run-scoped.
+func (jll *JsonLinesLogger) ForRun(runId string) logging.Logger {
+	return jll.loggerForRun(runId, jll, func(runBase LoggerBase) logging.Logger {
+		return &JsonLinesLogger{LoggerBase: runBase, runId: runId}
+	})
+}
+
+func (jll *JsonLinesLogger) Debug(message interface{}) {
+	jll.LogAtLevel(logging.DEBUG, message)
+}
+
+func (jll *JsonLinesLogger) Info(message interface{}) {
+	jll.LogAtLevel(logging.INFO, message)
+}
+
+func (jll *JsonLinesLogger) Warn(message interface{}) {
+	jll.LogAtLevel(logging.WARN, message)
+}
+
+func (jll *JsonLinesLogger) Error(message interface{}) {
+	jll.LogAtLevel(logging.ERROR, message)
+}
+
+func (jll *JsonLinesLogger) LogAtLevel(logLevel logging.Level, message interface{}) {
+	jll.LogAtLevelWithAttributes(logLevel, toLogAttributes(message))
+}
+
+func (jll *JsonLinesLogger) LogAtLevelWithAttributes(logLevel logging.Level, logAttributes attributes.Attributes) {
+	entryAttributes := jll.entryAttributes(logLevel, logAttributes)
+	io.WriteString(jll.deriveDestination(logLevel), jll.formatter.Format(entryAttributes)+"\n")
+}
+
+func (jll *JsonLinesLogger) entryAttributes(logLevel logging.Level, logAttributes attributes.Attributes) attributes.Attributes {
+	entryAttributes := make(attributes.Attributes, 0, len(logAttributes)+4)
+	entryAttributes = append(entryAttributes,
+		attributes.NameValuePair{Name: TimeLabel, Value: time.Now().Format(time.RFC3339Nano)},
+		attributes.NameValuePair{Name: LevelLabel, Value: string(logLevel)},
+		attributes.NameValuePair{Name: LoggerLabel, Value: jll.name},
+	)
+
+	runId := jll.runId
+	remainingAttributes := make(attributes.Attributes, 0, len(logAttributes))
+	for _, attribute := range logAttributes {
+		if attributeRunId, isRunId := attribute.Value.(string); isRunId && attribute.Name == runIdAttribute {
+			runId = attributeRunId
+			continue
+		}
+		remainingAttributes = append(remainingAttributes, attribute)
+	}
+
+	if runId != "" {
+		entryAttributes = append(entryAttributes, attributes.NameValuePair{Name: RunIdLabel, Value: runId})
+	}
+	return append(entryAttributes, remainingAttributes...)
+}
+
+func (jll *JsonLinesLogger) deriveDestination(logLevel logging.Level) logging.Destination {
+	return jll.destinations.Destinations[logLevel]
+}
+
+var _ logging.RunScopedLogger = new(JsonLinesLogger)
@@ -13,6 +13,8 @@ type LoggerBase struct {
 	name         string
 	destinations *logging.Destinations
 	formatter    logging.Formatter
+
+	runLoggers *runLoggers
 }
 
 // SetName allows a human-friendly name to be assigned to the loghandler to make it easier to configure
@@ -28,6 +30,7 @@ func (lb *LoggerBase) Name() string {
 // log destination stream resolution.
 func (lb *LoggerBase) SetDestinations(destinations *logging.Destinations) {
 	lb.destinations = destinations
+	lb.runLoggers = newRunLoggers()
 }
 
 func (lb *LoggerBase) Destinations() *logging.Destinations {
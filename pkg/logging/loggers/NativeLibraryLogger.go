@@ -39,6 +39,18 @@ func (nll *NativeLibraryLogger) WithFormatter(formatter logging.Formatter) *Nati
 	return nll
 }
 
+// ForRun returns the logger for the annealing run identified, writing to the run's own destination wherever a
+// destination is run-scoped.
+func (nll *NativeLibraryLogger) ForRun(runId string) logging.Logger {
+	return nll.loggerForRun(runId, nll, func(runBase LoggerBase) logging.Logger {
+		runLogger := &NativeLibraryLogger{LoggerBase: runBase, loggerMap: make(map[logging.Level]*log.Logger)}
+		for logLevel := range runBase.destinations.Destinations {
+			runLogger.addLogLevel(logLevel)
+		}
+		return runLogger
+	})
+}
+
 func (nll *NativeLibraryLogger) Debug(message interface{}) {
 	nll.LogAtLevel(logging.DEBUG, message)
 }
@@ -67,3 +79,5 @@ func (nll *NativeLibraryLogger) LogAtLevelWithAttributes(logLevel logging.Level,
 func (nll *NativeLibraryLogger) deriveDestination(logLevel logging.Level) *log.Logger {
 	return nll.loggerMap[logLevel]
 }
+
+var _ logging.RunScopedLogger = new(NativeLibraryLogger)
@@ -0,0 +1,55 @@
+// Copyright (c) 2021 Australian Rivers Institute.
+
+package loggers
+
+import (
+	"sync"
+
+	"github.com/LindsayBradford/crem/pkg/logging"
+)
+
+// runLoggers caches the loggers handed to each annealing run by a logger with run-scoped destinations.
+type runLoggers struct {
+	mutex   sync.Mutex
+	loggers map[string]logging.Logger
+}
+
+func newRunLoggers() *runLoggers {
+	return &runLoggers{loggers: make(map[string]logging.Logger)}
+}
+
+// loggerForRun returns the logger for the annealing run identified, built from a copy of the LoggerBase writing to the
+// run's destinations, or self where none of its destinations are run-scoped.
+func (lb *LoggerBase) loggerForRun(runId string, self logging.Logger, build func(runBase LoggerBase) logging.Logger) logging.Logger {
+	if lb.runLoggers == nil {
+		return lb.buildLoggerForRun(runId, self, build)
+	}
+
+	lb.runLoggers.mutex.Lock()
+	defer lb.runLoggers.mutex.Unlock()
+
+	if runLogger, cached := lb.runLoggers.loggers[runId]; cached {
+		return runLogger
+	}
+	runLogger := lb.buildLoggerForRun(runId, self, build)
+	lb.runLoggers.loggers[runId] = runLogger
+	return runLogger
+}
+
+// FinishRun forgets the logger of the annealing run identified, and closes its run-scoped destinations.
+func (lb *LoggerBase) FinishRun(runId string) error {
+	if lb.runLoggers != nil {
+		lb.runLoggers.mutex.Lock()
+		delete(lb.runLoggers.loggers, runId)
+		lb.runLoggers.mutex.Unlock()
+	}
+	return lb.destinations.FinishRun(runId)
+}
+
+func (lb *LoggerBase) buildLoggerForRun(runId string, self logging.Logger, build func(runBase LoggerBase) logging.Logger) logging.Logger {
+	runDestinations, isRunScoped := lb.destinations.ForRun(runId)
+	if !isRunScoped {
+		return self
+	}
+	return build(LoggerBase{name: lb.name, destinations: runDestinations, formatter: lb.formatter})
+}
@@ -111,6 +111,8 @@ func (c *Converter) convertGlobalisedNonString(value interface{}) string {
 		return strconv.FormatBool(value.(bool))
 	case int:
 		return fmt.Sprintf(integerFormat, value.(int))
+	case int64:
+		return fmt.Sprintf(integerFormat, value.(int64))
 	case uint64:
 		return fmt.Sprintf(integerFormat, value.(uint64))
 	case float64:
@@ -126,6 +128,8 @@ func (c *Converter) convertLocalisedNonString(value interface{}) string {
 		return strconv.FormatBool(value.(bool))
 	case int:
 		return localised.Sprintf(integerFormat, value.(int))
+	case int64:
+		return localised.Sprintf(integerFormat, value.(int64))
 	case uint64:
 		return localised.Sprintf(integerFormat, value.(uint64))
 	case float64: